@@ -0,0 +1,161 @@
+package main
+
+import (
+    "github.com/Ariemeth/frame_assault/achievements"
+    "github.com/Ariemeth/frame_assault/building"
+    "github.com/Ariemeth/frame_assault/eventbus"
+    "github.com/Ariemeth/frame_assault/mech"
+    "github.com/Ariemeth/frame_assault/waves"
+    tl "github.com/Ariemeth/termloop"
+)
+
+// newAchievementManager loads the player's unlocked achievements, returning
+// nil if there is nowhere to keep them
+func newAchievementManager() *achievements.Manager {
+    path, err := achievements.DefaultPath()
+    if err != nil {
+        logger.Warn("achievements disabled", "error", err)
+        return nil
+    }
+    manager, err := achievements.NewManager(path)
+    if err != nil {
+        logger.Warn("failed to load achievements", "file", path, "error", err)
+    }
+    return manager
+}
+
+// checkAchievement records event, popping up and saving any achievements
+// it unlocks
+func (gs *GameState) checkAchievement(event achievements.Event) {
+    if gs.achievements == nil {
+        return
+    }
+    unlocked := gs.achievements.Check(event)
+    for _, a := range unlocked {
+        logger.Info("achievement unlocked", "achievement", a.Name)
+        if gs.achievementPopup != nil {
+            gs.achievementPopup.Show(a.Name, a.Description)
+        }
+    }
+    if len(unlocked) > 0 || event.Type == achievements.GameCompleted {
+        if err := gs.achievements.Save(); err != nil {
+            logger.Warn("failed to save achievements", "error", err)
+        }
+    }
+}
+
+// achievementTracker watches the player's life and reports what happens
+// to the achievement manager
+type achievementTracker struct {
+    state     *GameState
+    player    *mech.PlayerMech
+    buildings *building.Manager
+    clock     *TimeSystem
+    waves     *waves.Manager
+    kills     int
+    structure int
+    streak    int
+    money     int
+    hour      float64
+    hours     float64
+    // civiliansHit counts the civilians the player has caught in
+    // explosions, reported on the next tick
+    civiliansHit int
+    // destroyed holds the buildings already reported, starting with the
+    // damage carried over from earlier lives
+    destroyed map[building.ID]bool
+    started   bool
+    won       bool
+}
+
+// newAchievementTracker creates a tracker for the life being built in gs
+func newAchievementTracker(gs *GameState, clock *TimeSystem, waveManager *waves.Manager) *achievementTracker {
+    return &achievementTracker{
+        state:     gs,
+        player:    gs.player,
+        buildings: gs.buildings,
+        clock:     clock,
+        waves:     waveManager,
+        structure: gs.player.StructureLeft(),
+        hour:      clock.GameHours(),
+        destroyed: make(map[building.ID]bool),
+    }
+}
+
+// HandleEvent counts the civilians the player catches in explosions
+func (t *achievementTracker) HandleEvent(event eventbus.Event) {
+    if hit, ok := event.(eventbus.CivilianHitEvent); ok && hit.Attacker == t.player.Name() {
+        t.civiliansHit++
+    }
+}
+
+// Draw does nothing, unlocked achievements are shown by the pop-up
+func (t *achievementTracker) Draw(screen *tl.Screen) {}
+
+// Tick compares the player's progress with the previous frame
+func (t *achievementTracker) Tick(event tl.Event) {
+    if event.Type != tl.EventNone {
+        return
+    }
+    if !t.started {
+        t.started = true
+        for _, b := range t.buildings.Buildings() {
+            t.destroyed[b.ID()] = b.Structure() == 0
+        }
+    }
+    gs := t.state
+    seconds := float64(gs.ElapsedTicks()) / gameFPS
+    gs.checkAchievement(achievements.Event{Type: achievements.TimeElapsed, Value: seconds})
+
+    for ; t.kills < t.player.Kills(); t.kills++ {
+        gs.checkAchievement(achievements.Event{Type: achievements.EnemyKilled})
+    }
+    for ; t.civiliansHit > 0; t.civiliansHit-- {
+        gs.checkAchievement(achievements.Event{Type: achievements.CivilianHit})
+    }
+    if structure := t.player.StructureLeft(); structure < t.structure {
+        gs.checkAchievement(achievements.Event{Type: achievements.DamageTaken})
+    }
+    t.structure = t.player.StructureLeft()
+    if streak := t.player.HitStreak(); streak != t.streak {
+        t.streak = streak
+        gs.checkAchievement(achievements.Event{Type: achievements.HitStreak, Value: float64(streak)})
+    }
+    if money := t.player.BountyPoints(); money != t.money {
+        t.money = money
+        gs.checkAchievement(achievements.Event{Type: achievements.MoneyChanged, Value: float64(money)})
+    }
+
+    // The clock wraps at midnight
+    hour := t.clock.GameHours()
+    if hour < t.hour {
+        t.hours += hour + 24 - t.hour
+    } else {
+        t.hours += hour - t.hour
+    }
+    t.hour = hour
+    gs.checkAchievement(achievements.Event{Type: achievements.HoursSurvived, Value: t.hours})
+
+    t.checkBuildings()
+
+    if !t.won && t.waves.Cleared() && len(t.player.EnemyPositions()) == 0 {
+        t.won = true
+        gs.checkAchievement(achievements.Event{Type: achievements.GameWon, Value: seconds})
+        gs.checkAchievement(achievements.Event{Type: achievements.GameCompleted})
+    }
+}
+
+// checkBuildings reports newly destroyed buildings and the buildings the
+// player is standing against
+func (t *achievementTracker) checkBuildings() {
+    x, y := t.player.Position()
+    for _, b := range t.buildings.Buildings() {
+        if b.Structure() == 0 && !t.destroyed[b.ID()] {
+            t.destroyed[b.ID()] = true
+            t.state.checkAchievement(achievements.Event{Type: achievements.BuildingDestroyed})
+        }
+        if b.Contains(x+1, y) || b.Contains(x-1, y) || b.Contains(x, y+1) || b.Contains(x, y-1) {
+            t.state.checkAchievement(achievements.Event{Type: achievements.BuildingVisited, Name: b.Name()})
+        }
+    }
+}
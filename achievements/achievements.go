@@ -0,0 +1,251 @@
+// Package achievements unlocks rewards for feats across games and keeps
+// them between runs
+package achievements
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Ariemeth/frame_assault/building"
+)
+
+// ID identifies an achievement in the unlock file
+type ID string
+
+// Achievement is a feat the player can unlock once
+type Achievement struct {
+	ID          ID
+	Name        string
+	Description string
+}
+
+// Achievement IDs
+const (
+	FirstBlood   ID = "first_blood"
+	Untouchable  ID = "untouchable"
+	Demolisher   ID = "demolisher"
+	SharpShooter ID = "sharp_shooter"
+	Pacifist     ID = "pacifist"
+	Explorer     ID = "explorer"
+	SpeedRunner  ID = "speed_runner"
+	Survivor     ID = "survivor"
+	Wealthy      ID = "wealthy"
+	Veteran      ID = "veteran"
+)
+
+// Unlock thresholds
+const (
+	untouchableSeconds  = 5 * 60
+	demolisherBuildings = 3
+	sharpShooterStreak  = 10
+	speedRunnerSeconds  = 2 * 60
+	survivorHours       = 10
+	wealthyMoney        = 5000
+	veteranGames        = 5
+)
+
+// All lists every achievement
+var All = []Achievement{
+	{FirstBlood, "First Blood", "Destroy your first enemy"},
+	{Untouchable, "Untouchable", "Survive 5 minutes without taking damage"},
+	{Demolisher, "Demolisher", "Destroy 3 buildings"},
+	{SharpShooter, "Sharp Shooter", "Hit 10 shots in a row"},
+	{Pacifist, "Pacifist", "Win without hitting a civilian"},
+	{Explorer, "Explorer", "Visit every type of building"},
+	{SpeedRunner, "Speed Runner", "Win in under 2 minutes"},
+	{Survivor, "Survivor", "Survive 10 in-game hours"},
+	{Wealthy, "Wealthy", "Hold 5000 money"},
+	{Veteran, "Veteran", "Complete 5 games"},
+}
+
+// byID returns the achievement with id
+func byID(id ID) Achievement {
+	for _, a := range All {
+		if a.ID == id {
+			return a
+		}
+	}
+	return Achievement{ID: id, Name: string(id)}
+}
+
+// EventType is the kind of game event an achievement may be earned by
+type EventType int
+
+// Event types checked by the Manager
+const (
+	// EnemyKilled is sent for every enemy the player destroys
+	EnemyKilled EventType = iota
+	// DamageTaken is sent when the player's mech is damaged
+	DamageTaken
+	// TimeElapsed carries the seconds played in Value
+	TimeElapsed
+	// HoursSurvived carries the in-game hours survived in Value
+	HoursSurvived
+	// BuildingDestroyed is sent for every building destroyed
+	BuildingDestroyed
+	// HitStreak carries the player's consecutive hits in Value
+	HitStreak
+	// CivilianHit is sent when the player damages a civilian
+	CivilianHit
+	// BuildingVisited carries the visited building's type in Name
+	BuildingVisited
+	// MoneyChanged carries the money held in Value
+	MoneyChanged
+	// GameWon carries the seconds taken to win in Value
+	GameWon
+	// GameCompleted is sent when a game ends, won or lost
+	GameCompleted
+)
+
+// Event is something that happened in a game
+type Event struct {
+	Type  EventType
+	Value float64
+	Name  string
+}
+
+// record is the unlock file's contents
+type record struct {
+	Unlocked       []ID `json:"unlocked"`
+	GamesCompleted int  `json:"games_completed"`
+}
+
+// Manager tracks progress toward each achievement
+type Manager struct {
+	path           string
+	unlocked       map[ID]bool
+	gamesCompleted int
+	// Progress in the current game
+	kills              int
+	seconds            float64
+	lastDamage         float64
+	buildingsDestroyed int
+	civiliansHit       int
+	visited            map[string]bool
+}
+
+// DefaultPath returns ~/.frame_assault/achievements.json
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error finding home directory: %v", err)
+	}
+	return filepath.Join(home, ".frame_assault", "achievements.json"), nil
+}
+
+// NewManager creates a manager saving unlocks to path, loading any
+// already unlocked there
+func NewManager(path string) (*Manager, error) {
+	m := &Manager{
+		path:     path,
+		unlocked: make(map[ID]bool),
+		visited:  make(map[string]bool),
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return m, fmt.Errorf("error reading achievements: %v", err)
+	}
+	var saved record
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return m, fmt.Errorf("error parsing achievements: %v", err)
+	}
+	for _, id := range saved.Unlocked {
+		m.unlocked[id] = true
+	}
+	m.gamesCompleted = saved.GamesCompleted
+	return m, nil
+}
+
+// Save writes the unlocked achievements and completed games to the
+// manager's file, creating its directory if needed
+func (m *Manager) Save() error {
+	saved := record{GamesCompleted: m.gamesCompleted}
+	for _, a := range All {
+		if m.unlocked[a.ID] {
+			saved.Unlocked = append(saved.Unlocked, a.ID)
+		}
+	}
+	data, err := json.MarshalIndent(saved, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding achievements: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(m.path), 0755); err != nil {
+		return fmt.Errorf("error creating achievements directory: %v", err)
+	}
+	if err := os.WriteFile(m.path, data, 0644); err != nil {
+		return fmt.Errorf("error writing achievements: %v", err)
+	}
+	return nil
+}
+
+// Unlocked returns true if the achievement id has been unlocked
+func (m *Manager) Unlocked(id ID) bool {
+	return m.unlocked[id]
+}
+
+// Check records event and returns the achievements it newly unlocks
+func (m *Manager) Check(event Event) []Achievement {
+	var earned []ID
+	switch event.Type {
+	case EnemyKilled:
+		m.kills++
+		earned = m.when(earned, FirstBlood, m.kills >= 1)
+	case DamageTaken:
+		m.lastDamage = m.seconds
+	case TimeElapsed:
+		m.seconds = event.Value
+		earned = m.when(earned, Untouchable, m.seconds-m.lastDamage >= untouchableSeconds)
+	case HoursSurvived:
+		earned = m.when(earned, Survivor, event.Value >= survivorHours)
+	case BuildingDestroyed:
+		m.buildingsDestroyed++
+		earned = m.when(earned, Demolisher, m.buildingsDestroyed >= demolisherBuildings)
+	case HitStreak:
+		earned = m.when(earned, SharpShooter, event.Value >= sharpShooterStreak)
+	case CivilianHit:
+		m.civiliansHit++
+	case BuildingVisited:
+		m.visited[event.Name] = true
+		earned = m.when(earned, Explorer, m.visitedAllTypes())
+	case MoneyChanged:
+		earned = m.when(earned, Wealthy, event.Value >= wealthyMoney)
+	case GameWon:
+		earned = m.when(earned, Pacifist, m.civiliansHit == 0)
+		earned = m.when(earned, SpeedRunner, event.Value < speedRunnerSeconds)
+	case GameCompleted:
+		m.gamesCompleted++
+		earned = m.when(earned, Veteran, m.gamesCompleted >= veteranGames)
+	}
+
+	unlocked := make([]Achievement, 0, len(earned))
+	for _, id := range earned {
+		unlocked = append(unlocked, byID(id))
+	}
+	return unlocked
+}
+
+// when unlocks id and appends it to earned if condition holds and it was
+// still locked
+func (m *Manager) when(earned []ID, id ID, condition bool) []ID {
+	if !condition || m.unlocked[id] {
+		return earned
+	}
+	m.unlocked[id] = true
+	return append(earned, id)
+}
+
+// visitedAllTypes returns true once every building type has been visited
+func (m *Manager) visitedAllTypes() bool {
+	for _, bt := range building.Types {
+		if !m.visited[bt.Name] {
+			return false
+		}
+	}
+	return true
+}
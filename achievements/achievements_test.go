@@ -0,0 +1,74 @@
+package achievements
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestFirstKillUnlocksFirstBlood(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "achievements.json")
+	m, err := NewManager(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unlocked := m.Check(Event{Type: EnemyKilled})
+	if len(unlocked) != 1 || unlocked[0].ID != FirstBlood {
+		t.Fatalf("first kill unlocked %v, want First Blood", unlocked)
+	}
+	if again := m.Check(Event{Type: EnemyKilled}); len(again) != 0 {
+		t.Errorf("second kill unlocked %v again", again)
+	}
+}
+
+func TestUnlocksPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "achievements.json")
+	m, err := NewManager(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Check(Event{Type: EnemyKilled})
+	m.Check(Event{Type: GameCompleted})
+	if err := m.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := NewManager(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.Unlocked(FirstBlood) || loaded.gamesCompleted != 1 {
+		t.Errorf("loaded unlocks %v after %d games, want First Blood after 1",
+			loaded.unlocked, loaded.gamesCompleted)
+	}
+}
+
+func TestUntouchableResetByDamage(t *testing.T) {
+	m, _ := NewManager(filepath.Join(t.TempDir(), "achievements.json"))
+
+	m.Check(Event{Type: TimeElapsed, Value: 60})
+	m.Check(Event{Type: DamageTaken})
+	if unlocked := m.Check(Event{Type: TimeElapsed, Value: untouchableSeconds + 30}); len(unlocked) != 0 {
+		t.Fatalf("unlocked %v only %d seconds after taking damage", unlocked, untouchableSeconds-30)
+	}
+	unlocked := m.Check(Event{Type: TimeElapsed, Value: untouchableSeconds + 60})
+	if len(unlocked) != 1 || unlocked[0].ID != Untouchable {
+		t.Errorf("unlocked %v after 5 minutes without damage, want Untouchable", unlocked)
+	}
+}
+
+func TestCivilianHitBlocksPacifist(t *testing.T) {
+	m, _ := NewManager(filepath.Join(t.TempDir(), "achievements.json"))
+	m.Check(Event{Type: CivilianHit})
+	for _, a := range m.Check(Event{Type: GameWon, Value: speedRunnerSeconds}) {
+		if a.ID == Pacifist {
+			t.Fatal("unlocked Pacifist after hitting a civilian")
+		}
+	}
+
+	clean, _ := NewManager(filepath.Join(t.TempDir(), "achievements.json"))
+	unlocked := clean.Check(Event{Type: GameWon, Value: speedRunnerSeconds})
+	if len(unlocked) != 1 || unlocked[0].ID != Pacifist {
+		t.Errorf("won without hitting a civilian and unlocked %v, want Pacifist", unlocked)
+	}
+}
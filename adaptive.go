@@ -0,0 +1,63 @@
+package main
+
+import (
+    "github.com/Ariemeth/frame_assault/ai"
+    "github.com/Ariemeth/frame_assault/config"
+    "github.com/Ariemeth/frame_assault/mech"
+    tl "github.com/Ariemeth/termloop"
+)
+
+// difficultyDirector shows the difficulty adapter how the player is doing
+// every tick and applies its recommendations through the same hot-patch
+// as remote configuration
+type difficultyDirector struct {
+    gs      *GameState
+    player  *mech.PlayerMech
+    adapter *ai.DifficultyAdapter
+    ticks   int
+}
+
+// newDifficultyDirector creates a director adapting the game to player
+func newDifficultyDirector(gs *GameState, player *mech.PlayerMech, adapter *ai.DifficultyAdapter) *difficultyDirector {
+    return &difficultyDirector{gs: gs, player: player, adapter: adapter}
+}
+
+// Tick observes the player once per frame and adjusts the enemies once
+// every adapter window
+func (d *difficultyDirector) Tick(event tl.Event) {
+    if event.Type != tl.EventNone {
+        return
+    }
+    d.adapter.Observe(d.player.StructureLeft(), d.player.MaxStructure())
+    d.ticks++
+    if d.ticks%ai.AdapterWindow == 0 {
+        d.apply(d.adapter.Adjust())
+    }
+}
+
+// apply hot-patches the game config with delta added to the current
+// enemy settings
+func (d *difficultyDirector) apply(delta ai.DifficultyDelta) {
+    if delta.IsZero() {
+        return
+    }
+    moveDelayChange := d.gs.MoveDelayChange() + delta.MoveDelay
+    hitRateBonus := d.gs.HitRateBonus() + delta.HitRate
+    cfg := &config.Game{
+        EnemyMoveDelayChange: &moveDelayChange,
+        EnemyHitRateBonus:    &hitRateBonus,
+    }
+    if delta.NextWaveMechs != 0 && d.gs.waves != nil {
+        if count, ok := d.gs.waves.NextWaveMechCount(); ok && count+delta.NextWaveMechs > 0 {
+            cfg.NextWaveMechs = count + delta.NextWaveMechs
+        }
+    }
+    logger.Info("difficulty adapted", "event_type", "difficulty",
+        "difficulty", d.adapter.CurrentDifficulty(),
+        "kill_rate", d.adapter.KillRate(),
+        "shots_per_kill", d.adapter.ShotsPerKill())
+    d.gs.ApplyConfig(cfg)
+}
+
+// Draw does nothing, the director only adjusts the enemies
+func (d *difficultyDirector) Draw(screen *tl.Screen) {}
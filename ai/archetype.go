@@ -0,0 +1,56 @@
+package ai
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+)
+
+// ArchetypeTemplate describes how an NPC personality behaves
+type ArchetypeTemplate struct {
+    Name                string  `json:"name"`
+    FleeThreshold       float64 `json:"fleeThreshold"`
+    AttackRange         int     `json:"attackRange"`
+    PreferredAction     string  `json:"preferredAction"`
+    BehaviorDescription string  `json:"behaviorDescription"`
+}
+
+// Validate checks the archetype has a name and thresholds in [0,1]
+func (a ArchetypeTemplate) Validate() error {
+    if a.Name == "" {
+        return fmt.Errorf("archetype name is empty")
+    }
+    if a.FleeThreshold < 0 || a.FleeThreshold > 1 {
+        return fmt.Errorf("archetype %s fleeThreshold %v is outside [0,1]", a.Name, a.FleeThreshold)
+    }
+    return nil
+}
+
+// LoadArchetypes reads a JSON list of archetypes from path keyed by name
+func LoadArchetypes(path string) (map[string]ArchetypeTemplate, error) {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return nil, fmt.Errorf("error reading archetypes: %v", err)
+    }
+    return ParseArchetypes(data)
+}
+
+// ParseArchetypes decodes a JSON list of archetypes keyed by name
+func ParseArchetypes(data []byte) (map[string]ArchetypeTemplate, error) {
+    var templates []ArchetypeTemplate
+    if err := json.Unmarshal(data, &templates); err != nil {
+        return nil, fmt.Errorf("error parsing archetypes: %v", err)
+    }
+
+    archetypes := make(map[string]ArchetypeTemplate, len(templates))
+    for _, template := range templates {
+        if err := template.Validate(); err != nil {
+            return nil, err
+        }
+        if _, exists := archetypes[template.Name]; exists {
+            return nil, fmt.Errorf("archetype %s is defined more than once", template.Name)
+        }
+        archetypes[template.Name] = template
+    }
+    return archetypes, nil
+}
@@ -0,0 +1,51 @@
+package ai
+
+import (
+    "strings"
+    "testing"
+)
+
+func TestLoadArchetypesInjectsPrompt(t *testing.T) {
+    archetypes, err := LoadArchetypes("testdata/archetypes.json")
+    if err != nil {
+        t.Fatalf("failed to load archetypes: %v", err)
+    }
+    if len(archetypes) != 2 {
+        t.Fatalf("loaded %d archetypes instead of 2", len(archetypes))
+    }
+
+    aggressive, ok := archetypes["Aggressive"]
+    if !ok {
+        t.Fatalf("Aggressive archetype not loaded")
+    }
+    if aggressive.FleeThreshold != 0.1 || aggressive.AttackRange != 5 || aggressive.PreferredAction != "combat" {
+        t.Errorf("Aggressive archetype loaded as %+v", aggressive)
+    }
+
+    profile := NPCProfile{Name: "Jane Smith", Age: 30, Occupation: "Nurse"}
+    prompt := FormatNPCPrompt(profile, &aggressive)
+    if !strings.HasSuffix(prompt, aggressive.BehaviorDescription) {
+        t.Errorf("prompt %q does not end with the archetype behavior", prompt)
+    }
+    if !strings.Contains(prompt, "Jane Smith") {
+        t.Errorf("prompt %q does not name the NPC", prompt)
+    }
+    if strings.Contains(FormatNPCPrompt(profile, nil), aggressive.BehaviorDescription) {
+        t.Errorf("prompt without an archetype contains its behavior")
+    }
+}
+
+func TestParseArchetypesValidation(t *testing.T) {
+    invalid := []string{
+        `[{"name": "", "fleeThreshold": 0.5}]`,
+        `[{"name": "Reckless", "fleeThreshold": -0.1}]`,
+        `[{"name": "Timid", "fleeThreshold": 1.5}]`,
+        `[{"name": "Twin"}, {"name": "Twin"}]`,
+        `{"name": "NotAList"}`,
+    }
+    for _, data := range invalid {
+        if _, err := ParseArchetypes([]byte(data)); err == nil {
+            t.Errorf("archetypes %s parsed without error", data)
+        }
+    }
+}
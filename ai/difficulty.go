@@ -0,0 +1,226 @@
+package ai
+
+import "github.com/Ariemeth/frame_assault/eventbus"
+
+const (
+    // AdapterWindow is how many ticks of play the difficulty adapter
+    // judges the player on
+    AdapterWindow = 60
+
+    // fastKillRate is the kills per minute above which enemies speed up
+    fastKillRate = 2.0
+    // aggressionHitRate is the hit rate enemy weapons gain while the player
+    // takes no damage under fire, and lose while the player is hit
+    aggressionHitRate = 0.05
+    // struggleStructure is the fraction of structure below which the next
+    // wave is made smaller
+    struggleStructure = 0.25
+
+    // difficultyStep is how far each recommendation moves the difficulty
+    difficultyStep = 0.1
+    maxDifficulty  = 2.0
+)
+
+// DifficultyDelta are recommended changes to the enemies
+type DifficultyDelta struct {
+    // MoveDelay is added to the ticks between enemy moves, negative for
+    // faster enemies
+    MoveDelay int
+    // HitRate is added to the hit rate of enemy weapons
+    HitRate float64
+    // NextWaveMechs is added to the number of mechs in the next wave
+    NextWaveMechs int
+}
+
+// IsZero returns true if the delta recommends no change
+func (d DifficultyDelta) IsZero() bool {
+    return d == DifficultyDelta{}
+}
+
+// adapterSample is what happened during one tick
+type adapterSample struct {
+    structureChange int
+    kills           int
+    shots           int
+    // enemyShots are the shots fired by anyone but the player
+    enemyShots int
+}
+
+// DifficultyAdapter watches how the player is doing over the last
+// AdapterWindow ticks and recommends making the enemies tougher while the
+// player is cruising and easier while they struggle
+type DifficultyAdapter struct {
+    player string
+    fps    float64
+    // samples are the last AdapterWindow ticks, oldest first
+    samples       []adapterSample
+    current       adapterSample
+    lastStructure int
+    structure     float64
+    observed      bool
+    difficulty    float64
+}
+
+// NewDifficultyAdapter creates an adapter judging the mech named player in
+// a game running at fps frames per second
+func NewDifficultyAdapter(player string, fps float64) *DifficultyAdapter {
+    return &DifficultyAdapter{
+        player:     player,
+        fps:        fps,
+        samples:    make([]adapterSample, 0, AdapterWindow),
+        structure:  1,
+        difficulty: 1,
+    }
+}
+
+// HandleEvent counts the player's shots and kills and the enemies' shots
+func (a *DifficultyAdapter) HandleEvent(event eventbus.Event) {
+    switch e := event.(type) {
+    case eventbus.WeaponFiredEvent:
+        if e.Attacker == a.player {
+            a.current.shots++
+        } else {
+            a.current.enemyShots++
+        }
+    case eventbus.MechDestroyedEvent:
+        if e.Name != a.player {
+            a.current.kills++
+        }
+    }
+}
+
+// Observe ends a tick in which the player had structure of maxStructure
+func (a *DifficultyAdapter) Observe(structure, maxStructure int) {
+    if a.observed {
+        a.current.structureChange = structure - a.lastStructure
+    }
+    a.observed = true
+    a.lastStructure = structure
+    if maxStructure > 0 {
+        a.structure = float64(structure) / float64(maxStructure)
+    }
+    if len(a.samples) == AdapterWindow {
+        a.samples = a.samples[1:]
+    }
+    a.samples = append(a.samples, a.current)
+    a.current = adapterSample{}
+}
+
+// KillRate returns the player's kills per minute over the window
+func (a *DifficultyAdapter) KillRate() float64 {
+    if len(a.samples) == 0 || a.fps <= 0 {
+        return 0
+    }
+    kills := 0
+    for _, sample := range a.samples {
+        kills += sample.kills
+    }
+    minutes := float64(len(a.samples)) / a.fps / 60
+    return float64(kills) / minutes
+}
+
+// ShotsPerKill returns the shots the player fired per kill over the
+// window, or every shot fired if nothing was killed
+func (a *DifficultyAdapter) ShotsPerKill() float64 {
+    kills, shots := 0, 0
+    for _, sample := range a.samples {
+        kills += sample.kills
+        shots += sample.shots
+    }
+    if kills == 0 {
+        return float64(shots)
+    }
+    return float64(shots) / float64(kills)
+}
+
+// StructureChangeRate returns the player's structure gained per second
+// over the window, negative while taking damage
+func (a *DifficultyAdapter) StructureChangeRate() float64 {
+    if len(a.samples) == 0 || a.fps <= 0 {
+        return 0
+    }
+    change := 0
+    for _, sample := range a.samples {
+        change += sample.structureChange
+    }
+    return float64(change) / (float64(len(a.samples)) / a.fps)
+}
+
+// untouched returns true if the player was fired at but has taken no
+// damage for the whole window
+func (a *DifficultyAdapter) untouched() bool {
+    if len(a.samples) < AdapterWindow {
+        return false
+    }
+    enemyShots := 0
+    for _, sample := range a.samples {
+        if sample.structureChange < 0 {
+            return false
+        }
+        enemyShots += sample.enemyShots
+    }
+    return enemyShots > 0
+}
+
+// kills returns the player's kills over the window
+func (a *DifficultyAdapter) kills() int {
+    kills := 0
+    for _, sample := range a.samples {
+        kills += sample.kills
+    }
+    return kills
+}
+
+// Adjust returns the changes recommended for how the player is doing and
+// moves the current difficulty to match. Whatever makes enemies tougher
+// is undone by its opposite: fast kills speed them up and taking damage
+// without a kill slows them down, dodging their fire sharpens their aim
+// and being hit blunts it. Enemies are never made tougher past the
+// maximum difficulty or easier below zero.
+func (a *DifficultyAdapter) Adjust() DifficultyDelta {
+    var delta DifficultyDelta
+    harder, easier := false, false
+    if a.difficulty < maxDifficulty {
+        if a.KillRate() > fastKillRate {
+            delta.MoveDelay--
+            harder = true
+        }
+        if a.untouched() {
+            delta.HitRate += aggressionHitRate
+            harder = true
+        }
+    }
+    if a.difficulty > 0 {
+        hit := a.StructureChangeRate() < 0
+        if hit && a.kills() == 0 {
+            delta.MoveDelay++
+            easier = true
+        }
+        if hit {
+            delta.HitRate -= aggressionHitRate
+            easier = true
+        }
+        if a.structure < struggleStructure {
+            delta.NextWaveMechs = -1
+            easier = true
+        }
+    }
+    if harder {
+        a.difficulty += difficultyStep
+    }
+    if easier {
+        a.difficulty -= difficultyStep
+    }
+    if a.difficulty > maxDifficulty {
+        a.difficulty = maxDifficulty
+    } else if a.difficulty < 0 {
+        a.difficulty = 0
+    }
+    return delta
+}
+
+// CurrentDifficulty returns how hard the adapter has made the game, from
+// 0 for easiest through 1 for normal to 2 for hardest
+func (a *DifficultyAdapter) CurrentDifficulty() float64 {
+    return a.difficulty
+}
@@ -0,0 +1,92 @@
+package ai
+
+import (
+    "testing"
+
+    "github.com/Ariemeth/frame_assault/eventbus"
+)
+
+func TestDifficultyAdapterRaisesAggressionWhenPlayerUntouched(t *testing.T) {
+    adapter := NewDifficultyAdapter("player", 10)
+    for i := 0; i < AdapterWindow; i++ {
+        if i%10 == 0 {
+            adapter.HandleEvent(eventbus.WeaponFiredEvent{Attacker: "Mech A"})
+        }
+        adapter.Observe(10, 10)
+    }
+
+    delta := adapter.Adjust()
+    if delta.HitRate <= 0 {
+        t.Errorf("recommended a hit rate change of %v for a player taking no damage, want an increase", delta.HitRate)
+    }
+    if delta.NextWaveMechs != 0 {
+        t.Errorf("recommended %d fewer mechs for a healthy player", -delta.NextWaveMechs)
+    }
+    if adapter.CurrentDifficulty() <= 1 {
+        t.Errorf("difficulty is %v, want above normal", adapter.CurrentDifficulty())
+    }
+}
+
+func TestDifficultyAdapterEasesOffStrugglingPlayer(t *testing.T) {
+    adapter := NewDifficultyAdapter("player", 10)
+    for i := 0; i < AdapterWindow; i++ {
+        adapter.HandleEvent(eventbus.WeaponFiredEvent{Attacker: "player"})
+        if i%10 == 0 {
+            adapter.HandleEvent(eventbus.MechDestroyedEvent{Name: "Mech A"})
+        }
+        adapter.Observe(10-i/6, 10)
+    }
+
+    delta := adapter.Adjust()
+    if delta.MoveDelay != -1 {
+        t.Errorf("move delay change is %d at %.0f kills a minute, want -1", delta.MoveDelay, adapter.KillRate())
+    }
+    if delta.HitRate >= 0 {
+        t.Errorf("hit rate change is %v for a player taking damage, want a decrease", delta.HitRate)
+    }
+    if delta.NextWaveMechs != -1 {
+        t.Errorf("next wave change is %d for a player below a quarter structure, want -1", delta.NextWaveMechs)
+    }
+    if adapter.ShotsPerKill() != 10 {
+        t.Errorf("shots per kill is %v, want 10", adapter.ShotsPerKill())
+    }
+}
+
+func TestDifficultyAdapterLeavesAimAloneWhenPlayerIsNotFiredAt(t *testing.T) {
+    adapter := NewDifficultyAdapter("player", 10)
+    for i := 0; i < AdapterWindow; i++ {
+        adapter.Observe(10, 10)
+    }
+
+    if delta := adapter.Adjust(); !delta.IsZero() {
+        t.Errorf("recommended %+v for a player nobody fired at, want no change", delta)
+    }
+    if adapter.CurrentDifficulty() != 1 {
+        t.Errorf("difficulty is %v, want normal", adapter.CurrentDifficulty())
+    }
+}
+
+func TestDifficultyAdapterUndoesAggressionOncePlayerIsHit(t *testing.T) {
+    adapter := NewDifficultyAdapter("player", 10)
+    for i := 0; i < AdapterWindow; i++ {
+        adapter.HandleEvent(eventbus.WeaponFiredEvent{Attacker: "Mech A"})
+        adapter.Observe(10, 10)
+    }
+    raised := adapter.Adjust()
+
+    for i := 0; i < AdapterWindow; i++ {
+        adapter.HandleEvent(eventbus.WeaponFiredEvent{Attacker: "Mech A"})
+        adapter.Observe(10-i/10, 10)
+    }
+    lowered := adapter.Adjust()
+
+    if raised.HitRate+lowered.HitRate != 0 {
+        t.Errorf("hit rate changed by %v then %v, want the rise undone", raised.HitRate, lowered.HitRate)
+    }
+    if lowered.MoveDelay != 1 {
+        t.Errorf("move delay change is %d for a player hit without a kill, want 1", lowered.MoveDelay)
+    }
+    if adapter.CurrentDifficulty() != 1 {
+        t.Errorf("difficulty is %v, want back to normal", adapter.CurrentDifficulty())
+    }
+}
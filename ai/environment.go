@@ -0,0 +1,76 @@
+package ai
+
+import (
+    "github.com/Ariemeth/frame_assault/building"
+    "github.com/Ariemeth/frame_assault/poi"
+)
+
+// conditionScale is the condition of an undamaged building
+const conditionScale = 10
+
+// BuildingInfo is what the model is told about one of the city's buildings
+type BuildingInfo struct {
+    Name string
+    X, Y int
+    // Condition is the building's structure out of conditionScale, 0 once
+    // it is destroyed
+    Condition int
+    // IsHostile is true while an enemy mech stands within the building
+    IsHostile bool
+}
+
+// EnemyLocator reports where the enemies still fighting are
+type EnemyLocator interface {
+    EnemyPositions() [][2]int
+}
+
+// EnvironmentBuilder gathers the current state of the city into the
+// EnvironmentInfo the model is told about, so building damage reaches the
+// prompt as it happens
+type EnvironmentBuilder struct {
+    pois      *poi.Registry
+    buildings *building.Manager
+    enemies   EnemyLocator
+}
+
+// NewEnvironmentBuilder creates a builder describing pois and buildings,
+// either of which may be nil
+func NewEnvironmentBuilder(pois *poi.Registry, buildings *building.Manager) *EnvironmentBuilder {
+    return &EnvironmentBuilder{pois: pois, buildings: buildings}
+}
+
+// AttachEnemies sets who reports the enemies marking buildings as hostile
+func (b *EnvironmentBuilder) AttachEnemies(enemies EnemyLocator) {
+    b.enemies = enemies
+}
+
+// Build returns the points of interest still standing and the condition
+// of every building, marking those an enemy stands within as hostile
+func (b *EnvironmentBuilder) Build() EnvironmentInfo {
+    var env EnvironmentInfo
+    if b.pois != nil {
+        env.PointsOfInterest = b.pois.GetAll()
+    }
+    if b.buildings == nil {
+        return env
+    }
+    var enemies [][2]int
+    if b.enemies != nil {
+        enemies = b.enemies.EnemyPositions()
+    }
+    for _, bldg := range b.buildings.Buildings() {
+        x, y := bldg.Position()
+        info := BuildingInfo{Name: bldg.Name(), X: x, Y: y}
+        if maxStructure := bldg.MaxStructure(); maxStructure > 0 {
+            info.Condition = bldg.Structure() * conditionScale / maxStructure
+        }
+        for _, enemy := range enemies {
+            if bldg.Contains(enemy[0], enemy[1]) {
+                info.IsHostile = true
+                break
+            }
+        }
+        env.Buildings = append(env.Buildings, info)
+    }
+    return env
+}
@@ -0,0 +1,38 @@
+package ai
+
+import (
+    "testing"
+
+    "github.com/Ariemeth/frame_assault/building"
+)
+
+// enemiesAt reports enemies at fixed positions
+type enemiesAt [][2]int
+
+func (e enemiesAt) EnemyPositions() [][2]int { return e }
+
+func TestBuildReportsBuildingCondition(t *testing.T) {
+    home, _ := building.TypeByName("Home")
+    buildings := building.NewManager(nil)
+    damaged := building.NewBuilding(0, 0, 4, 4, home)
+    damaged.SetStructure(damaged.MaxStructure() / 2)
+    buildings.Add(damaged)
+    buildings.Add(building.NewBuilding(10, 0, 4, 4, home))
+
+    builder := NewEnvironmentBuilder(nil, buildings)
+    builder.AttachEnemies(enemiesAt{{11, 2}})
+    env := builder.Build()
+
+    if len(env.Buildings) != 2 {
+        t.Fatalf("built %d buildings, want 2", len(env.Buildings))
+    }
+    if env.Buildings[0].Condition != 5 {
+        t.Errorf("half structure building has condition %d, want 5", env.Buildings[0].Condition)
+    }
+    if env.Buildings[0].IsHostile {
+        t.Error("building with no enemy inside is hostile")
+    }
+    if env.Buildings[1].Condition != conditionScale || !env.Buildings[1].IsHostile {
+        t.Errorf("occupied building is %+v, want undamaged and hostile", env.Buildings[1])
+    }
+}
@@ -0,0 +1,108 @@
+package ai
+
+import (
+    "fmt"
+    "net/http"
+    "strconv"
+    "strings"
+    "sync"
+    "sync/atomic"
+    "time"
+)
+
+// parseLatencyBuckets are the upper bounds in seconds of the parse latency
+// histogram's buckets
+var parseLatencyBuckets = []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1}
+
+// DefaultParseMetrics counts every response parsed by ParseOllamaResponse
+var DefaultParseMetrics = NewParseMetrics()
+
+// ParseMetrics counts how many model responses parsed and failed to parse,
+// and how long parsing took
+type ParseMetrics struct {
+    successCount int64
+    failureCount int64
+    // mu guards the latency histogram
+    mu           sync.Mutex
+    parseLatency []int64 // count of parses within each bucket
+    latencySum   float64
+    latencyCount int64
+}
+
+// NewParseMetrics creates metrics with nothing counted
+func NewParseMetrics() *ParseMetrics {
+    return &ParseMetrics{parseLatency: make([]int64, len(parseLatencyBuckets))}
+}
+
+// Record counts a parse that took latency, failed if err is not nil
+func (m *ParseMetrics) Record(latency time.Duration, err error) {
+    if err != nil {
+        atomic.AddInt64(&m.failureCount, 1)
+    } else {
+        atomic.AddInt64(&m.successCount, 1)
+    }
+    seconds := latency.Seconds()
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    for i, bound := range parseLatencyBuckets {
+        if seconds <= bound {
+            m.parseLatency[i]++
+        }
+    }
+    m.latencySum += seconds
+    m.latencyCount++
+}
+
+// SuccessCount returns how many responses parsed
+func (m *ParseMetrics) SuccessCount() int64 {
+    return atomic.LoadInt64(&m.successCount)
+}
+
+// FailureCount returns how many responses failed to parse
+func (m *ParseMetrics) FailureCount() int64 {
+    return atomic.LoadInt64(&m.failureCount)
+}
+
+// SuccessRate returns the fraction of responses that parsed, 1 before any
+// have been parsed
+func (m *ParseMetrics) SuccessRate() float64 {
+    success, failure := m.SuccessCount(), m.FailureCount()
+    if success+failure == 0 {
+        return 1
+    }
+    return float64(success) / float64(success+failure)
+}
+
+// PrometheusHandler serves the metrics as OpenMetrics text
+func (m *ParseMetrics) PrometheusHandler() http.Handler {
+    return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.Header().Set("Content-Type", "application/openmetrics-text; version=1.0.0; charset=utf-8")
+        fmt.Fprint(w, m.openMetrics())
+    })
+}
+
+// openMetrics formats the counters and latency histogram as OpenMetrics
+// text
+func (m *ParseMetrics) openMetrics() string {
+    var out strings.Builder
+    fmt.Fprintf(&out, "# TYPE ai_parse_success counter\n")
+    fmt.Fprintf(&out, "# HELP ai_parse_success Model responses parsed.\n")
+    fmt.Fprintf(&out, "ai_parse_success_total %d\n", m.SuccessCount())
+    fmt.Fprintf(&out, "# TYPE ai_parse_failure counter\n")
+    fmt.Fprintf(&out, "# HELP ai_parse_failure Model responses that failed to parse.\n")
+    fmt.Fprintf(&out, "ai_parse_failure_total %d\n", m.FailureCount())
+
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    fmt.Fprintf(&out, "# TYPE ai_parse_latency_seconds histogram\n")
+    fmt.Fprintf(&out, "# HELP ai_parse_latency_seconds Time taken to parse model responses.\n")
+    for i, bound := range parseLatencyBuckets {
+        fmt.Fprintf(&out, "ai_parse_latency_seconds_bucket{le=\"%s\"} %d\n",
+            strconv.FormatFloat(bound, 'g', -1, 64), m.parseLatency[i])
+    }
+    fmt.Fprintf(&out, "ai_parse_latency_seconds_bucket{le=\"+Inf\"} %d\n", m.latencyCount)
+    fmt.Fprintf(&out, "ai_parse_latency_seconds_sum %s\n", strconv.FormatFloat(m.latencySum, 'g', -1, 64))
+    fmt.Fprintf(&out, "ai_parse_latency_seconds_count %d\n", m.latencyCount)
+    fmt.Fprintf(&out, "# EOF\n")
+    return out.String()
+}
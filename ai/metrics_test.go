@@ -0,0 +1,60 @@
+package ai
+
+import (
+    "errors"
+    "net/http/httptest"
+    "strings"
+    "testing"
+)
+
+func TestParseOllamaResponseCountsOutcomes(t *testing.T) {
+    successes := DefaultParseMetrics.SuccessCount()
+    failures := DefaultParseMetrics.FailureCount()
+
+    response, err := ParseOllamaResponse([]byte(`{"response": "hold the line", "done": true}`))
+    if err != nil {
+        t.Fatalf("valid JSON failed to parse: %v", err)
+    }
+    if response.Response != "hold the line" {
+        t.Errorf("response is %q, want %q", response.Response, "hold the line")
+    }
+    if _, err := ParseOllamaResponse([]byte(`{"response": `)); err == nil {
+        t.Error("invalid JSON parsed")
+    }
+
+    if got := DefaultParseMetrics.SuccessCount() - successes; got != 1 {
+        t.Errorf("counted %d successes, want 1", got)
+    }
+    if got := DefaultParseMetrics.FailureCount() - failures; got != 1 {
+        t.Errorf("counted %d failures, want 1", got)
+    }
+}
+
+func TestParseMetricsPrometheusHandler(t *testing.T) {
+    metrics := NewParseMetrics()
+    if metrics.SuccessRate() != 1 {
+        t.Errorf("success rate before any parse is %v, want 1", metrics.SuccessRate())
+    }
+    metrics.Record(0, nil)
+    metrics.Record(0, nil)
+    metrics.Record(0, nil)
+    metrics.Record(0, errors.New("unexpected end of JSON input"))
+    if metrics.SuccessRate() != 0.75 {
+        t.Errorf("success rate is %v, want 0.75", metrics.SuccessRate())
+    }
+
+    recorder := httptest.NewRecorder()
+    metrics.PrometheusHandler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics/ai", nil))
+    body := recorder.Body.String()
+    for _, want := range []string{
+        "ai_parse_success_total 3\n",
+        "ai_parse_failure_total 1\n",
+        "ai_parse_latency_seconds_bucket{le=\"+Inf\"} 4\n",
+        "ai_parse_latency_seconds_count 4\n",
+        "# EOF\n",
+    } {
+        if !strings.Contains(body, want) {
+            t.Errorf("metrics are missing %q:\n%s", want, body)
+        }
+    }
+}
@@ -2,22 +2,26 @@ package ai
 
 import (
     "bytes"
+    "context"
     "encoding/json"
     "fmt"
     "io"
     "net"
     "net/http"
+    "sync/atomic"
     "time"
 )
 
-// Default timeout for HTTP requests
-const defaultTimeout = 30 * time.Second
+// DefaultTimeout is the timeout for HTTP requests unless another is set
+const DefaultTimeout = 30 * time.Second
 
 // OllamaClient handles communication with the Ollama API
 type OllamaClient struct {
     host    string
     model   string
     timeout time.Duration
+    // calls counts requests sent, read atomically
+    calls int64
 }
 
 // OllamaRequest represents the request body for Ollama API
@@ -41,7 +45,7 @@ func NewOllamaClient(host, model string) *OllamaClient {
     return &OllamaClient{
         host:    host,
         model:   model,
-        timeout: defaultTimeout,
+        timeout: DefaultTimeout,
     }
 }
 
@@ -50,8 +54,17 @@ func (c *OllamaClient) SetTimeout(timeout time.Duration) {
     c.timeout = timeout
 }
 
-// GenerateResponse sends a prompt to Ollama and returns the response
-func (c *OllamaClient) GenerateResponse(prompt string) (string, error) {
+// Calls returns how many requests have been sent to Ollama
+func (c *OllamaClient) Calls() int64 {
+    return atomic.LoadInt64(&c.calls)
+}
+
+// GenerateResponse sends a prompt to Ollama and returns the response.
+// Cancelling ctx abandons the request, returning an error wrapping the
+// context's error.
+func (c *OllamaClient) GenerateResponse(ctx context.Context, prompt string) (string, error) {
+    atomic.AddInt64(&c.calls, 1)
+
     // Prepare request body
     reqBody := OllamaRequest{
         Model:  c.model,
@@ -66,7 +79,7 @@ func (c *OllamaClient) GenerateResponse(prompt string) (string, error) {
     
     // Create HTTP request
     url := fmt.Sprintf("http://%s/api/generate", c.host)
-    req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonBody))
+    req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
     if err != nil {
         return "", fmt.Errorf("error creating request: %v", err)
     }
@@ -78,6 +91,9 @@ func (c *OllamaClient) GenerateResponse(prompt string) (string, error) {
     }
     resp, err := client.Do(req)
     if err != nil {
+        if ctx.Err() != nil {
+            return "", fmt.Errorf("request cancelled: %w", ctx.Err())
+        }
         if err, ok := err.(net.Error); ok && err.Timeout() {
             return "", fmt.Errorf("request timed out after %v: %v", c.timeout, err)
         }
@@ -98,9 +114,9 @@ func (c *OllamaClient) GenerateResponse(prompt string) (string, error) {
     }
     
     // Parse response
-    var ollamaResp OllamaResponse
-    if err := json.Unmarshal(body, &ollamaResp); err != nil {
-        return "", fmt.Errorf("error parsing response: %v", err)
+    ollamaResp, err := ParseOllamaResponse(body)
+    if err != nil {
+        return "", err
     }
     
     // Check for API error
@@ -110,3 +126,22 @@ func (c *OllamaClient) GenerateResponse(prompt string) (string, error) {
     
     return ollamaResp.Response, nil
 }
+
+// ParseOllamaResponse decodes the JSON body of a reply from the Ollama
+// API, counting the outcome in DefaultParseMetrics
+func ParseOllamaResponse(body []byte) (OllamaResponse, error) {
+    start := time.Now()
+    var response OllamaResponse
+    err := json.Unmarshal(body, &response)
+    DefaultParseMetrics.Record(time.Since(start), err)
+    if err != nil {
+        return OllamaResponse{}, fmt.Errorf("error parsing response: %v", err)
+    }
+    return response, nil
+}
+
+// GetStrategicAdvice asks the model for freeform advice on the situation
+// described by prompt
+func (c *OllamaClient) GetStrategicAdvice(ctx context.Context, prompt string) (string, error) {
+    return c.GenerateResponse(ctx, prompt)
+}
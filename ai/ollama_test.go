@@ -0,0 +1,38 @@
+package ai
+
+import (
+    "context"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestGenerateResponseCancelled(t *testing.T) {
+    const delay = 500 * time.Millisecond
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        select {
+        case <-time.After(delay):
+            w.Write([]byte(`{"response": "too late", "done": true}`))
+        case <-r.Context().Done():
+        }
+    }))
+    defer server.Close()
+
+    client := NewOllamaClient(strings.TrimPrefix(server.URL, "http://"), "test")
+    ctx, cancel := context.WithCancel(context.Background())
+    time.AfterFunc(50*time.Millisecond, cancel)
+
+    start := time.Now()
+    _, err := client.GenerateResponse(ctx, "Say hello!")
+    elapsed := time.Since(start)
+
+    if !errors.Is(err, context.Canceled) {
+        t.Errorf("cancelled request returned %v instead of a context.Canceled error", err)
+    }
+    if elapsed >= delay {
+        t.Errorf("cancelled request took %v, not returning before the %v delay", elapsed, delay)
+    }
+}
@@ -0,0 +1,62 @@
+package ai
+
+import (
+    "fmt"
+    "strings"
+
+    "github.com/Ariemeth/frame_assault/poi"
+)
+
+// NPCProfile holds the details of an NPC used to build its prompt
+type NPCProfile struct {
+    Name              string
+    Age               int
+    Occupation        string
+    PersonalityTraits []string
+    Environment       EnvironmentInfo
+}
+
+// EnvironmentInfo describes the city around an NPC
+type EnvironmentInfo struct {
+    PointsOfInterest []poi.PointOfInterest
+    Buildings        []BuildingInfo
+}
+
+// FormatNPCPrompt builds the prompt describing an NPC to the model and the
+// important places around it, so the model can send the NPC toward or away
+// from them. When archetype is not nil its behavior description is
+// appended.
+func FormatNPCPrompt(profile NPCProfile, archetype *ArchetypeTemplate) string {
+    var prompt strings.Builder
+    fmt.Fprintf(&prompt, "You are %s, a %d year old %s.", profile.Name, profile.Age, profile.Occupation)
+    if len(profile.PersonalityTraits) > 0 {
+        fmt.Fprintf(&prompt, " Your personality is %s.", strings.Join(profile.PersonalityTraits, ", "))
+    }
+    if points := profile.Environment.PointsOfInterest; len(points) > 0 {
+        places := make([]string, len(points))
+        for i, point := range points {
+            places[i] = fmt.Sprintf("%s at %d,%d (value %d)", point.Label, point.Position[0], point.Position[1],
+                point.StrategicValue)
+        }
+        fmt.Fprintf(&prompt, " Important places in the city: %s.", strings.Join(places, ", "))
+    }
+    // Only damaged buildings and those enemies hold are worth the words
+    notable := make([]string, 0)
+    for _, b := range profile.Environment.Buildings {
+        if b.Condition >= conditionScale && !b.IsHostile {
+            continue
+        }
+        description := fmt.Sprintf("%s at %d,%d (condition %d/%d", b.Name, b.X, b.Y, b.Condition, conditionScale)
+        if b.IsHostile {
+            description += ", enemies inside"
+        }
+        notable = append(notable, description+")")
+    }
+    if len(notable) > 0 {
+        fmt.Fprintf(&prompt, " Damaged or occupied buildings: %s.", strings.Join(notable, ", "))
+    }
+    if archetype != nil && archetype.BehaviorDescription != "" {
+        fmt.Fprintf(&prompt, " %s", archetype.BehaviorDescription)
+    }
+    return prompt.String()
+}
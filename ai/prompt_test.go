@@ -0,0 +1,38 @@
+package ai
+
+import (
+    "strings"
+    "testing"
+
+    "github.com/Ariemeth/frame_assault/poi"
+)
+
+func TestFormatNPCPromptListsPointsOfInterest(t *testing.T) {
+    profile := NPCProfile{
+        Name:       "Jane Smith",
+        Age:        30,
+        Occupation: "Nurse",
+        Environment: EnvironmentInfo{PointsOfInterest: []poi.PointOfInterest{
+            {EntityID: 1, Label: "Hospital", StrategicValue: poi.HighValue, Position: [2]int{12, 30}},
+        }},
+    }
+    prompt := FormatNPCPrompt(profile, nil)
+    if !strings.Contains(prompt, "Hospital at 12,30") {
+        t.Errorf("prompt %q does not list the hospital", prompt)
+    }
+    profile.Environment.Buildings = []BuildingInfo{
+        {Name: "Home", X: 4, Y: 8, Condition: 5, IsHostile: true},
+        {Name: "School", X: 20, Y: 8, Condition: conditionScale},
+    }
+    prompt = FormatNPCPrompt(profile, nil)
+    if !strings.Contains(prompt, "Home at 4,8 (condition 5/10, enemies inside)") {
+        t.Errorf("prompt %q does not describe the damaged home", prompt)
+    }
+    if strings.Contains(prompt, "School") {
+        t.Errorf("prompt %q describes the undamaged school", prompt)
+    }
+    profile.Environment = EnvironmentInfo{}
+    if strings.Contains(FormatNPCPrompt(profile, nil), "Important places") {
+        t.Error("prompt without points of interest lists places")
+    }
+}
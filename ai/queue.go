@@ -0,0 +1,98 @@
+package ai
+
+import (
+    "context"
+    "sync"
+)
+
+const (
+    // DefaultQueueWorkers is how many queries may run against the model at once
+    DefaultQueueWorkers = 2
+    // queueCapacity is how many queries may wait for a worker
+    queueCapacity = 64
+)
+
+// NPCResponder answers NPC prompts, usually an OllamaClient
+type NPCResponder interface {
+    GetNPCResponse(ctx context.Context, prompt string) (NPCResponse, error)
+}
+
+// AIRequest is an NPC's query waiting for a worker
+type AIRequest struct {
+    Ctx    context.Context
+    Prompt string
+    // FollowUp, if set, runs on the worker after a successful response so
+    // any model calls it makes count against the same limit
+    FollowUp func(ctx context.Context, response NPCResponse)
+    // ResponseCh receives the result. It should be buffered so a worker
+    // never waits on an NPC that has stopped listening.
+    ResponseCh chan AIResult
+}
+
+// AIResult is the answer to an AIRequest
+type AIResult struct {
+    Response NPCResponse
+    Err      error
+}
+
+// RequestQueue serializes NPC queries through a fixed pool of workers so
+// a crowd of NPCs cannot overwhelm the model server. NPCs submit requests
+// without blocking and collect the result on a later tick.
+type RequestQueue struct {
+    client  NPCResponder
+    inputCh chan AIRequest
+    wg      sync.WaitGroup
+    close   sync.Once
+}
+
+// NewRequestQueue starts workers answering queued requests with client
+func NewRequestQueue(client NPCResponder, workers int) *RequestQueue {
+    q := &RequestQueue{
+        client:  client,
+        inputCh: make(chan AIRequest, queueCapacity),
+    }
+    for i := 0; i < workers; i++ {
+        q.wg.Add(1)
+        go q.work()
+    }
+    return q
+}
+
+// Submit queues request without blocking, returning false if the queue is
+// full and the NPC should try again later
+func (q *RequestQueue) Submit(request AIRequest) bool {
+    select {
+    case q.inputCh <- request:
+        return true
+    default:
+        return false
+    }
+}
+
+// Len returns the number of requests waiting for a worker
+func (q *RequestQueue) Len() int {
+    return len(q.inputCh)
+}
+
+// Close stops accepting requests and waits for the workers to finish the
+// ones already queued
+func (q *RequestQueue) Close() {
+    q.close.Do(func() { close(q.inputCh) })
+    q.wg.Wait()
+}
+
+// work answers requests until the queue is closed
+func (q *RequestQueue) work() {
+    defer q.wg.Done()
+    for request := range q.inputCh {
+        ctx := request.Ctx
+        if ctx == nil {
+            ctx = context.Background()
+        }
+        response, err := q.client.GetNPCResponse(ctx, request.Prompt)
+        if err == nil && request.FollowUp != nil {
+            request.FollowUp(ctx, response)
+        }
+        request.ResponseCh <- AIResult{Response: response, Err: err}
+    }
+}
@@ -0,0 +1,58 @@
+package ai
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestRequestQueueRunsTwoAtATime(t *testing.T) {
+    const delay = 50 * time.Millisecond
+    var running, most int32
+    server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        now := atomic.AddInt32(&running, 1)
+        for {
+            seen := atomic.LoadInt32(&most)
+            if now <= seen || atomic.CompareAndSwapInt32(&most, seen, now) {
+                break
+            }
+        }
+        time.Sleep(delay)
+        atomic.AddInt32(&running, -1)
+        w.Write([]byte(`{"response": "ACTION: idle", "done": true}`))
+    }))
+    defer server.Close()
+
+    client := NewOllamaClient(strings.TrimPrefix(server.URL, "http://"), "test")
+    queue := NewRequestQueue(client, DefaultQueueWorkers)
+    defer queue.Close()
+
+    start := time.Now()
+    results := make([]chan AIResult, 4)
+    for i := range results {
+        results[i] = make(chan AIResult, 1)
+        if !queue.Submit(AIRequest{Ctx: context.Background(), Prompt: "Hello", ResponseCh: results[i]}) {
+            t.Fatalf("request %d was not queued", i)
+        }
+    }
+    for i, result := range results {
+        if r := <-result; r.Err != nil {
+            t.Errorf("request %d failed: %v", i, r.Err)
+        }
+    }
+    elapsed := time.Since(start)
+
+    if most := atomic.LoadInt32(&most); most > DefaultQueueWorkers {
+        t.Errorf("%d requests ran at once, want at most %d", most, DefaultQueueWorkers)
+    }
+    if elapsed >= 4*delay {
+        t.Errorf("4 requests took %v, want under %v with 2 workers", elapsed, 4*delay)
+    }
+    if queue.Len() != 0 {
+        t.Errorf("%d requests still queued", queue.Len())
+    }
+}
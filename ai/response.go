@@ -0,0 +1,62 @@
+package ai
+
+import (
+    "context"
+    "strings"
+)
+
+// ActionType is what an NPC decided to do
+type ActionType string
+
+const (
+    // ActionIdle carries on with the NPC's daily routine
+    ActionIdle ActionType = "idle"
+    // ActionTalk says something to those nearby
+    ActionTalk ActionType = "talk"
+    // ActionFlee runs from danger
+    ActionFlee ActionType = "flee"
+    // ActionHelp calls for or gives help
+    ActionHelp ActionType = "help"
+)
+
+// actionTypes lists the actions an NPC response is scanned for
+var actionTypes = []ActionType{ActionFlee, ActionHelp, ActionTalk, ActionIdle}
+
+// npcInstructions asks the model to start its reply with an action
+const npcInstructions = " Reply with one word for what you do next, idle, talk, flee or help," +
+    " followed by what you say."
+
+// NPCResponse is an NPC's decision parsed from the model's reply
+type NPCResponse struct {
+    ActionType ActionType
+    Dialogue   string
+}
+
+// IsUrgentAction returns true if the NPC must act right away
+func (r NPCResponse) IsUrgentAction() bool {
+    return r.ActionType == ActionFlee || r.ActionType == ActionHelp
+}
+
+// ParseNPCResponse reads the first action named in text, defaulting to
+// ActionIdle. The whole text is kept as the dialogue.
+func ParseNPCResponse(text string) NPCResponse {
+    response := NPCResponse{ActionType: ActionIdle, Dialogue: strings.TrimSpace(text)}
+    lower := strings.ToLower(text)
+    first := len(lower)
+    for _, action := range actionTypes {
+        if i := strings.Index(lower, string(action)); i >= 0 && i < first {
+            first = i
+            response.ActionType = action
+        }
+    }
+    return response
+}
+
+// GetNPCResponse asks the model what the NPC described by prompt does next
+func (c *OllamaClient) GetNPCResponse(ctx context.Context, prompt string) (NPCResponse, error) {
+    text, err := c.GenerateResponse(ctx, prompt+npcInstructions)
+    if err != nil {
+        return NPCResponse{}, err
+    }
+    return ParseNPCResponse(text), nil
+}
@@ -0,0 +1,61 @@
+package ai
+
+import "math"
+
+const (
+    // Hours bounding the business day
+    businessStart = 8.0
+    businessEnd   = 18.0
+
+    // Game seconds between queries during the day and at night
+    businessQuerySeconds = 30.0
+    nightQuerySeconds    = 60.0
+
+    secondsPerHour = 3600.0
+    hoursPerDay    = 24.0
+)
+
+// QueryScheduler spaces out each NPC's AI queries by the time of day.
+// NPCs are keyed by any comparable value, usually a pointer to the NPC.
+type QueryScheduler struct {
+    // next is when each NPC may query again in game hours
+    next map[interface{}]float64
+}
+
+// NewQueryScheduler creates a scheduler with no queries made yet
+func NewQueryScheduler() *QueryScheduler {
+    return &QueryScheduler{next: make(map[interface{}]float64)}
+}
+
+// QueryInterval returns the game hours between queries at hour
+func QueryInterval(hour float64) float64 {
+    if hour >= businessStart && hour < businessEnd {
+        return businessQuerySeconds / secondsPerHour
+    }
+    return nightQuerySeconds / secondsPerHour
+}
+
+// ShouldQuery returns true if npc is due to query the AI at currentHour,
+// scheduling its next query when it is
+func (s *QueryScheduler) ShouldQuery(npc interface{}, currentHour float64) bool {
+    if next, ok := s.next[npc]; ok && !reached(currentHour, next) {
+        return false
+    }
+    s.next[npc] = math.Mod(currentHour+QueryInterval(currentHour), hoursPerDay)
+    return true
+}
+
+// Record notes npc's latest response. An urgent response has the NPC
+// query again on the next tick.
+func (s *QueryScheduler) Record(npc interface{}, response NPCResponse) {
+    if response.IsUrgentAction() {
+        delete(s.next, npc)
+    }
+}
+
+// reached returns true if the clock at hour has reached next, allowing for
+// the clock wrapping past midnight
+func reached(hour, next float64) bool {
+    behind := math.Mod(hour-next+hoursPerDay, hoursPerDay)
+    return behind < hoursPerDay/2
+}
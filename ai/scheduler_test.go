@@ -0,0 +1,50 @@
+package ai
+
+import "testing"
+
+type testNPC struct{ name string }
+
+func TestQuerySchedulerBusinessHours(t *testing.T) {
+    scheduler := NewQueryScheduler()
+    npc := &testNPC{name: "Alice"}
+    const start = 10.0
+
+    if !scheduler.ShouldQuery(npc, start) {
+        t.Fatalf("first query was not allowed")
+    }
+    if scheduler.ShouldQuery(npc, start+29.0/secondsPerHour) {
+        t.Errorf("query allowed 29 seconds after the last during business hours")
+    }
+    if !scheduler.ShouldQuery(npc, start+30.0/secondsPerHour) {
+        t.Errorf("query not allowed 30 seconds after the last during business hours")
+    }
+}
+
+func TestQuerySchedulerNightAndUrgent(t *testing.T) {
+    scheduler := NewQueryScheduler()
+    npc := &testNPC{name: "Bob"}
+    const start = 23.99
+
+    scheduler.ShouldQuery(npc, start)
+    if scheduler.ShouldQuery(npc, start+30.0/secondsPerHour) {
+        t.Errorf("query allowed 30 seconds after the last at night")
+    }
+
+    scheduler.Record(npc, NPCResponse{ActionType: ActionFlee})
+    if !scheduler.ShouldQuery(npc, start+31.0/secondsPerHour) {
+        t.Errorf("urgent NPC was not allowed to query on the next tick")
+    }
+}
+
+func TestParseNPCResponse(t *testing.T) {
+    response := ParseNPCResponse("Flee! Those mechs will help nobody.")
+    if response.ActionType != ActionFlee {
+        t.Errorf("parsed action %q instead of %q", response.ActionType, ActionFlee)
+    }
+    if !response.IsUrgentAction() {
+        t.Errorf("fleeing was not urgent")
+    }
+    if got := ParseNPCResponse("Nice weather today").ActionType; got != ActionIdle {
+        t.Errorf("reply naming no action parsed as %q", got)
+    }
+}
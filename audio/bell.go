@@ -0,0 +1,66 @@
+// Package audio gives the player audible cues using only the terminal
+package audio
+
+import (
+	"io"
+	"os"
+
+	"github.com/Ariemeth/frame_assault/display"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// bell is the character that rings the terminal bell
+	bell = "\a"
+	// warningBellInterval is how many warnings are shown for each bell rung
+	warningBellInterval = 5
+)
+
+// BellNotifier rings the terminal bell like a siren for critical
+// notifications and for every fifth warning. The bell rings at most once
+// per game tick however many notifications arrive in it.
+type BellNotifier struct {
+	out           io.Writer
+	warnings      int
+	firedThisTick bool
+}
+
+// NewBellNotifier creates a notifier ringing the bell on standard error
+func NewBellNotifier() *BellNotifier {
+	return &BellNotifier{out: os.Stderr}
+}
+
+// AddMessage does nothing, normal notifications are silent
+func (n *BellNotifier) AddMessage(message string) {}
+
+// AddPriorityMessage rings the bell if the notification is important enough
+func (n *BellNotifier) AddPriorityMessage(message string, priority display.Priority) {
+	switch priority {
+	case display.PriorityCritical:
+		n.ring()
+	case display.PriorityWarning:
+		n.warnings++
+		if n.warnings%warningBellInterval == 0 {
+			n.ring()
+		}
+	}
+}
+
+// ring rings the bell unless it has already rung this tick
+func (n *BellNotifier) ring() {
+	if n.firedThisTick {
+		return
+	}
+	n.firedThisTick = true
+	io.WriteString(n.out, bell)
+}
+
+// Tick lets the bell ring again once per frame
+func (n *BellNotifier) Tick(event tl.Event) {
+	if event.Type == tl.EventNone {
+		n.firedThisTick = false
+	}
+}
+
+// Draw does nothing, the bell is only heard
+func (n *BellNotifier) Draw(screen *tl.Screen) {}
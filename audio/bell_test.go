@@ -0,0 +1,56 @@
+package audio
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/display"
+	tl "github.com/Ariemeth/termloop"
+)
+
+// captureStderr returns everything written to os.Stderr while run runs
+func captureStderr(t *testing.T, run func()) string {
+	t.Helper()
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = writer
+	defer func() { os.Stderr = stderr }()
+
+	run()
+	writer.Close()
+	out, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestBellRingsOncePerTick(t *testing.T) {
+	out := captureStderr(t, func() {
+		bell := NewBellNotifier()
+		bell.AddPriorityMessage("[ACID RAIN]", display.PriorityCritical)
+		bell.AddPriorityMessage("Reactor breach", display.PriorityCritical)
+	})
+	if rings := strings.Count(out, "\a"); rings != 1 {
+		t.Errorf("bell rang %d times for two critical messages in one tick, want once", rings)
+	}
+}
+
+func TestBellRingsEveryFifthWarning(t *testing.T) {
+	out := captureStderr(t, func() {
+		bell := NewBellNotifier()
+		for i := 0; i < 2*warningBellInterval; i++ {
+			bell.AddPriorityMessage("Fire spreading", display.PriorityWarning)
+			bell.AddMessage("Enemy spotted")
+			bell.Tick(tl.Event{Type: tl.EventNone})
+		}
+	})
+	if rings := strings.Count(out, "\a"); rings != 2 {
+		t.Errorf("bell rang %d times for %d warnings, want 2", rings, 2*warningBellInterval)
+	}
+}
@@ -0,0 +1,131 @@
+// Package balance reports how evenly matched the player and the enemies
+// are, so the game can be tuned without playing through it
+package balance
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// imbalanceRatio is how many times one side's DPS may be of the other's
+	// before the difference is reported
+	imbalanceRatio = 2.0
+	// maxKillRatio is the kills per player death above which enemies are
+	// reported as too weak
+	maxKillRatio = 10.0
+)
+
+// GameStats are the totals of a game so far
+type GameStats struct {
+	// Seconds is how long the game has run
+	Seconds      float64
+	PlayerDamage int
+	EnemyDamage  int
+	ShotsFired   int
+	// TotalRange is the sum of the range of every shot
+	TotalRange   int
+	PlayerKills  int
+	PlayerDeaths int
+}
+
+// BalanceReport summarises GameStats as rates a designer can compare
+type BalanceReport struct {
+	GameHour               float64 `json:"game_hour"`
+	PlayerDPS              float64 `json:"player_dps"`
+	EnemyDPS               float64 `json:"enemy_dps"`
+	TotalBulletsFired      float64 `json:"total_bullets_fired"`
+	AverageEngagementRange float64 `json:"average_engagement_range"`
+	// KillRatio is enemies destroyed per player death, or every enemy
+	// destroyed while the player has not died
+	KillRatio  float64  `json:"kill_ratio"`
+	Imbalances []string `json:"imbalances,omitempty"`
+}
+
+// Reporter writes balance reports to a directory and logs imbalances
+type Reporter struct {
+	dir    string
+	logger *slog.Logger
+}
+
+// NewReporter creates a reporter writing to dir and warning on logger
+func NewReporter(dir string, logger *slog.Logger) *Reporter {
+	return &Reporter{dir: dir, logger: logger}
+}
+
+// Report summarises stats at gameHour, logging a warning for every
+// imbalance found
+func (r *Reporter) Report(gameHour float64, stats GameStats) BalanceReport {
+	report := BalanceReport{
+		GameHour:          gameHour,
+		TotalBulletsFired: float64(stats.ShotsFired),
+		KillRatio:         float64(stats.PlayerKills),
+	}
+	if stats.Seconds > 0 {
+		report.PlayerDPS = float64(stats.PlayerDamage) / stats.Seconds
+		report.EnemyDPS = float64(stats.EnemyDamage) / stats.Seconds
+	}
+	if stats.ShotsFired > 0 {
+		report.AverageEngagementRange = float64(stats.TotalRange) / float64(stats.ShotsFired)
+	}
+	if stats.PlayerDeaths > 0 {
+		report.KillRatio = float64(stats.PlayerKills) / float64(stats.PlayerDeaths)
+	}
+	report.Imbalances = DetectImbalance(report)
+	if r.logger != nil {
+		for _, imbalance := range report.Imbalances {
+			r.logger.Warn(imbalance, "event_type", "balance", "game_hour", gameHour)
+		}
+	}
+	return report
+}
+
+// Save writes report to balance_TIMESTAMP.json in the reporter's
+// directory, returning the file written
+func (r *Reporter) Save(report BalanceReport, at time.Time) (string, error) {
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return "", fmt.Errorf("error creating balance report directory: %v", err)
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error encoding balance report: %v", err)
+	}
+	path := filepath.Join(r.dir, "balance_"+at.Format("20060102T150405")+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("error writing balance report: %v", err)
+	}
+	return path, nil
+}
+
+// DetectImbalance returns a message for every way report suggests the
+// game is unfair, with a suggestion of what to tune
+func DetectImbalance(r BalanceReport) []string {
+	messages := make([]string, 0)
+	switch {
+	case r.EnemyDPS > 0 && r.PlayerDPS == 0:
+		messages = append(messages, "Enemy deals damage while the player deals none, consider reducing enemy weapon damage")
+	case r.PlayerDPS > 0 && r.EnemyDPS == 0:
+		messages = append(messages, "Player deals damage while enemies deal none, consider increasing enemy weapon damage")
+	case r.EnemyDPS > r.PlayerDPS*imbalanceRatio:
+		messages = append(messages, fmt.Sprintf("Enemy DPS exceeds player by %.0f%%, consider reducing enemy weapon damage",
+			percentOver(r.EnemyDPS, r.PlayerDPS)))
+	case r.PlayerDPS > r.EnemyDPS*imbalanceRatio:
+		messages = append(messages, fmt.Sprintf("Player DPS exceeds enemy by %.0f%%, consider increasing enemy weapon damage",
+			percentOver(r.PlayerDPS, r.EnemyDPS)))
+	}
+	if r.KillRatio > maxKillRatio {
+		messages = append(messages, fmt.Sprintf("Kill ratio of %.1f is above %.0f, consider strengthening enemy mechs",
+			r.KillRatio, maxKillRatio))
+	}
+	return messages
+}
+
+// percentOver returns how many percent more a is than b
+func percentOver(a, b float64) float64 {
+	return math.Round((a - b) / b * 100)
+}
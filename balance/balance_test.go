@@ -0,0 +1,58 @@
+package balance
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/damagelog"
+	"github.com/Ariemeth/frame_assault/eventbus"
+)
+
+func TestDetectImbalance(t *testing.T) {
+	stats := GameStats{
+		Seconds:      10,
+		PlayerDamage: 10,
+		EnemyDamage:  40,
+		ShotsFired:   4,
+		TotalRange:   10,
+		PlayerKills:  12,
+	}
+	report := NewReporter(t.TempDir(), nil).Report(12, stats)
+
+	if report.PlayerDPS != 1 || report.EnemyDPS != 4 {
+		t.Errorf("DPS is %.1f for the player and %.1f for enemies, want 1 and 4", report.PlayerDPS, report.EnemyDPS)
+	}
+	if report.AverageEngagementRange != 2.5 {
+		t.Errorf("average engagement range is %.1f, want 2.5", report.AverageEngagementRange)
+	}
+	want := []string{
+		"Enemy DPS exceeds player by 300%, consider reducing enemy weapon damage",
+		"Kill ratio of 12.0 is above 10, consider strengthening enemy mechs",
+	}
+	if got := DetectImbalance(report); !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectImbalance returned %q, want %q", got, want)
+	}
+
+	even := NewReporter(t.TempDir(), nil).Report(12, GameStats{Seconds: 10, PlayerDamage: 30, EnemyDamage: 20, PlayerKills: 2, PlayerDeaths: 1})
+	if got := DetectImbalance(even); len(got) != 0 {
+		t.Errorf("DetectImbalance returned %q for an even game, want none", got)
+	}
+}
+
+func TestTrackerStats(t *testing.T) {
+	tracker := NewTracker("Player")
+	tracker.HandleEvent(eventbus.WeaponFiredEvent{Attacker: "Player", Range: 3})
+	tracker.HandleEvent(eventbus.WeaponFiredEvent{Attacker: "Mech A", Range: 5})
+	tracker.HandleEvent(eventbus.MechDestroyedEvent{Name: "Mech A"})
+	tracker.HandleEvent(eventbus.MechDestroyedEvent{Name: "Player"})
+
+	log := damagelog.NewLog()
+	log.RecordHit("Player", "Mech A", 4, 1)
+	log.RecordHit("Mech A", "Player", 2, 2)
+	log.RecordHit(damagelog.Environment, "Player", 1, 3)
+
+	want := GameStats{Seconds: 5, PlayerDamage: 4, EnemyDamage: 2, ShotsFired: 2, TotalRange: 8, PlayerKills: 1, PlayerDeaths: 1}
+	if got := tracker.Stats(log, 5); got != want {
+		t.Errorf("stats are %+v, want %+v", got, want)
+	}
+}
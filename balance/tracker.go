@@ -0,0 +1,60 @@
+package balance
+
+import (
+	"github.com/Ariemeth/frame_assault/damagelog"
+	"github.com/Ariemeth/frame_assault/eventbus"
+)
+
+// Tracker counts the shots and kills of a game from its events
+type Tracker struct {
+	player     string
+	shots      int
+	totalRange int
+	kills      int
+	deaths     int
+}
+
+// NewTracker creates a tracker of the game of the player named player
+func NewTracker(player string) *Tracker {
+	return &Tracker{player: player}
+}
+
+// HandleEvent counts weapons fired and mechs destroyed
+func (t *Tracker) HandleEvent(event eventbus.Event) {
+	switch e := event.(type) {
+	case eventbus.WeaponFiredEvent:
+		t.shots++
+		t.totalRange += e.Range
+	case eventbus.MechDestroyedEvent:
+		if e.Name == t.player {
+			t.deaths++
+		} else {
+			t.kills++
+		}
+	}
+}
+
+// Stats returns the game's totals after seconds of play, taking damage
+// dealt and received by the player from log
+func (t *Tracker) Stats(log *damagelog.Log, seconds float64) GameStats {
+	stats := GameStats{
+		Seconds:      seconds,
+		ShotsFired:   t.shots,
+		TotalRange:   t.totalRange,
+		PlayerKills:  t.kills,
+		PlayerDeaths: t.deaths,
+	}
+	if log == nil {
+		return stats
+	}
+	player := damagelog.EntityID(t.player)
+	for _, record := range log.Records() {
+		switch {
+		case record.AttackerID == player:
+			stats.PlayerDamage += record.Damage
+		case record.DefenderID == player && record.AttackerID != damagelog.Environment:
+			stats.EnemyDamage += record.Damage
+		}
+	}
+	return stats
+}
@@ -0,0 +1,125 @@
+// Package bestiary remembers every enemy mech type the player has fought,
+// across every game
+package bestiary
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Entry is what was learned about a mech type on first meeting it
+type Entry struct {
+	Name          string
+	WeaponType    string
+	MaxStructure  int
+	StrategyType  string
+	FirstSeenHour float64
+}
+
+// Combatant is an enemy mech the player has fought
+type Combatant interface {
+	Model() string
+	WeaponType() string
+	MaxStructure() int
+	StrategyType() string
+}
+
+// NewEntry describes c as first seen at hour of the game day
+func NewEntry(c Combatant, hour float64) Entry {
+	return Entry{
+		Name:          c.Model(),
+		WeaponType:    c.WeaponType(),
+		MaxStructure:  c.MaxStructure(),
+		StrategyType:  c.StrategyType(),
+		FirstSeenHour: hour,
+	}
+}
+
+// strategyDescriptions describe each way an enemy moves as the player saw it
+var strategyDescriptions = map[string]string{
+	"overwatch":   "Holds a position and fires into its zone",
+	"patrol":      "Patrols a fixed route between waypoints",
+	"random walk": "Wanders the streets without a plan",
+}
+
+// StrategyDescription returns a written description of a strategy type
+func StrategyDescription(strategy string) string {
+	if description, ok := strategyDescriptions[strategy]; ok {
+		return description
+	}
+	return "Behaviour not yet understood"
+}
+
+// Bestiary is every mech type encountered, keyed by name
+type Bestiary struct {
+	Entries map[string]Entry
+	path    string
+}
+
+// DefaultPath returns the bestiary file in the player's home directory
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error finding home directory: %v", err)
+	}
+	return filepath.Join(home, ".frame_assault", "bestiary.json"), nil
+}
+
+// Load creates a bestiary saving to path, loading any entries already
+// saved there
+func Load(path string) (*Bestiary, error) {
+	b := &Bestiary{
+		Entries: make(map[string]Entry),
+		path:    path,
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return b, nil
+	}
+	if err != nil {
+		return b, fmt.Errorf("error reading bestiary: %v", err)
+	}
+	if err := json.Unmarshal(data, b); err != nil {
+		return b, fmt.Errorf("error parsing bestiary: %v", err)
+	}
+	if b.Entries == nil {
+		b.Entries = make(map[string]Entry)
+	}
+	return b, nil
+}
+
+// Save writes the entries to the bestiary file, creating its directory if
+// needed
+func (b *Bestiary) Save() error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding bestiary: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(b.path), 0755); err != nil {
+		return fmt.Errorf("error creating bestiary directory: %v", err)
+	}
+	if err := os.WriteFile(b.path, data, 0644); err != nil {
+		return fmt.Errorf("error writing bestiary: %v", err)
+	}
+	return nil
+}
+
+// Record adds entry unless its mech type is already known, returning true
+// if it was added
+func (b *Bestiary) Record(entry Entry) bool {
+	if _, ok := b.Entries[entry.Name]; ok {
+		return false
+	}
+	b.Entries[entry.Name] = entry
+	return true
+}
+
+// Lookup returns the entry for a mech type and whether it has been
+// discovered
+func (b *Bestiary) Lookup(name string) (Entry, bool) {
+	entry, ok := b.Entries[name]
+	return entry, ok
+}
@@ -0,0 +1,54 @@
+package bestiary
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/mech"
+	"github.com/Ariemeth/frame_assault/mech/movement"
+	"github.com/Ariemeth/frame_assault/mech/weapon"
+	tl "github.com/Ariemeth/termloop"
+)
+
+// recorder records every enemy encountered in a bestiary
+type recorder struct {
+	bestiary *Bestiary
+}
+
+func (r recorder) Encounter(enemy *mech.EnemyMech) {
+	r.bestiary.Record(NewEntry(enemy, 14.5))
+}
+
+func TestContactRecordsEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bestiary.json")
+	b, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enemy := mech.NewEnemyMech("Mech C", 30, 5, 5, tl.ColorRed, 'C', movement.NewRandomWalkStrategy())
+	enemy.SetName("Crusher")
+	enemy.AddWeapon(weapon.CreateShotgun())
+	enemy.AttachEncounters(recorder{b})
+
+	enemy.Tick(tl.Event{Type: tl.EventNone})
+	if _, ok := b.Lookup("Mech C"); ok {
+		t.Fatal("Mech C was discovered before any combat")
+	}
+	enemy.Hit(5, "player")
+	enemy.Tick(tl.Event{Type: tl.EventNone})
+
+	if err := b.Save(); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, ok := reloaded.Lookup("Mech C")
+	if !ok {
+		t.Fatal("Mech C was not recorded after combat")
+	}
+	if entry.WeaponType != "Shotgun" || entry.MaxStructure != 30 || entry.FirstSeenHour != 14.5 {
+		t.Errorf("entry is %+v, want a shotgun with 30 structure first seen at 14.5", entry)
+	}
+}
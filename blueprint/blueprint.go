@@ -0,0 +1,132 @@
+// Package blueprint lets the player put up new buildings in the city
+// during the game, paid for with bounty points
+package blueprint
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Ariemeth/frame_assault/building"
+	"github.com/Ariemeth/frame_assault/spawnzones"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// Width and Height are the size of every building the player builds
+	Width  = 4
+	Height = 3
+)
+
+var (
+	// ErrUnknownKind is returned for a building that has no blueprint
+	ErrUnknownKind = errors.New("no blueprint for building")
+	// ErrInsufficientFunds is returned when the player cannot afford a building
+	ErrInsufficientFunds = errors.New("not enough bounty points")
+	// ErrBlocked is returned when something stands where the building would go
+	ErrBlocked = errors.New("space is not empty")
+	// ErrNoRoadAccess is returned when no road runs alongside the building
+	ErrNoRoadAccess = errors.New("space is not beside a road")
+)
+
+// Blueprint is a building the player can build and what it costs
+type Blueprint struct {
+	Kind string
+	Cost int
+	Type building.Type
+}
+
+// Blueprints lists every building the player can build
+var Blueprints = []Blueprint{
+	{"Turret", 500, building.Type{Name: "Turret", Color: tl.ColorRed, Char: 'T', Capacity: 0}},
+	{building.RepairBayName, 300, repairBay()},
+	{"Ammo Depot", 200, building.Type{Name: "Ammo Depot", Color: tl.ColorYellow, Char: 'A', Capacity: 2}},
+}
+
+// repairBay returns the city's repair bay type so player built bays repair
+// weapons like the city's own
+func repairBay() building.Type {
+	bt, _ := building.TypeByName(building.RepairBayName)
+	return bt
+}
+
+// ByKind returns the blueprint for the building called kind
+func ByKind(kind string) (Blueprint, bool) {
+	for _, blueprint := range Blueprints {
+		if blueprint.Kind == kind {
+			return blueprint, true
+		}
+	}
+	return Blueprint{}, false
+}
+
+// Payer pays for the buildings the player builds
+type Payer interface {
+	BountyPoints() int
+	AddBountyPoints(points int)
+}
+
+// Builder puts up buildings paid for by its payer
+type Builder struct {
+	payer Payer
+	roads building.RoadChecker
+}
+
+// NewBuilder creates a builder charging payer and building beside roads
+func NewBuilder(payer Payer, roads building.RoadChecker) *Builder {
+	return &Builder{payer: payer, roads: roads}
+}
+
+// PlaceBuilding builds a kind of building with its top left corner at x,y,
+// adding it to level and manager. The space must be empty, with a road
+// running alongside it, and the player must be able to afford it.
+func (b *Builder) PlaceBuilding(kind string, x, y int, level *tl.BaseLevel, manager *building.Manager) error {
+	blueprint, ok := ByKind(kind)
+	if !ok {
+		return fmt.Errorf("error building %s: %w", kind, ErrUnknownKind)
+	}
+	if b.payer.BountyPoints() < blueprint.Cost {
+		return fmt.Errorf("error building %s: %w", kind, ErrInsufficientFunds)
+	}
+	if err := b.validate(x, y, level, manager); err != nil {
+		return fmt.Errorf("error building %s: %w", kind, err)
+	}
+
+	b.payer.AddBountyPoints(-blueprint.Cost)
+	built := building.NewBuilding(x, y, Width, Height, blueprint.Type)
+	manager.AddPlayerBuilt(built)
+	level.AddEntity(built)
+	return nil
+}
+
+// validate returns an error unless the space at x,y is empty and a road
+// runs along one of its sides
+func (b *Builder) validate(x, y int, level *tl.BaseLevel, manager *building.Manager) error {
+	for i := 0; i < Width; i++ {
+		for j := 0; j < Height; j++ {
+			if b.hasRoad(x+i, y+j) || spawnzones.HasCollision(x+i, y+j, level) {
+				return ErrBlocked
+			}
+			for _, other := range manager.Buildings() {
+				if other.Contains(x+i, y+j) {
+					return ErrBlocked
+				}
+			}
+		}
+	}
+	for i := -1; i <= Width; i++ {
+		if b.hasRoad(x+i, y-1) || b.hasRoad(x+i, y+Height) {
+			return nil
+		}
+	}
+	for j := 0; j < Height; j++ {
+		if b.hasRoad(x-1, y+j) || b.hasRoad(x+Width, y+j) {
+			return nil
+		}
+	}
+	return ErrNoRoadAccess
+}
+
+// hasRoad returns true if a road runs through x,y
+func (b *Builder) hasRoad(x, y int) bool {
+	return b.roads != nil && b.roads.HasRoad(x, y)
+}
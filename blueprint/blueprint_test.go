@@ -0,0 +1,73 @@
+package blueprint
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/building"
+	tl "github.com/Ariemeth/termloop"
+)
+
+type fakePayer struct {
+	points int
+}
+
+func (p *fakePayer) BountyPoints() int {
+	return p.points
+}
+
+func (p *fakePayer) AddBountyPoints(points int) {
+	p.points += points
+}
+
+// street is a road running along y
+type street struct {
+	y int
+}
+
+func (s street) HasRoad(x, y int) bool {
+	return y == s.y
+}
+
+func TestPlacementFailsWithoutMoney(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	buildings := building.NewManager(nil)
+	payer := &fakePayer{points: 499}
+	builder := NewBuilder(payer, street{y: 10})
+
+	err := builder.PlaceBuilding("Turret", 0, 11, level, buildings)
+	if !errors.Is(err, ErrInsufficientFunds) {
+		t.Errorf("building a 500 point turret with 499 points returned %v, want ErrInsufficientFunds", err)
+	}
+	if len(buildings.Buildings()) != 0 || payer.points != 499 {
+		t.Errorf("failed placement left %d buildings and %d points", len(buildings.Buildings()), payer.points)
+	}
+
+	payer.points = 500
+	if err := builder.PlaceBuilding("Turret", 0, 11, level, buildings); err != nil {
+		t.Fatalf("failed to build an affordable turret: %v", err)
+	}
+	built := buildings.Buildings()
+	if len(built) != 1 || !built[0].IsPlayerBuilt() || built[0].Structure() == 0 || payer.points != 0 {
+		t.Errorf("built %d buildings with %d points left, want 1 player built turret and none left", len(built), payer.points)
+	}
+}
+
+func TestPlacementNeedsEmptySpaceBesideRoad(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	buildings := building.NewManager(nil)
+	builder := NewBuilder(&fakePayer{points: 1000}, street{y: 10})
+
+	if err := builder.PlaceBuilding("Ammo Depot", 0, 20, level, buildings); !errors.Is(err, ErrNoRoadAccess) {
+		t.Errorf("building away from the road returned %v, want ErrNoRoadAccess", err)
+	}
+	if err := builder.PlaceBuilding("Ammo Depot", 0, 9, level, buildings); !errors.Is(err, ErrBlocked) {
+		t.Errorf("building on the road returned %v, want ErrBlocked", err)
+	}
+	if err := builder.PlaceBuilding("Ammo Depot", 0, 11, level, buildings); err != nil {
+		t.Fatalf("failed to build beside the road: %v", err)
+	}
+	if err := builder.PlaceBuilding("Ammo Depot", 2, 11, level, buildings); !errors.Is(err, ErrBlocked) {
+		t.Errorf("building over another building returned %v, want ErrBlocked", err)
+	}
+}
@@ -0,0 +1,90 @@
+// Package bounty tracks the points awarded for destroying enemies
+package bounty
+
+const (
+	// RegularPoints is the bounty on an ordinary enemy mech
+	RegularPoints = 50
+	// BossPoints is the bounty on a high value boss mech
+	BossPoints = 500
+)
+
+// BountyEntry describes the reward for destroying a named enemy
+type BountyEntry struct {
+	Points      int
+	Description string
+}
+
+// Registry maps enemy names to their bounties
+type Registry struct {
+	entries     map[string]BountyEntry
+	fallback    BountyEntry
+	hasFallback bool
+}
+
+// NewRegistry creates an empty bounty registry
+func NewRegistry() *Registry {
+	return &Registry{entries: make(map[string]BountyEntry)}
+}
+
+// SetBounty places a bounty on the enemy called name
+func (r *Registry) SetBounty(name string, entry BountyEntry) {
+	r.entries[name] = entry
+}
+
+// SetDefaultBounty places a bounty on every enemy without one of its own
+func (r *Registry) SetDefaultBounty(entry BountyEntry) {
+	r.fallback = entry
+	r.hasFallback = true
+}
+
+// GetBounty returns the bounty on the enemy called name and whether one is set
+func (r *Registry) GetBounty(name string) (BountyEntry, bool) {
+	if entry, ok := r.entries[name]; ok {
+		return entry, true
+	}
+	return r.fallback, r.hasFallback
+}
+
+// Reward is something bounty points can be redeemed for
+type Reward int
+
+const (
+	// RestoreStructure repairs RestoreStructureAmount structure
+	RestoreStructure Reward = iota
+	// ResupplyAmmo refills every magazine
+	ResupplyAmmo
+	// RechargeShield fills the shield
+	RechargeShield
+)
+
+// Rewards lists every reward in the order the shop offers them
+var Rewards = []Reward{RestoreStructure, ResupplyAmmo, RechargeShield}
+
+// RestoreStructureAmount is the structure repaired by RestoreStructure
+const RestoreStructureAmount = 5
+
+// Cost returns the points needed to redeem the reward
+func (r Reward) Cost() int {
+	switch r {
+	case RestoreStructure:
+		return 100
+	case ResupplyAmmo:
+		return 50
+	case RechargeShield:
+		return 200
+	}
+	return 0
+}
+
+// String returns the display name of the reward
+func (r Reward) String() string {
+	switch r {
+	case RestoreStructure:
+		return "Structure Repair"
+	case ResupplyAmmo:
+		return "Ammo Resupply"
+	case RechargeShield:
+		return "Shield Recharge"
+	}
+	return "Unknown"
+}
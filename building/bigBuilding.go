@@ -0,0 +1,122 @@
+package building
+
+import (
+	tl "github.com/Ariemeth/termloop"
+)
+
+// GroupID identifies the buildings making up one big building
+type GroupID int
+
+// BigBuildingType describes a landmark built from several adjacent tiles
+type BigBuildingType struct {
+	Type
+	// Shape marks which tiles of the grid are built on, rows top to bottom
+	Shape [][]bool
+	// KarmaBonus is awarded when every tile of the building is destroyed
+	KarmaBonus int
+}
+
+// CityHall is the T shaped landmark at the center of the city
+var CityHall = BigBuildingType{
+	Type: Type{"City Hall", tl.ColorWhite, 'C', 1, 50},
+	Shape: [][]bool{
+		{true, true, true},
+		{false, true, false},
+	},
+	KarmaBonus: 250,
+}
+
+// Width returns the number of tile columns in the shape
+func (bt BigBuildingType) Width() int {
+	width := 0
+	for _, row := range bt.Shape {
+		if len(row) > width {
+			width = len(row)
+		}
+	}
+	return width
+}
+
+// Height returns the number of tile rows in the shape
+func (bt BigBuildingType) Height() int {
+	return len(bt.Shape)
+}
+
+// labelRow returns the shape row with the most tiles, where the name is drawn
+func (bt BigBuildingType) labelRow() int {
+	best, bestCount := 0, -1
+	for r, row := range bt.Shape {
+		count := 0
+		for _, built := range row {
+			if built {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = r, count
+		}
+	}
+	return best
+}
+
+// groupLabel is a name drawn across every tile of a big building
+type groupLabel struct {
+	text string
+	x, y int
+}
+
+// BigBuildingGenerator builds big buildings from tiles of a fixed size
+type BigBuildingGenerator struct {
+	manager    *Manager
+	tileWidth  int
+	tileHeight int
+	counts     map[string]int
+}
+
+// NewBigBuildingGenerator creates a generator registering the buildings it
+// creates with manager
+func NewBigBuildingGenerator(manager *Manager, tileWidth, tileHeight int) *BigBuildingGenerator {
+	return &BigBuildingGenerator{
+		manager:    manager,
+		tileWidth:  tileWidth,
+		tileHeight: tileHeight,
+		counts:     make(map[string]int),
+	}
+}
+
+// Generate creates one building per tile of bt's shape with the top left
+// tile at topLeftX,topLeftY. The buildings share a group so damage to any
+// of them is taken by all. Returns nil if the building does not fit within
+// the map or bt's MaxCount has been reached.
+func (g *BigBuildingGenerator) Generate(topLeftX, topLeftY, mapWidth, mapHeight int, bt BigBuildingType) []*Building {
+	if g.counts[bt.Name] >= bt.MaxCount {
+		return nil
+	}
+	width := bt.Width() * g.tileWidth
+	height := bt.Height() * g.tileHeight
+	if topLeftX < 0 || topLeftY < 0 || topLeftX+width > mapWidth || topLeftY+height > mapHeight {
+		return nil
+	}
+
+	label := &groupLabel{
+		text: bt.Name,
+		x:    topLeftX + (width-len(bt.Name))/2,
+		y:    topLeftY + bt.labelRow()*g.tileHeight + g.tileHeight/2,
+	}
+	buildings := make([]*Building, 0)
+	for r, row := range bt.Shape {
+		for c, built := range row {
+			if !built {
+				continue
+			}
+			b := NewBuilding(topLeftX+c*g.tileWidth, topLeftY+r*g.tileHeight,
+				g.tileWidth, g.tileHeight, bt.Type)
+			b.label = label
+			buildings = append(buildings, b)
+		}
+	}
+
+	g.manager.AddGroup(buildings, bt.KarmaBonus)
+	g.counts[bt.Name]++
+	return buildings
+}
@@ -0,0 +1,66 @@
+package building
+
+import (
+	"testing"
+)
+
+func TestGenerateBigBuilding(t *testing.T) {
+	manager := NewManager(nil)
+	generator := NewBigBuildingGenerator(manager, 4, 3)
+
+	buildings := generator.Generate(2, 1, 40, 40, CityHall)
+	if len(buildings) != 4 {
+		t.Fatalf("city hall has %d buildings instead of 4", len(buildings))
+	}
+
+	// The T shape covers the top row of three tiles and the middle tile below
+	expected := [][2]int{{2, 1}, {13, 1}, {6, 3}, {6, 6}, {9, 6}, {6, 4}, {9, 4}}
+	for _, cell := range expected {
+		if !coversCell(buildings, cell[0], cell[1]) {
+			t.Errorf("city hall does not cover %v", cell)
+		}
+	}
+	for _, cell := range [][2]int{{2, 4}, {13, 6}, {14, 1}, {2, 7}} {
+		if coversCell(buildings, cell[0], cell[1]) {
+			t.Errorf("city hall covers %v outside its shape", cell)
+		}
+	}
+
+	group := buildings[0].Group()
+	if group == 0 {
+		t.Fatalf("city hall buildings have no group")
+	}
+	for _, b := range buildings {
+		if b.Group() != group {
+			t.Errorf("building %d is in group %d instead of %d", b.ID(), b.Group(), group)
+		}
+	}
+	if len(manager.Group(group)) != len(buildings) {
+		t.Errorf("manager tracks %d buildings in the group instead of %d",
+			len(manager.Group(group)), len(buildings))
+	}
+
+	buildings[0].TakeHit(maxBuildingStructure)
+	for _, b := range buildings {
+		if b.Structure() != 0 {
+			t.Errorf("building %d has %d structure after the group was destroyed", b.ID(), b.Structure())
+		}
+	}
+	if manager.Karma() != CityHall.KarmaBonus {
+		t.Errorf("destroying city hall earned %d karma instead of %d", manager.Karma(), CityHall.KarmaBonus)
+	}
+
+	if generator.Generate(20, 20, 40, 40, CityHall) != nil {
+		t.Errorf("generated more city halls than its max count")
+	}
+}
+
+// coversCell returns true if any of buildings contains x,y
+func coversCell(buildings []*Building, x, y int) bool {
+	for _, b := range buildings {
+		if b.Contains(x, y) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,304 @@
+// Package building provides city buildings and tracking of their occupants
+package building
+
+import (
+	"github.com/Ariemeth/frame_assault/eventbus"
+	"github.com/Ariemeth/frame_assault/hooks"
+	"github.com/Ariemeth/frame_assault/lifecycle"
+	tl "github.com/Ariemeth/termloop"
+)
+
+// Type represents different types of buildings
+type Type struct {
+	Name     string
+	Color    tl.Attr
+	Char     rune
+	MaxCount int
+	Capacity int
+}
+
+// Types lists every kind of building that can be placed in the city
+var Types = []Type{
+	{"Hospital", tl.ColorRed, 'H', 1, 10},
+	{"School", tl.ColorYellow, 'S', 2, 30},
+	{"Bank", tl.ColorGreen, 'B', 2, 8},
+	{"Grocery", tl.ColorCyan, 'G', 3, 12},
+	{"Police", tl.ColorBlue, 'P', 2, 10},
+	{"Library", tl.ColorMagenta, 'L', 2, 15},
+	{"Mall", tl.ColorWhite, 'M', 2, 40},
+	{"Restaurant", tl.ColorRed, 'R', 4, 20},
+	{"Theater", tl.ColorYellow, 'T', 2, 25},
+	{"Gym", tl.ColorGreen, 'Y', 3, 15},
+	{"Home", tl.ColorWhite, 'H', 8, 4}, // Adding residential homes
+	{RepairBayName, tl.ColorCyan, 'W', 1, 2},
+	{PowerPlantName, tl.ColorYellow, 'P', 1, 6},
+	{ShelterName, tl.ColorBlue, 'Z', 2, 4},
+}
+
+// TypeByName returns the building type called name and whether it exists
+func TypeByName(name string) (Type, bool) {
+	for _, bt := range Types {
+		if bt.Name == name {
+			return bt, true
+		}
+	}
+	return Type{}, false
+}
+
+// RepairBayName is the name of the building that repairs mech weapons
+const RepairBayName = "Repair Bay"
+
+// PowerPlantName is the name of the building powering the city
+const PowerPlantName = "Power Plant"
+
+// ShelterName is the name of the building civilians evacuate to
+const ShelterName = "Shelter"
+
+// weaponRepairRate is the condition a repair bay restores per tick
+const weaponRepairRate = 10
+
+// maxBuildingStructure is the structure of an undamaged building
+const maxBuildingStructure = 100
+
+// Lighting adjusts the colors of a cell drawn at x,y for the time of day
+type Lighting interface {
+	Light(cell *tl.Cell, x, y int) *tl.Cell
+}
+
+// ID uniquely identifies a building managed by a Manager
+type ID int
+
+// Interior tracks how many entities are inside a building
+type Interior struct {
+	occupancy int
+	capacity  int
+}
+
+// Occupancy returns the number of entities currently inside
+func (in Interior) Occupancy() int {
+	return in.occupancy
+}
+
+// Capacity returns the maximum number of entities allowed inside
+func (in Interior) Capacity() int {
+	return in.capacity
+}
+
+// Building represents a city building with a specific purpose
+type Building struct {
+	*tl.Entity
+	Interior     Interior
+	id           ID
+	buildingType Type
+	width        int
+	height       int
+	lighting     Lighting
+	structure    int
+	manager      *Manager
+	group        GroupID
+	label        *groupLabel // Shared name drawn across a big building
+	// isPlayerBuilt marks buildings the player put up during the game
+	isPlayerBuilt bool
+}
+
+// NewBuilding creates a new building of the given type
+func NewBuilding(x, y, width, height int, buildingType Type) *Building {
+	building := &Building{
+		Entity:       tl.NewEntity(x, y, width, height),
+		Interior:     Interior{capacity: buildingType.Capacity},
+		buildingType: buildingType,
+		width:        width,
+		height:       height,
+		structure:    maxBuildingStructure,
+	}
+	lifecycle.NotifyCreate(building)
+	return building
+}
+
+// ID returns the identifier assigned by the Manager
+func (b *Building) ID() ID {
+	return b.id
+}
+
+// IsPlayerBuilt returns true if the player built the building during the
+// game rather than it being part of the city
+func (b *Building) IsPlayerBuilt() bool {
+	return b.isPlayerBuilt
+}
+
+// Type returns the type of the building
+func (b *Building) Type() Type {
+	return b.buildingType
+}
+
+// Name returns the name of the building type
+func (b *Building) Name() string {
+	return b.buildingType.Name
+}
+
+// centerDistance returns the squared distance from the building's center
+// to x,y
+func (b *Building) centerDistance(x, y int) int {
+	bx, by := b.Position()
+	dx, dy := bx+b.width/2-x, by+b.height/2-y
+	return dx*dx + dy*dy
+}
+
+// SetLighting sets the lighting applied when the building is drawn
+func (b *Building) SetLighting(lighting Lighting) {
+	b.lighting = lighting
+}
+
+// renderCell draws cell at x,y after applying any lighting
+func (b *Building) renderCell(s *tl.Screen, x, y int, cell *tl.Cell) {
+	if b.lighting != nil {
+		cell = b.lighting.Light(cell, x, y)
+	}
+	s.RenderCell(x, y, cell)
+}
+
+// WeaponRepairRate returns the weapon condition restored per tick to a mech
+// docked against the building, 0 for buildings that do not repair
+func (b *Building) WeaponRepairRate() int {
+	if b.buildingType.Name == RepairBayName {
+		return weaponRepairRate
+	}
+	return 0
+}
+
+// RestoresPower returns true for an undamaged power plant, which can
+// bring the city's power back on
+func (b *Building) RestoresPower() bool {
+	return b.buildingType.Name == PowerPlantName && b.structure == maxBuildingStructure
+}
+
+// Structure returns the building's remaining structure
+func (b *Building) Structure() int {
+	return b.structure
+}
+
+// MaxStructure returns the structure of an undamaged building
+func (b *Building) MaxStructure() int {
+	return maxBuildingStructure
+}
+
+// SetStructure sets the building's remaining structure, clamped between 0
+// and an undamaged building's structure
+func (b *Building) SetStructure(structure int) {
+	switch {
+	case structure < 0:
+		structure = 0
+	case structure > maxBuildingStructure:
+		structure = maxBuildingStructure
+	}
+	b.structure = structure
+}
+
+// Group returns the big building the building is part of, 0 if none
+func (b *Building) Group() GroupID {
+	return b.group
+}
+
+// TakeHit damages the building, stopping at 0 structure. Damage to part of
+// a big building is taken by the whole group.
+func (b *Building) TakeHit(damage int) {
+	if b.group != 0 && b.manager != nil {
+		b.manager.DamageGroup(b.group, damage)
+		return
+	}
+	b.takeHit(damage)
+}
+
+// takeHit damages only this building, exploding it when destroyed
+func (b *Building) takeHit(damage int) {
+	if b.structure == 0 {
+		return
+	}
+	b.structure -= damage
+	if b.structure > 0 {
+		return
+	}
+	b.structure = 0
+	if b.manager != nil && b.manager.stats != nil {
+		b.manager.stats.IncrementDestroyed(b.buildingType.Name)
+	}
+	if b.manager != nil && b.manager.exploder != nil {
+		x, y := b.Position()
+		b.manager.exploder.Explode(x+b.width/2, y+b.height/2)
+	}
+	if b.manager != nil && b.manager.bus != nil {
+		x, y := b.Position()
+		b.manager.bus.Publish(eventbus.BuildingBurnedEvent{
+			ID: int(b.id), Name: b.buildingType.Name, X: x, Y: y, Width: b.width, Height: b.height,
+		})
+	}
+}
+
+// Contains returns true if x,y lies within the building footprint
+func (b *Building) Contains(x, y int) bool {
+	bx, by := b.Position()
+	return x >= bx && x < bx+b.width && y >= by && y < by+b.height
+}
+
+// Draw renders the building outline, fill and name
+func (b *Building) Draw(s *tl.Screen) {
+	if hooks.Draw("Building", b, s) {
+		return
+	}
+	x, y := b.Position()
+
+	// Draw building outline and fill
+	for i := 0; i < b.width; i++ {
+		for j := 0; j < b.height; j++ {
+			// Draw building outline
+			if i == 0 || i == b.width-1 || j == 0 || j == b.height-1 {
+				b.renderCell(s, x+i, y+j, &tl.Cell{
+					Bg: b.buildingType.Color,
+					Fg: tl.ColorBlack,
+					Ch: '█',
+				})
+			} else {
+				// Fill building interior
+				b.renderCell(s, x+i, y+j, &tl.Cell{
+					Bg: b.buildingType.Color,
+					Fg: tl.ColorBlack,
+					Ch: ' ',
+				})
+			}
+		}
+	}
+
+	if b.label != nil {
+		b.drawGroupLabel(s)
+		return
+	}
+
+	// Draw building name in the center
+	name := b.buildingType.Name
+	startX := x + (b.width-len(name))/2
+	startY := y + b.height/2
+
+	for i, ch := range name {
+		if startX+i < x+b.width-1 { // Ensure we don't write outside building bounds
+			b.renderCell(s, startX+i, startY, &tl.Cell{
+				Bg: b.buildingType.Color,
+				Fg: tl.ColorBlack,
+				Ch: ch,
+			})
+		}
+	}
+}
+
+// drawGroupLabel draws the part of a big building's name that falls
+// within this building
+func (b *Building) drawGroupLabel(s *tl.Screen) {
+	for i, ch := range b.label.text {
+		if b.Contains(b.label.x+i, b.label.y) {
+			b.renderCell(s, b.label.x+i, b.label.y, &tl.Cell{
+				Bg: b.buildingType.Color,
+				Fg: tl.ColorBlack,
+				Ch: ch,
+			})
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package building
+
+import (
+	"sync"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+// casualtyKarmaPenalty is the karma lost for each civilian left outside
+// when an evacuation ends
+const casualtyKarmaPenalty = 5
+
+// Evacuee is a civilian that must reach a shelter during an evacuation
+type Evacuee interface {
+	Position() (int, int)
+}
+
+// EvacuationManager counts down an evacuation and tracks which civilians
+// reached a shelter in time
+type EvacuationManager struct {
+	mu         sync.Mutex
+	buildings  *Manager
+	evacuees   []Evacuee
+	sheltered  map[Evacuee]ID
+	occupancy  map[ID]int
+	ticksLeft  int
+	active     bool
+	casualties int
+}
+
+// NewEvacuationManager creates an evacuation manager for the shelters
+// among buildings
+func NewEvacuationManager(buildings *Manager) *EvacuationManager {
+	return &EvacuationManager{
+		buildings: buildings,
+		sheltered: make(map[Evacuee]ID),
+		occupancy: make(map[ID]int),
+	}
+}
+
+// Register adds a civilian who must reach a shelter
+func (em *EvacuationManager) Register(evacuee Evacuee) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	em.evacuees = append(em.evacuees, evacuee)
+}
+
+// Start begins an evacuation lasting durationTicks with every shelter
+// empty, so civilians sheltered last time have to take shelter again. An
+// evacuation already under way keeps its countdown.
+func (em *EvacuationManager) Start(durationTicks int) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	if em.active {
+		return
+	}
+	em.active = true
+	em.ticksLeft = durationTicks
+	em.sheltered = make(map[Evacuee]ID)
+	em.occupancy = make(map[ID]int)
+}
+
+// Active returns true while the countdown is running
+func (em *EvacuationManager) Active() bool {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	return em.active
+}
+
+// RemainingTicks returns the ticks left before the evacuation ends
+func (em *EvacuationManager) RemainingTicks() int {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	return em.ticksLeft
+}
+
+// Casualties returns the civilians left outside by past evacuations
+func (em *EvacuationManager) Casualties() int {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	return em.casualties
+}
+
+// IsSheltered returns true if evacuee has reached a shelter
+func (em *EvacuationManager) IsSheltered(evacuee Evacuee) bool {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	_, ok := em.sheltered[evacuee]
+	return ok
+}
+
+// Shelter lets evacuee into the shelter shelterID if it has room.
+// Returns true if the evacuee is now sheltered.
+func (em *EvacuationManager) Shelter(evacuee Evacuee, shelterID ID) bool {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	if _, ok := em.sheltered[evacuee]; ok {
+		return true
+	}
+	shelter := em.buildings.Get(shelterID)
+	if shelter == nil || shelter.Name() != ShelterName || em.occupancy[shelterID] >= shelter.Interior.Capacity() {
+		return false
+	}
+	em.occupancy[shelterID]++
+	em.sheltered[evacuee] = shelterID
+	return true
+}
+
+// NearestShelter returns the closest shelter to x,y with room left, nil if
+// every shelter is full
+func (em *EvacuationManager) NearestShelter(x, y int) *Building {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	var nearest *Building
+	best := -1
+	for _, b := range em.buildings.Buildings() {
+		if b.Name() != ShelterName || em.occupancy[b.ID()] >= b.Interior.Capacity() {
+			continue
+		}
+		if distance := b.centerDistance(x, y); best < 0 || distance < best {
+			nearest, best = b, distance
+		}
+	}
+	return nearest
+}
+
+// Draw is a no-op, the countdown is shown by the evacuation timer display.
+func (em *EvacuationManager) Draw(screen *tl.Screen) {}
+
+// Tick counts the evacuation down by one frame, ending it when time runs out
+func (em *EvacuationManager) Tick(event tl.Event) {
+	if event.Type != tl.EventNone {
+		return
+	}
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	if !em.active {
+		return
+	}
+	em.ticksLeft--
+	if em.ticksLeft > 0 {
+		return
+	}
+	em.active = false
+	em.ticksLeft = 0
+
+	// Everyone still outside is a casualty
+	casualties := 0
+	for _, evacuee := range em.evacuees {
+		if _, ok := em.sheltered[evacuee]; !ok {
+			casualties++
+		}
+	}
+	em.casualties += casualties
+	em.buildings.AdjustKarma(-casualties * casualtyKarmaPenalty)
+}
@@ -0,0 +1,89 @@
+package building
+
+import (
+	"testing"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+func newShelterManager(t *testing.T) (*Manager, ID) {
+	t.Helper()
+	shelterType, ok := TypeByName(ShelterName)
+	if !ok {
+		t.Fatalf("no %s building type", ShelterName)
+	}
+	manager := NewManager(testRoads{})
+	id := manager.Add(NewBuilding(0, 0, 4, 4, shelterType))
+	return manager, id
+}
+
+func TestEvacuationCasualties(t *testing.T) {
+	manager, shelter := newShelterManager(t)
+	evacuation := NewEvacuationManager(manager)
+	manager.SetEvacuation(evacuation, 3)
+
+	sheltered := &testOccupant{x: 1, y: 1}
+	outside := &testOccupant{x: 20, y: 20}
+	evacuation.Register(sheltered)
+	evacuation.Register(outside)
+
+	manager.SetAlarm(true)
+	if !evacuation.Active() {
+		t.Fatalf("evacuation did not start when the alarm sounded")
+	}
+	if !evacuation.Shelter(sheltered, shelter) {
+		t.Fatalf("civilian was turned away from an empty shelter")
+	}
+
+	for i := 0; i < 3; i++ {
+		evacuation.Tick(tl.Event{Type: tl.EventNone})
+	}
+	if evacuation.Active() {
+		t.Fatalf("evacuation still active after the countdown expired")
+	}
+	if got := evacuation.Casualties(); got != 1 {
+		t.Errorf("expected 1 casualty, got %d", got)
+	}
+	if got := manager.Karma(); got != -casualtyKarmaPenalty {
+		t.Errorf("expected karma %d, got %d", -casualtyKarmaPenalty, got)
+	}
+}
+
+func TestShelterCapacity(t *testing.T) {
+	manager, shelter := newShelterManager(t)
+	evacuation := NewEvacuationManager(manager)
+	evacuation.Start(10)
+
+	capacity := manager.Get(shelter).Interior.Capacity()
+	for i := 0; i < capacity; i++ {
+		if !evacuation.Shelter(&testOccupant{}, shelter) {
+			t.Fatalf("civilian %d was turned away below capacity", i)
+		}
+	}
+	if evacuation.Shelter(&testOccupant{}, shelter) {
+		t.Errorf("full shelter accepted another civilian")
+	}
+	if evacuation.NearestShelter(0, 0) != nil {
+		t.Errorf("full shelter offered as nearest shelter")
+	}
+}
+
+func TestEvacuationStartsWithEmptyShelters(t *testing.T) {
+	manager, shelter := newShelterManager(t)
+	evacuation := NewEvacuationManager(manager)
+	civilian := &testOccupant{x: 1, y: 1}
+	evacuation.Register(civilian)
+
+	evacuation.Start(1)
+	evacuation.Shelter(civilian, shelter)
+	evacuation.Tick(tl.Event{Type: tl.EventNone})
+
+	evacuation.Start(1)
+	if evacuation.IsSheltered(civilian) {
+		t.Fatal("civilian still sheltered from the last evacuation")
+	}
+	evacuation.Tick(tl.Event{Type: tl.EventNone})
+	if got := evacuation.Casualties(); got != 1 {
+		t.Errorf("expected 1 casualty from the second evacuation, got %d", got)
+	}
+}
@@ -0,0 +1,305 @@
+package building
+
+import (
+	"fmt"
+
+	"github.com/Ariemeth/frame_assault/eventbus"
+)
+
+// maxExitSearchRadius limits how far from a building to look for a road
+const maxExitSearchRadius = 20
+
+// RoadChecker reports whether a cell contains a road
+type RoadChecker interface {
+	HasRoad(x, y int) bool
+}
+
+// Occupant is an entity that can enter and exit buildings
+type Occupant interface {
+	Position() (int, int)
+	SetPosition(x, y int)
+}
+
+// Manager tracks all buildings in the city and who is inside them
+type Manager struct {
+	buildings map[ID]*Building
+	order     []ID
+	nextID    ID
+	roads     RoadChecker
+	alarm     bool
+	locked    bool
+	groups    map[GroupID]*buildingGroup
+	nextGroup GroupID
+	karma     int
+	exploder  Exploder
+	stats     DestructionRecorder
+	bus       *eventbus.Bus
+	// evacuation starts when the alarm sounds
+	evacuation      *EvacuationManager
+	evacuationTicks int
+}
+
+// Exploder shows an explosion where a building is destroyed
+type Exploder interface {
+	Explode(x, y int)
+}
+
+// DestructionRecorder counts destroyed buildings by type name
+type DestructionRecorder interface {
+	IncrementDestroyed(name string)
+}
+
+// buildingGroup is the set of buildings making up one big building
+type buildingGroup struct {
+	members    []ID
+	karmaBonus int
+	destroyed  bool
+}
+
+// NewManager creates a building manager using roads to find exits
+func NewManager(roads RoadChecker) *Manager {
+	return &Manager{
+		buildings: make(map[ID]*Building),
+		nextID:    1,
+		roads:     roads,
+		groups:    make(map[GroupID]*buildingGroup),
+		nextGroup: 1,
+	}
+}
+
+// Add registers a building with the manager and returns its ID
+func (m *Manager) Add(b *Building) ID {
+	b.id = m.nextID
+	b.manager = m
+	m.nextID++
+	m.buildings[b.id] = b
+	m.order = append(m.order, b.id)
+	return b.id
+}
+
+// AddPlayerBuilt registers a building the player built and returns its
+// ID. Player built buildings never join a big building, so destroying
+// them earns no karma.
+func (m *Manager) AddPlayerBuilt(b *Building) ID {
+	b.isPlayerBuilt = true
+	return m.Add(b)
+}
+
+// AddGroup registers buildings as a single big building worth karmaBonus
+// when destroyed and returns the group's ID
+func (m *Manager) AddGroup(buildings []*Building, karmaBonus int) GroupID {
+	group := &buildingGroup{karmaBonus: karmaBonus}
+	groupID := m.nextGroup
+	m.nextGroup++
+	for _, b := range buildings {
+		b.group = groupID
+		group.members = append(group.members, m.Add(b))
+	}
+	m.groups[groupID] = group
+	return groupID
+}
+
+// Group returns the buildings in the big building groupID
+func (m *Manager) Group(groupID GroupID) []*Building {
+	group, ok := m.groups[groupID]
+	if !ok {
+		return nil
+	}
+	buildings := make([]*Building, 0, len(group.members))
+	for _, id := range group.members {
+		buildings = append(buildings, m.buildings[id])
+	}
+	return buildings
+}
+
+// DamageGroup damages every building in the group, awarding its karma
+// bonus the first time the whole group is destroyed
+func (m *Manager) DamageGroup(groupID GroupID, damage int) {
+	group, ok := m.groups[groupID]
+	if !ok {
+		return
+	}
+	for _, id := range group.members {
+		m.buildings[id].takeHit(damage)
+	}
+	if !group.destroyed && m.buildings[group.members[0]].Structure() == 0 {
+		group.destroyed = true
+		m.karma += group.karmaBonus
+	}
+}
+
+// SetExploder sets what shows the explosion of a destroyed building
+func (m *Manager) SetExploder(exploder Exploder) {
+	m.exploder = exploder
+}
+
+// SetDestructionRecorder sets what counts destroyed buildings
+func (m *Manager) SetDestructionRecorder(stats DestructionRecorder) {
+	m.stats = stats
+}
+
+// SetEventBus sets the bus building destruction is published on
+func (m *Manager) SetEventBus(bus *eventbus.Bus) {
+	m.bus = bus
+}
+
+// Karma returns the karma earned from destroyed big buildings less any
+// lost along the way
+func (m *Manager) Karma() int {
+	return m.karma
+}
+
+// AdjustKarma adds delta, which may be negative, to the karma total
+func (m *Manager) AdjustKarma(delta int) {
+	m.karma += delta
+}
+
+// Get returns the building with the given ID or nil
+func (m *Manager) Get(id ID) *Building {
+	return m.buildings[id]
+}
+
+// Buildings returns all managed buildings in the order they were added
+func (m *Manager) Buildings() []*Building {
+	buildings := make([]*Building, 0, len(m.order))
+	for _, id := range m.order {
+		buildings = append(buildings, m.buildings[id])
+	}
+	return buildings
+}
+
+// FindNearest returns the building called name whose center is closest
+// to x,y, nil if there is none
+func (m *Manager) FindNearest(name string, x, y int) *Building {
+	var nearest *Building
+	best := -1
+	for _, b := range m.Buildings() {
+		if b.Name() != name {
+			continue
+		}
+		if distance := b.centerDistance(x, y); best < 0 || distance < best {
+			nearest, best = b, distance
+		}
+	}
+	return nearest
+}
+
+// SetAlarm enables or disables the emergency alarm.
+// While the alarm is active capacity limits are ignored. Sounding the
+// alarm starts the evacuation countdown.
+func (m *Manager) SetAlarm(active bool) {
+	m.alarm = active
+	if active && m.evacuation != nil {
+		m.evacuation.Start(m.evacuationTicks)
+	}
+}
+
+// SetEvacuation sets the evacuation started for durationTicks whenever the
+// alarm sounds
+func (m *Manager) SetEvacuation(evacuation *EvacuationManager, durationTicks int) {
+	m.evacuation = evacuation
+	m.evacuationTicks = durationTicks
+}
+
+// AlarmActive returns true if the emergency alarm is active
+func (m *Manager) AlarmActive() bool {
+	return m.alarm
+}
+
+// SetLocked locks or unlocks the doors of every building. Nobody can
+// enter a locked building, even during an alarm.
+func (m *Manager) SetLocked(locked bool) {
+	m.locked = locked
+}
+
+// Locked returns true while building doors are locked
+func (m *Manager) Locked() bool {
+	return m.locked
+}
+
+// HandleEvent locks the doors when the power goes out and unlocks them
+// when it is restored
+func (m *Manager) HandleEvent(event eventbus.Event) {
+	switch event.(type) {
+	case eventbus.PowerOutageEvent:
+		m.SetLocked(true)
+	case eventbus.PowerRestoredEvent:
+		m.SetLocked(false)
+	}
+}
+
+// IsAtCapacity returns true if the building cannot accept more occupants
+func (m *Manager) IsAtCapacity(buildingID ID) bool {
+	b, ok := m.buildings[buildingID]
+	if !ok {
+		return true
+	}
+	return b.Interior.occupancy >= b.Interior.capacity
+}
+
+// Enter attempts to move an occupant into a building.
+// Returns true if the occupant entered. If the building is full or locked
+// the occupant is returned to the nearest exterior road cell and false is
+// returned.
+func (m *Manager) Enter(buildingID ID, occupant Occupant) bool {
+	b, ok := m.buildings[buildingID]
+	if !ok {
+		return false
+	}
+	if m.locked || !m.alarm && m.IsAtCapacity(buildingID) {
+		x, y := m.nearestExit(b, occupant)
+		occupant.SetPosition(x, y)
+		return false
+	}
+	b.Interior.occupancy++
+	return true
+}
+
+// Exit removes an occupant from a building
+func (m *Manager) Exit(buildingID ID) {
+	b, ok := m.buildings[buildingID]
+	if !ok || b.Interior.occupancy == 0 {
+		return
+	}
+	b.Interior.occupancy--
+}
+
+// Tooltip returns the building name with its occupancy, e.g. "Hospital (7/10)"
+func (m *Manager) Tooltip(buildingID ID) string {
+	b, ok := m.buildings[buildingID]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%s (%d/%d)", b.Name(), b.Interior.occupancy, b.Interior.capacity)
+}
+
+// nearestExit finds the closest road cell outside the building to the occupant.
+// Falls back to the cell just left of the building if no road is found.
+func (m *Manager) nearestExit(b *Building, occupant Occupant) (int, int) {
+	bx, by := b.Position()
+	fallbackX, fallbackY := bx-1, by
+	if m.roads == nil {
+		return fallbackX, fallbackY
+	}
+
+	ox, oy := occupant.Position()
+	for radius := 1; radius <= maxExitSearchRadius; radius++ {
+		for dx := -radius; dx <= radius; dx++ {
+			dy := radius - abs(dx)
+			for _, cy := range []int{oy + dy, oy - dy} {
+				cx := ox + dx
+				if !b.Contains(cx, cy) && m.roads.HasRoad(cx, cy) {
+					return cx, cy
+				}
+			}
+		}
+	}
+	return fallbackX, fallbackY
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,102 @@
+package building
+
+import (
+	"testing"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+type testRoads struct {
+	roads map[[2]int]bool
+}
+
+func (r testRoads) HasRoad(x, y int) bool {
+	return r.roads[[2]int{x, y}]
+}
+
+type testOccupant struct {
+	x, y int
+}
+
+func (o *testOccupant) Position() (int, int) {
+	return o.x, o.y
+}
+
+func (o *testOccupant) SetPosition(x, y int) {
+	o.x, o.y = x, y
+}
+
+func homeType() Type {
+	for _, bt := range Types {
+		if bt.Name == "Home" {
+			return bt
+		}
+	}
+	return Type{}
+}
+
+func TestCapacities(t *testing.T) {
+	expected := map[string]int{"Hospital": 10, "School": 30, "Home": 4}
+	for _, bt := range Types {
+		if want, ok := expected[bt.Name]; ok && bt.Capacity != want {
+			t.Errorf("%s capacity is %d instead of %d", bt.Name, bt.Capacity, want)
+		}
+	}
+}
+
+func TestEnterAtCapacity(t *testing.T) {
+	roads := testRoads{roads: map[[2]int]bool{{10, 14}: true}}
+	manager := NewManager(roads)
+	home := NewBuilding(10, 5, 8, 6, homeType())
+	id := manager.Add(home)
+
+	for i := 0; i < home.Interior.Capacity(); i++ {
+		occupant := &testOccupant{x: 10, y: 5}
+		if !manager.Enter(id, occupant) {
+			t.Fatalf("occupant %d was rejected before capacity was reached", i)
+		}
+	}
+
+	if !manager.IsAtCapacity(id) {
+		t.Errorf("building with %d/%d occupants is not at capacity",
+			home.Interior.Occupancy(), home.Interior.Capacity())
+	}
+
+	occupant := &testOccupant{x: 11, y: 10}
+	if manager.Enter(id, occupant) {
+		t.Errorf("occupant entered a building at capacity")
+	}
+	if occupant.x != 10 || occupant.y != 14 {
+		t.Errorf("rejected occupant is at (%d,%d) instead of road (10,14)", occupant.x, occupant.y)
+	}
+
+	if manager.Tooltip(id) != "Home (4/4)" {
+		t.Errorf("tooltip is %q instead of \"Home (4/4)\"", manager.Tooltip(id))
+	}
+}
+
+func TestEnterDuringAlarm(t *testing.T) {
+	manager := NewManager(nil)
+	home := NewBuilding(0, 0, 8, 6, homeType())
+	id := manager.Add(home)
+
+	for i := 0; i < home.Interior.Capacity(); i++ {
+		manager.Enter(id, &testOccupant{})
+	}
+
+	manager.SetAlarm(true)
+	if !manager.Enter(id, &testOccupant{}) {
+		t.Errorf("occupant was rejected while the alarm was active")
+	}
+}
+
+func TestExit(t *testing.T) {
+	manager := NewManager(nil)
+	id := manager.Add(NewBuilding(0, 0, 8, 6, Type{Name: "Test", Color: tl.ColorRed, Capacity: 1}))
+
+	manager.Enter(id, &testOccupant{})
+	manager.Exit(id)
+	if manager.IsAtCapacity(id) {
+		t.Errorf("building is at capacity after its only occupant exited")
+	}
+}
@@ -0,0 +1,159 @@
+// Package camera moves the view around the level
+package camera
+
+import (
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// spectateSeconds is how long the spectator watches before giving up
+	spectateSeconds = 10
+	// cycleSeconds is how long each entity is followed
+	cycleSeconds = 3
+)
+
+// positioned is an entity the camera can follow
+type positioned interface {
+	Position() (int, int)
+}
+
+// destroyable is an entity that may be destroyed while being followed
+type destroyable interface {
+	IsDestroyed() bool
+}
+
+// Spectator follows the entities left on the map after the player is
+// destroyed. The level keeps ticking underneath so everything carries on
+// moving while the camera cycles between them.
+type Spectator struct {
+	level    *tl.BaseLevel
+	entities []tl.Drawable
+	current  int
+	active   bool
+	done     func()
+	// Ticks spent on the current entity and spectating overall
+	cycleTicks    int
+	ticks         int
+	ticksPerCycle int
+	durationTicks int
+}
+
+// NewSpectator creates a spectator camera for level at fps frames per
+// second, calling done once spectating ends
+func NewSpectator(level *tl.BaseLevel, fps int, done func()) *Spectator {
+	return &Spectator{
+		level:         level,
+		done:          done,
+		ticksPerCycle: cycleSeconds * fps,
+		durationTicks: spectateSeconds * fps,
+	}
+}
+
+// SetEntities sets the entities the camera cycles between
+func (s *Spectator) SetEntities(entities []tl.Drawable) {
+	s.entities = entities
+	s.current = 0
+}
+
+// Start begins spectating from the first entity still in play
+func (s *Spectator) Start() {
+	s.active = true
+	s.ticks = 0
+	s.cycleTicks = 0
+	s.current = -1
+	s.Next()
+}
+
+// Active returns true while the spectator has the camera
+func (s *Spectator) Active() bool {
+	return s.active
+}
+
+// Current returns the entity being followed, nil if there is none
+func (s *Spectator) Current() tl.Drawable {
+	if s.current < 0 || s.current >= len(s.entities) {
+		return nil
+	}
+	return s.entities[s.current]
+}
+
+// Next moves the camera on to the following entity still in play
+func (s *Spectator) Next() {
+	s.cycle(1)
+}
+
+// Previous moves the camera back to the preceding entity still in play
+func (s *Spectator) Previous() {
+	s.cycle(-1)
+}
+
+// cycle steps through the entities in direction, skipping destroyed ones
+func (s *Spectator) cycle(direction int) {
+	s.cycleTicks = 0
+	count := len(s.entities)
+	for i := 1; i <= count; i++ {
+		next := ((s.current+direction*i)%count + count) % count
+		if d, ok := s.entities[next].(destroyable); ok && d.IsDestroyed() {
+			continue
+		}
+		s.current = next
+		return
+	}
+}
+
+// Follow centers a screen of the given size on the current entity
+func (s *Spectator) Follow(screenWidth, screenHeight int) {
+	entity, ok := s.Current().(positioned)
+	if !ok {
+		return
+	}
+	x, y := entity.Position()
+	s.level.SetOffset(screenWidth/2-x, screenHeight/2-y)
+}
+
+// Draw keeps the view on the followed entity while spectating
+func (s *Spectator) Draw(screen *tl.Screen) {
+	if !s.active {
+		return
+	}
+	s.Follow(screen.Size())
+}
+
+// Tick switches entities every few seconds or on the arrow keys and ends
+// spectating when time runs out or Esc is pressed
+func (s *Spectator) Tick(event tl.Event) {
+	if !s.active {
+		return
+	}
+	if event.Type == tl.EventKey {
+		switch event.Key {
+		case tl.KeyArrowRight, tl.KeyArrowDown:
+			s.Next()
+		case tl.KeyArrowLeft, tl.KeyArrowUp:
+			s.Previous()
+		case tl.KeyEsc:
+			s.finish()
+		}
+		return
+	}
+	if event.Type != tl.EventNone {
+		return
+	}
+	s.ticks++
+	s.cycleTicks++
+	if s.ticks >= s.durationTicks {
+		s.finish()
+		return
+	}
+	if s.cycleTicks >= s.ticksPerCycle {
+		s.Next()
+	}
+}
+
+// finish hands the camera back, calling done
+func (s *Spectator) finish() {
+	s.active = false
+	if s.done != nil {
+		s.done()
+	}
+}
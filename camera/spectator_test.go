@@ -0,0 +1,53 @@
+package camera
+
+import (
+	"testing"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+func TestSpectatorFollowsEntities(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	first := tl.NewEntity(10, 5, 1, 1)
+	second := tl.NewEntity(30, 20, 1, 1)
+	spectator := NewSpectator(level, 10, nil)
+	spectator.SetEntities([]tl.Drawable{first, second})
+	spectator.Start()
+
+	const width, height = 80, 40
+	spectator.Follow(width, height)
+	if x, y := level.Offset(); x != width/2-10 || y != height/2-5 {
+		t.Errorf("offset %d,%d does not center the first entity", x, y)
+	}
+
+	spectator.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyArrowRight})
+	spectator.Follow(width, height)
+	if x, y := level.Offset(); x != width/2-30 || y != height/2-20 {
+		t.Errorf("offset %d,%d does not center the second entity", x, y)
+	}
+
+	spectator.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyArrowLeft})
+	if spectator.Current() != first {
+		t.Errorf("left arrow did not return to the first entity")
+	}
+}
+
+func TestSpectatorCyclesAndEnds(t *testing.T) {
+	done := false
+	first := tl.NewEntity(0, 0, 1, 1)
+	second := tl.NewEntity(5, 5, 1, 1)
+	spectator := NewSpectator(tl.NewBaseLevel(tl.Cell{}), 1, func() { done = true })
+	spectator.SetEntities([]tl.Drawable{first, second})
+	spectator.Start()
+
+	for i := 0; i < cycleSeconds; i++ {
+		spectator.Tick(tl.Event{Type: tl.EventNone})
+	}
+	if spectator.Current() != second {
+		t.Errorf("camera did not move on after %d seconds", cycleSeconds)
+	}
+	spectator.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyEsc})
+	if !done || spectator.Active() {
+		t.Errorf("Esc did not end spectating")
+	}
+}
@@ -0,0 +1,170 @@
+// Package challenge contains optional game modes that make the game harder
+package challenge
+
+import (
+	"github.com/Ariemeth/frame_assault/lifecycle"
+	"github.com/Ariemeth/frame_assault/mech"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// respawnDelaySeconds is how long a destroyed enemy stays down
+	respawnDelaySeconds = 5
+	// MaxRespawns caps the respawns of a mode so fights always end
+	MaxRespawns = 5
+)
+
+// Mode reacts to the destruction of enemy mechs
+type Mode interface {
+	OnEnemyDestroyed(e *mech.EnemyMech, level *tl.BaseLevel)
+}
+
+// Player is the mech respawned enemies are registered with
+type Player interface {
+	AddEnemy(enemy *mech.Mech)
+}
+
+// pendingRespawn is a destroyed enemy waiting out its delay
+type pendingRespawn struct {
+	enemy     *mech.EnemyMech
+	level     *tl.BaseLevel
+	ticksLeft int
+}
+
+// RespawnMode brings destroyed enemies back at their spawn position with
+// their structure multiplied by structureMultiplier
+type RespawnMode struct {
+	structureMultiplier int
+	delayTicks          int
+	player              Player
+	level               *tl.BaseLevel
+	// spawns are the positions enemies were first seen at
+	spawns map[*mech.EnemyMech][2]int
+	// watching are the enemies still alive
+	watching map[*mech.EnemyMech]bool
+	// respawnCount is how many times each enemy has been respawned
+	respawnCount map[*mech.EnemyMech]int
+	total        int
+	pending      []*pendingRespawn
+}
+
+// NewRespawnMode creates a respawn mode ticking at fps frames per second
+// that watches the enemies in level
+func NewRespawnMode(structureMultiplier int, fps float64, player Player, level *tl.BaseLevel) *RespawnMode {
+	return &RespawnMode{
+		structureMultiplier: structureMultiplier,
+		delayTicks:          int(respawnDelaySeconds * fps),
+		player:              player,
+		level:               level,
+		spawns:              make(map[*mech.EnemyMech][2]int),
+		watching:            make(map[*mech.EnemyMech]bool),
+		respawnCount:        make(map[*mech.EnemyMech]int),
+	}
+}
+
+// StructureMultiplier returns how much each respawn multiplies structure by
+func (rm *RespawnMode) StructureMultiplier() int {
+	return rm.structureMultiplier
+}
+
+// Respawns returns how many enemies have respawned so far
+func (rm *RespawnMode) Respawns() int {
+	return rm.total
+}
+
+// RespawnsLeft returns how many more respawns the mode allows
+func (rm *RespawnMode) RespawnsLeft() int {
+	return MaxRespawns - rm.total
+}
+
+// OnEnemyDestroyed schedules e to respawn once the delay has passed
+func (rm *RespawnMode) OnEnemyDestroyed(e *mech.EnemyMech, level *tl.BaseLevel) {
+	scheduled := rm.total + len(rm.pending)
+	if scheduled >= MaxRespawns {
+		return
+	}
+	rm.pending = append(rm.pending, &pendingRespawn{
+		enemy:     e,
+		level:     level,
+		ticksLeft: rm.delayTicks,
+	})
+}
+
+// Draw is a no-op, the ChallengePanel display shows the mode's progress.
+func (rm *RespawnMode) Draw(screen *tl.Screen) {}
+
+// Tick watches for destroyed enemies and respawns those whose delay has
+// passed
+func (rm *RespawnMode) Tick(event tl.Event) {
+	if event.Type != tl.EventNone {
+		return
+	}
+	rm.watch()
+
+	waiting := rm.pending[:0]
+	for _, p := range rm.pending {
+		p.ticksLeft--
+		if p.ticksLeft > 0 {
+			waiting = append(waiting, p)
+			continue
+		}
+		rm.respawn(p)
+	}
+	rm.pending = waiting
+}
+
+// watch starts watching new enemies and reports those destroyed since
+// the last tick
+func (rm *RespawnMode) watch() {
+	for _, entity := range rm.level.Entities {
+		if enemy, ok := entity.(*mech.EnemyMech); ok {
+			rm.track(enemy)
+		}
+	}
+	for enemy := range rm.watching {
+		if enemy.IsDestroyed() {
+			delete(rm.watching, enemy)
+			rm.OnEnemyDestroyed(enemy, rm.level)
+		}
+	}
+}
+
+// track remembers where a newly seen enemy spawned
+func (rm *RespawnMode) track(enemy *mech.EnemyMech) {
+	if _, known := rm.spawns[enemy]; known || enemy.IsDestroyed() {
+		return
+	}
+	x, y := enemy.Position()
+	rm.spawns[enemy] = [2]int{x, y}
+	rm.watching[enemy] = true
+}
+
+// RespawnCount returns how many times enemy has been respawned
+func (rm *RespawnMode) RespawnCount(enemy *mech.EnemyMech) int {
+	return rm.respawnCount[enemy]
+}
+
+// respawn brings p's enemy back at its spawn position with multiplied
+// structure
+func (rm *RespawnMode) respawn(p *pendingRespawn) {
+	spawn, ok := rm.spawns[p.enemy]
+	if !ok {
+		spawn[0], spawn[1] = p.enemy.Position()
+	}
+	maxStructure := p.enemy.MaxStructure()
+	if maxStructure < 1 {
+		maxStructure = 1
+	}
+	enemy := p.enemy.Respawn(spawn[0], spawn[1], maxStructure*rm.structureMultiplier)
+
+	rm.total++
+	rm.spawns[enemy] = spawn
+	rm.respawnCount[enemy] = rm.respawnCount[p.enemy] + 1
+	rm.watching[enemy] = true
+
+	if rm.player != nil {
+		rm.player.AddEnemy(enemy.Mech)
+	}
+	lifecycle.RemoveEntity(p.level, p.enemy)
+	p.level.AddEntity(enemy)
+}
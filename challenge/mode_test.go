@@ -0,0 +1,89 @@
+package challenge
+
+import (
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/mech"
+	"github.com/Ariemeth/frame_assault/mech/movement"
+	tl "github.com/Ariemeth/termloop"
+)
+
+type testPlayer struct {
+	enemies []*mech.Mech
+}
+
+func (p *testPlayer) AddEnemy(enemy *mech.Mech) {
+	p.enemies = append(p.enemies, enemy)
+}
+
+// respawned returns the live enemy mechs in level other than original
+func respawned(level *tl.BaseLevel, original *mech.EnemyMech) []*mech.EnemyMech {
+	var found []*mech.EnemyMech
+	for _, entity := range level.Entities {
+		if enemy, ok := entity.(*mech.EnemyMech); ok && enemy != original {
+			found = append(found, enemy)
+		}
+	}
+	return found
+}
+
+func TestDestroyedEnemyRespawnsWithDoubledStructure(t *testing.T) {
+	const fps = 10
+	level := tl.NewBaseLevel(tl.Cell{})
+	player := &testPlayer{}
+	mode := NewRespawnMode(2, fps, player, level)
+
+	enemy := mech.NewEnemyMech("A I", 4, 7, 3, tl.ColorRed, 'A', movement.NewRandomWalkStrategy())
+	level.AddEntity(enemy)
+	tick := tl.Event{Type: tl.EventNone}
+	mode.Tick(tick)
+
+	enemy.Hit(enemy.StructureLeft(), "Player")
+
+	for i := 0; i < respawnDelaySeconds*fps-1; i++ {
+		mode.Tick(tick)
+	}
+	if found := respawned(level, enemy); len(found) != 0 {
+		t.Fatalf("enemy respawned before the %d second delay", respawnDelaySeconds)
+	}
+
+	mode.Tick(tick)
+	mode.Tick(tick)
+	found := respawned(level, enemy)
+	if len(found) != 1 {
+		t.Fatalf("%d enemies respawned after the delay, want 1", len(found))
+	}
+	again := found[0]
+	if again.MaxStructure() != 8 || again.StructureLeft() != 8 {
+		t.Errorf("respawned with %d/%d structure, want 8/8", again.StructureLeft(), again.MaxStructure())
+	}
+	if x, y := again.Position(); x != 7 || y != 3 {
+		t.Errorf("respawned at %d,%d, want the spawn position 7,3", x, y)
+	}
+	if len(player.enemies) != 1 || player.enemies[0] != again.Mech {
+		t.Error("respawned enemy was not added to the player's enemies")
+	}
+	if mode.RespawnCount(again) != 1 {
+		t.Errorf("respawn count is %d, want 1", mode.RespawnCount(again))
+	}
+}
+
+func TestRespawnsCappedAtMax(t *testing.T) {
+	const fps = 1
+	level := tl.NewBaseLevel(tl.Cell{})
+	mode := NewRespawnMode(2, fps, nil, level)
+	level.AddEntity(mech.NewEnemyMech("A I", 1, 0, 0, tl.ColorRed, 'A', movement.NewRandomWalkStrategy()))
+
+	tick := tl.Event{Type: tl.EventNone}
+	for i := 0; i < (MaxRespawns+2)*(respawnDelaySeconds+2); i++ {
+		mode.Tick(tick)
+		for _, entity := range level.Entities {
+			if enemy, ok := entity.(*mech.EnemyMech); ok {
+				enemy.Hit(enemy.StructureLeft(), "Player")
+			}
+		}
+	}
+	if mode.Respawns() != MaxRespawns {
+		t.Errorf("%d respawns, want the cap of %d", mode.Respawns(), MaxRespawns)
+	}
+}
@@ -0,0 +1,90 @@
+// Package config loads the optional game configuration file
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Game is the root of the game configuration file
+type Game struct {
+	Waves      []Wave     `yaml:"waves"`
+	Difficulty Difficulty `yaml:"difficulty"`
+	// Lives is how many times the player can respawn into the damaged city
+	Lives int `yaml:"lives"`
+	// MaxBullets is how many bullets may fly at once before weapons hold
+	// their fire
+	MaxBullets int `yaml:"max_bullets"`
+	// EntityWarnThreshold is the level entity count above which a warning
+	// is logged, and EntityHardLimit the count above which bullets are culled
+	EntityWarnThreshold int `yaml:"entity_warn_threshold"`
+	EntityHardLimit     int `yaml:"entity_hard_limit"`
+	// Weapons overrides the damage and hit rate of weapons by name
+	Weapons map[string]WeaponStats `yaml:"weapons"`
+	// NextWaveMechs is how many mechs the next wave brings, 0 to leave the
+	// wave as configured
+	NextWaveMechs int `yaml:"next_wave_mechs"`
+	// FPS is the frames per second the game runs at, 0 for the default
+	FPS int `yaml:"fps"`
+	// EnemyMoveDelayChange is added to the ticks between enemy moves,
+	// negative for faster enemies. Unset leaves the current change, 0
+	// resets it.
+	EnemyMoveDelayChange *int `yaml:"enemy_move_delay_change"`
+	// EnemyHitRateBonus is added to the hit rate of enemy weapons. Unset
+	// leaves the current bonus, 0 resets it.
+	EnemyHitRateBonus *float64 `yaml:"enemy_hit_rate_bonus"`
+}
+
+// WeaponStats overrides a weapon's stats, 0 leaving a stat unchanged
+type WeaponStats struct {
+	Damage  int     `yaml:"damage"`
+	HitRate float64 `yaml:"hit_rate"`
+}
+
+// Difficulty controls how enemies toughen as the game goes on
+type Difficulty struct {
+	// ScalingPeriod is the number of ticks per step of scaling
+	ScalingPeriod int `yaml:"scaling_period"`
+	// ScalingFactor is the fraction of a base stat added each period
+	ScalingFactor float64 `yaml:"scaling_factor"`
+}
+
+// Wave describes a wave of enemy reinforcements
+type Wave struct {
+	// Trigger is either "time" or "kills"
+	Trigger   string  `yaml:"trigger"`
+	Seconds   float64 `yaml:"seconds"`
+	Kills     int     `yaml:"kills"`
+	MechCount int     `yaml:"mech_count"`
+	Mechs     []Mech  `yaml:"mechs"`
+}
+
+// Mech describes an enemy mech spawned by a wave
+type Mech struct {
+	Name      string `yaml:"name"`
+	Symbol    string `yaml:"symbol"`
+	Weapon    string `yaml:"weapon"`
+	Structure int    `yaml:"structure"`
+	// DistanceMode is "euclidean" or "manhattan", defaulting to the weapon's own
+	DistanceMode string `yaml:"distance_mode"`
+}
+
+// Load reads and parses the configuration file at path
+func Load(path string) (*Game, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config: %v", err)
+	}
+	return Parse(data)
+}
+
+// Parse parses YAML configuration data
+func Parse(data []byte) (*Game, error) {
+	var game Game
+	if err := yaml.Unmarshal(data, &game); err != nil {
+		return nil, fmt.Errorf("error parsing config: %v", err)
+	}
+	return &game, nil
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RemoteConfig polls a URL for configuration changes so the game can be
+// tuned while it runs
+type RemoteConfig struct {
+	client *http.Client
+	etag   string
+	done   chan struct{}
+	stop   sync.Once
+}
+
+// NewRemoteConfig creates a remote configuration poller
+func NewRemoteConfig() *RemoteConfig {
+	return &RemoteConfig{
+		client: &http.Client{Timeout: 5 * time.Second},
+		done:   make(chan struct{}),
+	}
+}
+
+// PollForUpdates fetches url every interval until Stop is called. Whenever
+// the ETag of the response changes its configuration is laid over current,
+// so settings it leaves out keep their values, and sent on notify. Failed
+// polls are tried again at the next interval.
+func (r *RemoteConfig) PollForUpdates(url string, interval time.Duration, current *Game, notify chan<- *Game) {
+	if current == nil {
+		current = &Game{}
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if updated, err := r.fetch(url, current); err == nil && updated != nil {
+			current = updated
+			select {
+			case notify <- updated:
+			case <-r.done:
+				return
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-r.done:
+			return
+		}
+	}
+}
+
+// Stop ends polling
+func (r *RemoteConfig) Stop() {
+	r.stop.Do(func() { close(r.done) })
+}
+
+// fetch returns the configuration at url laid over current, or nil if its
+// ETag has not changed since the last fetch
+func (r *RemoteConfig) fetch(url string, current *Game) (*Game, error) {
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating config request: %v", err)
+	}
+	if r.etag != "" {
+		request.Header.Set("If-None-Match", r.etag)
+	}
+	response, err := r.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching config: %v", err)
+	}
+	defer response.Body.Close()
+
+	etag := response.Header.Get("ETag")
+	if response.StatusCode == http.StatusNotModified || (etag != "" && etag == r.etag) {
+		return nil, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching config: %s", response.Status)
+	}
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config: %v", err)
+	}
+
+	// Copy the weapons so the update does not change current's
+	updated := *current
+	updated.Weapons = make(map[string]WeaponStats, len(current.Weapons))
+	for name, stats := range current.Weapons {
+		updated.Weapons[name] = stats
+	}
+	if err := yaml.Unmarshal(data, &updated); err != nil {
+		return nil, fmt.Errorf("error parsing config: %v", err)
+	}
+	r.etag = etag
+	return &updated, nil
+}
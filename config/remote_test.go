@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPollForUpdatesReceivesNewConfigs(t *testing.T) {
+	configs := []string{
+		"fps: 20\nweapons:\n  rifle:\n    damage: 3\n",
+		"weapons:\n  rifle:\n    hit_rate: 0.5\n",
+	}
+	var mu sync.Mutex
+	served := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		version := served
+		if version >= len(configs) {
+			version = len(configs) - 1
+		}
+		etag := fmt.Sprintf("%q", fmt.Sprint(version))
+		served++
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		fmt.Fprint(w, configs[version])
+	}))
+	defer server.Close()
+
+	remote := NewRemoteConfig()
+	defer remote.Stop()
+	updates := make(chan *Game)
+	go remote.PollForUpdates(server.URL, time.Millisecond, &Game{Lives: 5}, updates)
+
+	receive := func() *Game {
+		select {
+		case update := <-updates:
+			return update
+		case <-time.After(time.Second):
+			t.Fatal("no config update received")
+			return nil
+		}
+	}
+	first := receive()
+	if first.FPS != 20 || first.Weapons["rifle"].Damage != 3 || first.Lives != 5 {
+		t.Errorf("first update is %+v, want 20 fps, rifle damage 3 and 5 lives kept", first)
+	}
+	second := receive()
+	if second.FPS != 20 || second.Weapons["rifle"].HitRate != 0.5 {
+		t.Errorf("second update is %+v, want 20 fps kept and rifle hit rate 0.5", second)
+	}
+
+	select {
+	case update := <-updates:
+		t.Errorf("received %+v although the config did not change", update)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
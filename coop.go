@@ -0,0 +1,34 @@
+package main
+
+import (
+    "github.com/Ariemeth/frame_assault/mech"
+    "github.com/Ariemeth/frame_assault/mech/weapon"
+    tl "github.com/Ariemeth/termloop"
+)
+
+const (
+    defaultCoopAddr = ":7777"
+    coopPlayerID    = "Player 2"
+    // coopSpawnOffset is how far right of the player the second player starts
+    coopSpawnOffset = 2
+)
+
+// coopPlayerConfig draws the second player as a blue P
+func coopPlayerConfig() mech.PlayerConfig {
+    return mech.PlayerConfig{Symbol: 'P', Color: tl.ColorBlue, Loadout: weapon.Loadouts[0]}
+}
+
+// joinCoopPlayer creates the mech a co-op player controls beside the
+// player and hands it to the co-op server
+func (gs *GameState) joinCoopPlayer(x, y int, enemies []*mech.Mech) {
+    config := coopPlayerConfig()
+    player := mech.NewPlayerMech(coopPlayerID, 10, x+coopSpawnOffset, y, gs.level, config)
+    player.AttachGame(gs.game)
+    player.SetEnemyList(enemies)
+    player.AttachLogger(logger)
+    player.AttachClock(gs.ElapsedTicks)
+    player.AttachDamageLog(gs.damageLog)
+    player.EquipLoadout(config.Loadout)
+    gs.coopPlayer = player
+    gs.coop.Join(player, gs.level)
+}
@@ -0,0 +1,120 @@
+package coop
+
+import (
+	"bufio"
+	"net"
+	"strings"
+
+	"github.com/Ariemeth/frame_assault/mech"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// firePrefix starts an attack command, such as "fire_A"
+	firePrefix = "fire_"
+	// commandBuffer is how many received commands can wait to be applied
+	commandBuffer = 16
+)
+
+// moves maps each move command to the step it makes
+var moves = map[string][2]int{
+	"up":    {0, -1},
+	"down":  {0, 1},
+	"left":  {-1, 0},
+	"right": {1, 0},
+}
+
+// RemotePlayer is a second player mech driven by commands read from a
+// network connection, one per line. Commands are applied one per tick so a
+// remote player moves no faster than the local one.
+type RemotePlayer struct {
+	player   *mech.PlayerMech
+	conn     net.Conn
+	commands chan string
+}
+
+// NewRemotePlayer creates a remote player controlling player with the
+// commands received on conn
+func NewRemotePlayer(conn net.Conn, player *mech.PlayerMech) *RemotePlayer {
+	remote := &RemotePlayer{
+		player:   player,
+		conn:     conn,
+		commands: make(chan string, commandBuffer),
+	}
+	go remote.read()
+	return remote
+}
+
+// read queues every command received until the connection closes
+func (remote *RemotePlayer) read() {
+	defer close(remote.commands)
+	scanner := bufio.NewScanner(remote.conn)
+	for scanner.Scan() {
+		if command := strings.TrimSpace(scanner.Text()); command != "" {
+			remote.commands <- command
+		}
+	}
+}
+
+// Player returns the mech the remote player controls
+func (remote *RemotePlayer) Player() *mech.PlayerMech {
+	return remote.player
+}
+
+// Close closes the connection to the remote player
+func (remote *RemotePlayer) Close() error {
+	return remote.conn.Close()
+}
+
+// apply moves or attacks with the remote player's mech. Unknown commands
+// are ignored.
+func (remote *RemotePlayer) apply(command string) {
+	if step, ok := moves[command]; ok {
+		remote.player.Move(step[0], step[1])
+		return
+	}
+	if strings.HasPrefix(command, firePrefix) {
+		remote.player.Attack(strings.ToUpper(strings.TrimPrefix(command, firePrefix)))
+	}
+}
+
+// Tick applies the next received command on every game tick until the
+// remote mech is destroyed. Key events belong to the local player and are
+// not passed on to the remote mech.
+func (remote *RemotePlayer) Tick(event tl.Event) {
+	if event.Type != tl.EventNone || remote.player.IsDestroyed() {
+		return
+	}
+	select {
+	case command, ok := <-remote.commands:
+		if ok {
+			remote.apply(command)
+		}
+	default:
+	}
+	remote.player.Tick(event)
+}
+
+// Draw draws the remote player's mech, unless destroyed, without moving
+// the camera, which stays on the local player
+func (remote *RemotePlayer) Draw(screen *tl.Screen) {
+	if remote.player.IsDestroyed() {
+		return
+	}
+	remote.player.Mech.Draw(screen)
+}
+
+// Position returns the position of the remote player's mech
+func (remote *RemotePlayer) Position() (int, int) {
+	return remote.player.Position()
+}
+
+// Size returns the size of the remote player's mech
+func (remote *RemotePlayer) Size() (int, int) {
+	return remote.player.Size()
+}
+
+// Collide passes collisions on to the remote player's mech
+func (remote *RemotePlayer) Collide(collision tl.Physical) {
+	remote.player.Collide(collision)
+}
@@ -0,0 +1,63 @@
+package coop
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Ariemeth/frame_assault/mech"
+	"github.com/Ariemeth/frame_assault/mech/movement"
+	"github.com/Ariemeth/frame_assault/mech/weapon"
+	tl "github.com/Ariemeth/termloop"
+)
+
+func TestRemotePlayerMovesRight(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+
+	level := tl.NewBaseLevel(tl.Cell{})
+	player := mech.NewPlayerMech("Player 2", 10, 5, 5, level, mech.DefaultPlayerConfig())
+	remote := NewRemotePlayer(server, player)
+	defer remote.Close()
+
+	if _, err := client.Write([]byte("right\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		remote.Tick(tl.Event{Type: tl.EventNone})
+		if x, _ := remote.Position(); x != 5 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if x, y := remote.Position(); x != 6 || y != 5 {
+		t.Errorf("remote player is at %d,%d after moving right from 5,5, want 6,5", x, y)
+	}
+}
+
+func TestOverwatchFiresAtRemotePlayer(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+
+	level := tl.NewBaseLevel(tl.Cell{})
+	player := mech.NewPlayerMech("Player 2", 10, 7, 3, level, mech.DefaultPlayerConfig())
+	remote := NewRemotePlayer(server, player)
+	defer remote.Close()
+	level.AddEntity(remote)
+
+	overwatch := movement.NewOverwatchStrategy([4]int{5, 0, 10, 5},
+		func() weapon.Target { return player })
+	sniper := mech.NewEnemyMech("Sniper", 5, 0, 0, tl.ColorRed, 'S', overwatch)
+	sniper.AddWeapon(weapon.Create(100, 1, "test rifle", 1.0))
+	sniper.SetLevel(level)
+	level.AddEntity(sniper)
+
+	for i := 0; i < 20; i++ {
+		sniper.Tick(tl.Event{Type: tl.EventNone})
+	}
+	if player.StructureLeft() >= 10 {
+		t.Error("overwatch did not fire at the remote player in its zone")
+	}
+}
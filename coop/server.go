@@ -0,0 +1,87 @@
+package coop
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/Ariemeth/frame_assault/mech"
+	tl "github.com/Ariemeth/termloop"
+)
+
+// Server waits for a second player to connect and puts them in control of
+// a mech in the level. Only the first connection is accepted.
+type Server struct {
+	listener net.Listener
+	accepted chan net.Conn
+	remote   *RemotePlayer
+	player   *mech.PlayerMech
+	level    *tl.BaseLevel
+}
+
+// Listen starts accepting a second player on the TCP address addr
+func Listen(addr string) (*Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("error listening for co-op player on %s: %v", addr, err)
+	}
+	server := &Server{
+		listener: listener,
+		accepted: make(chan net.Conn, 1),
+	}
+	go server.accept()
+	return server, nil
+}
+
+// accept waits for the one connection the server takes
+func (server *Server) accept() {
+	conn, err := server.listener.Accept()
+	if err != nil {
+		return
+	}
+	server.accepted <- conn
+	server.listener.Close()
+}
+
+// Addr returns the address the server is listening on
+func (server *Server) Addr() net.Addr {
+	return server.listener.Addr()
+}
+
+// Join sets the mech a remote player controls and adds the server to level.
+// A player who has already connected takes over the new mech, so they stay
+// in the game when the city is rebuilt for a new life.
+func (server *Server) Join(player *mech.PlayerMech, level *tl.BaseLevel) {
+	server.player = player
+	server.level = level
+	level.AddEntity(server)
+	if server.remote != nil {
+		server.remote.player = player
+		level.AddEntity(server.remote)
+	}
+}
+
+// Close stops listening and disconnects the remote player
+func (server *Server) Close() error {
+	err := server.listener.Close()
+	if server.remote != nil {
+		server.remote.Close()
+	}
+	return err
+}
+
+// Tick spawns the remote player once they have connected. It runs on the
+// game loop so the level is never changed from another goroutine.
+func (server *Server) Tick(event tl.Event) {
+	if server.remote != nil || server.player == nil {
+		return
+	}
+	select {
+	case conn := <-server.accepted:
+		server.remote = NewRemotePlayer(conn, server.player)
+		server.level.AddEntity(server.remote)
+	default:
+	}
+}
+
+// Draw is a no-op, the remote player draws itself
+func (server *Server) Draw(screen *tl.Screen) {}
@@ -0,0 +1,91 @@
+package cover
+
+import (
+	"errors"
+
+	"github.com/Ariemeth/frame_assault/building"
+	"github.com/Ariemeth/frame_assault/util"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// searchRadius is how far from the player cover is looked for
+	searchRadius = 5
+	// suggestionTicks is how long a suggestion stays on screen
+	suggestionTicks = 5
+	suggestionGlyph = '★'
+)
+
+// ErrNoCover is returned when there is no cover near the player
+var ErrNoCover = errors.New("no cover within reach")
+
+// Advisor points the player to the nearest cell with the best cover from
+// their attacker by marking it with a star for a few ticks
+type Advisor struct {
+	buildings  *building.Manager
+	suggestion [2]int
+	ticks      int
+}
+
+// NewAdvisor creates an advisor looking for cover among buildings
+func NewAdvisor(buildings *building.Manager) *Advisor {
+	return &Advisor{buildings: buildings}
+}
+
+// SuggestCover returns the cell next to a building within searchRadius of
+// the player with the highest cover bonus against the attacker, the
+// nearest one if several are as good. Returns ErrNoCover if no cell nearby
+// gives any cover.
+func (a *Advisor) SuggestCover(playerX, playerY, attackerX, attackerY int, buildingManager *building.Manager) (suggestX, suggestY int, bonus float64, err error) {
+	system := NewSystem(buildingManager)
+	var nearest float64
+	for x := playerX - searchRadius; x <= playerX+searchRadius; x++ {
+		for y := playerY - searchRadius; y <= playerY+searchRadius; y++ {
+			distance := util.Distance(playerX, playerY, x, y, util.Euclidean)
+			if distance > searchRadius {
+				continue
+			}
+			cellBonus := system.CalculateCoverBonus(x, y, attackerX, attackerY)
+			if cellBonus == 0 {
+				continue
+			}
+			if cellBonus > bonus || (cellBonus == bonus && distance < nearest) {
+				suggestX, suggestY, bonus, nearest = x, y, cellBonus, distance
+			}
+		}
+	}
+	if bonus == 0 {
+		return 0, 0, 0, ErrNoCover
+	}
+	return suggestX, suggestY, bonus, nil
+}
+
+// Advise marks the best cover near the player from the attacker, if any
+func (a *Advisor) Advise(playerX, playerY, attackerX, attackerY int) {
+	x, y, _, err := a.SuggestCover(playerX, playerY, attackerX, attackerY, a.buildings)
+	if err != nil {
+		return
+	}
+	a.suggestion = [2]int{x, y}
+	a.ticks = suggestionTicks
+}
+
+// Suggestion returns the cell marked and true while a suggestion is shown
+func (a *Advisor) Suggestion() (x, y int, ok bool) {
+	return a.suggestion[0], a.suggestion[1], a.ticks > 0
+}
+
+// Tick counts down how long the suggestion is shown
+func (a *Advisor) Tick(event tl.Event) {
+	if event.Type == tl.EventNone && a.ticks > 0 {
+		a.ticks--
+	}
+}
+
+// Draw marks the suggested cover with a star
+func (a *Advisor) Draw(screen *tl.Screen) {
+	if a.ticks > 0 {
+		screen.RenderCell(a.suggestion[0], a.suggestion[1],
+			&tl.Cell{Fg: tl.ColorYellow | tl.AttrBold, Ch: suggestionGlyph})
+	}
+}
@@ -0,0 +1,62 @@
+// Package cover rates how well the city's buildings shelter a mech from an
+// attacker and points the player to the best cover nearby
+package cover
+
+import (
+	"math"
+
+	"github.com/Ariemeth/frame_assault/building"
+)
+
+const (
+	// FullCoverBonus is given by a building straight between a cell and
+	// the attacker
+	FullCoverBonus = 0.5
+	// PartialCoverBonus is given by a building beside that line
+	PartialCoverBonus = 0.25
+)
+
+// directions are the eight neighbouring cells, going round clockwise from
+// the right
+var directions = [8][2]int{{1, 0}, {1, 1}, {0, 1}, {-1, 1}, {-1, 0}, {-1, -1}, {0, -1}, {1, -1}}
+
+// System rates cover using the buildings still standing in the city
+type System struct {
+	buildings *building.Manager
+}
+
+// NewSystem creates a cover system for the city's buildings
+func NewSystem(buildings *building.Manager) *System {
+	return &System{buildings: buildings}
+}
+
+// IsBuilding returns true if a standing building covers x,y
+func (s *System) IsBuilding(x, y int) bool {
+	for _, b := range s.buildings.Buildings() {
+		if b.Structure() > 0 && b.Contains(x, y) {
+			return true
+		}
+	}
+	return false
+}
+
+// CalculateCoverBonus returns the cover a mech at x,y has against an
+// attacker at attackerX,attackerY. A building in the neighbouring cell
+// facing the attacker gives FullCoverBonus and one in either cell beside
+// it PartialCoverBonus. Cells inside buildings give no cover.
+func (s *System) CalculateCoverBonus(x, y, attackerX, attackerY int) float64 {
+	if (x == attackerX && y == attackerY) || s.IsBuilding(x, y) {
+		return 0
+	}
+	angle := math.Atan2(float64(attackerY-y), float64(attackerX-x))
+	facing := int(math.Round(angle/(math.Pi/4))+8) % 8
+	if d := directions[facing]; s.IsBuilding(x+d[0], y+d[1]) {
+		return FullCoverBonus
+	}
+	for _, side := range []int{(facing + 1) % 8, (facing + 7) % 8} {
+		if d := directions[side]; s.IsBuilding(x+d[0], y+d[1]) {
+			return PartialCoverBonus
+		}
+	}
+	return 0
+}
@@ -0,0 +1,40 @@
+package cover
+
+import (
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/building"
+)
+
+func TestSuggestCoverFindsFullCover(t *testing.T) {
+	buildings := building.NewManager(nil)
+	// A 3x3 building east of the player, with the attacker further east
+	buildings.Add(building.NewBuilding(13, 9, 3, 3, building.Types[0]))
+	advisor := NewAdvisor(buildings)
+
+	x, y, bonus, err := advisor.SuggestCover(10, 10, 30, 10, buildings)
+	if err != nil {
+		t.Fatalf("no cover suggested: %v", err)
+	}
+	if bonus != FullCoverBonus {
+		t.Errorf("suggested cover gives %v, want the maximum of %v", bonus, FullCoverBonus)
+	}
+	if x != 12 || y != 10 {
+		t.Errorf("suggested %d,%d, want 12,10 directly behind the building", x, y)
+	}
+
+	system := NewSystem(buildings)
+	if got := system.CalculateCoverBonus(12, 8, 30, 10); got != PartialCoverBonus {
+		t.Errorf("cell off the building's corner gives %v, want %v", got, PartialCoverBonus)
+	}
+	if got := system.CalculateCoverBonus(16, 10, 30, 10); got != 0 {
+		t.Errorf("cell on the attacker's side gives %v, want no cover", got)
+	}
+}
+
+func TestSuggestCoverWithoutBuildings(t *testing.T) {
+	buildings := building.NewManager(nil)
+	if _, _, _, err := NewAdvisor(buildings).SuggestCover(10, 10, 30, 10, buildings); err != ErrNoCover {
+		t.Errorf("got error %v, want ErrNoCover", err)
+	}
+}
@@ -0,0 +1,124 @@
+// Package damagelog records who damaged whom and when during a game
+package damagelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+)
+
+// EntityID identifies an attacker or defender in the log by name
+type EntityID string
+
+// Environment is the attacker recorded for damage from hazards such as
+// acid rain
+const Environment EntityID = "Environment"
+
+// DamageRecord is a single hit
+type DamageRecord struct {
+	AttackerID EntityID `json:"attacker"`
+	DefenderID EntityID `json:"defender"`
+	Damage     int      `json:"damage"`
+	Tick       int      `json:"tick"`
+}
+
+// AttackerTotal is the total damage an attacker has dealt
+type AttackerTotal struct {
+	AttackerID EntityID
+	Damage     int
+}
+
+// Log collects the hits of a game session
+type Log struct {
+	mu      sync.Mutex
+	records []DamageRecord
+}
+
+// NewLog creates an empty damage log
+func NewLog() *Log {
+	return &Log{records: make([]DamageRecord, 0)}
+}
+
+// RecordHit adds a hit of damage by attacker on defender at tick
+func (l *Log) RecordHit(attacker, defender EntityID, damage, tick int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records = append(l.records, DamageRecord{
+		AttackerID: attacker,
+		DefenderID: defender,
+		Damage:     damage,
+		Tick:       tick,
+	})
+}
+
+// Records returns a copy of every hit recorded, oldest first
+func (l *Log) Records() []DamageRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]DamageRecord(nil), l.records...)
+}
+
+// LastAttacker returns who last hit defender, false if nobody has
+func (l *Log) LastAttacker(defender EntityID) (EntityID, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for i := len(l.records) - 1; i >= 0; i-- {
+		if l.records[i].DefenderID == defender {
+			return l.records[i].AttackerID, true
+		}
+	}
+	return "", false
+}
+
+// SummaryByAttacker returns the total damage dealt by each attacker
+func (l *Log) SummaryByAttacker() map[EntityID]int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	summary := make(map[EntityID]int)
+	for _, record := range l.records {
+		summary[record.AttackerID] += record.Damage
+	}
+	return summary
+}
+
+// TopAttackers returns up to n attackers that dealt the most damage to
+// defender, most damage first
+func (l *Log) TopAttackers(defender EntityID, n int) []AttackerTotal {
+	l.mu.Lock()
+	totals := make(map[EntityID]int)
+	for _, record := range l.records {
+		if record.DefenderID == defender {
+			totals[record.AttackerID] += record.Damage
+		}
+	}
+	l.mu.Unlock()
+
+	top := make([]AttackerTotal, 0, len(totals))
+	for id, damage := range totals {
+		top = append(top, AttackerTotal{AttackerID: id, Damage: damage})
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Damage != top[j].Damage {
+			return top[i].Damage > top[j].Damage
+		}
+		return top[i].AttackerID < top[j].AttackerID
+	})
+	if len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// Save writes every recorded hit to path as JSON
+func (l *Log) Save(path string) error {
+	data, err := json.MarshalIndent(l.Records(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding damage log: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("error writing damage log: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package damagelog
+
+import "testing"
+
+func TestSummaryByAttacker(t *testing.T) {
+	log := NewLog()
+	log.RecordHit("Mech A", "Player", 3, 1)
+	log.RecordHit("Mech B", "Player", 5, 2)
+	log.RecordHit("Mech A", "Player", 2, 3)
+	log.RecordHit("Mech B", "Mech C", 4, 4)
+	log.RecordHit("Mech A", "Player", 1, 5)
+
+	summary := log.SummaryByAttacker()
+	if len(summary) != 2 {
+		t.Errorf("summary has %d attackers, want 2: %v", len(summary), summary)
+	}
+	if summary["Mech A"] != 6 {
+		t.Errorf("Mech A dealt %d damage, want 6", summary["Mech A"])
+	}
+	if summary["Mech B"] != 9 {
+		t.Errorf("Mech B dealt %d damage, want 9", summary["Mech B"])
+	}
+
+	top := log.TopAttackers("Player", 3)
+	if len(top) != 2 || top[0].AttackerID != "Mech A" || top[1].Damage != 5 {
+		t.Errorf("top attackers against Player are %v, want Mech A 6 then Mech B 5", top)
+	}
+}
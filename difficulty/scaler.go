@@ -0,0 +1,59 @@
+// Package difficulty scales enemy stats with the time the game has run
+package difficulty
+
+const (
+	// DefaultScalingPeriod is 3 minutes of ticks at 10 FPS
+	DefaultScalingPeriod = 1800
+	// DefaultScalingFactor adds half the base stat every scaling period
+	DefaultScalingFactor = 0.5
+)
+
+// DefaultBaseStats are the reinforcement stats shown by Preview
+var DefaultBaseStats = map[string]int{
+	"structure": 4,
+	"damage":    1,
+}
+
+// Scaler grows a base stat by scalingFactor of itself every scalingPeriod
+// ticks
+type Scaler struct {
+	scalingPeriod int
+	scalingFactor float64
+	baseStats     map[string]int
+}
+
+// NewScaler creates a scaler, falling back to the defaults for a
+// non-positive period or a negative factor
+func NewScaler(scalingPeriod int, scalingFactor float64) *Scaler {
+	if scalingPeriod <= 0 {
+		scalingPeriod = DefaultScalingPeriod
+	}
+	if scalingFactor < 0 {
+		scalingFactor = DefaultScalingFactor
+	}
+	return &Scaler{
+		scalingPeriod: scalingPeriod,
+		scalingFactor: scalingFactor,
+		baseStats:     DefaultBaseStats,
+	}
+}
+
+// Scale returns baseStat * (1 + elapsedTicks/scalingPeriod * scalingFactor)
+func (s *Scaler) Scale(baseStat int, elapsedTicks int) int {
+	periods := float64(elapsedTicks) / float64(s.scalingPeriod)
+	return int(float64(baseStat) * (1 + periods*s.scalingFactor))
+}
+
+// SetBaseStats sets the stats shown by Preview
+func (s *Scaler) SetBaseStats(stats map[string]int) {
+	s.baseStats = stats
+}
+
+// Preview returns the base stats scaled to elapsedTicks, for balancing
+func (s *Scaler) Preview(elapsedTicks int) map[string]int {
+	preview := make(map[string]int, len(s.baseStats))
+	for name, base := range s.baseStats {
+		preview[name] = s.Scale(base, elapsedTicks)
+	}
+	return preview
+}
@@ -0,0 +1,28 @@
+package difficulty
+
+import "testing"
+
+func TestScaleDoublesAfterTwoPeriods(t *testing.T) {
+	scaler := NewScaler(100, 0.5)
+
+	if stat := scaler.Scale(10, 0); stat != 10 {
+		t.Errorf("stat at the start is %d instead of 10", stat)
+	}
+	if stat := scaler.Scale(10, 200); stat != 20 {
+		t.Errorf("stat after two scaling periods is %d instead of 20", stat)
+	}
+
+	preview := scaler.Preview(200)
+	for name, base := range DefaultBaseStats {
+		if preview[name] != base*2 {
+			t.Errorf("preview %s is %d instead of %d", name, preview[name], base*2)
+		}
+	}
+}
+
+func TestNewScalerDefaults(t *testing.T) {
+	scaler := NewScaler(0, -1)
+	if scaler.Scale(10, 2*DefaultScalingPeriod) != 20 {
+		t.Errorf("default scaler does not double stats after two periods")
+	}
+}
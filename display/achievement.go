@@ -0,0 +1,83 @@
+package display
+
+import (
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	achievementPopupWidth  = 40
+	achievementPopupHeight = 4
+	// achievementPopupTicks is how many frames a pop-up stays on screen
+	achievementPopupTicks = 40
+)
+
+// AchievementPopup briefly shows each newly unlocked achievement
+type AchievementPopup struct {
+	Status
+	textLine1 *tl.Text
+	textLine2 *tl.Text
+	ticksLeft int
+	// queued are achievements waiting for the current pop-up to close
+	queued [][2]string
+}
+
+// NewAchievementPopup creates a hidden achievement pop-up
+func NewAchievementPopup(x, y int, level *tl.BaseLevel) *AchievementPopup {
+	return &AchievementPopup{
+		Status:    *NewStatus(x, y, achievementPopupWidth, achievementPopupHeight, level),
+		textLine1: tl.NewText(x, y, "", tl.ColorYellow|tl.AttrBold, tl.ColorBlack),
+		textLine2: tl.NewText(x, y+1, "", tl.ColorWhite, tl.ColorBlack),
+	}
+}
+
+// Show pops up the achievement called name, waiting for any pop-up
+// already showing to close
+func (display *AchievementPopup) Show(name, description string) {
+	display.queued = append(display.queued, [2]string{name, description})
+	if display.ticksLeft == 0 {
+		display.next()
+	}
+}
+
+// Visible returns true while a pop-up is on screen
+func (display *AchievementPopup) Visible() bool {
+	return display.ticksLeft > 0
+}
+
+// next shows the oldest queued achievement
+func (display *AchievementPopup) next() {
+	if len(display.queued) == 0 {
+		return
+	}
+	achievement := display.queued[0]
+	display.queued = display.queued[1:]
+	display.textLine1.SetText("Achievement unlocked: " + achievement[0])
+	display.textLine2.SetText(achievement[1])
+	display.ticksLeft = achievementPopupTicks
+}
+
+// Draw passes the draw call to entity while a pop-up is showing.
+func (display *AchievementPopup) Draw(screen *tl.Screen) {
+	if !display.Visible() {
+		return
+	}
+	display.Status.Draw(screen)
+
+	offSetX, offSetY := display.level.Offset()
+	display.textLine1.SetPosition(-offSetX+textLineStartX+display.x, -offSetY+textLineStartY+display.y)
+	display.textLine2.SetPosition(-offSetX+textLineStartX+display.x, -offSetY+textLineStartY+textLineSpacing+display.y)
+
+	display.textLine1.Draw(screen)
+	display.textLine2.Draw(screen)
+}
+
+// Tick counts down the pop-up on screen and moves on to the next.
+func (display *AchievementPopup) Tick(event tl.Event) {
+	if event.Type != tl.EventNone || display.ticksLeft == 0 {
+		return
+	}
+	display.ticksLeft--
+	if display.ticksLeft == 0 {
+		display.next()
+	}
+}
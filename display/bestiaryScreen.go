@@ -0,0 +1,83 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Ariemeth/frame_assault/bestiary"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	bestiaryBarWidth = 10
+	bestiaryTitle    = "BESTIARY"
+	bestiaryPrompt   = "Press B to return"
+	bestiaryUnknown  = "???"
+)
+
+// BestiaryScreen lists every enemy mech type, showing the weapon,
+// structure and strategy of each one the player has fought and ??? for
+// the rest
+type BestiaryScreen struct {
+	bestiary *bestiary.Bestiary
+	models   []string
+}
+
+// NewBestiaryScreen creates a bestiary screen listing models in order
+func NewBestiaryScreen(b *bestiary.Bestiary, models []string) *BestiaryScreen {
+	return &BestiaryScreen{bestiary: b, models: models}
+}
+
+// Lines returns the text of the bestiary screen
+func (display *BestiaryScreen) Lines() []string {
+	entries := make(map[string]bestiary.Entry)
+	strongest := 0
+	for _, model := range display.models {
+		if display.bestiary == nil {
+			break
+		}
+		if entry, ok := display.bestiary.Lookup(model); ok {
+			entries[model] = entry
+			if entry.MaxStructure > strongest {
+				strongest = entry.MaxStructure
+			}
+		}
+	}
+
+	lines := []string{bestiaryTitle, ""}
+	for _, model := range display.models {
+		entry, ok := entries[model]
+		if !ok {
+			lines = append(lines, fmt.Sprintf("%-8s %s", model, bestiaryUnknown))
+			continue
+		}
+		filled := 0
+		if strongest > 0 {
+			filled = entry.MaxStructure * bestiaryBarWidth / strongest
+		}
+		bar := strings.Repeat("█", filled) + strings.Repeat("░", bestiaryBarWidth-filled)
+		lines = append(lines, fmt.Sprintf("%-8s %-8s %s %3d  %s", entry.Name, entry.WeaponType, bar,
+			entry.MaxStructure, bestiary.StrategyDescription(entry.StrategyType)))
+	}
+	return append(lines, "", bestiaryPrompt)
+}
+
+// Draw centers the bestiary on the screen
+func (display *BestiaryScreen) Draw(screen *tl.Screen) {
+	width, height := screen.Size()
+	lines := display.Lines()
+	top := (height - len(lines)) / 2
+	for i, line := range lines {
+		color := tl.ColorWhite
+		if i == 0 {
+			color = tl.ColorYellow | tl.AttrBold
+		}
+		text := tl.NewText(0, 0, line, color, tl.ColorBlack)
+		textWidth, _ := text.Size()
+		text.SetPosition((width-textWidth)/2, top+i)
+		text.Draw(screen)
+	}
+}
+
+// Tick does nothing, the game over screen toggles the bestiary with B
+func (display *BestiaryScreen) Tick(event tl.Event) {}
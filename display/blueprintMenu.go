@@ -0,0 +1,102 @@
+package display
+
+import (
+	"fmt"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	blueprintMenuWidth = 28
+	blueprintTitle     = "BUILD Enter:build ^B:close"
+)
+
+// BlueprintOption is a building listed in the blueprint menu
+type BlueprintOption struct {
+	Name string
+	Cost int
+}
+
+// BlueprintMenu lists the buildings the player can build with their costs.
+// Ctrl+B opens and closes it, the arrow keys pick a building and Enter
+// builds it.
+type BlueprintMenu struct {
+	Status
+	options  []BlueprintOption
+	selected int
+	open     bool
+	onBuild  func(name string)
+}
+
+// NewBlueprintMenu creates a closed menu of options at x,y, calling
+// onBuild with the name of the building picked
+func NewBlueprintMenu(x, y int, options []BlueprintOption, onBuild func(name string), level *tl.BaseLevel) *BlueprintMenu {
+	return &BlueprintMenu{
+		Status:  *NewStatus(x, y, blueprintMenuWidth, len(options)+3, level),
+		options: options,
+		onBuild: onBuild,
+	}
+}
+
+// Toggle opens or closes the menu
+func (display *BlueprintMenu) Toggle() {
+	display.open = !display.open
+}
+
+// IsOpen returns true while the menu is being shown
+func (display *BlueprintMenu) IsOpen() bool {
+	return display.open
+}
+
+// Selected returns the highlighted building
+func (display *BlueprintMenu) Selected() BlueprintOption {
+	return display.options[display.selected]
+}
+
+// Draw lists the buildings and their costs while the menu is open
+func (display *BlueprintMenu) Draw(screen *tl.Screen) {
+	if !display.open {
+		return
+	}
+	display.Status.Draw(screen)
+
+	offSetX, offSetY := display.level.Offset()
+	x := -offSetX + display.x + textLineStartX
+	y := -offSetY + display.y + textLineStartY
+	tl.NewText(x, y, blueprintTitle, tl.ColorWhite|tl.AttrBold, tl.ColorBlack).Draw(screen)
+	for i, option := range display.options {
+		fg, bg := tl.ColorWhite, tl.ColorBlack
+		if i == display.selected {
+			fg, bg = tl.ColorBlack, tl.ColorWhite
+		}
+		line := fmt.Sprintf("%-16s %5d", option.Name, option.Cost)
+		tl.NewText(x, y+1+i*textLineSpacing, line, fg, bg).Draw(screen)
+	}
+}
+
+// Tick toggles the menu on Ctrl+B and, while it is open, moves the
+// highlight with the arrow keys and builds the highlighted building on
+// Enter
+func (display *BlueprintMenu) Tick(event tl.Event) {
+	if event.Type != tl.EventKey {
+		return
+	}
+	if event.Key == tl.KeyCtrlB {
+		display.Toggle()
+		return
+	}
+	if !display.open || len(display.options) == 0 {
+		return
+	}
+	switch event.Key {
+	case tl.KeyArrowUp:
+		display.selected = (display.selected + len(display.options) - 1) % len(display.options)
+	case tl.KeyArrowDown:
+		display.selected = (display.selected + 1) % len(display.options)
+	case tl.KeyEnter:
+		display.open = false
+		if display.onBuild != nil {
+			display.onBuild(display.Selected().Name)
+		}
+	}
+}
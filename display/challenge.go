@@ -0,0 +1,55 @@
+package display
+
+import (
+	"fmt"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	challengePanelWidth  = 22
+	challengePanelHeight = 4
+)
+
+// ChallengeStatus defines the methods required for challenge display
+type ChallengeStatus interface {
+	StructureMultiplier() int
+	RespawnsLeft() int
+}
+
+// ChallengePanel shows the respawn multiplier of challenge mode
+type ChallengePanel struct {
+	Status
+	challenge ChallengeStatus
+	textLine1 *tl.Text
+	textLine2 *tl.Text
+}
+
+// NewChallengePanel creates a new challenge mode display
+func NewChallengePanel(x, y int, challenge ChallengeStatus, level *tl.BaseLevel) *ChallengePanel {
+	return &ChallengePanel{
+		Status:    *NewStatus(x, y, challengePanelWidth, challengePanelHeight, level),
+		challenge: challenge,
+		textLine1: tl.NewText(x, y, "", tl.ColorRed, tl.ColorBlack),
+		textLine2: tl.NewText(x, y+1, "", tl.ColorWhite, tl.ColorBlack),
+	}
+}
+
+// Draw passes the draw call to entity.
+func (display *ChallengePanel) Draw(screen *tl.Screen) {
+	display.Status.Draw(screen)
+
+	offSetX, offSetY := display.level.Offset()
+	display.textLine1.SetPosition(-offSetX+textLineStartX+display.x, -offSetY+textLineStartY+display.y)
+	display.textLine2.SetPosition(-offSetX+textLineStartX+display.x, -offSetY+textLineStartY+textLineSpacing+display.y)
+
+	display.textLine1.Draw(screen)
+	display.textLine2.Draw(screen)
+}
+
+// Tick is called to process 1 tick of actions based on the
+// current state of the game.
+func (display *ChallengePanel) Tick(event tl.Event) {
+	display.textLine1.SetText(fmt.Sprintf("Respawn x%d", display.challenge.StructureMultiplier()))
+	display.textLine2.SetText(fmt.Sprintf("Respawns left: %d", display.challenge.RespawnsLeft()))
+}
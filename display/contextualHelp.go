@@ -0,0 +1,267 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+// HelpContext is what the player is doing, deciding which controls are
+// worth showing
+type HelpContext int
+
+const (
+	// HelpDefault shows moving and attacking
+	HelpDefault HelpContext = iota
+	// HelpCombat shows the special weapons and tricks while enemies are near
+	HelpCombat
+	// HelpInteraction shows talking, trading and building beside a civilian
+	// or building
+	HelpInteraction
+)
+
+const (
+	// helpKeyWidth is the width of the key column
+	helpKeyWidth = 10
+	helpBoxWidth = 36
+	// helpReferenceTitle heads the full control reference
+	helpReferenceTitle = "CONTROLS - press ? to close"
+)
+
+// KeyBinding is a key and what it does
+type KeyBinding struct {
+	Key    string
+	Action string
+}
+
+// Control is something the player can do
+type Control int
+
+const (
+	ControlMove Control = iota
+	ControlAttack
+	ControlShop
+	ControlBulletTime
+	ControlOverload
+	ControlSmartBomb
+	ControlRepair
+	ControlResupply
+	ControlThermal
+	ControlReplay
+	ControlExtraction
+	ControlGrenade
+	ControlEMP
+	ControlBuild
+	ControlTalk
+	ControlAcceptQuest
+	ControlTrade
+	ControlInventory
+	ControlSnare
+	ControlStealth
+	ControlWaypoint
+	ControlExtinguish
+	ControlUndo
+	ControlSpeed
+	ControlHelp
+	ControlQuit
+)
+
+// controls binds every control to its key, in the order of the full
+// control reference
+var controls = [...]KeyBinding{
+	ControlMove:        {"Arrows", "Move"},
+	ControlAttack:      {"A-H", "Attack enemy"},
+	ControlShop:        {"F2", "Redeem bounties in the shop"},
+	ControlBulletTime:  {"F3", "Bullet time"},
+	ControlOverload:    {"F4", "Overload, double damage"},
+	ControlSmartBomb:   {"F5", "Smart bomb"},
+	ControlRepair:      {"F6", "Repair for 100 bounty"},
+	ControlResupply:    {"F7", "Resupply ammo for 50 bounty"},
+	ControlThermal:     {"F8", "Thermal imaging"},
+	ControlReplay:      {"F10", "Replay the last 30 seconds"},
+	ControlExtraction:  {"F12", "Call extraction from a landing zone"},
+	ControlGrenade:     {"Ctrl+F", "Grenade launcher"},
+	ControlEMP:         {"P", "EMP pulse"},
+	ControlBuild:       {"Ctrl+B", "Build beside you"},
+	ControlTalk:        {"Ctrl+E", "Talk to quest giver"},
+	ControlAcceptQuest: {"Enter", "Accept quest"},
+	ControlTrade:       {"Ctrl+T", "Trade for intel"},
+	ControlInventory:   {"I", "Parts inventory"},
+	ControlSnare:       {"N", "Set snare"},
+	ControlStealth:     {"V", "Stealth"},
+	ControlWaypoint:    {"W", "Drop or remove waypoint"},
+	ControlExtinguish:  {"X", "Put out fire"},
+	ControlUndo:        {"Backspace", "Undo move"},
+	ControlSpeed:       {"+ / -", "Game speed"},
+	ControlHelp:        {"?", "Show or hide all controls"},
+	ControlQuit:        {"Esc", "Quit"},
+}
+
+// Binding returns the key binding of control
+func Binding(control Control) KeyBinding {
+	return controls[control]
+}
+
+// helpControls are the controls shown for each context, most useful first
+var helpControls = map[HelpContext][]Control{
+	HelpDefault:     {ControlMove, ControlAttack, ControlGrenade, ControlInventory, ControlWaypoint, ControlHelp},
+	HelpCombat:      {ControlAttack, ControlEMP, ControlSmartBomb, ControlGrenade, ControlOverload, ControlBulletTime},
+	HelpInteraction: {ControlTalk, ControlAcceptQuest, ControlTrade, ControlExtinguish, ControlBuild, ControlHelp},
+}
+
+// helpReference is every control in the game
+var helpReference = controls[:]
+
+// ControlTable returns the full control reference as a Markdown table, the
+// form the README lists it in
+func ControlTable() string {
+	var table strings.Builder
+	table.WriteString("| Key | Action |\n| --- | --- |\n")
+	for _, binding := range helpReference {
+		fmt.Fprintf(&table, "| %s | %s |\n", binding.Key, binding.Action)
+	}
+	return table.String()
+}
+
+// ContextualHelp lists the controls that fit what the player is doing in a
+// small box at the bottom of the screen. Pressing ? swaps it for a full
+// control reference.
+type ContextualHelp struct {
+	context HelpContext
+	// reference is true while the full control reference is shown
+	reference bool
+	level     *tl.BaseLevel
+}
+
+// NewContextualHelp creates the help box for level
+func NewContextualHelp(level *tl.BaseLevel) *ContextualHelp {
+	return &ContextualHelp{level: level}
+}
+
+// SetContext sets which controls are shown
+func (display *ContextualHelp) SetContext(context HelpContext) {
+	display.context = context
+}
+
+// Context returns which controls are shown
+func (display *ContextualHelp) Context() HelpContext {
+	return display.context
+}
+
+// SetSituation shows the combat controls while enemies are near, the
+// interaction controls beside a civilian or building and otherwise the
+// default ones
+func (display *ContextualHelp) SetSituation(inCombat, canInteract bool) {
+	switch {
+	case inCombat:
+		display.SetContext(HelpCombat)
+	case canInteract:
+		display.SetContext(HelpInteraction)
+	default:
+		display.SetContext(HelpDefault)
+	}
+}
+
+// Bindings returns the controls shown for the current context
+func (display *ContextualHelp) Bindings() []KeyBinding {
+	shown := helpControls[display.context]
+	bindings := make([]KeyBinding, len(shown))
+	for i, control := range shown {
+		bindings[i] = Binding(control)
+	}
+	return bindings
+}
+
+// IsOpen returns true while the full control reference is shown
+func (display *ContextualHelp) IsOpen() bool {
+	return display.reference
+}
+
+// Toggle shows or hides the full control reference
+func (display *ContextualHelp) Toggle() {
+	display.reference = !display.reference
+}
+
+// Tick toggles the full control reference when ? is pressed
+func (display *ContextualHelp) Tick(event tl.Event) {
+	if event.Type == tl.EventKey && event.Ch == '?' {
+		display.Toggle()
+	}
+}
+
+// Draw shows the full control reference in the middle of the screen while
+// it is open and otherwise the current context's controls along the
+// bottom
+func (display *ContextualHelp) Draw(screen *tl.Screen) {
+	width, height := screen.Size()
+	offSetX, offSetY := display.level.Offset()
+	bindings, title := display.Bindings(), ""
+	if display.reference {
+		bindings, title = helpReference, helpReferenceTitle
+	}
+	boxHeight := len(bindings) + 2*textLineStartY
+	if title != "" {
+		boxHeight++
+	}
+	left := -offSetX + (width-helpBoxWidth)/2
+	top := -offSetY + height - boxHeight
+	if display.reference {
+		top = -offSetY + (height-boxHeight)/2
+	}
+	display.render(screen, left, top, title, bindings)
+}
+
+// render draws bindings in a bordered box with its top left corner at
+// left,top, headed by title unless it is empty
+func (display *ContextualHelp) render(screen cellRenderer, left, top int, title string, bindings []KeyBinding) {
+	lines := make([]string, 0, len(bindings)+1)
+	if title != "" {
+		lines = append(lines, title)
+	}
+	for _, binding := range bindings {
+		lines = append(lines, fmt.Sprintf("%-*s %s", helpKeyWidth, binding.Key, binding.Action))
+	}
+	right, bottom := left+helpBoxWidth-1, top+len(lines)+2*textLineStartY-1
+	for y := top; y <= bottom; y++ {
+		for x := left; x <= right; x++ {
+			screen.RenderCell(x, y, &tl.Cell{Bg: tl.ColorBlack, Ch: ' '})
+		}
+	}
+	drawBox(screen, left, top, right, bottom)
+	for i, line := range lines {
+		color := tl.ColorWhite
+		if i == 0 && title != "" {
+			color = tl.ColorYellow | tl.AttrBold
+		}
+		for j, ch := range []rune(line) {
+			if left+textLineStartX+j >= right {
+				break
+			}
+			screen.RenderCell(left+textLineStartX+j, top+textLineStartY+i, &tl.Cell{Fg: color, Bg: tl.ColorBlack, Ch: ch})
+		}
+	}
+}
+
+// drawBox draws a single line border around the cells from left,top to
+// right,bottom
+func drawBox(screen cellRenderer, left, top, right, bottom int) {
+	border := &tl.Cell{Fg: tl.ColorWhite, Bg: tl.ColorBlack}
+	for x := left; x <= right; x++ {
+		border.Ch = '─'
+		screen.RenderCell(x, top, border)
+		screen.RenderCell(x, bottom, border)
+	}
+	for y := top; y <= bottom; y++ {
+		border.Ch = '│'
+		screen.RenderCell(left, y, border)
+		screen.RenderCell(right, y, border)
+	}
+	for _, corner := range []struct {
+		x, y int
+		ch   rune
+	}{{left, top, '┌'}, {right, top, '┐'}, {left, bottom, '└'}, {right, bottom, '┘'}} {
+		border.Ch = corner.ch
+		screen.RenderCell(corner.x, corner.y, border)
+	}
+}
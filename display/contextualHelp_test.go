@@ -0,0 +1,67 @@
+package display
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+// hasKey returns true if one of bindings is for key
+func hasKey(bindings []KeyBinding, key string) bool {
+	for _, binding := range bindings {
+		if binding.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCombatHelpShowsWeaponsNotTrading(t *testing.T) {
+	help := NewContextualHelp(tl.NewBaseLevel(tl.Cell{}))
+	help.SetSituation(true, true)
+
+	if help.Context() != HelpCombat {
+		t.Fatalf("context is %v with enemies near, want combat", help.Context())
+	}
+	bindings := help.Bindings()
+	if !hasKey(bindings, Binding(ControlEMP).Key) {
+		t.Error("combat help is missing the EMP key")
+	}
+	if hasKey(bindings, Binding(ControlTrade).Key) {
+		t.Error("combat help shows the trade key")
+	}
+	if len(bindings) < 4 || len(bindings) > 6 {
+		t.Errorf("combat help lists %d bindings, want 4 to 6", len(bindings))
+	}
+}
+
+func TestHelpReferenceToggles(t *testing.T) {
+	help := NewContextualHelp(tl.NewBaseLevel(tl.Cell{}))
+	help.Tick(tl.Event{Type: tl.EventKey, Ch: '?'})
+	if !help.IsOpen() {
+		t.Fatal("? did not open the control reference")
+	}
+
+	screen := newTestScreen()
+	help.render(screen, 0, 0, helpReferenceTitle, helpReference)
+	if screen.cells[[2]int{0, 0}].Ch != '┌' {
+		t.Error("control reference has no border")
+	}
+
+	help.Tick(tl.Event{Type: tl.EventKey, Ch: '?'})
+	if help.IsOpen() {
+		t.Error("? did not close the control reference")
+	}
+}
+
+func TestReadmeListsTheControlReference(t *testing.T) {
+	readme, err := os.ReadFile("../README.md")
+	if err != nil {
+		t.Fatalf("reading the README: %v", err)
+	}
+	if !strings.Contains(string(readme), ControlTable()) {
+		t.Errorf("README control table is out of date, replace it with the output of go run . -controls:\n%s", ControlTable())
+	}
+}
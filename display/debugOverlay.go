@@ -0,0 +1,108 @@
+package display
+
+import (
+	"fmt"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	debugOverlayWidth  = 22
+	debugOverlayHeight = 6
+)
+
+// ProjectileCounter reports how many projectiles are in flight
+type ProjectileCounter interface {
+	Count() int
+}
+
+// SpeedSource reports the game speed multiplier
+type SpeedSource interface {
+	SpeedMultiplier() float64
+}
+
+// ParseRateSource reports the fraction of AI responses that parsed
+type ParseRateSource interface {
+	SuccessRate() float64
+}
+
+// FrameMeter measures the frame rate from the end of every frame
+type FrameMeter interface {
+	Mark()
+	ActualFPS() float64
+}
+
+// DebugOverlay shows engine metrics while debugging
+type DebugOverlay struct {
+	Status
+	bullets   ProjectileCounter
+	speed     SpeedSource
+	aiParse   ParseRateSource
+	frameRate FrameMeter
+	textLine  *tl.Text
+	textLine2 *tl.Text
+	textLine3 *tl.Text
+	textLine4 *tl.Text
+}
+
+// NewDebugOverlay creates a debug display counting bullets in flight
+func NewDebugOverlay(x, y int, bullets ProjectileCounter, level *tl.BaseLevel) *DebugOverlay {
+	return &DebugOverlay{
+		Status:    *NewStatus(x, y, debugOverlayWidth, debugOverlayHeight, level),
+		bullets:   bullets,
+		textLine:  tl.NewText(x, y, "", tl.ColorCyan, tl.ColorBlack),
+		textLine2: tl.NewText(x, y+1, "", tl.ColorCyan, tl.ColorBlack),
+		textLine3: tl.NewText(x, y+2, "", tl.ColorCyan, tl.ColorBlack),
+		textLine4: tl.NewText(x, y+3, "", tl.ColorCyan, tl.ColorBlack),
+	}
+}
+
+// AttachSpeed sets where the game speed shown by the overlay comes from
+func (display *DebugOverlay) AttachSpeed(speed SpeedSource) {
+	display.speed = speed
+}
+
+// AttachAIMetrics sets where the AI response parse rate shown by the
+// overlay comes from
+func (display *DebugOverlay) AttachAIMetrics(aiParse ParseRateSource) {
+	display.aiParse = aiParse
+}
+
+// AttachFrameMeter sets what measures the frame rate shown by the
+// overlay, told of every frame the overlay ticks through
+func (display *DebugOverlay) AttachFrameMeter(frameRate FrameMeter) {
+	display.frameRate = frameRate
+}
+
+// Draw passes the draw call to entity.
+func (display *DebugOverlay) Draw(screen *tl.Screen) {
+	display.Status.Draw(screen)
+
+	offSetX, offSetY := display.level.Offset()
+	display.textLine.SetPosition(-offSetX+textLineStartX+display.x, -offSetY+textLineStartY+display.y)
+	display.textLine2.SetPosition(-offSetX+textLineStartX+display.x, -offSetY+textLineStartY+textLineSpacing+display.y)
+	display.textLine3.SetPosition(-offSetX+textLineStartX+display.x, -offSetY+textLineStartY+2*textLineSpacing+display.y)
+	display.textLine4.SetPosition(-offSetX+textLineStartX+display.x, -offSetY+textLineStartY+3*textLineSpacing+display.y)
+	display.textLine.Draw(screen)
+	display.textLine2.Draw(screen)
+	display.textLine3.Draw(screen)
+	display.textLine4.Draw(screen)
+}
+
+// Tick is called to process 1 tick of actions based on the
+// current state of the game.
+func (display *DebugOverlay) Tick(event tl.Event) {
+	display.textLine.SetText(fmt.Sprintf("Bullets: %d", display.bullets.Count()))
+	if display.speed != nil {
+		display.textLine2.SetText(fmt.Sprintf("[Speed: %.1f×]", display.speed.SpeedMultiplier()))
+	}
+	if display.aiParse != nil {
+		display.textLine3.SetText(fmt.Sprintf("AI parse: %.0f%%", display.aiParse.SuccessRate()*100))
+	}
+	if display.frameRate != nil {
+		if event.Type == tl.EventNone {
+			display.frameRate.Mark()
+		}
+		display.textLine4.SetText(fmt.Sprintf("FPS: %.1f", display.frameRate.ActualFPS()))
+	}
+}
@@ -0,0 +1,91 @@
+package display
+
+import (
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	dialogueBoxWidth  = 45
+	dialogueBoxHeight = 5
+	dialoguePrompt    = "Enter: accept  Backspace: decline"
+)
+
+// DialogueBox shows an offer from a character that the player can accept
+// or decline
+type DialogueBox struct {
+	Status
+	speaker  *tl.Text
+	line     *tl.Text
+	prompt   *tl.Text
+	open     bool
+	onAccept func()
+}
+
+// NewDialogueBox creates a closed dialogue box
+func NewDialogueBox(x, y int, level *tl.BaseLevel) *DialogueBox {
+	return &DialogueBox{
+		Status:  *NewStatus(x, y, dialogueBoxWidth, dialogueBoxHeight, level),
+		speaker: tl.NewText(x, y, "", tl.ColorYellow|tl.AttrBold, tl.ColorBlack),
+		line:    tl.NewText(x, y+1, "", tl.ColorWhite, tl.ColorBlack),
+		prompt:  tl.NewText(x, y+2, dialoguePrompt, tl.ColorCyan, tl.ColorBlack),
+	}
+}
+
+// Open shows speaker offering text, calling onAccept if the player accepts
+func (display *DialogueBox) Open(speaker, text string, onAccept func()) {
+	display.speaker.SetText(speaker + ":")
+	display.line.SetText(text)
+	display.onAccept = onAccept
+	display.open = true
+}
+
+// Close hides the dialogue box without accepting
+func (display *DialogueBox) Close() {
+	display.open = false
+	display.onAccept = nil
+}
+
+// IsOpen returns true while the dialogue box is showing
+func (display *DialogueBox) IsOpen() bool {
+	return display.open
+}
+
+// Accept closes the dialogue box and calls its accept callback
+func (display *DialogueBox) Accept() {
+	if !display.open {
+		return
+	}
+	onAccept := display.onAccept
+	display.Close()
+	if onAccept != nil {
+		onAccept()
+	}
+}
+
+// Draw passes the draw call to entity while the dialogue is open.
+func (display *DialogueBox) Draw(screen *tl.Screen) {
+	if !display.open {
+		return
+	}
+	display.Status.Draw(screen)
+
+	offSetX, offSetY := display.level.Offset()
+	lines := []*tl.Text{display.speaker, display.line, display.prompt}
+	for i, text := range lines {
+		text.SetPosition(-offSetX+textLineStartX+display.x, -offSetY+textLineStartY+i*textLineSpacing+display.y)
+		text.Draw(screen)
+	}
+}
+
+// Tick accepts or declines the offer from the keyboard.
+func (display *DialogueBox) Tick(event tl.Event) {
+	if !display.open || event.Type != tl.EventKey {
+		return
+	}
+	switch event.Key {
+	case tl.KeyEnter:
+		display.Accept()
+	case tl.KeyBackspace, tl.KeyBackspace2:
+		display.Close()
+	}
+}
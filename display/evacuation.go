@@ -0,0 +1,62 @@
+package display
+
+import (
+	"fmt"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+// EvacuationStatus defines the methods required for evacuation display
+type EvacuationStatus interface {
+	Active() bool
+	RemainingTicks() int
+}
+
+// EvacuationTimer counts down the time civilians have left to reach a
+// shelter. It is hidden while no evacuation is under way.
+type EvacuationTimer struct {
+	*tl.Text
+	evacuation EvacuationStatus
+	fps        int
+	level      *tl.BaseLevel
+	x, y       int
+}
+
+// NewEvacuationTimer creates an evacuation countdown at x,y for a game
+// running at fps frames per second
+func NewEvacuationTimer(x, y int, evacuation EvacuationStatus, fps int, level *tl.BaseLevel) *EvacuationTimer {
+	return &EvacuationTimer{
+		Text:       tl.NewText(x, y, "", tl.ColorYellow|tl.AttrBold, tl.ColorBlack),
+		evacuation: evacuation,
+		fps:        fps,
+		level:      level,
+		x:          x,
+		y:          y,
+	}
+}
+
+// Draw shows the countdown in place on the screen while an evacuation is
+// under way
+func (display *EvacuationTimer) Draw(screen *tl.Screen) {
+	if !display.evacuation.Active() {
+		return
+	}
+	offSetX, offSetY := display.level.Offset()
+	display.Text.SetPosition(-offSetX+display.x, -offSetY+display.y)
+	display.Text.Draw(screen)
+}
+
+// Tick is called to process 1 tick of actions based on the
+// current state of the game.
+func (display *EvacuationTimer) Tick(event tl.Event) {
+	display.SetText(evacuationText(display.evacuation.RemainingTicks(), display.fps))
+}
+
+// evacuationText formats the ticks left as [EVACUATION: MM:SS]
+func evacuationText(ticks, fps int) string {
+	seconds := 0
+	if fps > 0 {
+		seconds = (ticks + fps - 1) / fps
+	}
+	return fmt.Sprintf("[EVACUATION: %02d:%02d]", seconds/60, seconds%60)
+}
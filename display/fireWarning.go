@@ -0,0 +1,56 @@
+package display
+
+import (
+	"fmt"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+// FireStatus defines the methods required for the fire warning
+type FireStatus interface {
+	Burning() int
+}
+
+// FireWarning shows how many buildings are on fire. It is hidden while
+// nothing is burning.
+type FireWarning struct {
+	*tl.Text
+	fires FireStatus
+	level *tl.BaseLevel
+	x, y  int
+}
+
+// NewFireWarning creates a fire warning at x,y
+func NewFireWarning(x, y int, fires FireStatus, level *tl.BaseLevel) *FireWarning {
+	return &FireWarning{
+		Text:  tl.NewText(x, y, "", tl.ColorRed|tl.AttrBold, tl.ColorBlack),
+		fires: fires,
+		level: level,
+		x:     x,
+		y:     y,
+	}
+}
+
+// Draw shows the warning in place on the screen while buildings are burning
+func (display *FireWarning) Draw(screen *tl.Screen) {
+	if display.fires.Burning() == 0 {
+		return
+	}
+	offSetX, offSetY := display.level.Offset()
+	display.Text.SetPosition(-offSetX+display.x, -offSetY+display.y)
+	display.Text.Draw(screen)
+}
+
+// Tick is called to process 1 tick of actions based on the
+// current state of the game.
+func (display *FireWarning) Tick(event tl.Event) {
+	display.SetText(fireText(display.fires.Burning()))
+}
+
+// fireText formats the number of burning buildings as [FIRE: N buildings burning]
+func fireText(burning int) string {
+	if burning == 1 {
+		return "[FIRE: 1 building burning]"
+	}
+	return fmt.Sprintf("[FIRE: %d buildings burning]", burning)
+}
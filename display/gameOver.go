@@ -0,0 +1,59 @@
+package display
+
+import (
+	"fmt"
+
+	"github.com/Ariemeth/frame_assault/damagelog"
+	tl "github.com/Ariemeth/termloop"
+)
+
+// gameOverTopAttackers is how many attackers the game over screen lists
+const gameOverTopAttackers = 3
+
+// GameOverScreen shows why the game ended, what the player can do next and
+// who dealt the player the most damage
+type GameOverScreen struct {
+	title     *tl.Text
+	prompt    *tl.Text
+	attackers []*tl.Text
+}
+
+// NewGameOverScreen creates a game over screen listing the top attackers
+// recorded against player in log, which may be nil
+func NewGameOverScreen(title, prompt string, log *damagelog.Log, player damagelog.EntityID) *GameOverScreen {
+	display := &GameOverScreen{
+		title:  tl.NewText(0, 0, title, tl.ColorRed|tl.AttrBold, tl.ColorBlack),
+		prompt: tl.NewText(0, 0, prompt, tl.ColorWhite, tl.ColorBlack),
+	}
+	if log == nil {
+		return display
+	}
+	top := log.TopAttackers(player, gameOverTopAttackers)
+	if len(top) > 0 {
+		display.attackers = append(display.attackers,
+			tl.NewText(0, 0, "Most damage taken from", tl.ColorYellow, tl.ColorBlack))
+	}
+	for i, attacker := range top {
+		line := fmt.Sprintf("%d. %s - %d", i+1, attacker.AttackerID, attacker.Damage)
+		display.attackers = append(display.attackers, tl.NewText(0, 0, line, tl.ColorWhite, tl.ColorBlack))
+	}
+	return display
+}
+
+// Draw centers the message and the attackers on the screen
+func (display *GameOverScreen) Draw(screen *tl.Screen) {
+	width, height := screen.Size()
+	for i, text := range []*tl.Text{display.title, display.prompt} {
+		textWidth, _ := text.Size()
+		text.SetPosition((width-textWidth)/2, height/2+i*2)
+		text.Draw(screen)
+	}
+	for i, text := range display.attackers {
+		textWidth, _ := text.Size()
+		text.SetPosition((width-textWidth)/2, height/2+5+i)
+		text.Draw(screen)
+	}
+}
+
+// Tick does nothing, the game over level handles the keys
+func (display *GameOverScreen) Tick(event tl.Event) {}
@@ -0,0 +1,78 @@
+package display
+
+import (
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	healthBarFilled = '█'
+	healthBarEmpty  = '░'
+
+	// Health percentages at which the bar changes color
+	healthBarHighPercent = 66
+	healthBarLowPercent  = 33
+)
+
+// cellRenderer is the part of tl.Screen used to draw bars
+type cellRenderer interface {
+	RenderCell(x, y int, c *tl.Cell)
+}
+
+// HealthBar draws a horizontal bar of filled and empty cells colored by
+// how much health remains
+type HealthBar struct {
+	// Color, when set, fills the bar in one color whatever is left, as
+	// the shield bar does in cyan
+	Color tl.Attr
+}
+
+// shieldBar draws shields in cyan
+var shieldBar = HealthBar{Color: tl.ColorCyan}
+
+// Render draws a bar width cells wide at x,y showing current out of max
+func (bar HealthBar) Render(screen *tl.Screen, x, y, current, max, width int) {
+	bar.render(screen, x, y, current, max, width)
+}
+
+// render draws the bar to any cell renderer
+func (bar HealthBar) render(screen cellRenderer, x, y, current, max, width int) {
+	filled := bar.filledCells(current, max, width)
+	color := bar.color(current, max)
+	for i := 0; i < width; i++ {
+		if i < filled {
+			screen.RenderCell(x+i, y, &tl.Cell{Fg: color, Bg: tl.ColorBlack, Ch: healthBarFilled})
+		} else {
+			screen.RenderCell(x+i, y, &tl.Cell{Fg: tl.ColorWhite, Bg: tl.ColorBlack, Ch: healthBarEmpty})
+		}
+	}
+}
+
+// filledCells returns how many of width cells represent current out of max
+func (bar HealthBar) filledCells(current, max, width int) int {
+	if max <= 0 || current <= 0 {
+		return 0
+	}
+	if current > max {
+		current = max
+	}
+	return (current * width) / max
+}
+
+// color returns the bar's own color if it has one, otherwise green above
+// 66%, yellow from 33% to 66% and red below 33%
+func (bar HealthBar) color(current, max int) tl.Attr {
+	if bar.Color != 0 {
+		return bar.Color
+	}
+	if max <= 0 {
+		return tl.ColorRed
+	}
+	percent := current * 100 / max
+	switch {
+	case percent > healthBarHighPercent:
+		return tl.ColorGreen
+	case percent >= healthBarLowPercent:
+		return tl.ColorYellow
+	}
+	return tl.ColorRed
+}
@@ -0,0 +1,70 @@
+package display
+
+import (
+	"testing"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+type testScreen struct {
+	cells map[[2]int]tl.Cell
+}
+
+func newTestScreen() *testScreen {
+	return &testScreen{cells: make(map[[2]int]tl.Cell)}
+}
+
+func (s *testScreen) RenderCell(x, y int, c *tl.Cell) {
+	s.cells[[2]int{x, y}] = *c
+}
+
+func TestHealthBarHalf(t *testing.T) {
+	const width = 10
+	screen := newTestScreen()
+	HealthBar{}.render(screen, 0, 0, 5, 10, width)
+
+	filled := 0
+	for i := 0; i < width; i++ {
+		cell := screen.cells[[2]int{i, 0}]
+		if cell.Ch == healthBarFilled {
+			filled++
+			if cell.Fg != tl.ColorYellow {
+				t.Errorf("filled cell %d is color %d instead of yellow", i, cell.Fg)
+			}
+		} else if cell.Ch != healthBarEmpty {
+			t.Errorf("cell %d drew %q", i, cell.Ch)
+		}
+	}
+	if filled != width/2 {
+		t.Errorf("%d cells filled at 50%% health instead of %d", filled, width/2)
+	}
+}
+
+func TestHealthBarColors(t *testing.T) {
+	tests := []struct {
+		current, max int
+		color        tl.Attr
+	}{
+		{10, 10, tl.ColorGreen},
+		{7, 10, tl.ColorGreen},
+		{6, 10, tl.ColorYellow},
+		{4, 10, tl.ColorYellow},
+		{3, 10, tl.ColorRed},
+		{0, 10, tl.ColorRed},
+	}
+	for _, test := range tests {
+		if color := (HealthBar{}).color(test.current, test.max); color != test.color {
+			t.Errorf("%d/%d is color %d instead of %d", test.current, test.max, color, test.color)
+		}
+	}
+}
+
+func TestShieldBarIsCyan(t *testing.T) {
+	screen := newTestScreen()
+	shieldBar.render(screen, 0, 0, 2, 10, 10)
+	for i := 0; i < 2; i++ {
+		if cell := screen.cells[[2]int{i, 0}]; cell.Ch != healthBarFilled || cell.Fg != tl.ColorCyan {
+			t.Errorf("shield cell %d drew %q in color %d instead of a cyan %q", i, cell.Ch, cell.Fg, healthBarFilled)
+		}
+	}
+}
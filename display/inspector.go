@@ -0,0 +1,193 @@
+package display
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Ariemeth/frame_assault/mech/weapon"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	inspectorWidth        = 40
+	inspectorHeight       = 14
+	inspectorVisibleLines = inspectorHeight - 2*textLineStartY
+)
+
+// EntityInspector is a debug overlay listing the fields of an entity
+type EntityInspector struct {
+	Status
+	open   bool
+	lines  []string
+	scroll int
+	text   []*tl.Text
+}
+
+// NewEntityInspector creates a new, closed, entity inspector
+func NewEntityInspector(x, y int, level *tl.BaseLevel) *EntityInspector {
+	inspector := &EntityInspector{
+		Status: *NewStatus(x, y, inspectorWidth, inspectorHeight, level),
+		text:   make([]*tl.Text, inspectorVisibleLines),
+	}
+	for i := range inspector.text {
+		inspector.text[i] = tl.NewText(x, y+i, "", tl.ColorWhite, tl.ColorBlack)
+	}
+	return inspector
+}
+
+// Open shows the inspector populated with the fields of entity
+func (display *EntityInspector) Open(entity interface{}) {
+	display.lines = inspectFields(entity)
+	display.scroll = 0
+	display.open = true
+}
+
+// Close hides the inspector
+func (display *EntityInspector) Close() {
+	display.open = false
+}
+
+// IsOpen returns true if the inspector is being shown
+func (display *EntityInspector) IsOpen() bool {
+	return display.open
+}
+
+// Draw passes the draw call to entity.
+func (display *EntityInspector) Draw(screen *tl.Screen) {
+	if !display.open {
+		return
+	}
+	display.Status.Draw(screen)
+
+	offSetX, offSetY := display.level.Offset()
+	for i, line := range display.text {
+		index := display.scroll + i
+		if index < len(display.lines) {
+			line.SetText(truncate(display.lines[index], inspectorWidth-2*textLineStartX))
+		} else {
+			line.SetText("")
+		}
+		line.SetPosition(-offSetX+display.x+textLineStartX,
+			-offSetY+display.y+textLineStartY+i*textLineSpacing)
+		line.Draw(screen)
+	}
+}
+
+// Tick scrolls the inspector with the arrow keys and closes it on any
+// other key. F9 is left to the player mech which toggles the inspector.
+func (display *EntityInspector) Tick(event tl.Event) {
+	if !display.open || event.Type != tl.EventKey {
+		return
+	}
+
+	switch event.Key {
+	case tl.KeyF9:
+	case tl.KeyArrowUp:
+		if display.scroll > 0 {
+			display.scroll--
+		}
+	case tl.KeyArrowDown:
+		if display.scroll+inspectorVisibleLines < len(display.lines) {
+			display.scroll++
+		}
+	default:
+		display.Close()
+	}
+}
+
+// truncate shortens s to at most width runes
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width])
+}
+
+// inspectFields describes an entity as "key: value" lines. Well known
+// accessors are listed first followed by every struct field found via
+// reflection, with embedded structs flattened.
+func inspectFields(entity interface{}) []string {
+	if entity == nil {
+		return []string{"<nil>"}
+	}
+
+	lines := []string{"Type: " + reflect.TypeOf(entity).String()}
+	if value := reflect.ValueOf(entity); value.Kind() == reflect.Ptr {
+		// Accessors would dereference a typed nil pointer
+		if value.IsNil() {
+			return append(lines, "<nil>")
+		}
+		lines = append(lines, fmt.Sprintf("ID: %p", entity))
+	}
+	// Labels such as [OVERWATCH] head the list
+	if labeled, ok := entity.(interface{ Label() string }); ok && labeled.Label() != "" {
+		lines = append([]string{labeled.Label()}, lines...)
+	}
+	if named, ok := entity.(interface{ Name() string }); ok {
+		lines = append(lines, "Name: "+named.Name())
+	}
+	if physical, ok := entity.(tl.Physical); ok {
+		x, y := physical.Position()
+		lines = append(lines, "Position: ("+strconv.Itoa(x)+","+strconv.Itoa(y)+")")
+	}
+	if structured, ok := entity.(interface{ StructureLeft() int }); ok {
+		lines = append(lines, "Structure: "+strconv.Itoa(structured.StructureLeft()))
+	}
+	if armed, ok := entity.(interface{ Weapons() []weapon.Weapon }); ok {
+		names := make([]string, 0)
+		for _, w := range armed.Weapons() {
+			names = append(names, w.Name())
+		}
+		lines = append(lines, "Weapons: "+strings.Join(names, ", "))
+	}
+
+	return append(lines, reflectFields(reflect.ValueOf(entity))...)
+}
+
+// reflectFields lists the fields of the struct v points to
+func reflectFields(v reflect.Value) []string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	lines := make([]string, 0, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		value := v.Field(i)
+		if field.Anonymous {
+			lines = append(lines, reflectFields(value)...)
+			continue
+		}
+		lines = append(lines, field.Name+": "+formatValue(value))
+	}
+	return lines
+}
+
+// formatValue summarises a field value without following pointers
+func formatValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		if v.IsNil() {
+			return "<nil>"
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		return v.Elem().Type().String()
+	case reflect.Ptr, reflect.Func, reflect.Chan, reflect.Struct:
+		return v.Type().String()
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Type().String() + " (" + strconv.Itoa(v.Len()) + ")"
+	}
+	return fmt.Sprint(v)
+}
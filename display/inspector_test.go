@@ -0,0 +1,51 @@
+package display
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/mech"
+	"github.com/Ariemeth/frame_assault/mech/movement"
+	"github.com/Ariemeth/frame_assault/mech/weapon"
+	tl "github.com/Ariemeth/termloop"
+)
+
+func hasLine(lines []string, prefix string) bool {
+	for _, line := range lines {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestInspectEnemyMech(t *testing.T) {
+	enemy := mech.NewEnemyMech("Mech A", 3, 1, 2, tl.ColorRed, 'A', movement.NewRandomWalkStrategy())
+	enemy.AddWeapon(weapon.CreateRifle())
+
+	lines := inspectFields(enemy)
+
+	for _, prefix := range []string{
+		"Name: Mech A",
+		"structure: 3",
+		"moveStrategy: *movement.RandomWalkStrategy",
+		"Weapons: Rifle",
+		"Position: (1,2)",
+		// Unattached references must be reported rather than followed
+		"game: <nil>",
+	} {
+		if !hasLine(lines, prefix) {
+			t.Errorf("inspector output is missing %q:\n%s", prefix, strings.Join(lines, "\n"))
+		}
+	}
+}
+
+func TestInspectNil(t *testing.T) {
+	var enemy *mech.EnemyMech
+	if lines := inspectFields(enemy); len(lines) == 0 {
+		t.Errorf("inspecting a nil mech returned no lines")
+	}
+	if lines := inspectFields(nil); len(lines) != 1 {
+		t.Errorf("inspecting nil returned %d lines instead of 1", len(lines))
+	}
+}
@@ -0,0 +1,145 @@
+package display
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Ariemeth/frame_assault/damagelog"
+	"github.com/Ariemeth/frame_assault/eventbus"
+	"github.com/Ariemeth/frame_assault/util"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// killFeedSize is how many destructions the kill feed shows
+	killFeedSize = 5
+	// killFeedDimAge is how old an entry is when it starts to fade
+	killFeedDimAge = 8 * time.Second
+	// killFeedLifetime is how old an entry is when it is removed
+	killFeedLifetime = 10 * time.Second
+)
+
+// KillEntry is a destruction shown in the kill feed
+type KillEntry struct {
+	// At is when the entry was added, in real time
+	At time.Time
+	// Timestamp is the game time of the destruction
+	Timestamp string
+	// Attacker is who landed the last hit, empty if nobody is known
+	Attacker  string
+	Victim    string
+	EventType string
+	Color     tl.Attr
+}
+
+// Text returns the entry as a line of the feed
+func (entry KillEntry) Text() string {
+	if entry.Attacker == "" {
+		return fmt.Sprintf("[%s] %s destroyed", entry.Timestamp, entry.Victim)
+	}
+	return fmt.Sprintf("[%s] %s destroyed %s", entry.Timestamp, entry.Attacker, entry.Victim)
+}
+
+// AttackerSource reports who last hit a mech
+type AttackerSource interface {
+	LastAttacker(defender damagelog.EntityID) (damagelog.EntityID, bool)
+}
+
+// KillFeed lists the last few mechs and buildings destroyed, each fading
+// out a few seconds after it happened
+type KillFeed struct {
+	clock     TimeSystemInterface
+	attackers AttackerSource
+	// entries are the destructions shown, oldest first
+	entries []KillEntry
+	level   *tl.BaseLevel
+	x, y    int
+	now     func() time.Time
+}
+
+// NewKillFeed creates a kill feed at x,y stamping entries with the game
+// time from clock and naming killers from attackers
+func NewKillFeed(x, y int, clock TimeSystemInterface, attackers AttackerSource, level *tl.BaseLevel) *KillFeed {
+	return &KillFeed{
+		clock:     clock,
+		attackers: attackers,
+		entries:   make([]KillEntry, 0, killFeedSize),
+		level:     level,
+		x:         x,
+		y:         y,
+		now:       time.Now,
+	}
+}
+
+// HandleEvent adds destroyed mechs and buildings to the feed
+func (display *KillFeed) HandleEvent(event eventbus.Event) {
+	switch e := event.(type) {
+	case eventbus.MechDestroyedEvent:
+		entry := KillEntry{Victim: e.Name, EventType: "mech", Color: tl.ColorRed}
+		if display.attackers != nil {
+			if attacker, ok := display.attackers.LastAttacker(damagelog.EntityID(e.Name)); ok {
+				entry.Attacker = string(attacker)
+			}
+		}
+		display.add(entry)
+	case eventbus.BuildingBurnedEvent:
+		display.add(KillEntry{Victim: e.Name, EventType: "building", Color: tl.ColorYellow})
+	}
+}
+
+// add stamps entry and adds it to the feed, dropping the oldest entry once
+// the feed is full
+func (display *KillFeed) add(entry KillEntry) {
+	entry.At = display.now()
+	if display.clock != nil {
+		entry.Timestamp = display.clock.FormatGameTime()
+	}
+	if len(display.entries) == killFeedSize {
+		display.entries = display.entries[1:]
+	}
+	display.entries = append(display.entries, entry)
+}
+
+// expire removes entries older than killFeedLifetime
+func (display *KillFeed) expire() {
+	now := display.now()
+	kept := display.entries[:0]
+	for _, entry := range display.entries {
+		if now.Sub(entry.At) <= killFeedLifetime {
+			kept = append(kept, entry)
+		}
+	}
+	display.entries = kept
+}
+
+// Lines returns the text of the feed, oldest entry first
+func (display *KillFeed) Lines() []string {
+	display.expire()
+	lines := make([]string, len(display.entries))
+	for i, entry := range display.entries {
+		lines[i] = entry.Text()
+	}
+	return lines
+}
+
+// Draw shows each entry in its event color, dimmed as it ages
+func (display *KillFeed) Draw(screen *tl.Screen) {
+	display.expire()
+	offSetX, offSetY := display.level.Offset()
+	now := display.now()
+	for i, entry := range display.entries {
+		color := entry.Color
+		if now.Sub(entry.At) > killFeedDimAge {
+			color |= util.AttrDim
+		}
+		text := tl.NewText(-offSetX+display.x, -offSetY+display.y+i, entry.Text(), color, tl.ColorBlack)
+		text.Draw(screen)
+	}
+}
+
+// Tick drops expired entries
+func (display *KillFeed) Tick(event tl.Event) {
+	if event.Type == tl.EventNone {
+		display.expire()
+	}
+}
@@ -0,0 +1,52 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ariemeth/frame_assault/damagelog"
+	"github.com/Ariemeth/frame_assault/eventbus"
+	tl "github.com/Ariemeth/termloop"
+)
+
+type fakeClock struct{}
+
+func (fakeClock) FormatGameTime() string { return "12:34 PM" }
+
+func TestKillFeedShowsFiveNewest(t *testing.T) {
+	log := damagelog.NewLog()
+	log.RecordHit("Player", "Mech 7", 3, 0)
+	feed := NewKillFeed(0, 0, fakeClock{}, log, tl.NewBaseLevel(tl.Cell{}))
+	for i := 1; i <= 7; i++ {
+		feed.HandleEvent(eventbus.MechDestroyedEvent{Name: fmt.Sprintf("Mech %d", i)})
+	}
+
+	lines := feed.Lines()
+	if len(lines) != killFeedSize {
+		t.Fatalf("feed shows %d entries, want %d:\n%s", len(lines), killFeedSize, strings.Join(lines, "\n"))
+	}
+	if lines[0] != "[12:34 PM] Mech 3 destroyed" {
+		t.Errorf("oldest entry shown is %q, want Mech 3", lines[0])
+	}
+	if lines[4] != "[12:34 PM] Player destroyed Mech 7" {
+		t.Errorf("newest entry shown is %q, want the player destroying Mech 7", lines[4])
+	}
+}
+
+func TestKillFeedEntriesExpire(t *testing.T) {
+	now := time.Now()
+	feed := NewKillFeed(0, 0, fakeClock{}, nil, tl.NewBaseLevel(tl.Cell{}))
+	feed.now = func() time.Time { return now }
+	feed.HandleEvent(eventbus.BuildingBurnedEvent{Name: "Hospital"})
+
+	now = now.Add(killFeedLifetime - time.Second)
+	if lines := feed.Lines(); len(lines) != 1 || lines[0] != "[12:34 PM] Hospital destroyed" {
+		t.Fatalf("feed is %q before the entry expired", lines)
+	}
+	now = now.Add(2 * time.Second)
+	if lines := feed.Lines(); len(lines) != 0 {
+		t.Errorf("feed still shows %q after the entry expired", lines)
+	}
+}
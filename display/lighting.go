@@ -0,0 +1,131 @@
+package display
+
+import (
+	"github.com/Ariemeth/frame_assault/eventbus"
+	"github.com/Ariemeth/frame_assault/util"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// Hours bounding dusk, night and dawn
+	duskStart  = 20.0
+	nightStart = 22.0
+	nightEnd   = 4.0
+	dawnEnd    = 6.0
+
+	// streetLightRadius is how far a street light reaches in cells
+	streetLightRadius = 3
+)
+
+// darkerColors maps background colors to the darker variant used at night
+var darkerColors = map[tl.Attr]tl.Attr{
+	tl.ColorWhite:       util.ColorLightGray,
+	util.ColorLightGray: util.ColorDarkGray,
+	util.ColorDarkGray:  tl.ColorBlack,
+}
+
+// Clock provides the current in game time of day
+type Clock interface {
+	GameHours() float64
+}
+
+// LightingSystem darkens cells at night and brightens the cells around
+// street lights
+type LightingSystem struct {
+	clock  Clock
+	lit    map[[2]int]bool
+	outage bool
+}
+
+// NewLightingSystem creates a lighting system following the time of clock
+func NewLightingSystem(clock Clock) *LightingSystem {
+	return &LightingSystem{
+		clock: clock,
+		lit:   make(map[[2]int]bool),
+	}
+}
+
+// AddStreetLight places a street light at x,y lighting every cell within
+// streetLightRadius
+func (ls *LightingSystem) AddStreetLight(x, y int) {
+	for dx := -streetLightRadius; dx <= streetLightRadius; dx++ {
+		for dy := -streetLightRadius; dy <= streetLightRadius; dy++ {
+			if dx*dx+dy*dy <= streetLightRadius*streetLightRadius {
+				ls.lit[[2]int{x + dx, y + dy}] = true
+			}
+		}
+	}
+}
+
+// SetPowerOutage turns the city's power off or back on. Without power
+// street lights go out and every hour is as dark as night.
+func (ls *LightingSystem) SetPowerOutage(outage bool) {
+	ls.outage = outage
+}
+
+// HandleEvent darkens the city when the power goes out and lights it
+// again when the power is restored
+func (ls *LightingSystem) HandleEvent(event eventbus.Event) {
+	switch event.(type) {
+	case eventbus.PowerOutageEvent:
+		ls.SetPowerOutage(true)
+	case eventbus.PowerRestoredEvent:
+		ls.SetPowerOutage(false)
+	}
+}
+
+// IsLit returns true while the city has power
+func (ls *LightingSystem) IsLit() bool {
+	return !ls.outage
+}
+
+// Light returns cell adjusted for the clock's current time at x,y
+func (ls *LightingSystem) Light(cell *tl.Cell, x, y int) *tl.Cell {
+	if ls.outage {
+		return ls.ApplyLighting(cell, nightStart)
+	}
+	if ls.clock == nil {
+		return cell
+	}
+	return ls.ApplyLightingAt(cell, ls.clock.GameHours(), x, y)
+}
+
+// ApplyLightingAt returns cell adjusted for hour, letting a nearby street
+// light override the darkness with a bold cell
+func (ls *LightingSystem) ApplyLightingAt(cell *tl.Cell, hour float64, x, y int) *tl.Cell {
+	if isDark(hour) && ls.lit[[2]int{x, y}] {
+		lit := *cell
+		lit.Fg |= tl.AttrBold
+		return &lit
+	}
+	return ls.ApplyLighting(cell, hour)
+}
+
+// ApplyLighting returns a copy of cell dimmed during twilight and dimmed
+// with a darker background at night. Daytime cells are returned unchanged.
+func (ls *LightingSystem) ApplyLighting(cell *tl.Cell, hour float64) *tl.Cell {
+	if !isDark(hour) {
+		return cell
+	}
+	lit := *cell
+	lit.Fg |= util.AttrDim
+	if isNight(hour) {
+		if darker, ok := darkerColors[lit.Bg]; ok {
+			lit.Bg = darker
+		} else {
+			// Bold backgrounds are the bright variant of their color
+			lit.Bg &^= tl.AttrBold
+		}
+	}
+	return &lit
+}
+
+// isDark returns true from dusk until dawn
+func isDark(hour float64) bool {
+	return hour >= duskStart || hour < dawnEnd
+}
+
+// isNight returns true in the darkest hours between dusk and dawn
+func isNight(hour float64) bool {
+	return hour >= nightStart || hour < nightEnd
+}
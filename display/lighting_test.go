@@ -0,0 +1,100 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/eventbus"
+	"github.com/Ariemeth/frame_assault/util"
+	tl "github.com/Ariemeth/termloop"
+)
+
+type testClock float64
+
+func (c testClock) GameHours() float64 {
+	return float64(c)
+}
+
+func TestNightDimsRoadCell(t *testing.T) {
+	lighting := NewLightingSystem(testClock(23.0))
+	road := &tl.Cell{Bg: tl.ColorBlue, Fg: tl.ColorBlue, Ch: ' '}
+
+	cell := lighting.Light(road, 5, 5)
+	if cell.Fg&util.AttrDim == 0 {
+		t.Errorf("road cell at 23:00 is not dim")
+	}
+	if road.Fg&util.AttrDim != 0 {
+		t.Errorf("lighting modified the original cell")
+	}
+}
+
+func TestDaylightLeavesCellUnchanged(t *testing.T) {
+	lighting := NewLightingSystem(testClock(12.0))
+	road := &tl.Cell{Bg: tl.ColorBlue, Fg: tl.ColorBlue, Ch: ' '}
+
+	if cell := lighting.Light(road, 5, 5); *cell != *road {
+		t.Errorf("road cell at noon changed to %+v", *cell)
+	}
+}
+
+func TestNightDarkensBackground(t *testing.T) {
+	lighting := NewLightingSystem(testClock(1.0))
+
+	cell := lighting.Light(&tl.Cell{Bg: tl.ColorWhite, Ch: ' '}, 0, 0)
+	if cell.Bg != util.ColorLightGray {
+		t.Errorf("white background at night is %d instead of light gray", cell.Bg)
+	}
+
+	cell = lighting.Light(&tl.Cell{Bg: tl.ColorRed | tl.AttrBold, Ch: ' '}, 0, 0)
+	if cell.Bg != tl.ColorRed {
+		t.Errorf("bright red background at night is %d instead of red", cell.Bg)
+	}
+
+	cell = lighting.ApplyLighting(&tl.Cell{Bg: tl.ColorWhite, Ch: ' '}, 21.0)
+	if cell.Bg != tl.ColorWhite || cell.Fg&util.AttrDim == 0 {
+		t.Errorf("twilight cell is %+v instead of dim with an unchanged background", *cell)
+	}
+}
+
+func TestStreetLightOverridesNight(t *testing.T) {
+	lighting := NewLightingSystem(testClock(23.0))
+	lighting.AddStreetLight(10, 10)
+
+	cell := lighting.Light(&tl.Cell{Bg: tl.ColorBlue, Fg: tl.ColorBlue, Ch: ' '}, 12, 10)
+	if cell.Fg&tl.AttrBold == 0 || cell.Fg&util.AttrDim != 0 {
+		t.Errorf("cell near a street light is not bold")
+	}
+
+	cell = lighting.Light(&tl.Cell{Bg: tl.ColorBlue, Fg: tl.ColorBlue, Ch: ' '}, 14, 10)
+	if cell.Fg&util.AttrDim == 0 {
+		t.Errorf("cell beyond a street light's reach is not dim")
+	}
+}
+
+func TestPowerOutageDarkensDaylight(t *testing.T) {
+	lighting := NewLightingSystem(testClock(12.0))
+	lighting.AddStreetLight(0, 0)
+	lighting.SetPowerOutage(true)
+
+	cell := lighting.Light(&tl.Cell{Bg: tl.ColorWhite, Ch: ' '}, 0, 0)
+	if cell.Fg&util.AttrDim == 0 || cell.Bg != util.ColorLightGray {
+		t.Errorf("cell at noon during an outage is %+v instead of dark", *cell)
+	}
+}
+
+func TestPowerOutageEventDimsLighting(t *testing.T) {
+	lighting := NewLightingSystem(testClock(12.0))
+	bus := eventbus.New()
+	bus.Subscribe(lighting.HandleEvent)
+
+	bus.Publish(eventbus.PowerOutageEvent{})
+	cell := lighting.Light(&tl.Cell{Bg: tl.ColorBlue, Fg: tl.ColorBlue, Ch: ' '}, 3, 3)
+	if cell.Fg&util.AttrDim == 0 {
+		t.Errorf("cell after a power outage event is %+v instead of dim", *cell)
+	}
+
+	bus.Publish(eventbus.PowerRestoredEvent{})
+	cell = lighting.Light(&tl.Cell{Bg: tl.ColorBlue, Fg: tl.ColorBlue, Ch: ' '}, 3, 3)
+	if cell.Fg&util.AttrDim != 0 {
+		t.Errorf("cell at noon is still dim after power was restored")
+	}
+}
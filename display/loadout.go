@@ -0,0 +1,141 @@
+package display
+
+import (
+	"fmt"
+
+	"github.com/Ariemeth/frame_assault/mech"
+	"github.com/Ariemeth/frame_assault/mech/weapon"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const loadoutTitle = "CHOOSE YOUR MECH - Up/Down select, Left/Right change, Enter start"
+
+// LoadoutSymbols are the symbols the player's mech can be drawn with
+var LoadoutSymbols = []rune{'M', 'W', 'X', 'Z'}
+
+// LoadoutColors are the colors the player's mech can be drawn in
+var LoadoutColors = []struct {
+	Name  string
+	Color tl.Attr
+}{
+	{"Red", tl.ColorRed},
+	{"Green", tl.ColorGreen},
+	{"Cyan", tl.ColorCyan},
+	{"Yellow", tl.ColorYellow},
+}
+
+// LoadoutScreen lets the player choose a weapon loadout, a symbol and a
+// color for their mech before the game starts. The rows are every loadout
+// followed by the symbol and the color.
+type LoadoutScreen struct {
+	loadouts []weapon.Loadout
+	row      int
+	loadout  int
+	symbol   int
+	color    int
+	start    func(mech.PlayerConfig)
+}
+
+// NewLoadoutScreen creates a loadout screen calling start with the
+// player's choices when Enter is pressed
+func NewLoadoutScreen(start func(mech.PlayerConfig)) *LoadoutScreen {
+	return &LoadoutScreen{
+		loadouts: weapon.Loadouts,
+		start:    start,
+	}
+}
+
+// symbolRow and colorRow are the rows after the loadouts
+func (display *LoadoutScreen) symbolRow() int {
+	return len(display.loadouts)
+}
+
+func (display *LoadoutScreen) colorRow() int {
+	return len(display.loadouts) + 1
+}
+
+// Config returns the player's current choices
+func (display *LoadoutScreen) Config() mech.PlayerConfig {
+	return mech.PlayerConfig{
+		Symbol:  LoadoutSymbols[display.symbol],
+		Color:   LoadoutColors[display.color].Color,
+		Loadout: display.loadouts[display.loadout],
+	}
+}
+
+// cycle moves index by delta through count options, wrapping around
+func cycle(index, delta, count int) int {
+	return ((index+delta)%count + count) % count
+}
+
+// Tick moves between rows with Up and Down, changes the symbol or color
+// with Left and Right and starts the game on Enter
+func (display *LoadoutScreen) Tick(event tl.Event) {
+	if event.Type != tl.EventKey {
+		return
+	}
+	switch event.Key {
+	case tl.KeyArrowUp:
+		display.row = cycle(display.row, -1, display.colorRow()+1)
+	case tl.KeyArrowDown:
+		display.row = cycle(display.row, 1, display.colorRow()+1)
+	case tl.KeyArrowLeft, tl.KeyArrowRight:
+		delta := 1
+		if event.Key == tl.KeyArrowLeft {
+			delta = -1
+		}
+		switch display.row {
+		case display.symbolRow():
+			display.symbol = cycle(display.symbol, delta, len(LoadoutSymbols))
+		case display.colorRow():
+			display.color = cycle(display.color, delta, len(LoadoutColors))
+		}
+	case tl.KeyEnter:
+		if display.start != nil {
+			display.start(display.Config())
+		}
+		return
+	}
+	if display.row < len(display.loadouts) {
+		display.loadout = display.row
+	}
+}
+
+// Draw lists the loadouts with the chosen one's description, then the
+// symbol and color previewed in the chosen color
+func (display *LoadoutScreen) Draw(screen *tl.Screen) {
+	const x = 2
+	drawText(screen, x, 1, loadoutTitle, tl.ColorWhite|tl.AttrBold)
+
+	y := 3
+	for i, loadout := range display.loadouts {
+		marker := "  "
+		if i == display.loadout {
+			marker = "> "
+		}
+		display.drawRow(screen, x, y, i, marker+loadout.Name)
+		y++
+	}
+	drawText(screen, x+2, y+1, display.loadouts[display.loadout].Description, tl.ColorYellow)
+
+	y += 3
+	config := display.Config()
+	display.drawRow(screen, x, y, display.symbolRow(), fmt.Sprintf("Symbol: < %c >", config.Symbol))
+	display.drawRow(screen, x, y+1, display.colorRow(), "Color:  < "+LoadoutColors[display.color].Name+" >")
+	drawText(screen, x, y+3, "Preview: "+string(config.Symbol), tl.ColorWhite)
+	screen.RenderCell(x+len("Preview: "), y+3, &tl.Cell{Fg: config.Color, Bg: tl.ColorBlack, Ch: config.Symbol})
+}
+
+// drawRow draws a row, highlighted when it has the cursor
+func (display *LoadoutScreen) drawRow(screen *tl.Screen, x, y, row int, text string) {
+	if row == display.row {
+		tl.NewText(x, y, text, tl.ColorBlack, tl.ColorWhite).Draw(screen)
+		return
+	}
+	drawText(screen, x, y, text, tl.ColorWhite)
+}
+
+// drawText draws text at x,y on a black background
+func drawText(screen *tl.Screen, x, y int, text string, fg tl.Attr) {
+	tl.NewText(x, y, text, fg, tl.ColorBlack).Draw(screen)
+}
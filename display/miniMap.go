@@ -0,0 +1,291 @@
+package display
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/Ariemeth/frame_assault/building"
+	"github.com/Ariemeth/frame_assault/damagelog"
+	"github.com/Ariemeth/frame_assault/entities"
+	"github.com/Ariemeth/frame_assault/eventbus"
+	"github.com/Ariemeth/frame_assault/intel"
+	"github.com/Ariemeth/frame_assault/util"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	miniMapWidth  = 21
+	miniMapHeight = 13
+	// miniMapTooltipLines are the lines below the map describing what is
+	// beside the player
+	miniMapTooltipLines = 2
+	// miniMapRows and miniMapCols are the map cells between the title line
+	// and the tooltip
+	miniMapCols = miniMapWidth - 2*textLineStartX
+	miniMapRows = miniMapHeight - textLineStartY - 2 - miniMapTooltipLines
+
+	// Mini map glyphs
+	miniMapPlayerGlyph = '+'
+	miniMapEnemyGlyph  = 'x'
+	miniMapTowerGlyph  = '↑'
+	miniMapGraveGlyph  = '†'
+	miniMapTipGlyph    = '!'
+	miniMapLZGlyph     = '⬡'
+)
+
+// WaypointSource provides the waypoints shown on the mini map
+type WaypointSource interface {
+	Positions() [][2]int
+}
+
+// BuildingDirectory provides the buildings whose occupancy the mini map
+// shows while the player stands beside one
+type BuildingDirectory interface {
+	Buildings() []*building.Building
+	Tooltip(buildingID building.ID) string
+}
+
+// EnemyContact is an enemy whose structure the mini map shows
+type EnemyContact interface {
+	Name() string
+	StructureLeft() int
+	MaxStructure() int
+}
+
+// RadarSource provides what the mini map shows around the player
+type RadarSource interface {
+	Name() string
+	Position() (int, int)
+	RadarRange() int
+	EnemyPositions() [][2]int
+	RadioTowers() []*entities.RadioTower
+}
+
+// MiniMap shows the enemies and radio towers within the player's radar,
+// along with a grave where each enemy was destroyed
+type MiniMap struct {
+	Status
+	radar RadarSource
+	title *tl.Text
+	// graveyard is where each destroyed enemy was last seen
+	graveyard map[damagelog.EntityID][2]int
+	waypoints WaypointSource
+	// tips are where civilians last saw each enemy
+	tips map[damagelog.EntityID]intel.TipOff
+	// landingZones are marked once they are revealed
+	landingZones []*entities.LandingZone
+	buildings    BuildingDirectory
+	// buildingTooltip shows the occupancy of the building beside the player
+	buildingTooltip *tl.Text
+	// nearestEnemy returns the enemy whose health bar is shown below the
+	// building tooltip
+	nearestEnemy func() EnemyContact
+	enemyTooltip *tl.Text
+	healthBar    HealthBar
+}
+
+// NewMiniMap creates a mini map centered on the radar source
+func NewMiniMap(x, y int, radar RadarSource, level *tl.BaseLevel) *MiniMap {
+	return &MiniMap{
+		Status:          *NewStatus(x, y, miniMapWidth, miniMapHeight, level),
+		radar:           radar,
+		title:           tl.NewText(x, y, "", tl.ColorWhite, tl.ColorBlack),
+		graveyard:       make(map[damagelog.EntityID][2]int),
+		tips:            make(map[damagelog.EntityID]intel.TipOff),
+		buildingTooltip: tl.NewText(x, y, "", tl.ColorCyan, tl.ColorBlack),
+		enemyTooltip:    tl.NewText(x, y, "", tl.ColorRed, tl.ColorBlack),
+	}
+}
+
+// HandleEvent marks where civilians have seen enemies and digs a grave
+// where an enemy mech was destroyed, forgetting any tip on it
+func (display *MiniMap) HandleEvent(event eventbus.Event) {
+	switch e := event.(type) {
+	case intel.TipOff:
+		display.tips[e.EnemyID] = e
+	case eventbus.MechDestroyedEvent:
+		if e.Name == display.radar.Name() {
+			return
+		}
+		display.graveyard[damagelog.EntityID(e.Name)] = [2]int{e.X, e.Y}
+		delete(display.tips, damagelog.EntityID(e.Name))
+	}
+}
+
+// Graves returns where each destroyed enemy was last seen
+func (display *MiniMap) Graves() map[damagelog.EntityID][2]int {
+	graves := make(map[damagelog.EntityID][2]int, len(display.graveyard))
+	for id, pos := range display.graveyard {
+		graves[id] = pos
+	}
+	return graves
+}
+
+// ClearGrave removes the grave of the enemy id
+func (display *MiniMap) ClearGrave(id damagelog.EntityID) {
+	delete(display.graveyard, id)
+}
+
+// AttachWaypoints sets the waypoints marked on the mini map
+func (display *MiniMap) AttachWaypoints(waypoints WaypointSource) {
+	display.waypoints = waypoints
+}
+
+// AttachLandingZones sets the landing zones marked on the mini map once
+// enough objectives are complete
+func (display *MiniMap) AttachLandingZones(zones []*entities.LandingZone) {
+	display.landingZones = zones
+}
+
+// AttachBuildings sets the buildings whose occupancy is shown when the
+// player stands beside one
+func (display *MiniMap) AttachBuildings(buildings BuildingDirectory) {
+	display.buildings = buildings
+}
+
+// AttachNearestEnemy sets how the enemy whose health bar is shown is
+// found, returning nil when there is none in radar range
+func (display *MiniMap) AttachNearestEnemy(nearest func() EnemyContact) {
+	display.nearestEnemy = nearest
+}
+
+// Draw renders the radar contacts scaled to fit the mini map
+func (display *MiniMap) Draw(screen *tl.Screen) {
+	display.Status.Draw(screen)
+
+	offSetX, offSetY := display.level.Offset()
+	originX := -offSetX + display.x + textLineStartX
+	originY := -offSetY + display.y + textLineStartY
+	display.title.SetPosition(originX, originY)
+	display.title.Draw(screen)
+
+	// Map cells start on the line below the title
+	originY += textLineSpacing
+	for pos, cell := range display.cells() {
+		screen.RenderCell(originX+pos[0], originY+pos[1], cell)
+	}
+
+	// The tooltip sits below the map cells
+	originY += miniMapRows
+	display.buildingTooltip.SetPosition(originX, originY+1)
+	display.buildingTooltip.Draw(screen)
+	display.drawEnemyTooltip(screen, originX, originY+2)
+}
+
+// drawEnemyTooltip shows the name and health bar of the nearest enemy at
+// x,y, the bar filling the rest of the line after the name
+func (display *MiniMap) drawEnemyTooltip(screen *tl.Screen, x, y int) {
+	label := display.EnemyTooltip()
+	if label == "" {
+		return
+	}
+	display.enemyTooltip.SetText(label)
+	display.enemyTooltip.SetPosition(x, y)
+	display.enemyTooltip.Draw(screen)
+	labelWidth := len([]rune(label))
+	display.renderEnemyBar(screen, x+labelWidth, y, miniMapCols-labelWidth)
+}
+
+// renderEnemyBar draws the nearest enemy's health bar width cells wide
+func (display *MiniMap) renderEnemyBar(screen cellRenderer, x, y, width int) {
+	if display.nearestEnemy == nil || width <= 0 {
+		return
+	}
+	if enemy := display.nearestEnemy(); enemy != nil {
+		display.healthBar.render(screen, x, y, enemy.StructureLeft(), enemy.MaxStructure(), width)
+	}
+}
+
+// EnemyTooltip returns the label before the nearest enemy's health bar,
+// such as "Mech A ", or "" if no enemy is in radar range
+func (display *MiniMap) EnemyTooltip() string {
+	if display.nearestEnemy == nil {
+		return ""
+	}
+	if enemy := display.nearestEnemy(); enemy != nil {
+		return enemy.Name() + " "
+	}
+	return ""
+}
+
+// BuildingTooltip returns the name and occupancy of the building beside the
+// player, such as "Hospital (7/10)", or "" if there is none with room for
+// occupants
+func (display *MiniMap) BuildingTooltip() string {
+	if display.buildings == nil {
+		return ""
+	}
+	x, y := display.radar.Position()
+	for _, b := range display.buildings.Buildings() {
+		if b.Interior.Capacity() == 0 || b.Structure() == 0 {
+			continue
+		}
+		if b.Contains(x+1, y) || b.Contains(x-1, y) || b.Contains(x, y+1) || b.Contains(x, y-1) {
+			return display.buildings.Tooltip(b.ID())
+		}
+	}
+	return ""
+}
+
+// cells maps mini map positions to the contact drawn there. Waypoints are
+// drawn over graves, revealed landing zones over waypoints, tips over
+// landing zones, enemies over tips, towers over enemies and the player over
+// everything. Tips the civilian was unsure of are faded.
+func (display *MiniMap) cells() map[[2]int]*tl.Cell {
+	cells := make(map[[2]int]*tl.Cell)
+	px, py := display.radar.Position()
+	radar := display.radar.RadarRange()
+	scaleX := int(math.Ceil(float64(2*radar+1) / miniMapCols))
+	scaleY := int(math.Ceil(float64(2*radar+1) / miniMapRows))
+	if scaleX < 1 {
+		scaleX = 1
+	}
+	if scaleY < 1 {
+		scaleY = 1
+	}
+
+	plot := func(x, y int, cell *tl.Cell) {
+		dx, dy := x-px, y-py
+		if dx*dx+dy*dy > radar*radar {
+			return
+		}
+		cells[[2]int{miniMapCols/2 + dx/scaleX, miniMapRows/2 + dy/scaleY}] = cell
+	}
+
+	for _, pos := range display.graveyard {
+		plot(pos[0], pos[1], &tl.Cell{Fg: tl.ColorWhite | util.AttrDim, Ch: miniMapGraveGlyph})
+	}
+	if display.waypoints != nil {
+		for _, pos := range display.waypoints.Positions() {
+			plot(pos[0], pos[1], &tl.Cell{Fg: waypointColor, Ch: waypointGlyph})
+		}
+	}
+	for _, zone := range display.landingZones {
+		if zone.Revealed() {
+			x, y := zone.Position()
+			plot(x, y, &tl.Cell{Fg: tl.ColorGreen | tl.AttrBold, Ch: miniMapLZGlyph})
+		}
+	}
+	for _, tip := range display.tips {
+		color := tl.ColorYellow | tl.AttrBold
+		if tip.Confidence < intel.FadeConfidence {
+			color = tl.ColorYellow | util.AttrDim
+		}
+		plot(tip.LastKnownX, tip.LastKnownY, &tl.Cell{Fg: color, Ch: miniMapTipGlyph})
+	}
+	for _, pos := range display.radar.EnemyPositions() {
+		plot(pos[0], pos[1], &tl.Cell{Fg: tl.ColorRed, Ch: miniMapEnemyGlyph})
+	}
+	for _, tower := range display.radar.RadioTowers() {
+		x, y := tower.Position()
+		plot(x, y, &tl.Cell{Fg: tower.Owner().Color(), Ch: miniMapTowerGlyph})
+	}
+	plot(px, py, &tl.Cell{Fg: tl.ColorWhite | tl.AttrBold, Ch: miniMapPlayerGlyph})
+	return cells
+}
+
+// Tick updates the radar range shown in the title
+func (display *MiniMap) Tick(event tl.Event) {
+	display.title.SetText("Radar: " + strconv.Itoa(display.radar.RadarRange()))
+	display.buildingTooltip.SetText(display.BuildingTooltip())
+}
@@ -0,0 +1,105 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/building"
+	"github.com/Ariemeth/frame_assault/damagelog"
+	"github.com/Ariemeth/frame_assault/entities"
+	"github.com/Ariemeth/frame_assault/eventbus"
+	tl "github.com/Ariemeth/termloop"
+)
+
+// fakeRadar is a radar source at a fixed position
+type fakeRadar struct {
+	x, y int
+}
+
+func (r *fakeRadar) Name() string                        { return "Player" }
+func (r *fakeRadar) Position() (int, int)                { return r.x, r.y }
+func (r *fakeRadar) RadarRange() int                     { return 20 }
+func (r *fakeRadar) EnemyPositions() [][2]int            { return nil }
+func (r *fakeRadar) RadioTowers() []*entities.RadioTower { return nil }
+func (r *fakeRadar) SetPosition(x, y int)                { r.x, r.y = x, y }
+
+func TestMiniMapGraveUntilVisited(t *testing.T) {
+	radar := &fakeRadar{x: 50, y: 50}
+	miniMap := NewMiniMap(0, 0, radar, tl.NewBaseLevel(tl.Cell{}))
+
+	miniMap.HandleEvent(eventbus.MechDestroyedEvent{Name: "Player", X: 51, Y: 50})
+	miniMap.HandleEvent(eventbus.MechDestroyedEvent{Name: "Mech A", X: 62, Y: 44})
+	if len(miniMap.Graves()) != 1 {
+		t.Fatalf("mini map has %d graves, want only the enemy's", len(miniMap.Graves()))
+	}
+
+	// A radar of 20 covers 41 cells, 3 to each of the 19 columns and 6 to
+	// each of the 8 rows
+	want := [2]int{miniMapCols/2 + 12/3, miniMapRows/2 - 6/6}
+	cell, ok := miniMap.cells()[want]
+	if !ok || cell.Ch != miniMapGraveGlyph {
+		t.Fatalf("no grave drawn at mini map cell %v", want)
+	}
+
+	miniMap.ClearGrave(damagelog.EntityID("Mech A"))
+	for _, cell := range miniMap.cells() {
+		if cell.Ch == miniMapGraveGlyph {
+			t.Error("grave still drawn after it was cleared")
+		}
+	}
+}
+
+func TestMiniMapTooltipShowsBuildingOccupancy(t *testing.T) {
+	var hospital building.Type
+	for _, bt := range building.Types {
+		if bt.Name == "Hospital" {
+			hospital = bt
+		}
+	}
+	buildings := building.NewManager(nil)
+	id := buildings.Add(building.NewBuilding(10, 10, 8, 6, hospital))
+	buildings.Enter(id, &fakeRadar{x: 10, y: 10})
+
+	radar := &fakeRadar{x: 5, y: 12}
+	miniMap := NewMiniMap(0, 0, radar, tl.NewBaseLevel(tl.Cell{}))
+	miniMap.AttachBuildings(buildings)
+	if tooltip := miniMap.BuildingTooltip(); tooltip != "" {
+		t.Errorf("tooltip is %q away from every building", tooltip)
+	}
+
+	radar.x = 9
+	if tooltip := miniMap.BuildingTooltip(); tooltip != "Hospital (1/10)" {
+		t.Errorf("tooltip is %q beside the hospital, want \"Hospital (1/10)\"", tooltip)
+	}
+}
+
+// fakeEnemy is an enemy contact with fixed structure
+type fakeEnemy struct {
+	structure, max int
+}
+
+func (e *fakeEnemy) Name() string       { return "Mech A" }
+func (e *fakeEnemy) StructureLeft() int { return e.structure }
+func (e *fakeEnemy) MaxStructure() int  { return e.max }
+
+func TestMiniMapTooltipShowsNearestEnemyHealth(t *testing.T) {
+	miniMap := NewMiniMap(0, 0, &fakeRadar{x: 5, y: 5}, tl.NewBaseLevel(tl.Cell{}))
+	var nearest EnemyContact
+	miniMap.AttachNearestEnemy(func() EnemyContact { return nearest })
+	if tooltip := miniMap.EnemyTooltip(); tooltip != "" {
+		t.Errorf("tooltip is %q with no enemy in radar range", tooltip)
+	}
+
+	nearest = &fakeEnemy{structure: 2, max: 10}
+	if tooltip := miniMap.EnemyTooltip(); tooltip != "Mech A " {
+		t.Errorf("tooltip is %q, want \"Mech A \"", tooltip)
+	}
+	const width = 10
+	screen := newTestScreen()
+	miniMap.renderEnemyBar(screen, 0, 0, width)
+	if cell := screen.cells[[2]int{0, 0}]; cell.Ch != healthBarFilled || cell.Fg != tl.ColorRed {
+		t.Errorf("enemy bar starts with %q in %v, want a red filled cell", cell.Ch, cell.Fg)
+	}
+	if cell := screen.cells[[2]int{2, 0}]; cell.Ch != healthBarEmpty {
+		t.Errorf("enemy bar cell 2 is %q at 20%% structure, want empty", cell.Ch)
+	}
+}
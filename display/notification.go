@@ -45,12 +45,48 @@ func (display *Notification) Draw(screen *tl.Screen) {
 
 }
 
+// Priority controls how prominently a notification is shown
+type Priority int
+
+const (
+	// PriorityNormal notifications are shown in white
+	PriorityNormal Priority = iota
+	// PriorityCritical notifications are shown in red
+	PriorityCritical
+	// PriorityWarning notifications are shown in yellow
+	PriorityWarning
+)
+
+// color returns the text color used for the priority
+func (p Priority) color() tl.Attr {
+	switch p {
+	case PriorityCritical:
+		return tl.ColorRed | tl.AttrBold
+	case PriorityWarning:
+		return tl.ColorYellow
+	}
+	return tl.ColorWhite
+}
+
 // AddMessage adds a notification to the notification list.
 func (display *Notification) AddMessage(message string) {
-	display.textLine1.SetText(display.textLine2.Text())
-	display.textLine2.SetText(display.textLine3.Text())
-	display.textLine3.SetText(display.textLine4.Text())
+	display.AddPriorityMessage(message, PriorityNormal)
+}
+
+// AddCriticalMessage adds a notification highlighted as critical
+func (display *Notification) AddCriticalMessage(message string) {
+	display.AddPriorityMessage(message, PriorityCritical)
+}
+
+// AddPriorityMessage adds a notification colored by its priority
+func (display *Notification) AddPriorityMessage(message string, priority Priority) {
+	lines := []*tl.Text{display.textLine1, display.textLine2, display.textLine3, display.textLine4}
+	for i := 0; i < len(lines)-1; i++ {
+		lines[i].SetText(lines[i+1].Text())
+		lines[i].SetColor(lines[i+1].Color())
+	}
 	display.textLine4.SetText(message)
+	display.textLine4.SetColor(priority.color(), tl.ColorBlack)
 }
 
 // Clear clears all entries from the notification display
@@ -60,3 +96,42 @@ func (display *Notification) Clear() {
 	display.textLine3.SetText("")
 	display.textLine4.SetText("")
 }
+
+// PriorityNotifier receives notifications of differing priority
+type PriorityNotifier interface {
+	AddPriorityMessage(message string, priority Priority)
+}
+
+// MultiPriorityNotifier passes every notification on to each of its
+// notifiers in the order they were added
+type MultiPriorityNotifier struct {
+	notifiers []PriorityNotifier
+}
+
+// NewMultiPriorityNotifier creates a notifier passing notifications on to
+// notifiers
+func NewMultiPriorityNotifier(notifiers ...PriorityNotifier) *MultiPriorityNotifier {
+	return &MultiPriorityNotifier{notifiers: notifiers}
+}
+
+// Add appends notifier to the end of the notifiers passed to
+func (m *MultiPriorityNotifier) Add(notifier PriorityNotifier) {
+	m.notifiers = append(m.notifiers, notifier)
+}
+
+// AddMessage passes on a normal notification
+func (m *MultiPriorityNotifier) AddMessage(message string) {
+	m.AddPriorityMessage(message, PriorityNormal)
+}
+
+// AddCriticalMessage passes on a critical notification
+func (m *MultiPriorityNotifier) AddCriticalMessage(message string) {
+	m.AddPriorityMessage(message, PriorityCritical)
+}
+
+// AddPriorityMessage passes the notification on to every notifier
+func (m *MultiPriorityNotifier) AddPriorityMessage(message string, priority Priority) {
+	for _, notifier := range m.notifiers {
+		notifier.AddPriorityMessage(message, priority)
+	}
+}
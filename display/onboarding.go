@@ -0,0 +1,286 @@
+package display
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	onboardingWidth  = 52
+	onboardingHeight = 9
+	// onboardingBodyLines is how many wrapped lines each step may fill
+	onboardingBodyLines = 5
+	// onboardingStepSeconds is how long a step is shown before moving on
+	onboardingStepSeconds = 5
+	// onboardingSteps counts the steps, the last marking the intro done
+	onboardingSteps = 5
+	// onboardingPulseTicks is how many ticks the NPC highlight is shown or
+	// hidden for
+	onboardingPulseTicks = 5
+	onboardingPrompt     = "Space: next  Enter: skip"
+	// onboardingTestPrompt is sent to the model to show a live reply
+	onboardingTestPrompt = "You are a citizen of a city under attack by giant mechs. " +
+		"Greet a newcomer in one short sentence."
+)
+
+// ResponseGenerator asks the language model for a reply to a prompt
+type ResponseGenerator interface {
+	GenerateResponse(ctx context.Context, prompt string) (string, error)
+}
+
+// highlighted is implemented by the NPC pointed out by the intro
+type highlighted interface {
+	Position() (int, int)
+}
+
+// OnboardingSequence introduces new players to the AI-driven citizens the
+// first time the game is played. Each step moves on with Space or after
+// onboardingStepSeconds, Enter skips the rest, and the last step writes a
+// flag file so the intro is not shown again.
+type OnboardingSequence struct {
+	Status
+	path      string
+	step      int
+	open      bool
+	ticks     int
+	stepTicks int
+	// stepLength is how many ticks a step is shown before moving on
+	stepLength int
+	npc        highlighted
+	model      ResponseGenerator
+	ctx        context.Context
+	host       string
+	modelName  string
+	// reply is the model's answer to onboardingTestPrompt, guarded by mu
+	// as it arrives from another goroutine
+	mu    sync.Mutex
+	reply string
+	title *tl.Text
+	body  []*tl.Text
+	hint  *tl.Text
+}
+
+// DefaultOnboardingPath returns the flag file marking the intro as seen,
+// ~/.frame_assault/.onboarding_done
+func DefaultOnboardingPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error finding home directory: %v", err)
+	}
+	return filepath.Join(home, ".frame_assault", ".onboarding_done"), nil
+}
+
+// OnboardingDone returns true if the flag file at path shows the intro
+// has been seen
+func OnboardingDone(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// NewOnboardingSequence creates an open intro at x,y that writes path
+// once it is done, running at fps ticks per second
+func NewOnboardingSequence(x, y int, path string, fps int, level *tl.BaseLevel) *OnboardingSequence {
+	display := &OnboardingSequence{
+		Status:     *NewStatus(x, y, onboardingWidth, onboardingHeight, level),
+		path:       path,
+		open:       true,
+		stepLength: onboardingStepSeconds * fps,
+		ctx:        context.Background(),
+		title:      tl.NewText(x, y, "", tl.ColorYellow|tl.AttrBold, tl.ColorBlack),
+		hint:       tl.NewText(x, y, onboardingPrompt, tl.ColorCyan, tl.ColorBlack),
+	}
+	for i := 0; i < onboardingBodyLines; i++ {
+		display.body = append(display.body, tl.NewText(x, y, "", tl.ColorWhite, tl.ColorBlack))
+	}
+	return display
+}
+
+// AttachNPC sets the citizen highlighted while the intro describes them
+func (display *OnboardingSequence) AttachNPC(npc highlighted) {
+	display.npc = npc
+}
+
+// AttachModel sets the language model asked for a live reply and the host
+// and model name the intro tells the player about. Cancelling ctx
+// abandons the request.
+func (display *OnboardingSequence) AttachModel(ctx context.Context, model ResponseGenerator, host, modelName string) {
+	display.ctx = ctx
+	display.model = model
+	display.host = host
+	display.modelName = modelName
+}
+
+// IsOpen returns true until the intro is done or skipped
+func (display *OnboardingSequence) IsOpen() bool {
+	return display.open
+}
+
+// Step returns the current step, counting from 1
+func (display *OnboardingSequence) Step() int {
+	return display.step + 1
+}
+
+// Advance moves to the next step, asking the model for its live reply on
+// the second step. Reaching the last step writes the flag file and closes
+// the intro.
+func (display *OnboardingSequence) Advance() error {
+	if !display.open {
+		return nil
+	}
+	display.step++
+	display.stepTicks = 0
+	if display.step == 1 {
+		display.askModel()
+	}
+	if display.step == onboardingSteps-1 {
+		return display.Finish()
+	}
+	return nil
+}
+
+// Finish closes the intro and writes the flag file so it is not shown
+// again
+func (display *OnboardingSequence) Finish() error {
+	display.open = false
+	display.step = onboardingSteps - 1
+	if err := os.MkdirAll(filepath.Dir(display.path), 0755); err != nil {
+		return fmt.Errorf("error creating onboarding directory: %v", err)
+	}
+	if err := os.WriteFile(display.path, nil, 0644); err != nil {
+		return fmt.Errorf("error writing onboarding flag: %v", err)
+	}
+	return nil
+}
+
+// askModel sends onboardingTestPrompt to the model in the background
+func (display *OnboardingSequence) askModel() {
+	if display.model == nil {
+		return
+	}
+	go func() {
+		reply, err := display.model.GenerateResponse(display.ctx, onboardingTestPrompt)
+		if err != nil {
+			reply = "(no answer: " + err.Error() + ")"
+		}
+		display.mu.Lock()
+		display.reply = strings.TrimSpace(reply)
+		display.mu.Unlock()
+	}()
+}
+
+// Text returns what the current step says
+func (display *OnboardingSequence) Text() string {
+	switch display.step {
+	case 0:
+		return "This city is populated by AI-driven citizens."
+	case 1:
+		if display.model == nil {
+			return "They react to you in real time using an LLM. No model answered at start up, " +
+				"so this game they keep to simple routines."
+		}
+		display.mu.Lock()
+		reply := display.reply
+		display.mu.Unlock()
+		if reply == "" {
+			reply = "thinking..."
+		}
+		return "They react to you in real time using an LLM. Model says: " + reply
+	case 2:
+		return fmt.Sprintf("Citizens ask the Ollama server at %s using the %s model. "+
+			"Change them with the -ollama-host and -ollama-model flags.", display.host, display.modelName)
+	case 3:
+		return "Every citizen thinking at once can slow the game, so they take turns " +
+			"asking the model. Expect slower replies in a crowded city."
+	}
+	return ""
+}
+
+// Tick moves on with Space or after onboardingStepSeconds and skips the
+// rest with Enter. Failing to write the flag file only means the intro is
+// shown again next time, so errors are dropped.
+func (display *OnboardingSequence) Tick(event tl.Event) {
+	if !display.open {
+		return
+	}
+	switch {
+	case event.Type == tl.EventKey && event.Key == tl.KeySpace:
+		display.Advance()
+	case event.Type == tl.EventKey && event.Key == tl.KeyEnter:
+		display.Finish()
+	case event.Type == tl.EventNone:
+		display.ticks++
+		display.stepTicks++
+		if display.stepTicks >= display.stepLength {
+			display.Advance()
+		}
+	}
+}
+
+// Draw shows the current step and, on the first, brackets the citizen
+// it describes
+func (display *OnboardingSequence) Draw(screen *tl.Screen) {
+	if !display.open {
+		return
+	}
+	if display.step == 0 && display.npc != nil && (display.ticks/onboardingPulseTicks)%2 == 0 {
+		x, y := display.npc.Position()
+		marker := &tl.Cell{Fg: tl.ColorYellow | tl.AttrBold, Bg: tl.ColorBlack}
+		marker.Ch = '['
+		screen.RenderCell(x-1, y, marker)
+		marker.Ch = ']'
+		screen.RenderCell(x+1, y, marker)
+	}
+
+	display.Status.Draw(screen)
+	display.title.SetText(fmt.Sprintf("Welcome to the city (%d/%d)", display.Step(), onboardingSteps))
+	lines := wrapText(display.Text(), onboardingWidth-2*textLineStartX)
+	for i, text := range display.body {
+		text.SetText("")
+		if i < len(lines) {
+			text.SetText(lines[i])
+		}
+	}
+
+	offSetX, offSetY := display.level.Offset()
+	rows := append(append([]*tl.Text{display.title}, display.body...), display.hint)
+	for i, text := range rows {
+		text.SetPosition(-offSetX+textLineStartX+display.x, -offSetY+textLineStartY+i*textLineSpacing+display.y)
+		text.Draw(screen)
+	}
+}
+
+// wrapText breaks text into lines of at most width characters between
+// words, cutting words longer than a line
+func wrapText(text string, width int) []string {
+	lines := make([]string, 0)
+	line := ""
+	for _, word := range strings.Fields(text) {
+		for len(word) > width {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+			lines = append(lines, word[:width])
+			word = word[width:]
+		}
+		switch {
+		case line == "":
+			line = word
+		case len(line)+1+len(word) <= width:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
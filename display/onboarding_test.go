@@ -0,0 +1,34 @@
+package display
+
+import (
+	"path/filepath"
+	"testing"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+func TestOnboardingWritesDoneFileAfterLastStep(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".frame_assault", ".onboarding_done")
+	intro := NewOnboardingSequence(0, 0, path, 10, tl.NewBaseLevel(tl.Cell{}))
+
+	for intro.Step() < onboardingSteps-1 {
+		intro.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeySpace})
+		if OnboardingDone(path) {
+			t.Fatalf("done file written at step %d", intro.Step())
+		}
+	}
+	// The fourth step moves on by itself after onboardingStepSeconds
+	for i := 0; i < onboardingStepSeconds*10; i++ {
+		intro.Tick(tl.Event{Type: tl.EventNone})
+	}
+
+	if intro.Step() != onboardingSteps {
+		t.Errorf("intro is on step %d, want %d", intro.Step(), onboardingSteps)
+	}
+	if intro.IsOpen() {
+		t.Error("intro is still open after its last step")
+	}
+	if !OnboardingDone(path) {
+		t.Error("done file was not written after the last step")
+	}
+}
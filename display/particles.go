@@ -0,0 +1,148 @@
+package display
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/Ariemeth/frame_assault/util"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// Particle glyphs from a fresh particle to one about to fade out
+	particleBrightGlyph = '*'
+	particleDimGlyph    = '·'
+	particleFadedGlyph  = '`'
+
+	// Speed range of a particle in cells per tick
+	minParticleSpeed = 0.3
+	maxParticleSpeed = 1.0
+)
+
+// Particle is a single spark thrown out by an explosion
+type Particle struct {
+	x, y        float64 // Current position as float for smooth movement
+	dx, dy      float64 // Velocity in cells per tick
+	lifetime    int
+	maxLifetime int
+	level       *tl.BaseLevel
+}
+
+// newParticle creates a particle at x,y flying at speed in direction
+// radians for lifetime ticks
+func newParticle(x, y int, direction, speed float64, lifetime int, level *tl.BaseLevel) *Particle {
+	return &Particle{
+		x:           float64(x),
+		y:           float64(y),
+		dx:          math.Cos(direction) * speed,
+		dy:          math.Sin(direction) * speed,
+		lifetime:    lifetime,
+		maxLifetime: lifetime,
+		level:       level,
+	}
+}
+
+// Position returns the cell the particle is drawn in
+func (p *Particle) Position() (int, int) {
+	return int(math.Round(p.x)), int(math.Round(p.y))
+}
+
+// Lifetime returns the ticks the particle has left
+func (p *Particle) Lifetime() int {
+	return p.lifetime
+}
+
+// cell returns the particle's glyph and color, fading from a bright
+// yellow star to a dim red speck over its lifetime
+func (p *Particle) cell() *tl.Cell {
+	remaining := float64(p.lifetime) / float64(p.maxLifetime)
+	switch {
+	case remaining > 2.0/3.0:
+		return &tl.Cell{Fg: tl.ColorYellow | tl.AttrBold, Ch: particleBrightGlyph}
+	case remaining > 1.0/3.0:
+		return &tl.Cell{Fg: tl.ColorRed, Ch: particleDimGlyph}
+	}
+	return &tl.Cell{Fg: tl.ColorRed | util.AttrDim, Ch: particleFadedGlyph}
+}
+
+// Draw implements the Draw method of the Drawable interface
+func (p *Particle) Draw(screen *tl.Screen) {
+	x, y := p.Position()
+	screen.RenderCell(x, y, p.cell())
+}
+
+// Tick moves the particle along its velocity and removes it from the
+// level once its lifetime runs out
+func (p *Particle) Tick(event tl.Event) {
+	if event.Type != tl.EventNone {
+		return
+	}
+	p.x += p.dx
+	p.y += p.dy
+	p.lifetime--
+	if p.lifetime <= 0 && p.level != nil {
+		removeEntity(p.level, p)
+	}
+}
+
+// removeEntity removes d from level without shifting the entities the
+// level is ticking through. tl.BaseLevel.RemoveEntity shifts them in place,
+// which makes the level skip the entity after d that tick, so a burst of
+// particles expiring together would outlive their lifetime.
+func removeEntity(level *tl.BaseLevel, d tl.Drawable) {
+	kept := make([]tl.Drawable, 0, len(level.Entities))
+	for _, e := range level.Entities {
+		if e != d {
+			kept = append(kept, e)
+		}
+	}
+	level.Entities = kept
+}
+
+// ParticleSystem is a burst of particles thrown out from a single point
+type ParticleSystem struct {
+	particles []*Particle
+}
+
+// NewParticleSystem adds count particles at x,y to level, each flying in a
+// random direction for up to maxLifetime ticks
+func NewParticleSystem(x, y, count, maxLifetime int, level *tl.BaseLevel) *ParticleSystem {
+	if maxLifetime < 1 {
+		maxLifetime = 1
+	}
+	ps := &ParticleSystem{particles: make([]*Particle, 0, count)}
+	for i := 0; i < count; i++ {
+		direction := rand.Float64() * 2 * math.Pi
+		speed := minParticleSpeed + rand.Float64()*(maxParticleSpeed-minParticleSpeed)
+		lifetime := maxLifetime/2 + rand.Intn(maxLifetime-maxLifetime/2) + 1
+		particle := newParticle(x, y, direction, speed, lifetime, level)
+		ps.particles = append(ps.particles, particle)
+		if level != nil {
+			level.AddEntity(particle)
+		}
+	}
+	return ps
+}
+
+// Particles returns the particles of the burst
+func (ps *ParticleSystem) Particles() []*Particle {
+	return ps.particles
+}
+
+// Explosions spawns a particle burst wherever something explodes
+type Explosions struct {
+	level       *tl.BaseLevel
+	count       int
+	maxLifetime int
+}
+
+// NewExplosions creates explosions of count particles lasting up to
+// maxLifetime ticks in level
+func NewExplosions(count, maxLifetime int, level *tl.BaseLevel) *Explosions {
+	return &Explosions{level: level, count: count, maxLifetime: maxLifetime}
+}
+
+// Explode throws out a burst of particles at x,y
+func (e *Explosions) Explode(x, y int) {
+	NewParticleSystem(x, y, e.count, e.maxLifetime, e.level)
+}
@@ -0,0 +1,42 @@
+package display
+
+import (
+	"testing"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+func TestParticlesRemovedAfterMaxLifetime(t *testing.T) {
+	const maxLifetime = 8
+	level := tl.NewBaseLevel(tl.Cell{})
+	ps := NewParticleSystem(5, 5, 20, maxLifetime, level)
+	if len(level.Entities) != 20 {
+		t.Fatalf("level has %d entities instead of 20 particles", len(level.Entities))
+	}
+
+	for i := 0; i < maxLifetime; i++ {
+		level.Tick(tl.Event{Type: tl.EventNone})
+	}
+	if len(level.Entities) != 0 {
+		t.Errorf("%d particles left after %d ticks", len(level.Entities), maxLifetime)
+	}
+	for _, p := range ps.Particles() {
+		if p.Lifetime() > 0 {
+			t.Errorf("particle has %d ticks left", p.Lifetime())
+		}
+	}
+}
+
+func TestParticleGlyphFades(t *testing.T) {
+	p := newParticle(0, 0, 0, 1, 3, nil)
+	expected := []rune{particleBrightGlyph, particleDimGlyph, particleFadedGlyph}
+	for i, glyph := range expected {
+		if ch := p.cell().Ch; ch != glyph {
+			t.Errorf("glyph at tick %d is %q, want %q", i, ch, glyph)
+		}
+		p.Tick(tl.Event{Type: tl.EventNone})
+	}
+	if x, y := p.Position(); x != 3 || y != 0 {
+		t.Errorf("particle at %d,%d after 3 ticks, want 3,0", x, y)
+	}
+}
@@ -0,0 +1,200 @@
+package display
+
+import (
+	"fmt"
+
+	"github.com/Ariemeth/frame_assault/mech"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	partsTitle      = "MECH PARTS - Up/Down select, Enter install, D discard, I close"
+	partsListStartY = 3
+	partsStatsWidth = 30
+)
+
+// PartsOwner is the mech whose salvaged parts the inventory manages
+type PartsOwner interface {
+	InstallPart(part *mech.MechPart) bool
+	DiscardPart(part *mech.MechPart) bool
+	Stats() mech.Stats
+}
+
+// PartsInventory is a full screen overlay listing salvaged mech parts. The
+// highlighted part can be installed with Enter or discarded with D.
+type PartsInventory struct {
+	Status
+	owner      PartsOwner
+	parts      []*mech.MechPart
+	selected   int
+	scroll     int
+	open       bool
+	confirming bool
+}
+
+// NewPartsInventory creates a new, closed, parts inventory for owner
+func NewPartsInventory(owner PartsOwner, level *tl.BaseLevel) *PartsInventory {
+	return &PartsInventory{
+		Status: *NewStatus(0, 0, 0, 0, level),
+		owner:  owner,
+	}
+}
+
+// SetParts replaces the listed parts, keeping the selection in range
+func (display *PartsInventory) SetParts(parts []*mech.MechPart) {
+	display.parts = parts
+	if display.selected >= len(parts) {
+		display.selected = 0
+	}
+}
+
+// Toggle opens or closes the inventory
+func (display *PartsInventory) Toggle() {
+	display.open = !display.open
+	display.confirming = false
+}
+
+// IsOpen returns true if the inventory is being shown
+func (display *PartsInventory) IsOpen() bool {
+	return display.open
+}
+
+// Selected returns the index of the highlighted part
+func (display *PartsInventory) Selected() int {
+	return display.selected
+}
+
+// MoveSelection moves the highlight by delta parts, wrapping around at
+// either end of the list
+func (display *PartsInventory) MoveSelection(delta int) {
+	if len(display.parts) == 0 {
+		display.selected = 0
+		return
+	}
+	count := len(display.parts)
+	display.selected = ((display.selected+delta)%count + count) % count
+}
+
+// selectedPart returns the highlighted part or nil if there are none
+func (display *PartsInventory) selectedPart() *mech.MechPart {
+	if display.selected < len(display.parts) {
+		return display.parts[display.selected]
+	}
+	return nil
+}
+
+// Draw covers the screen with the part list on the left and the owner's
+// current and post install stats on the right
+func (display *PartsInventory) Draw(screen *tl.Screen) {
+	if !display.open {
+		return
+	}
+	width, height := screen.Size()
+	display.background.SetSize(width, height)
+	display.Status.Draw(screen)
+
+	display.drawLine(screen, textLineStartX, 1, partsTitle, tl.ColorWhite|tl.AttrBold, tl.ColorBlack)
+
+	listWidth := width - partsStatsWidth - 2*textLineStartX
+	visible := height - partsListStartY - 2
+	display.scrollTo(visible)
+	if len(display.parts) == 0 {
+		display.drawLine(screen, textLineStartX, partsListStartY, "No parts salvaged", tl.ColorWhite, tl.ColorBlack)
+	}
+	for i := 0; i < visible && display.scroll+i < len(display.parts); i++ {
+		index := display.scroll + i
+		part := display.parts[index]
+		line := fmt.Sprintf("%-16s %-10s %s", part.Name, part.Type, part.Effect())
+		if part.Equipped {
+			line += " [equipped]"
+		}
+		fg, bg := tl.ColorWhite, tl.ColorBlack
+		if index == display.selected {
+			fg, bg = tl.ColorBlack, tl.ColorWhite
+		}
+		display.drawLine(screen, textLineStartX, partsListStartY+i, truncate(line, listWidth), fg, bg)
+	}
+
+	if display.owner != nil {
+		current := display.owner.Stats()
+		after := current.With(display.selectedPart())
+		statsX := width - partsStatsWidth
+		display.drawLine(screen, statsX, partsListStartY, "Stat        Now  Installed", tl.ColorWhite|tl.AttrBold, tl.ColorBlack)
+		for i, stat := range []struct {
+			name       string
+			now, after int
+		}{
+			{"Structure", current.Structure, after.Structure},
+			{"Speed", current.Speed, after.Speed},
+			{"Damage", current.Damage, after.Damage},
+		} {
+			display.drawLine(screen, statsX, partsListStartY+1+i,
+				fmt.Sprintf("%-10s %4d %4d", stat.name, stat.now, stat.after), statColor(stat.now, stat.after), tl.ColorBlack)
+		}
+	}
+
+	if part := display.selectedPart(); display.confirming && part != nil {
+		display.drawLine(screen, textLineStartX, height-2, "Discard "+part.Name+"? (y/n)", tl.ColorYellow|tl.AttrBold, tl.ColorBlack)
+	}
+}
+
+// scrollTo keeps the selected part within the visible rows
+func (display *PartsInventory) scrollTo(visible int) {
+	if visible < 1 {
+		visible = 1
+	}
+	if display.selected < display.scroll {
+		display.scroll = display.selected
+	}
+	if display.selected >= display.scroll+visible {
+		display.scroll = display.selected - visible + 1
+	}
+}
+
+// drawLine draws text at the screen position x,y regardless of the level offset
+func (display *PartsInventory) drawLine(screen *tl.Screen, x, y int, text string, fg, bg tl.Attr) {
+	offSetX, offSetY := display.level.Offset()
+	tl.NewText(-offSetX+x, -offSetY+y, text, fg, bg).Draw(screen)
+}
+
+// statColor shows improvements in green and losses in red
+func statColor(now, after int) tl.Attr {
+	switch {
+	case after > now:
+		return tl.ColorGreen
+	case after < now:
+		return tl.ColorRed
+	}
+	return tl.ColorWhite
+}
+
+// Tick moves the highlight with the arrow keys, installs the highlighted
+// part on Enter and discards it on D once confirmed with Y. I is left to
+// the player mech which toggles the inventory.
+func (display *PartsInventory) Tick(event tl.Event) {
+	if !display.open || event.Type != tl.EventKey {
+		return
+	}
+
+	if display.confirming {
+		display.confirming = false
+		if part := display.selectedPart(); part != nil && (event.Ch == 'y' || event.Ch == 'Y') {
+			display.owner.DiscardPart(part)
+		}
+		return
+	}
+
+	switch event.Key {
+	case tl.KeyArrowUp:
+		display.MoveSelection(-1)
+	case tl.KeyArrowDown:
+		display.MoveSelection(1)
+	case tl.KeyEnter:
+		if part := display.selectedPart(); part != nil {
+			display.owner.InstallPart(part)
+		}
+	}
+	if (event.Ch == 'd' || event.Ch == 'D') && display.selectedPart() != nil {
+		display.confirming = true
+	}
+}
@@ -0,0 +1,35 @@
+package display
+
+import (
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/mech"
+)
+
+func TestPartsInventorySelectionWraps(t *testing.T) {
+	inventory := NewPartsInventory(nil, nil)
+	inventory.SetParts([]*mech.MechPart{
+		{Name: "Plating", Type: mech.PartArmor, Structure: 5},
+		{Name: "Servo", Type: mech.PartActuator, Speed: 1},
+		{Name: "Barrel Mod", Type: mech.PartWeaponMod, Damage: 1},
+	})
+
+	inventory.MoveSelection(-1)
+	if got := inventory.Selected(); got != 2 {
+		t.Errorf("moving up from the first part selected %d, expected 2", got)
+	}
+	inventory.MoveSelection(1)
+	if got := inventory.Selected(); got != 0 {
+		t.Errorf("moving down from the last part selected %d, expected 0", got)
+	}
+	inventory.MoveSelection(4)
+	if got := inventory.Selected(); got != 1 {
+		t.Errorf("moving down 4 parts selected %d, expected 1", got)
+	}
+
+	inventory.SetParts(nil)
+	inventory.MoveSelection(1)
+	if got := inventory.Selected(); got != 0 {
+		t.Errorf("moving through an empty list selected %d, expected 0", got)
+	}
+}
@@ -12,8 +12,14 @@ const (
     textLineStartY = 1    // Y offset for first text line
     textLineSpacing = 1   // Spacing between text lines
     displayWidth = 25     // Width of the status display
-    displayHeight = 12    // Height of the status display (9 text lines + margins)
-    numTextLines = 9      // Total number of text lines in display
+    displayHeight = 14    // Height of the status display (12 text lines + margins)
+    numTextLines = 12     // Total number of text lines in display
+    structureLabel = "Structure: "
+    structureBarLine = 2  // Text line index holding the structure bar
+    shieldLabel = "Shield:    "
+    shieldBarLine = 3     // Text line index holding the shield bar
+    goldStreak = 5        // Hit streak above which the streak is shown in gold
+    overloadPulseTicks = 5 // Ticks the overload warning stays bright or dim
 )
 
 //Player represents a player status display
@@ -30,6 +36,11 @@ type Player struct {
     textLine7   *tl.Text
     textLine8   *tl.Text
     textLine9   *tl.Text
+    textLine10   *tl.Text
+    textLine11  *tl.Text
+    textLine12  *tl.Text
+    healthBar   HealthBar
+    ticks       int
 }
 
 // TimeSystemInterface defines the methods required for time display
@@ -52,6 +63,9 @@ func NewPlayer(x, y int, player *mech.PlayerMech, timeSystem TimeSystemInterface
         textLine7:  tl.NewText(x, y+6, "", tl.ColorWhite, tl.ColorBlack),
         textLine8:  tl.NewText(x, y+7, "", tl.ColorWhite, tl.ColorBlack),
         textLine9:  tl.NewText(x, y+8, "", tl.ColorWhite, tl.ColorBlack),
+        textLine10:  tl.NewText(x, y+9, "", tl.ColorWhite, tl.ColorBlack),
+        textLine11: tl.NewText(x, y+10, "", tl.ColorWhite, tl.ColorBlack),
+        textLine12: tl.NewText(x, y+11, "", tl.ColorWhite, tl.ColorBlack),
     }
     return display
 }
@@ -62,6 +76,7 @@ func (display *Player) positionTextLines(offsetX, offsetY int) {
         display.textLine1, display.textLine2, display.textLine3,
         display.textLine4, display.textLine5, display.textLine6,
         display.textLine7, display.textLine8, display.textLine9,
+        display.textLine10, display.textLine11, display.textLine12,
     }
     
     for i, line := range lines {
@@ -77,6 +92,7 @@ func (display *Player) drawTextLines(screen *tl.Screen) {
         display.textLine1, display.textLine2, display.textLine3,
         display.textLine4, display.textLine5, display.textLine6,
         display.textLine7, display.textLine8, display.textLine9,
+        display.textLine10, display.textLine11, display.textLine12,
     }
     
     for _, line := range lines {
@@ -95,6 +111,18 @@ func (display *Player) Draw(screen *tl.Screen) {
     // Position and draw text lines
     display.positionTextLines(offSetX, offSetY)
     display.drawTextLines(screen)
+
+    // Draw the structure bar after its label
+    barX := -offSetX + display.x + textLineStartX + len(structureLabel)
+    barY := -offSetY + display.y + textLineStartY + structureBarLine*textLineSpacing
+    barWidth := displayWidth - len(structureLabel) - 2*textLineStartX
+    display.healthBar.Render(screen, barX, barY,
+        display.player.StructureLeft(), display.player.MaxStructure(), barWidth)
+
+    // Draw the shield bar below the structure bar
+    shieldY := -offSetY + display.y + textLineStartY + shieldBarLine*textLineSpacing
+    shieldBar.Render(screen, barX, shieldY,
+        display.player.Shield(), display.player.MaxShield(), barWidth)
 }
 
 // Tick is called to process 1 tick of actions based on the
@@ -106,25 +134,94 @@ func (display *Player) Tick(event tl.Event) {
     }
     
     // Player info moved down one line
-    display.textLine2.SetText(display.player.Name())
-    display.textLine3.SetText("Struture: " + strconv.Itoa(display.player.StructureLeft()))
+    display.textLine2.SetText(display.player.Name() + " [Streak: " + strconv.Itoa(display.player.HitStreak()) + "]")
+    if display.player.HitStreak() > goldStreak {
+        display.textLine2.SetColor(tl.ColorYellow|tl.AttrBold, tl.ColorBlack)
+    } else {
+        display.textLine2.SetColor(tl.ColorWhite, tl.ColorBlack)
+    }
+    display.textLine3.SetText(structureLabel)
+    display.textLine4.SetText(shieldLabel)
     x, y := display.player.Position()
-    display.textLine4.SetText("Location: (" + strconv.Itoa(x) + "," + strconv.Itoa(y) + ")")
+    display.textLine5.SetText("Loc: (" + strconv.Itoa(x) + "," + strconv.Itoa(y) + ") [Undos: " +
+        strconv.Itoa(display.player.UndosRemaining()) + "]")
 
     //assume for now there is only 1 Weapon
-    display.textLine5.SetText("Weapons")
+    display.textLine6.SetText("Weapons")
     weapons := display.player.Weapons()
     if len(weapons) > 0 {
-        display.textLine6.SetText("    Name: " + weapons[0].Name())
-        display.textLine6.SetColor(tl.ColorWhite, tl.ColorBlack)
-        display.textLine7.SetText("   Range: " + strconv.Itoa(weapons[0].Range()))
-        display.textLine8.SetText("  Damage: " + strconv.Itoa(weapons[0].Damage()))
-        display.textLine9.SetText("Accuracy: " + strconv.FormatFloat(weapons[0].Accuracy()*100, 'f', 1, 64) + "%")
+        display.textLine6.SetText("Weapons  Cond: " + strconv.Itoa(weapons[0].Condition()) + "%")
+        display.textLine7.SetText("    Name: " + weapons[0].Name())
+        display.textLine7.SetColor(tl.ColorWhite, tl.ColorBlack)
+        display.textLine8.SetText("   Range: " + strconv.Itoa(weapons[0].Range()) + "  Dmg: " + strconv.Itoa(weapons[0].Damage()))
+        display.textLine9.SetText("Accuracy: " + strconv.FormatFloat(display.player.EffectiveAccuracy()*100, 'f', 1, 64) + "%")
+        display.textLine9.SetColor(accuracyColor(weapons[0].StabilityBonus(), display.player.MovementPenalty()), tl.ColorBlack)
+        if secondary := weapons[0].Secondary(); secondary != nil {
+            display.textLine10.SetText("  Alt: " + secondary.Name() + " " +
+                strconv.Itoa(secondary.Ammo()) + "/" + strconv.Itoa(secondary.MagazineSize()))
+        } else {
+            display.textLine10.SetText("")
+        }
     } else {
-        display.textLine6.SetText("    None")
-        display.textLine6.SetColor(tl.ColorRed, tl.ColorBlack)
-        display.textLine7.SetText("")
+        display.textLine7.SetText("    None")
+        display.textLine7.SetColor(tl.ColorRed, tl.ColorBlack)
         display.textLine8.SetText("")
         display.textLine9.SetText("")
+        display.textLine10.SetText("")
+    }
+
+    display.textLine11.SetText(thermalText(display.player.ThermalStatus()))
+    if display.player.ThermalActive() {
+        display.textLine11.SetColor(tl.ColorRed|tl.AttrBold, tl.ColorBlack)
+    } else {
+        display.textLine11.SetColor(tl.ColorWhite, tl.ColorBlack)
+    }
+
+    // The overload warning pulses while the mech is burning itself up
+    if event.Type == tl.EventNone {
+        display.ticks++
+    }
+    display.textLine12.SetText(overloadText(display.player.OverloadStatus()))
+    if display.player.OverloadActive() {
+        color := tl.ColorRed | tl.AttrBold
+        if (display.ticks/overloadPulseTicks)%2 == 1 {
+            color = tl.ColorRed
+        }
+        display.textLine12.SetColor(color, tl.ColorBlack)
+    } else {
+        display.textLine12.SetColor(tl.ColorWhite, tl.ColorBlack)
+    }
+}
+
+// accuracyColor shows accuracy in red while the player is penalised for
+// moving and in green while standing still earns a stability bonus
+func accuracyColor(stabilityBonus, movementPenalty float64) tl.Attr {
+    if movementPenalty > 0 {
+        return tl.ColorRed
+    }
+    if stabilityBonus > 0 {
+        return tl.ColorGreen
+    }
+    return tl.ColorWhite
+}
+
+// thermalText describes thermal imaging as [THERMAL: 8t | CD: 200t], or
+// as ready once it has recharged
+func thermalText(remaining, cooldown int) string {
+    if remaining == 0 && cooldown == 0 {
+        return "[THERMAL: ready]"
+    }
+    return "[THERMAL: " + strconv.Itoa(remaining) + "t | CD: " + strconv.Itoa(cooldown) + "t]"
+}
+
+// overloadText shows [OVERLOAD] with the ticks left while overload is on,
+// then its cooldown as [OVERLOAD CD: 200t] until it is ready
+func overloadText(remaining, cooldown int) string {
+    if remaining > 0 {
+        return "[OVERLOAD] " + strconv.Itoa(remaining) + "t"
+    }
+    if cooldown > 0 {
+        return "[OVERLOAD CD: " + strconv.Itoa(cooldown) + "t]"
     }
+    return "[OVERLOAD: ready]"
 }
@@ -0,0 +1,52 @@
+package display
+
+import tl "github.com/Ariemeth/termloop"
+
+// bulletTimeTint is the background laid over the screen in bullet time
+const bulletTimeTint = tl.ColorBlue
+
+// SlowMotionSource reports whether the game is slowed by bullet time
+type SlowMotionSource interface {
+	BulletTimeActive() bool
+}
+
+// ScreenEffect tints the whole screen blue while bullet time slows the
+// game. Only the background is drawn, so every glyph and its color show
+// through. It belongs last in the level so it tints what came before.
+type ScreenEffect struct {
+	source SlowMotionSource
+	level  *tl.BaseLevel
+}
+
+// NewScreenEffect creates a tint shown while source is in bullet time
+func NewScreenEffect(source SlowMotionSource, level *tl.BaseLevel) *ScreenEffect {
+	return &ScreenEffect{source: source, level: level}
+}
+
+// Active returns true while the tint is shown
+func (effect *ScreenEffect) Active() bool {
+	return effect.source != nil && effect.source.BulletTimeActive()
+}
+
+// Tick does nothing, the effect follows its source
+func (effect *ScreenEffect) Tick(event tl.Event) {}
+
+// Draw tints every cell of the screen while bullet time is on
+func (effect *ScreenEffect) Draw(screen *tl.Screen) {
+	if !effect.Active() {
+		return
+	}
+	width, height := screen.Size()
+	offSetX, offSetY := effect.level.Offset()
+	effect.render(screen, -offSetX, -offSetY, width, height)
+}
+
+// render tints the width by height cells from left,top
+func (effect *ScreenEffect) render(screen cellRenderer, left, top, width, height int) {
+	tint := &tl.Cell{Bg: bulletTimeTint}
+	for x := left; x < left+width; x++ {
+		for y := top; y < top+height; y++ {
+			screen.RenderCell(x, y, tint)
+		}
+	}
+}
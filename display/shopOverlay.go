@@ -0,0 +1,108 @@
+package display
+
+import (
+	"fmt"
+	"strconv"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	shopOverlayWidth = 30
+	shopTitle        = "[Redeem Bounties] F2:close"
+)
+
+// ShopOption is a reward listed in the shop
+type ShopOption struct {
+	Name string
+	Cost int
+}
+
+// ShopOverlay lists what bounty points can be redeemed for under its
+// [Redeem Bounties] tab. F2 opens and closes it, the arrow keys pick a
+// reward and Enter redeems it, leaving the shop open to redeem another.
+type ShopOverlay struct {
+	Status
+	options  []ShopOption
+	selected int
+	open     bool
+	points   func() int
+	onRedeem func(option int)
+}
+
+// NewShopOverlay creates a closed shop of options at x,y showing the points
+// left to spend and calling onRedeem with the index of the option picked
+func NewShopOverlay(x, y int, options []ShopOption, points func() int, onRedeem func(option int), level *tl.BaseLevel) *ShopOverlay {
+	return &ShopOverlay{
+		Status:   *NewStatus(x, y, shopOverlayWidth, len(options)+4, level),
+		options:  options,
+		points:   points,
+		onRedeem: onRedeem,
+	}
+}
+
+// Toggle opens or closes the shop
+func (display *ShopOverlay) Toggle() {
+	display.open = !display.open
+}
+
+// IsOpen returns true while the shop is being shown
+func (display *ShopOverlay) IsOpen() bool {
+	return display.open
+}
+
+// Selected returns the index of the highlighted reward
+func (display *ShopOverlay) Selected() int {
+	return display.selected
+}
+
+// Draw lists the rewards, their costs and the points left while the shop
+// is open
+func (display *ShopOverlay) Draw(screen *tl.Screen) {
+	if !display.open {
+		return
+	}
+	display.Status.Draw(screen)
+
+	offSetX, offSetY := display.level.Offset()
+	x := -offSetX + display.x + textLineStartX
+	y := -offSetY + display.y + textLineStartY
+	tl.NewText(x, y, shopTitle, tl.ColorWhite|tl.AttrBold, tl.ColorBlack).Draw(screen)
+	for i, option := range display.options {
+		fg, bg := tl.ColorWhite, tl.ColorBlack
+		if i == display.selected {
+			fg, bg = tl.ColorBlack, tl.ColorWhite
+		}
+		line := fmt.Sprintf("%-18s %5d", option.Name, option.Cost)
+		tl.NewText(x, y+1+i*textLineSpacing, line, fg, bg).Draw(screen)
+	}
+	if display.points != nil {
+		pointsY := y + 1 + len(display.options)*textLineSpacing
+		tl.NewText(x, pointsY, "Points: "+strconv.Itoa(display.points()), tl.ColorYellow, tl.ColorBlack).Draw(screen)
+	}
+}
+
+// Tick toggles the shop on F2 and, while it is open, moves the highlight
+// with the arrow keys and redeems the highlighted reward on Enter
+func (display *ShopOverlay) Tick(event tl.Event) {
+	if event.Type != tl.EventKey {
+		return
+	}
+	if event.Key == tl.KeyF2 {
+		display.Toggle()
+		return
+	}
+	if !display.open || len(display.options) == 0 {
+		return
+	}
+	switch event.Key {
+	case tl.KeyArrowUp:
+		display.selected = (display.selected + len(display.options) - 1) % len(display.options)
+	case tl.KeyArrowDown:
+		display.selected = (display.selected + 1) % len(display.options)
+	case tl.KeyEnter:
+		if display.onRedeem != nil {
+			display.onRedeem(display.selected)
+		}
+	}
+}
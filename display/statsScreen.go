@@ -0,0 +1,69 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Ariemeth/frame_assault/stats"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// statsTopBuildings is how many building types the stats screen lists
+	statsTopBuildings = 3
+	statsBarWidth     = 20
+	statsTitle        = "LIFETIME BUILDING DESTRUCTION"
+	statsPrompt       = "Press S to return"
+)
+
+// StatsScreen shows the building types destroyed most often across every
+// game, each with a bar showing its share of all destroyed buildings
+type StatsScreen struct {
+	stats *stats.BuildingStats
+}
+
+// NewStatsScreen creates a stats screen for buildingStats
+func NewStatsScreen(buildingStats *stats.BuildingStats) *StatsScreen {
+	return &StatsScreen{stats: buildingStats}
+}
+
+// Lines returns the text of the stats screen
+func (display *StatsScreen) Lines() []string {
+	lines := []string{statsTitle, ""}
+	total := 0
+	var top []stats.Count
+	if display.stats != nil {
+		total = display.stats.Total()
+		top = display.stats.Top(statsTopBuildings)
+	}
+	if total == 0 {
+		lines = append(lines, "No buildings destroyed yet")
+	}
+	for i, count := range top {
+		percent := count.Destroyed * 100 / total
+		filled := count.Destroyed * statsBarWidth / total
+		bar := strings.Repeat("█", filled) + strings.Repeat("░", statsBarWidth-filled)
+		lines = append(lines, fmt.Sprintf("%d. %-12s %5d %s %3d%%", i+1, count.Name, count.Destroyed, bar, percent))
+	}
+	return append(lines, "", statsPrompt)
+}
+
+// Draw centers the stats on the screen
+func (display *StatsScreen) Draw(screen *tl.Screen) {
+	width, height := screen.Size()
+	lines := display.Lines()
+	top := (height - len(lines)) / 2
+	for i, line := range lines {
+		color := tl.ColorWhite
+		if i == 0 {
+			color = tl.ColorYellow | tl.AttrBold
+		}
+		text := tl.NewText(0, 0, line, color, tl.ColorBlack)
+		textWidth, _ := text.Size()
+		text.SetPosition((width-textWidth)/2, top+i)
+		text.Draw(screen)
+	}
+}
+
+// Tick does nothing, the game over screen toggles the stats with S
+func (display *StatsScreen) Tick(event tl.Event) {}
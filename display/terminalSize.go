@@ -0,0 +1,26 @@
+package display
+
+import (
+	"fmt"
+	"os"
+)
+
+// TerminalSizeDetector reads the size of the terminal a file is attached to
+type TerminalSizeDetector struct {
+	file *os.File
+}
+
+// NewTerminalSizeDetector creates a detector for the terminal behind file,
+// usually os.Stdout
+func NewTerminalSizeDetector(file *os.File) *TerminalSizeDetector {
+	return &TerminalSizeDetector{file: file}
+}
+
+// Size returns the terminal's width in columns and height in rows
+func (d *TerminalSizeDetector) Size() (width, height int, err error) {
+	width, height, err = terminalSize(d.file.Fd())
+	if err != nil {
+		return 0, 0, fmt.Errorf("error reading terminal size: %v", err)
+	}
+	return width, height, nil
+}
@@ -0,0 +1,22 @@
+//go:build linux || darwin
+
+package display
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// winsize is the terminal size filled in by the TIOCGWINSZ ioctl
+type winsize struct {
+	rows, cols, xpixel, ypixel uint16
+}
+
+// terminalSize asks the terminal behind fd for its size
+func terminalSize(fd uintptr) (width, height int, err error) {
+	var ws winsize
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws))); errno != 0 {
+		return 0, 0, errno
+	}
+	return int(ws.cols), int(ws.rows), nil
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package display
+
+import "errors"
+
+// terminalSize is not supported on this platform
+func terminalSize(fd uintptr) (width, height int, err error) {
+	return 0, 0, errors.New("terminal size detection is not supported")
+}
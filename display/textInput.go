@@ -0,0 +1,114 @@
+package display
+
+import (
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	textInputWidth  = 24
+	textInputHeight = 4
+	textInputCursor = '_'
+)
+
+// TextInput is a bordered box the player types a short line of text into
+type TextInput struct {
+	Status
+	prompt    string
+	text      []rune
+	maxLength int
+	open      bool
+	onConfirm func(text string)
+}
+
+// NewTextInput creates a closed text input taking up to maxLength
+// characters
+func NewTextInput(x, y, maxLength int, level *tl.BaseLevel) *TextInput {
+	return &TextInput{
+		Status:    *NewStatus(x, y, textInputWidth, textInputHeight, level),
+		maxLength: maxLength,
+	}
+}
+
+// Open shows prompt over an empty line of text, calling onConfirm with
+// the text typed when Enter is pressed
+func (display *TextInput) Open(prompt string, onConfirm func(text string)) {
+	display.prompt = prompt
+	display.text = display.text[:0]
+	display.onConfirm = onConfirm
+	display.open = true
+}
+
+// IsOpen returns true while the text input is taking the keyboard
+func (display *TextInput) IsOpen() bool {
+	return display.open
+}
+
+// Text returns the text typed so far
+func (display *TextInput) Text() string {
+	return string(display.text)
+}
+
+// Confirm closes the text input and calls its confirm callback with the
+// text typed
+func (display *TextInput) Confirm() {
+	if !display.open {
+		return
+	}
+	onConfirm := display.onConfirm
+	display.open = false
+	display.onConfirm = nil
+	if onConfirm != nil {
+		onConfirm(display.Text())
+	}
+}
+
+// Tick types characters into the input, Backspace deleting the last and
+// Enter confirming
+func (display *TextInput) Tick(event tl.Event) {
+	if !display.open || event.Type != tl.EventKey {
+		return
+	}
+	switch {
+	case event.Key == tl.KeyEnter:
+		display.Confirm()
+	case event.Key == tl.KeyBackspace || event.Key == tl.KeyBackspace2:
+		if len(display.text) > 0 {
+			display.text = display.text[:len(display.text)-1]
+		}
+	case event.Key == tl.KeySpace && len(display.text) < display.maxLength:
+		display.text = append(display.text, ' ')
+	case event.Ch != 0 && len(display.text) < display.maxLength:
+		display.text = append(display.text, event.Ch)
+	}
+}
+
+// Draw renders the prompt and the text typed inside a border while the
+// input is open
+func (display *TextInput) Draw(screen *tl.Screen) {
+	if !display.open {
+		return
+	}
+	display.Status.Draw(screen)
+
+	offSetX, offSetY := display.level.Offset()
+	left, top := -offSetX+display.x, -offSetY+display.y
+	right, bottom := left+textInputWidth-1, top+textInputHeight-1
+	drawBox(screen, left, top, right, bottom)
+
+	lines := []struct {
+		text  []rune
+		color tl.Attr
+	}{
+		{[]rune(display.prompt), tl.ColorYellow | tl.AttrBold},
+		{append(append([]rune(nil), display.text...), textInputCursor), tl.ColorWhite},
+	}
+	for i, line := range lines {
+		for j, ch := range line.text {
+			if left+textLineStartX+j >= right {
+				break
+			}
+			screen.RenderCell(left+textLineStartX+j, top+textLineStartY+i,
+				&tl.Cell{Fg: line.color, Bg: tl.ColorBlack, Ch: ch})
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package display
+
+import (
+	"fmt"
+	"math"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	waveIndicatorWidth  = 22
+	waveIndicatorHeight = 4
+)
+
+// WaveStatusInterface defines the methods required for wave display
+type WaveStatusInterface interface {
+	CurrentWave() int
+	TotalWaves() int
+	Countdown() (float64, bool)
+}
+
+// WaveIndicator shows the current wave and the time until the next
+type WaveIndicator struct {
+	Status
+	waves     WaveStatusInterface
+	textLine1 *tl.Text
+	textLine2 *tl.Text
+}
+
+// NewWaveIndicator creates a new wave display
+func NewWaveIndicator(x, y int, waves WaveStatusInterface, level *tl.BaseLevel) *WaveIndicator {
+	return &WaveIndicator{
+		Status:    *NewStatus(x, y, waveIndicatorWidth, waveIndicatorHeight, level),
+		waves:     waves,
+		textLine1: tl.NewText(x, y, "", tl.ColorWhite, tl.ColorBlack),
+		textLine2: tl.NewText(x, y+1, "", tl.ColorWhite, tl.ColorBlack),
+	}
+}
+
+// Draw passes the draw call to entity.
+func (display *WaveIndicator) Draw(screen *tl.Screen) {
+	display.Status.Draw(screen)
+
+	offSetX, offSetY := display.level.Offset()
+	display.textLine1.SetPosition(-offSetX+textLineStartX+display.x, -offSetY+textLineStartY+display.y)
+	display.textLine2.SetPosition(-offSetX+textLineStartX+display.x, -offSetY+textLineStartY+textLineSpacing+display.y)
+
+	display.textLine1.Draw(screen)
+	display.textLine2.Draw(screen)
+}
+
+// Tick is called to process 1 tick of actions based on the
+// current state of the game.
+func (display *WaveIndicator) Tick(event tl.Event) {
+	display.textLine1.SetText(fmt.Sprintf("Wave %d/%d", display.waves.CurrentWave(), display.waves.TotalWaves()))
+
+	if remaining, ok := display.waves.Countdown(); ok {
+		display.textLine2.SetText(fmt.Sprintf("Next wave in %ds", int(math.Ceil(remaining))))
+	} else if display.waves.CurrentWave() >= display.waves.TotalWaves() {
+		display.textLine2.SetText("Final wave")
+	} else {
+		display.textLine2.SetText("")
+	}
+}
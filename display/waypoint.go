@@ -0,0 +1,167 @@
+package display
+
+import (
+	"strconv"
+
+	"github.com/Ariemeth/frame_assault/lifecycle"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// MaxWaypoints is how many waypoints the player can have at once
+	MaxWaypoints = 5
+	// WaypointNameLength is the longest name a waypoint can be given
+	WaypointNameLength = 10
+	waypointGlyph      = '♦'
+	waypointColor      = tl.ColorCyan
+)
+
+// Waypoint is a named marker the player has dropped on the map
+type Waypoint struct {
+	x, y int
+	name string
+}
+
+// NewWaypoint creates a waypoint called name at x,y
+func NewWaypoint(x, y int, name string) *Waypoint {
+	waypoint := &Waypoint{x: x, y: y, name: name}
+	lifecycle.NotifyCreate(waypoint)
+	return waypoint
+}
+
+// Position returns where the waypoint was dropped
+func (w *Waypoint) Position() (int, int) {
+	return w.x, w.y
+}
+
+// Name returns what the waypoint is called
+func (w *Waypoint) Name() string {
+	return w.name
+}
+
+// SetName renames the waypoint, cutting name to WaypointNameLength
+func (w *Waypoint) SetName(name string) {
+	if runes := []rune(name); len(runes) > WaypointNameLength {
+		name = string(runes[:WaypointNameLength])
+	}
+	w.name = name
+}
+
+// Draw renders the waypoint's marker with its name alongside
+func (w *Waypoint) Draw(screen *tl.Screen) {
+	screen.RenderCell(w.x, w.y, &tl.Cell{Fg: waypointColor | tl.AttrBold, Ch: waypointGlyph})
+	for i, ch := range []rune(w.name) {
+		screen.RenderCell(w.x+2+i, w.y, &tl.Cell{Fg: waypointColor, Ch: ch})
+	}
+}
+
+// Tick only reports to the lifecycle hooks, waypoints stay where they are
+// dropped
+func (w *Waypoint) Tick(event tl.Event) {
+	lifecycle.NotifyTick(w)
+}
+
+// WaypointDropper is who drops waypoints where they stand
+type WaypointDropper interface {
+	Position() (int, int)
+}
+
+// Waypoints lets the player drop up to MaxWaypoints named markers. Pressing
+// W drops one where the player stands and asks for its name, or removes
+// the waypoint the player is standing next to.
+type Waypoints struct {
+	level     *tl.BaseLevel
+	dropper   WaypointDropper
+	input     *TextInput
+	waypoints []*Waypoint
+}
+
+// NewWaypoints creates the waypoints dropped by dropper, named in input
+func NewWaypoints(dropper WaypointDropper, input *TextInput, level *tl.BaseLevel) *Waypoints {
+	return &Waypoints{level: level, dropper: dropper, input: input}
+}
+
+// Add drops a waypoint called name at x,y, returning nil if there are
+// already MaxWaypoints
+func (w *Waypoints) Add(name string, x, y int) *Waypoint {
+	if len(w.waypoints) >= MaxWaypoints {
+		return nil
+	}
+	waypoint := NewWaypoint(x, y, "")
+	waypoint.SetName(name)
+	w.waypoints = append(w.waypoints, waypoint)
+	w.level.AddEntity(waypoint)
+	return waypoint
+}
+
+// Remove takes waypoint off the map
+func (w *Waypoints) Remove(waypoint *Waypoint) {
+	for i, dropped := range w.waypoints {
+		if dropped == waypoint {
+			w.waypoints = append(w.waypoints[:i], w.waypoints[i+1:]...)
+			lifecycle.RemoveEntity(w.level, waypoint)
+			return
+		}
+	}
+}
+
+// All returns the waypoints on the map in the order they were dropped
+func (w *Waypoints) All() []*Waypoint {
+	return append([]*Waypoint(nil), w.waypoints...)
+}
+
+// Positions returns where every waypoint is, for the mini map
+func (w *Waypoints) Positions() [][2]int {
+	positions := make([][2]int, len(w.waypoints))
+	for i, waypoint := range w.waypoints {
+		positions[i][0], positions[i][1] = waypoint.Position()
+	}
+	return positions
+}
+
+// adjacent returns the waypoint at or next to x,y, nil if there is none
+func (w *Waypoints) adjacent(x, y int) *Waypoint {
+	for _, waypoint := range w.waypoints {
+		wx, wy := waypoint.Position()
+		if wx-x <= 1 && x-wx <= 1 && wy-y <= 1 && y-wy <= 1 {
+			return waypoint
+		}
+	}
+	return nil
+}
+
+// toggle removes the waypoint next to the dropper or drops a new one and
+// asks for its name
+func (w *Waypoints) toggle() {
+	x, y := w.dropper.Position()
+	if waypoint := w.adjacent(x, y); waypoint != nil {
+		w.Remove(waypoint)
+		return
+	}
+	waypoint := w.Add("WP"+strconv.Itoa(len(w.waypoints)+1), x, y)
+	if waypoint == nil {
+		return
+	}
+	w.input.Open("Name waypoint:", func(name string) {
+		if name != "" {
+			waypoint.SetName(name)
+		}
+	})
+}
+
+// Tick drops or removes a waypoint when W is pressed, passing the keyboard
+// to the name input while it is open
+func (w *Waypoints) Tick(event tl.Event) {
+	if w.input.IsOpen() {
+		w.input.Tick(event)
+		return
+	}
+	if event.Type == tl.EventKey && (event.Ch == 'w' || event.Ch == 'W') {
+		w.toggle()
+	}
+}
+
+// Draw shows the name input while a waypoint is being named
+func (w *Waypoints) Draw(screen *tl.Screen) {
+	w.input.Draw(screen)
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+    "os"
+    "strconv"
+
+    "github.com/Ariemeth/frame_assault/building"
+    "github.com/Ariemeth/frame_assault/lifecycle"
+    "github.com/Ariemeth/frame_assault/mapdata"
+    tl "github.com/Ariemeth/termloop"
+)
+
+const (
+    defaultMapFile = "map_layout.json"
+    roadBrush      = "Road"
+    emptyBrush     = "Empty"
+    sidebarWidth   = 24
+)
+
+// editorBrushes lists the tiles the editor cycles through with 'b'
+var editorBrushes = []string{roadBrush, "Hospital", "School", "Bank", emptyBrush}
+
+// MapEditor lets the user paint roads and buildings onto the city map
+// and save the result as a layout file
+type MapEditor struct {
+    level     *tl.BaseLevel
+    path      string
+    layout    *mapdata.Layout
+    roads     *RoadSystem
+    buildings []*building.Building
+    cursorX   int
+    cursorY   int
+    brush     int
+    status    string
+}
+
+// NewMapEditor creates an editor for the layout saved at path, starting
+// from an empty map if the file does not exist yet
+func NewMapEditor(path string, level *tl.BaseLevel) (*MapEditor, error) {
+    layout := mapdata.NewLayout()
+    if _, err := os.Stat(path); err == nil {
+        layout, err = mapdata.LoadLayout(path)
+        if err != nil {
+            return nil, err
+        }
+    }
+
+    editor := &MapEditor{
+        level:   level,
+        path:    path,
+        layout:  layout,
+        roads:   NewRoadSystem(),
+        cursorX: levelWidth / 2,
+        cursorY: levelHeight / 2,
+        status:  "Editing " + path,
+    }
+    level.AddEntity(editor.roads)
+    editor.rebuild()
+    return editor, nil
+}
+
+// Brush returns the name of the tile placed by Enter
+func (e *MapEditor) Brush() string {
+    return editorBrushes[e.brush]
+}
+
+// Place paints the current brush at the cursor
+func (e *MapEditor) Place() {
+    switch brush := e.Brush(); brush {
+    case roadBrush:
+        e.layout.AddRoad(e.cursorX, e.cursorY)
+    case emptyBrush:
+        e.layout.Clear(e.cursorX, e.cursorY)
+    default:
+        e.layout.AddBuilding(mapdata.BuildingTile{
+            X:      e.cursorX,
+            Y:      e.cursorY,
+            Width:  buildingWidth,
+            Height: buildingHeight,
+            Type:   brush,
+        })
+    }
+    e.rebuild()
+}
+
+// Save writes the layout to the editor's file
+func (e *MapEditor) Save() error {
+    if err := mapdata.SaveLayout(e.path, e.layout); err != nil {
+        e.status = "Save failed"
+        return err
+    }
+    e.status = "Saved " + e.path
+    return nil
+}
+
+// rebuild recreates the road and building entities from the layout
+func (e *MapEditor) rebuild() {
+    for _, cell := range e.roads.RoadCells() {
+        e.roads.RemoveRoad(cell[0], cell[1])
+    }
+    for _, road := range e.layout.Roads {
+        e.roads.AddRoad(road[0], road[1])
+    }
+
+    for _, b := range e.buildings {
+        lifecycle.RemoveEntity(e.level, b)
+    }
+    e.buildings = e.buildings[:0]
+    for _, tile := range e.layout.Buildings {
+        buildingType, ok := building.TypeByName(tile.Type)
+        if !ok {
+            logger.Warn("unknown building type in layout", "type", tile.Type)
+            continue
+        }
+        b := building.NewBuilding(tile.X, tile.Y, tile.Width, tile.Height, buildingType)
+        e.buildings = append(e.buildings, b)
+        e.level.AddEntity(b)
+    }
+}
+
+// Tick handles cursor movement, brush selection, placement and saving
+func (e *MapEditor) Tick(event tl.Event) {
+    if event.Type != tl.EventKey {
+        return
+    }
+    switch event.Ch {
+    case 'b', 'B':
+        e.brush = (e.brush + 1) % len(editorBrushes)
+    case 's', 'S':
+        if err := e.Save(); err != nil {
+            logger.Error("failed to save layout", "file", e.path, "error", err)
+        }
+    }
+    switch event.Key {
+    case tl.KeyEnter:
+        e.Place()
+    case tl.KeyArrowRight:
+        e.cursorX++
+    case tl.KeyArrowLeft:
+        e.cursorX--
+    case tl.KeyArrowUp:
+        e.cursorY--
+    case tl.KeyArrowDown:
+        e.cursorY++
+    }
+}
+
+// Draw centers the map on the cursor and draws the cursor
+func (e *MapEditor) Draw(screen *tl.Screen) {
+    screenWidth, screenHeight := screen.Size()
+    e.level.SetOffset((screenWidth+sidebarWidth)/2-e.cursorX, screenHeight/2-e.cursorY)
+    screen.RenderCell(e.cursorX, e.cursorY, &tl.Cell{Fg: tl.ColorYellow | tl.AttrBold, Ch: '+'})
+}
+
+// editorSidebar shows the brush, cursor position and key legend. It is a
+// screen entity so it draws over the map without following the level offset.
+type editorSidebar struct {
+    editor *MapEditor
+    lines  []*tl.Text
+}
+
+// newEditorSidebar creates the sidebar for editor
+func newEditorSidebar(editor *MapEditor) *editorSidebar {
+    sidebar := &editorSidebar{editor: editor}
+    for i := 0; i < 8; i++ {
+        sidebar.lines = append(sidebar.lines, tl.NewText(1, i+1, "", tl.ColorWhite, tl.ColorBlack))
+    }
+    return sidebar
+}
+
+// Tick does nothing, the sidebar only reflects the editor's state
+func (sidebar *editorSidebar) Tick(event tl.Event) {}
+
+// Draw draws the sidebar background and text
+func (sidebar *editorSidebar) Draw(screen *tl.Screen) {
+    editor := sidebar.editor
+    text := []string{
+        "MAP EDITOR",
+        "Brush: " + editor.Brush(),
+        "Cursor: (" + strconv.Itoa(editor.cursorX) + "," + strconv.Itoa(editor.cursorY) + ")",
+        "Arrows  move cursor",
+        "B       next brush",
+        "Enter   place brush",
+        "S       save layout",
+        editor.status,
+    }
+    for y := 0; y < len(text)+2; y++ {
+        for x := 0; x < sidebarWidth; x++ {
+            screen.RenderCell(x, y, &tl.Cell{Bg: tl.ColorBlack, Ch: ' '})
+        }
+    }
+    for i, line := range sidebar.lines {
+        line.SetText(text[i])
+        line.Draw(screen)
+    }
+}
+
+// runEditor starts the map editor on the layout file at path
+func runEditor(path string) error {
+    game := tl.NewGame()
+    game.Screen().SetFps(gameFPS)
+    level := tl.NewBaseLevel(tl.Cell{
+        Bg: tl.ColorBlack,
+        Fg: tl.ColorBlack,
+        Ch: ' ',
+    })
+
+    editor, err := NewMapEditor(path, level)
+    if err != nil {
+        return err
+    }
+    level.AddEntity(editor)
+    game.Screen().SetLevel(level)
+    game.Screen().AddEntity(newEditorSidebar(editor))
+    game.Start()
+    return nil
+}
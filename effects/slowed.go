@@ -0,0 +1,20 @@
+// Package effects provides timed status effects applied to mechs
+package effects
+
+// SlowedEffect divides a mech's movement speed for a number of ticks
+type SlowedEffect struct {
+	DurationTicks int
+	SpeedDivisor  int
+}
+
+// Active returns true while the effect has ticks remaining
+func (s SlowedEffect) Active() bool {
+	return s.DurationTicks > 0 && s.SpeedDivisor > 1
+}
+
+// Tick counts down one tick of the effect's duration
+func (s *SlowedEffect) Tick() {
+	if s.DurationTicks > 0 {
+		s.DurationTicks--
+	}
+}
@@ -0,0 +1,16 @@
+package effects
+
+import "testing"
+
+func TestSlowedEffectExpires(t *testing.T) {
+	slowed := SlowedEffect{DurationTicks: 2, SpeedDivisor: 3}
+	for i := 0; i < 2; i++ {
+		if !slowed.Active() {
+			t.Fatalf("slowed effect expired after %d ticks instead of 2", i)
+		}
+		slowed.Tick()
+	}
+	if slowed.Active() {
+		t.Errorf("slowed effect is still active after its duration")
+	}
+}
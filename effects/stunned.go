@@ -0,0 +1,18 @@
+package effects
+
+// StunnedEffect stops a mech from moving for a number of ticks
+type StunnedEffect struct {
+	DurationTicks int
+}
+
+// Active returns true while the effect has ticks remaining
+func (s StunnedEffect) Active() bool {
+	return s.DurationTicks > 0
+}
+
+// Tick counts down one tick of the effect's duration
+func (s *StunnedEffect) Tick() {
+	if s.DurationTicks > 0 {
+		s.DurationTicks--
+	}
+}
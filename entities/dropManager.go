@@ -0,0 +1,159 @@
+package entities
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/Ariemeth/frame_assault/lifecycle"
+	tl "github.com/Ariemeth/termloop"
+)
+
+// killsPerDrop is the number of kills needed to earn a supply drop
+const killsPerDrop = 5
+
+// packageWeights is the relative chance of each package type being dropped
+var packageWeights = []struct {
+	contents PackageType
+	weight   int
+}{
+	{AmmoCrate, 40},
+	{RepairKit, 30},
+	{WeaponUpgrade, 20},
+	{RareWeapon, 10},
+}
+
+// RoadLocator provides the road cells a drop can land on
+type RoadLocator interface {
+	RoadCells() [][2]int
+}
+
+// DropManager spawns supply drops and tracks those not yet collected
+type DropManager struct {
+	level  *tl.BaseLevel
+	roads  RoadLocator
+	rng    *rand.Rand
+	drops  []*SupplyDrop
+	symbol rune
+}
+
+// NewDropManager creates a new drop manager placing drops on roads in level
+func NewDropManager(level *tl.BaseLevel, roads RoadLocator) *DropManager {
+	return &DropManager{
+		level:  level,
+		roads:  roads,
+		rng:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		symbol: dropFallbackSymbol,
+	}
+}
+
+// UseEmoji renders drops as a package emoji instead of the fallback letter.
+// Only enable this for terminals that draw emoji in a single cell.
+func (m *DropManager) UseEmoji(enabled bool) {
+	if enabled {
+		m.symbol = dropSymbol
+	} else {
+		m.symbol = dropFallbackSymbol
+	}
+}
+
+// RecordKill is called with the running kill total and spawns a drop
+// every killsPerDrop kills. Returns the new drop or nil.
+func (m *DropManager) RecordKill(totalKills int) *SupplyDrop {
+	if totalKills <= 0 || totalKills%killsPerDrop != 0 {
+		return nil
+	}
+	return m.Spawn()
+}
+
+// Spawn places a weighted random supply drop on an open road cell.
+// Returns nil if no open road cell is available.
+func (m *DropManager) Spawn() *SupplyDrop {
+	x, y, ok := m.openRoadCell()
+	if !ok {
+		return nil
+	}
+
+	return m.SpawnAt(x, y)
+}
+
+// SpawnAt places a weighted random supply drop landing at x,y
+func (m *DropManager) SpawnAt(x, y int) *SupplyDrop {
+	drop := newSupplyDrop(x, y, m.randomContents(), m.symbol, m)
+	m.drops = append(m.drops, drop)
+	if m.level != nil {
+		m.level.AddEntity(drop)
+	}
+	return drop
+}
+
+// Active returns the drops that have not been collected or expired
+func (m *DropManager) Active() []*SupplyDrop {
+	return m.drops
+}
+
+// remove takes a drop off the map and stops tracking it
+func (m *DropManager) remove(drop *SupplyDrop) {
+	for i, d := range m.drops {
+		if d == drop {
+			m.drops = append(m.drops[:i], m.drops[i+1:]...)
+			break
+		}
+	}
+	if m.level != nil {
+		lifecycle.RemoveEntity(m.level, drop)
+	}
+}
+
+// randomContents picks a package type using packageWeights
+func (m *DropManager) randomContents() PackageType {
+	total := 0
+	for _, pw := range packageWeights {
+		total += pw.weight
+	}
+
+	roll := m.rng.Intn(total)
+	for _, pw := range packageWeights {
+		if roll < pw.weight {
+			return pw.contents
+		}
+		roll -= pw.weight
+	}
+	return AmmoCrate
+}
+
+// openRoadCell picks a random road cell not occupied by a physical entity
+func (m *DropManager) openRoadCell() (int, int, bool) {
+	if m.roads == nil {
+		return 0, 0, false
+	}
+	cells := m.roads.RoadCells()
+	for _, i := range m.rng.Perm(len(cells)) {
+		x, y := cells[i][0], cells[i][1]
+		if !m.occupied(x, y) {
+			return x, y, true
+		}
+	}
+	return 0, 0, false
+}
+
+// occupied returns true if a physical entity other than the road covers x,y
+func (m *DropManager) occupied(x, y int) bool {
+	if m.level == nil {
+		return false
+	}
+	for _, entity := range m.level.Entities {
+		physical, ok := entity.(tl.Physical)
+		if !ok {
+			continue
+		}
+		if _, isRoad := entity.(RoadLocator); isRoad {
+			continue
+		}
+		eX, eY := physical.Position()
+		w, h := physical.Size()
+		if x >= eX && x < eX+w && y >= eY && y < eY+h {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"testing"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+type testRoads struct{}
+
+func (testRoads) RoadCells() [][2]int {
+	return [][2]int{{1, 1}, {2, 1}, {3, 1}}
+}
+
+func TestRecordKillThresholds(t *testing.T) {
+	manager := NewDropManager(nil, testRoads{})
+
+	for kills := 1; kills <= 15; kills++ {
+		drop := manager.RecordKill(kills)
+		shouldDrop := kills%killsPerDrop == 0
+		if shouldDrop && drop == nil {
+			t.Errorf("no supply drop spawned at %d kills", kills)
+		}
+		if !shouldDrop && drop != nil {
+			t.Errorf("supply drop spawned at %d kills", kills)
+		}
+	}
+
+	if len(manager.Active()) != 3 {
+		t.Errorf("%d drops are active after 15 kills instead of 3", len(manager.Active()))
+	}
+}
+
+func TestSupplyDropFalls(t *testing.T) {
+	manager := NewDropManager(nil, testRoads{})
+	drop := manager.Spawn()
+	if drop == nil {
+		t.Fatalf("supply drop was not spawned")
+	}
+
+	for i := 0; i < dropFallHeight; i++ {
+		if drop.Landed() {
+			t.Fatalf("supply drop landed after %d ticks instead of %d", i, dropFallHeight)
+		}
+		drop.Tick(tl.Event{})
+	}
+	if !drop.Landed() {
+		t.Errorf("supply drop has not landed after %d ticks", dropFallHeight)
+	}
+}
+
+func TestSupplyDropCollect(t *testing.T) {
+	manager := NewDropManager(nil, testRoads{})
+	drop := manager.Spawn()
+
+	drop.Collect()
+	if len(manager.Active()) != 0 {
+		t.Errorf("collected supply drop is still active")
+	}
+}
@@ -0,0 +1,161 @@
+package entities
+
+import (
+	"strconv"
+
+	"github.com/Ariemeth/frame_assault/lifecycle"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// Landing zone constants
+	landingZoneSymbol     = '⬡'
+	landingZoneColor      = tl.ColorGreen | tl.AttrBold
+	landingZonePulseTicks = 5
+	// ExtractionDangerRange is how close in cells an enemy has to be to
+	// keep the helicopter waiting
+	ExtractionDangerRange = 5
+	// ExtractionDelayTicks is how long the helicopter waits for enemies
+	// near the landing zone
+	ExtractionDelayTicks = 10
+)
+
+// Objectives reports how many of the mission's objectives are complete
+type Objectives interface {
+	Progress() (completed, total int)
+}
+
+// LandingZone is where the player can call in a helicopter with F12 once
+// an objective is complete. With enemies close by the helicopter waits
+// ExtractionDelayTicks before picking the player up, counting down beside
+// the landing zone.
+type LandingZone struct {
+	*tl.Entity
+	pilot      Sensor
+	objectives Objectives
+	onExtract  func()
+	countdown  int
+	extracted  bool
+	ticks      int
+}
+
+// NewLandingZone creates a landing zone at x,y calling onExtract when the
+// pilot is picked up
+func NewLandingZone(x, y int, pilot Sensor, objectives Objectives, onExtract func()) *LandingZone {
+	zone := &LandingZone{
+		Entity:     tl.NewEntity(x, y, 1, 1),
+		pilot:      pilot,
+		objectives: objectives,
+		onExtract:  onExtract,
+	}
+	zone.SetCell(0, 0, &tl.Cell{Fg: landingZoneColor, Ch: landingZoneSymbol})
+	lifecycle.NotifyCreate(zone)
+	return zone
+}
+
+// Available returns true once at least one objective is complete
+func (z *LandingZone) Available() bool {
+	completed, _ := z.objectives.Progress()
+	return completed > 0
+}
+
+// Revealed returns true once half the objectives are complete, showing
+// the landing zone on the mini map
+func (z *LandingZone) Revealed() bool {
+	completed, total := z.objectives.Progress()
+	return total > 0 && completed*2 >= total
+}
+
+// Countdown returns the ticks left before the helicopter lands, 0 when it
+// is not waiting
+func (z *LandingZone) Countdown() int {
+	return z.countdown
+}
+
+// Extracted returns true once the pilot has been picked up
+func (z *LandingZone) Extracted() bool {
+	return z.extracted
+}
+
+// CallExtraction calls in the helicopter, returning false if the pilot is
+// not on the landing zone or no objective is complete
+func (z *LandingZone) CallExtraction() bool {
+	if z.extracted || z.countdown > 0 || !z.occupied() || !z.Available() {
+		return false
+	}
+	if z.enemiesNear() {
+		z.countdown = ExtractionDelayTicks
+		return true
+	}
+	z.extract()
+	return true
+}
+
+// Tick calls extraction when F12 is pressed and counts down a waiting
+// helicopter, calling it off if the pilot leaves the landing zone
+func (z *LandingZone) Tick(event tl.Event) {
+	if event.Type == tl.EventKey && event.Key == tl.KeyF12 {
+		z.CallExtraction()
+		return
+	}
+	if event.Type != tl.EventNone {
+		return
+	}
+	z.ticks++
+	lifecycle.NotifyTick(z)
+	if z.countdown == 0 {
+		return
+	}
+	if !z.occupied() {
+		z.countdown = 0
+		return
+	}
+	z.countdown--
+	if z.countdown == 0 {
+		z.extract()
+	}
+}
+
+// Draw pulses the landing zone and shows the countdown of a waiting
+// helicopter beside it
+func (z *LandingZone) Draw(screen *tl.Screen) {
+	x, y := z.Position()
+	color := landingZoneColor
+	if (z.ticks/landingZonePulseTicks)%2 == 1 {
+		color = tl.ColorGreen
+	}
+	screen.RenderCell(x, y, &tl.Cell{Fg: color, Ch: landingZoneSymbol})
+	if z.countdown > 0 {
+		for i, ch := range strconv.Itoa(z.countdown) {
+			screen.RenderCell(x+2+i, y, &tl.Cell{Fg: tl.ColorYellow | tl.AttrBold, Ch: ch})
+		}
+	}
+}
+
+// occupied returns true if the pilot is standing on the landing zone
+func (z *LandingZone) occupied() bool {
+	x, y := z.pilot.Position()
+	zx, zy := z.Position()
+	return x == zx && y == zy
+}
+
+// enemiesNear returns true if an enemy is within ExtractionDangerRange
+func (z *LandingZone) enemiesNear() bool {
+	zx, zy := z.Position()
+	for _, pos := range z.pilot.EnemyPositions() {
+		dx, dy := pos[0]-zx, pos[1]-zy
+		if dx*dx+dy*dy <= ExtractionDangerRange*ExtractionDangerRange {
+			return true
+		}
+	}
+	return false
+}
+
+// extract picks the pilot up
+func (z *LandingZone) extract() {
+	z.extracted = true
+	z.countdown = 0
+	if z.onExtract != nil {
+		z.onExtract()
+	}
+}
@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"testing"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+type testObjectives struct {
+	completed, total int
+}
+
+func (o *testObjectives) Progress() (int, int) { return o.completed, o.total }
+
+func TestExtractionWaitsForNearbyEnemies(t *testing.T) {
+	pilot := &testSensor{x: 5, y: 5, enemies: [][2]int{{8, 5}}}
+	extracted := false
+	zone := NewLandingZone(5, 5, pilot, &testObjectives{completed: 1, total: 3}, func() { extracted = true })
+
+	zone.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyF12})
+	if extracted || zone.Countdown() != ExtractionDelayTicks {
+		t.Fatalf("extracted %v with countdown %d, want a wait of %d ticks with an enemy in range",
+			extracted, zone.Countdown(), ExtractionDelayTicks)
+	}
+	for i := 0; i < ExtractionDelayTicks-1; i++ {
+		zone.Tick(tl.Event{Type: tl.EventNone})
+	}
+	if extracted {
+		t.Fatalf("extracted after %d ticks, before the delay ran out", ExtractionDelayTicks-1)
+	}
+	zone.Tick(tl.Event{Type: tl.EventNone})
+	if !extracted {
+		t.Errorf("not extracted after waiting %d ticks", ExtractionDelayTicks)
+	}
+}
+
+func TestExtractionIsImmediateWithoutEnemiesNear(t *testing.T) {
+	pilot := &testSensor{x: 5, y: 5, enemies: [][2]int{{5 + ExtractionDangerRange + 1, 5}}}
+	objectives := &testObjectives{total: 3}
+	extracted := false
+	zone := NewLandingZone(5, 5, pilot, objectives, func() { extracted = true })
+
+	zone.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyF12})
+	if extracted || zone.Countdown() != 0 {
+		t.Fatal("extraction was called before any objective was complete")
+	}
+
+	objectives.completed = 1
+	zone.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyF12})
+	if !extracted {
+		t.Error("not extracted at once with no enemies in range")
+	}
+}
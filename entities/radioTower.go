@@ -0,0 +1,140 @@
+package entities
+
+import (
+	"github.com/Ariemeth/frame_assault/util"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// Radio tower constants
+	towerSymbol       = '╫'
+	towerCaptureTicks = 5
+	// TowerSensorBonus is the sensor range a captured tower adds
+	TowerSensorBonus = 10
+	// TowerJamPenalty is the radar range lost to each enemy controlled tower
+	TowerJamPenalty = 5
+)
+
+// TowerOwner is who controls a radio tower
+type TowerOwner int
+
+const (
+	// Neutral towers are controlled by nobody
+	Neutral TowerOwner = iota
+	// Captured towers are controlled by the player
+	Captured
+	// EnemyControlled towers jam the player's radar
+	EnemyControlled
+)
+
+// String returns the name of the owner
+func (o TowerOwner) String() string {
+	switch o {
+	case Neutral:
+		return "Neutral"
+	case Captured:
+		return "Captured"
+	case EnemyControlled:
+		return "EnemyControlled"
+	}
+	return "Unknown"
+}
+
+// Color returns the color a tower with the owner is drawn in
+func (o TowerOwner) Color() tl.Attr {
+	switch o {
+	case Captured:
+		return tl.ColorGreen
+	case EnemyControlled:
+		return tl.ColorRed
+	}
+	return util.ColorLightGray
+}
+
+// Sensor is the player's radar, boosted by capturing towers
+type Sensor interface {
+	Position() (int, int)
+	AddSensorRange(amount int)
+	EnemyPositions() [][2]int
+}
+
+// RadioTower is a tower captured by the side with the most mechs next to it
+type RadioTower struct {
+	*tl.Entity
+	owner        TowerOwner
+	sensor       Sensor
+	captureTicks int
+	bonusAwarded bool
+}
+
+// NewRadioTower creates a neutral tower at x,y that the sensor's owner
+// can capture
+func NewRadioTower(x, y int, sensor Sensor) *RadioTower {
+	tower := &RadioTower{
+		Entity: tl.NewEntity(x, y, 1, 1),
+		sensor: sensor,
+	}
+	tower.SetCell(0, 0, &tl.Cell{Fg: Neutral.Color(), Ch: towerSymbol})
+	return tower
+}
+
+// Owner returns who controls the tower
+func (t *RadioTower) Owner() TowerOwner {
+	return t.owner
+}
+
+// Tick updates the tower's owner from the mechs next to it. Enemies take
+// the tower as soon as they outnumber the player while the player has to
+// hold it alone for towerCaptureTicks ticks.
+func (t *RadioTower) Tick(event tl.Event) {
+	if event.Type != tl.EventNone || t.sensor == nil {
+		return
+	}
+
+	players := 0
+	if x, y := t.sensor.Position(); t.adjacent(x, y) {
+		players = 1
+	}
+	enemies := 0
+	for _, pos := range t.sensor.EnemyPositions() {
+		if t.adjacent(pos[0], pos[1]) {
+			enemies++
+		}
+	}
+
+	switch {
+	case enemies > players:
+		t.captureTicks = 0
+		t.setOwner(EnemyControlled)
+	case players > 0 && enemies == 0:
+		t.captureTicks++
+		if t.captureTicks >= towerCaptureTicks {
+			t.capture()
+		}
+	default:
+		t.captureTicks = 0
+	}
+}
+
+// capture gives the tower to the player, boosting their sensors the
+// first time it is taken
+func (t *RadioTower) capture() {
+	t.setOwner(Captured)
+	if !t.bonusAwarded {
+		t.bonusAwarded = true
+		t.sensor.AddSensorRange(TowerSensorBonus)
+	}
+}
+
+// setOwner changes the owner and recolors the tower
+func (t *RadioTower) setOwner(owner TowerOwner) {
+	t.owner = owner
+	t.SetCell(0, 0, &tl.Cell{Fg: owner.Color(), Ch: towerSymbol})
+}
+
+// adjacent returns true if x,y touches the tower, diagonals included
+func (t *RadioTower) adjacent(x, y int) bool {
+	tx, ty := t.Position()
+	w, h := t.Size()
+	return x >= tx-1 && x <= tx+w && y >= ty-1 && y <= ty+h
+}
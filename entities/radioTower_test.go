@@ -0,0 +1,52 @@
+package entities
+
+import (
+	"testing"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+type testSensor struct {
+	x, y    int
+	rng     int
+	enemies [][2]int
+}
+
+func (s *testSensor) Position() (int, int)      { return s.x, s.y }
+func (s *testSensor) AddSensorRange(amount int) { s.rng += amount }
+func (s *testSensor) EnemyPositions() [][2]int  { return s.enemies }
+
+func TestCapturingTowerIncreasesSensorRange(t *testing.T) {
+	sensor := &testSensor{x: 6, y: 5, rng: 20}
+	tower := NewRadioTower(5, 5, sensor)
+
+	for i := 0; i < towerCaptureTicks-1; i++ {
+		tower.Tick(tl.Event{Type: tl.EventNone})
+	}
+	if tower.Owner() != Neutral || sensor.rng != 20 {
+		t.Fatalf("tower is %v with sensor range %d before being held for %d ticks",
+			tower.Owner(), sensor.rng, towerCaptureTicks)
+	}
+
+	tower.Tick(tl.Event{Type: tl.EventNone})
+	if tower.Owner() != Captured {
+		t.Errorf("tower is %v after %d ticks instead of Captured", tower.Owner(), towerCaptureTicks)
+	}
+	if sensor.rng != 20+TowerSensorBonus {
+		t.Errorf("sensor range is %d after capture instead of %d", sensor.rng, 20+TowerSensorBonus)
+	}
+
+	// Enemies outnumbering the player take the tower back but the bonus stays
+	sensor.enemies = [][2]int{{4, 4}, {5, 6}}
+	tower.Tick(tl.Event{Type: tl.EventNone})
+	if tower.Owner() != EnemyControlled {
+		t.Errorf("tower is %v with enemies adjacent instead of EnemyControlled", tower.Owner())
+	}
+	sensor.enemies = nil
+	for i := 0; i < towerCaptureTicks; i++ {
+		tower.Tick(tl.Event{Type: tl.EventNone})
+	}
+	if sensor.rng != 20+TowerSensorBonus {
+		t.Errorf("recapturing the tower changed the sensor range to %d", sensor.rng)
+	}
+}
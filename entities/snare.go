@@ -0,0 +1,133 @@
+package entities
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/Ariemeth/frame_assault/effects"
+	"github.com/Ariemeth/frame_assault/lifecycle"
+	"github.com/Ariemeth/frame_assault/util"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// Snare constants
+	snareSymbol       = '×'
+	snareColor        = tl.ColorRed | util.AttrDim
+	snareSlowTicks    = 20
+	snareSpeedDivisor = 3
+	// MaxActiveSnares is the most snares that can be placed at once
+	MaxActiveSnares = 3
+	// SnareCost is the bounty points spent placing a snare
+	SnareCost = 75
+)
+
+// ErrTooManySnares is returned when placing a snare beyond MaxActiveSnares
+var ErrTooManySnares = errors.New("too many active snares")
+
+// ErrSnareOccupied is returned when a snare is already set at a position
+var ErrSnareOccupied = errors.New("snare already set here")
+
+// Slowable is anything a snare can slow down
+type Slowable interface {
+	Slow(effect effects.SlowedEffect)
+}
+
+// Snare is a trap that slows the first mech to step on it
+type Snare struct {
+	*tl.Entity
+	manager *SnareManager
+}
+
+// newSnare creates a snare at x,y
+func newSnare(x, y int, manager *SnareManager) *Snare {
+	snare := &Snare{
+		Entity:  tl.NewEntity(x, y, 1, 1),
+		manager: manager,
+	}
+	snare.SetCell(0, 0, &tl.Cell{Fg: snareColor, Ch: snareSymbol})
+	lifecycle.NotifyCreate(snare)
+	return snare
+}
+
+// Trigger slows target and removes the snare from the map. Returns false if
+// the snare was already sprung.
+func (s *Snare) Trigger(target Slowable) bool {
+	if !s.manager.remove(s) {
+		return false
+	}
+	target.Slow(effects.SlowedEffect{
+		DurationTicks: snareSlowTicks,
+		SpeedDivisor:  snareSpeedDivisor,
+	})
+	return true
+}
+
+// SnareManager places snares and tracks those not yet triggered
+type SnareManager struct {
+	mu     sync.Mutex
+	level  *tl.BaseLevel
+	snares map[[2]int]*Snare
+}
+
+// NewSnareManager creates a new snare manager placing snares in level
+func NewSnareManager(level *tl.BaseLevel) *SnareManager {
+	return &SnareManager{
+		level:  level,
+		snares: make(map[[2]int]*Snare),
+	}
+}
+
+// Place sets a snare at x,y
+func (m *SnareManager) Place(x, y int) (*Snare, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.snares) >= MaxActiveSnares {
+		return nil, ErrTooManySnares
+	}
+	pos := [2]int{x, y}
+	if _, ok := m.snares[pos]; ok {
+		return nil, ErrSnareOccupied
+	}
+
+	snare := newSnare(x, y, m)
+	m.snares[pos] = snare
+	if m.level != nil {
+		m.level.AddEntity(snare)
+	}
+	return snare, nil
+}
+
+// Active returns the number of snares waiting to be triggered
+func (m *SnareManager) Active() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.snares)
+}
+
+// IsSnared returns true if an active snare is set at x,y
+func (m *SnareManager) IsSnared(x, y int) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.snares[[2]int{x, y}]
+	return ok
+}
+
+// remove takes a snare off the map, returning false if it was not active.
+// Collisions are checked concurrently so two mechs may reach a snare at once.
+func (m *SnareManager) remove(snare *Snare) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	x, y := snare.Position()
+	pos := [2]int{x, y}
+	if m.snares[pos] != snare {
+		return false
+	}
+	delete(m.snares, pos)
+	if m.level != nil {
+		lifecycle.RemoveEntity(m.level, snare)
+	}
+	return true
+}
@@ -0,0 +1,106 @@
+// Package entities provides pickups and other non-mech entities placed in the level
+package entities
+
+import (
+	"time"
+
+	"github.com/Ariemeth/frame_assault/lifecycle"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// Supply drop constants
+	dropSymbol         = '📦'
+	dropFallbackSymbol = 's'
+	dropColor          = tl.ColorCyan
+	dropLifetime       = 30 * time.Second
+	dropFallHeight     = 5 // Cells above the landing spot a drop appears
+)
+
+// PackageType identifies the contents of a supply drop
+type PackageType int
+
+const (
+	// AmmoCrate refills all weapons
+	AmmoCrate PackageType = iota
+	// RepairKit restores structure
+	RepairKit
+	// WeaponUpgrade permanently increases active weapon damage
+	WeaponUpgrade
+	// RareWeapon unlocks a rare weapon
+	RareWeapon
+)
+
+// String returns the display name of the package type
+func (p PackageType) String() string {
+	switch p {
+	case AmmoCrate:
+		return "Ammo Crate"
+	case RepairKit:
+		return "Repair Kit"
+	case WeaponUpgrade:
+		return "Weapon Upgrade"
+	case RareWeapon:
+		return "Rare Weapon"
+	}
+	return "Unknown"
+}
+
+// SupplyDrop is a collectable package that falls onto the map
+type SupplyDrop struct {
+	*tl.Entity
+	contents PackageType
+	finalY   int
+	spawned  time.Time
+	manager  *DropManager
+}
+
+// newSupplyDrop creates a drop that falls from above to land at x,y
+func newSupplyDrop(x, y int, contents PackageType, symbol rune, manager *DropManager) *SupplyDrop {
+	drop := &SupplyDrop{
+		Entity:   tl.NewEntity(x, y-dropFallHeight, 1, 1),
+		contents: contents,
+		finalY:   y,
+		spawned:  time.Now(),
+		manager:  manager,
+	}
+	drop.SetCell(0, 0, &tl.Cell{Fg: dropColor, Ch: symbol})
+	lifecycle.NotifyCreate(drop)
+	return drop
+}
+
+// Contents returns the package type contained in the drop
+func (d *SupplyDrop) Contents() PackageType {
+	return d.contents
+}
+
+// Landed returns true once the drop has finished falling
+func (d *SupplyDrop) Landed() bool {
+	_, y := d.Position()
+	return y >= d.finalY
+}
+
+// Expired returns true if the drop has been uncollected for too long
+func (d *SupplyDrop) Expired() bool {
+	return time.Since(d.spawned) >= dropLifetime
+}
+
+// Tick animates the fall and removes the drop once it expires
+func (d *SupplyDrop) Tick(event tl.Event) {
+	lifecycle.NotifyTick(d)
+	if d.Expired() {
+		d.manager.remove(d)
+		return
+	}
+
+	if !d.Landed() {
+		x, y := d.Position()
+		d.SetPosition(x, y+1)
+	}
+}
+
+// Collect removes the drop from the map and returns its contents
+func (d *SupplyDrop) Collect() PackageType {
+	d.manager.remove(d)
+	return d.contents
+}
@@ -0,0 +1,180 @@
+package entities
+
+import (
+	"github.com/Ariemeth/frame_assault/building"
+	"github.com/Ariemeth/frame_assault/damagelog"
+	"github.com/Ariemeth/frame_assault/effects"
+	"github.com/Ariemeth/frame_assault/lifecycle"
+	"github.com/Ariemeth/frame_assault/util"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// Vehicle constants
+	vehicleSymbol = '▬'
+	vehicleColor  = tl.ColorBlue | tl.AttrBold
+	vehicleHP     = 10
+	// VehicleImpactFactor converts a vehicle's speed into impact damage
+	VehicleImpactFactor = 4.0
+	// impactCooldownTicks stops one crash from damaging every tick
+	impactCooldownTicks = 10
+	impactStunTicks     = 10
+	// vehicleAttacker is recorded as the attacker of impact damage
+	vehicleAttacker damagelog.EntityID = "Vehicle"
+
+	// Wreck constants
+	wreckSymbol    = '#'
+	wreckColor     = tl.ColorBlack | tl.AttrBold
+	wreckLootDrops = 2
+)
+
+// Impactable is anything a vehicle can crash into and hurt
+type Impactable interface {
+	Hit(damage int, attacker damagelog.EntityID)
+	Stun(effect effects.StunnedEffect)
+}
+
+// Vehicle is a civilian car driving back and forth between two points
+type Vehicle struct {
+	*tl.Entity
+	HP             int
+	speed          float64
+	start, end     [2]int
+	dx, dy         int
+	progress       float64
+	prevX, prevY   int
+	impactCooldown int
+	wrecked        bool
+	level          *tl.BaseLevel
+	drops          *DropManager
+}
+
+// NewVehicle creates a vehicle at startX,startY driving toward endX,endY
+// at speed cells per tick. The two points should share a row or column.
+func NewVehicle(startX, startY, endX, endY int, speed float64, level *tl.BaseLevel) *Vehicle {
+	vehicle := &Vehicle{
+		Entity: tl.NewEntity(startX, startY, 1, 1),
+		HP:     vehicleHP,
+		speed:  speed,
+		start:  [2]int{startX, startY},
+		end:    [2]int{endX, endY},
+		dx:     util.Sign(endX - startX),
+		dy:     util.Sign(endY - startY),
+		prevX:  startX,
+		prevY:  startY,
+		level:  level,
+	}
+	vehicle.SetCell(0, 0, &tl.Cell{Fg: vehicleColor, Ch: vehicleSymbol})
+	lifecycle.NotifyCreate(vehicle)
+	return vehicle
+}
+
+// AttachDropManager sets where loot from the vehicle's wreck is dropped
+func (v *Vehicle) AttachDropManager(drops *DropManager) {
+	v.drops = drops
+}
+
+// Speed returns the cells the vehicle travels per tick
+func (v *Vehicle) Speed() float64 {
+	return v.speed
+}
+
+// Wrecked returns true once the vehicle has been destroyed
+func (v *Vehicle) Wrecked() bool {
+	return v.wrecked
+}
+
+// Tick drives the vehicle along its route, turning around at either end
+func (v *Vehicle) Tick(event tl.Event) {
+	lifecycle.NotifyTick(v)
+	if v.wrecked || event.Type != tl.EventNone {
+		return
+	}
+	if v.impactCooldown > 0 {
+		v.impactCooldown--
+	}
+
+	v.prevX, v.prevY = v.Position()
+	v.progress += v.speed
+	for v.progress >= 1 {
+		v.progress--
+		x, y := v.Position()
+		if x == v.end[0] && y == v.end[1] {
+			v.turnAround()
+		}
+		v.SetPosition(x+v.dx, y+v.dy)
+	}
+}
+
+// turnAround reverses the vehicle's direction of travel
+func (v *Vehicle) turnAround() {
+	v.dx, v.dy = -v.dx, -v.dy
+	v.start, v.end = v.end, v.start
+}
+
+// Collide damages a mech the vehicle runs into along with the vehicle
+// itself, and turns the vehicle around at obstacles
+func (v *Vehicle) Collide(physical tl.Physical) {
+	if v.wrecked {
+		return
+	}
+	switch target := physical.(type) {
+	case Impactable:
+		if v.impactCooldown > 0 {
+			return
+		}
+		v.impactCooldown = impactCooldownTicks
+		damage := int(v.speed * VehicleImpactFactor)
+		target.Hit(damage, vehicleAttacker)
+		target.Stun(effects.StunnedEffect{DurationTicks: impactStunTicks})
+		v.SetPosition(v.prevX, v.prevY)
+		v.turnAround()
+		v.TakeDamage(damage)
+	case *building.Building, *tl.Rectangle, *Vehicle, *VehicleWreck:
+		v.SetPosition(v.prevX, v.prevY)
+		v.turnAround()
+	}
+}
+
+// TakeDamage reduces the vehicle's HP, wrecking it at zero
+func (v *Vehicle) TakeDamage(damage int) {
+	if v.wrecked {
+		return
+	}
+	v.HP -= damage
+	if v.HP <= 0 {
+		v.HP = 0
+		v.wreck()
+	}
+}
+
+// wreck replaces the vehicle with a wreck and scatters loot around it
+func (v *Vehicle) wreck() {
+	v.wrecked = true
+	if v.level == nil {
+		return
+	}
+	x, y := v.Position()
+	lifecycle.RemoveEntity(v.level, v)
+	v.level.AddEntity(NewVehicleWreck(x, y))
+
+	if v.drops == nil {
+		return
+	}
+	offsets := [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}}
+	for i := 0; i < wreckLootDrops; i++ {
+		v.drops.SpawnAt(x+offsets[i][0], y+offsets[i][1])
+	}
+}
+
+// VehicleWreck is the static remains of a destroyed vehicle
+type VehicleWreck struct {
+	*tl.Entity
+}
+
+// NewVehicleWreck creates a wreck obstacle at x,y
+func NewVehicleWreck(x, y int) *VehicleWreck {
+	wreck := &VehicleWreck{Entity: tl.NewEntity(x, y, 1, 1)}
+	wreck.SetCell(0, 0, &tl.Cell{Fg: wreckColor, Ch: wreckSymbol})
+	return wreck
+}
@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"testing"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+func TestWreckedVehicleLeavesWreckAndLoot(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	drops := NewDropManager(level, testRoads{})
+	vehicle := NewVehicle(5, 5, 10, 5, 1.0, level)
+	vehicle.AttachDropManager(drops)
+	level.AddEntity(vehicle)
+
+	vehicle.TakeDamage(vehicleHP)
+	if !vehicle.Wrecked() {
+		t.Fatalf("vehicle with no HP is not wrecked")
+	}
+
+	wrecks := 0
+	for _, entity := range level.Entities {
+		switch entity.(type) {
+		case *Vehicle:
+			t.Errorf("wrecked vehicle is still in the level")
+		case *VehicleWreck:
+			wrecks++
+		}
+	}
+	if wrecks != 1 {
+		t.Errorf("level has %d wrecks instead of 1", wrecks)
+	}
+	if len(drops.Active()) != wreckLootDrops {
+		t.Errorf("wreck scattered %d drops instead of %d", len(drops.Active()), wreckLootDrops)
+	}
+
+	vehicle.Tick(tl.Event{Type: tl.EventNone})
+	if x, y := vehicle.Position(); x != 5 || y != 5 {
+		t.Errorf("wrecked vehicle moved to %d,%d", x, y)
+	}
+}
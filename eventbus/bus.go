@@ -0,0 +1,111 @@
+// Package eventbus lets game systems react to events published by others
+// without depending on them directly
+package eventbus
+
+import (
+	"sync"
+)
+
+// MechDestroyedEvent is published when a mech's structure reaches 0
+type MechDestroyedEvent struct {
+	Name string
+	X, Y int
+}
+
+// ExplosionEvent is published when an explosive set off by Attacker
+// detonates at X,Y
+type ExplosionEvent struct {
+	Attacker string
+	X, Y     int
+}
+
+// CivilianHitEvent is published when Attacker catches a civilian at X,Y in
+// an explosion
+type CivilianHitEvent struct {
+	Attacker string
+	X, Y     int
+}
+
+// WeaponFiredEvent is published when a mech fires a weapon at a target
+// Range cells away
+type WeaponFiredEvent struct {
+	Attacker string
+	Weapon   string
+	Range    int
+}
+
+// BuildingBurnedEvent is published when the building ID, covering the
+// cells from X,Y of Width by Height, is destroyed
+type BuildingBurnedEvent struct {
+	ID            int
+	Name          string
+	X, Y          int
+	Width, Height int
+}
+
+// PowerOutageEvent is published when the city loses power
+type PowerOutageEvent struct{}
+
+// PowerRestoredEvent is published when the city's power comes back on
+type PowerRestoredEvent struct{}
+
+// Event is any value published on a Bus
+type Event interface{}
+
+// Handler is called with every event published on a Bus
+type Handler func(Event)
+
+// Subscription identifies a handler subscribed to a Bus so it can be
+// unsubscribed again
+type Subscription int
+
+// subscriber is a handler and the subscription it was added under
+type subscriber struct {
+	id      Subscription
+	handler Handler
+}
+
+// Bus delivers published events to every subscribed handler
+type Bus struct {
+	mu          sync.Mutex
+	subscribers []subscriber
+	nextID      Subscription
+}
+
+// New creates an empty event bus
+func New() *Bus {
+	return &Bus{}
+}
+
+// Subscribe adds handler to receive every event published after the call,
+// returning the subscription to pass to Unsubscribe
+func (b *Bus) Subscribe(handler Handler) Subscription {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nextID++
+	b.subscribers = append(b.subscribers, subscriber{id: b.nextID, handler: handler})
+	return b.nextID
+}
+
+// Unsubscribe stops the handler added under sub receiving events
+func (b *Bus) Unsubscribe(sub Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, s := range b.subscribers {
+		if s.id == sub {
+			b.subscribers = append(b.subscribers[:i:i], b.subscribers[i+1:]...)
+			return
+		}
+	}
+}
+
+// Publish delivers event to each subscribed handler in subscription order
+func (b *Bus) Publish(event Event) {
+	b.mu.Lock()
+	subscribers := append([]subscriber(nil), b.subscribers...)
+	b.mu.Unlock()
+
+	for _, s := range subscribers {
+		s.handler(event)
+	}
+}
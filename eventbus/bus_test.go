@@ -0,0 +1,36 @@
+package eventbus
+
+import "testing"
+
+func TestPublishReachesSubscribers(t *testing.T) {
+	bus := New()
+	received := make([]Event, 0)
+	bus.Subscribe(func(e Event) {
+		received = append(received, e)
+	})
+
+	bus.Publish(ExplosionEvent{X: 1, Y: 2})
+	bus.Publish(MechDestroyedEvent{Name: "Enemy A"})
+
+	if len(received) != 2 {
+		t.Fatalf("subscriber received %d events instead of 2", len(received))
+	}
+	if explosion, ok := received[0].(ExplosionEvent); !ok || explosion.X != 1 || explosion.Y != 2 {
+		t.Errorf("first event was %v instead of an explosion at 1,2", received[0])
+	}
+}
+
+func TestUnsubscribeStopsEvents(t *testing.T) {
+	bus := New()
+	var first, second int
+	sub := bus.Subscribe(func(e Event) { first++ })
+	bus.Subscribe(func(e Event) { second++ })
+
+	bus.Publish(PowerOutageEvent{})
+	bus.Unsubscribe(sub)
+	bus.Publish(PowerRestoredEvent{})
+
+	if first != 1 || second != 2 {
+		t.Errorf("handlers received %d and %d events, want 1 after unsubscribing and 2", first, second)
+	}
+}
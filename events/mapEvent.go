@@ -0,0 +1,157 @@
+// Package events fires random map wide events that change the state of the
+// city during play
+package events
+
+import (
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	powerOutageSeconds = 30
+	evacuationSeconds  = 60
+	reinforcementCount = 2
+	airdropCount       = 3
+)
+
+// World is the game state map events act upon
+type World interface {
+	// SetPowerOutage turns the city's lights off or back on
+	SetPowerOutage(outage bool)
+	// SpawnEnemies adds count enemy mechs to the level
+	SpawnEnemies(count int)
+	// PlaceSupplyDrops drops count supply drops on the roads
+	PlaceSupplyDrops(count int)
+	// SetEvacuated sounds or silences the alarm sending civilians to shelter
+	SetEvacuated(evacuated bool)
+}
+
+// WeightedMapEvent is an event the registry can pick, with a weight
+// relative to the other events
+type WeightedMapEvent interface {
+	Name() string
+	Description() string
+	Weight() int
+	Execute(level *tl.BaseLevel, state World)
+}
+
+// TimedMapEvent is an event that ends after a number of seconds
+type TimedMapEvent interface {
+	WeightedMapEvent
+	Duration() float64
+	End(level *tl.BaseLevel, state World)
+}
+
+// PowerOutageEvent cuts the city's lights for 30 seconds
+type PowerOutageEvent struct{ weight int }
+
+// NewPowerOutageEvent creates a power outage with the given weight
+func NewPowerOutageEvent(weight int) *PowerOutageEvent {
+	return &PowerOutageEvent{weight: weight}
+}
+
+// Name returns the event name
+func (e *PowerOutageEvent) Name() string { return "Power Outage" }
+
+// Description describes the event to the player
+func (e *PowerOutageEvent) Description() string { return "The city lights are out" }
+
+// Weight returns the event's selection weight
+func (e *PowerOutageEvent) Weight() int { return e.weight }
+
+// Duration returns how long the outage lasts in seconds
+func (e *PowerOutageEvent) Duration() float64 { return powerOutageSeconds }
+
+// Execute turns the lights off
+func (e *PowerOutageEvent) Execute(level *tl.BaseLevel, state World) {
+	state.SetPowerOutage(true)
+}
+
+// End turns the lights back on
+func (e *PowerOutageEvent) End(level *tl.BaseLevel, state World) {
+	state.SetPowerOutage(false)
+}
+
+// ReinforcementEvent brings 2 more enemy mechs into the city
+type ReinforcementEvent struct{ weight int }
+
+// NewReinforcementEvent creates a reinforcement event with the given weight
+func NewReinforcementEvent(weight int) *ReinforcementEvent {
+	return &ReinforcementEvent{weight: weight}
+}
+
+// Name returns the event name
+func (e *ReinforcementEvent) Name() string { return "Reinforcements" }
+
+// Description describes the event to the player
+func (e *ReinforcementEvent) Description() string { return "Enemy mechs have entered the city" }
+
+// Weight returns the event's selection weight
+func (e *ReinforcementEvent) Weight() int { return e.weight }
+
+// Execute spawns the reinforcements
+func (e *ReinforcementEvent) Execute(level *tl.BaseLevel, state World) {
+	state.SpawnEnemies(reinforcementCount)
+}
+
+// AirdropEvent drops 3 supply drops onto the roads
+type AirdropEvent struct{ weight int }
+
+// NewAirdropEvent creates an airdrop with the given weight
+func NewAirdropEvent(weight int) *AirdropEvent {
+	return &AirdropEvent{weight: weight}
+}
+
+// Name returns the event name
+func (e *AirdropEvent) Name() string { return "Airdrop" }
+
+// Description describes the event to the player
+func (e *AirdropEvent) Description() string { return "Supplies are falling on the streets" }
+
+// Weight returns the event's selection weight
+func (e *AirdropEvent) Weight() int { return e.weight }
+
+// Execute places the supply drops
+func (e *AirdropEvent) Execute(level *tl.BaseLevel, state World) {
+	state.PlaceSupplyDrops(airdropCount)
+}
+
+// EvacuationEvent sounds the alarm for 60 seconds, sending civilians
+// running for the shelters
+type EvacuationEvent struct{ weight int }
+
+// NewEvacuationEvent creates an evacuation with the given weight
+func NewEvacuationEvent(weight int) *EvacuationEvent {
+	return &EvacuationEvent{weight: weight}
+}
+
+// Name returns the event name
+func (e *EvacuationEvent) Name() string { return "Evacuation" }
+
+// Description describes the event to the player
+func (e *EvacuationEvent) Description() string { return "Civilians are running for the shelters" }
+
+// Weight returns the event's selection weight
+func (e *EvacuationEvent) Weight() int { return e.weight }
+
+// Duration returns how long the evacuation lasts in seconds
+func (e *EvacuationEvent) Duration() float64 { return evacuationSeconds }
+
+// Execute sounds the alarm
+func (e *EvacuationEvent) Execute(level *tl.BaseLevel, state World) {
+	state.SetEvacuated(true)
+}
+
+// End silences the alarm
+func (e *EvacuationEvent) End(level *tl.BaseLevel, state World) {
+	state.SetEvacuated(false)
+}
+
+// DefaultEvents returns the standard map events and their weights
+func DefaultEvents() []WeightedMapEvent {
+	return []WeightedMapEvent{
+		NewPowerOutageEvent(20),
+		NewReinforcementEvent(30),
+		NewAirdropEvent(30),
+		NewEvacuationEvent(20),
+	}
+}
@@ -0,0 +1,128 @@
+package events
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/Ariemeth/frame_assault/display"
+	tl "github.com/Ariemeth/termloop"
+)
+
+// eventIntervalSeconds is the time between map events
+const eventIntervalSeconds = 60
+
+// Notifier shows event announcements to the player
+type Notifier interface {
+	AddPriorityMessage(message string, priority display.Priority)
+}
+
+// activeEvent is a timed event waiting to end
+type activeEvent struct {
+	event     TimedMapEvent
+	ticksLeft int
+}
+
+// MapEventRegistry fires a weighted random event every 60 seconds
+type MapEventRegistry struct {
+	events   []WeightedMapEvent
+	level    *tl.BaseLevel
+	state    World
+	notifier Notifier
+	fps      int
+	ticks    int
+	active   []activeEvent
+	random   *rand.Rand
+}
+
+// NewMapEventRegistry creates a registry firing events against level and
+// state at fps ticks per second
+func NewMapEventRegistry(events []WeightedMapEvent, fps int, level *tl.BaseLevel, state World) *MapEventRegistry {
+	return &MapEventRegistry{
+		events: events,
+		level:  level,
+		state:  state,
+		fps:    fps,
+		random: rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// AttachNotifier sets where event announcements are shown
+func (r *MapEventRegistry) AttachNotifier(notifier Notifier) {
+	r.notifier = notifier
+}
+
+// Select picks an event at random in proportion to its weight, returning
+// nil if no event has a positive weight
+func (r *MapEventRegistry) Select(random *rand.Rand) WeightedMapEvent {
+	total := 0
+	for _, event := range r.events {
+		if event.Weight() > 0 {
+			total += event.Weight()
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+	roll := random.Intn(total)
+	for _, event := range r.events {
+		if event.Weight() <= 0 {
+			continue
+		}
+		if roll < event.Weight() {
+			return event
+		}
+		roll -= event.Weight()
+	}
+	return nil
+}
+
+// Fire executes event, announces it and schedules its end if it is timed
+func (r *MapEventRegistry) Fire(event WeightedMapEvent) {
+	event.Execute(r.level, r.state)
+	if r.notifier != nil {
+		r.notifier.AddPriorityMessage(event.Name()+": "+event.Description(), display.PriorityCritical)
+	}
+	timed, ok := event.(TimedMapEvent)
+	if !ok {
+		return
+	}
+	ticks := int(timed.Duration() * float64(r.fps))
+	// Refiring an event that is still running extends it
+	for i := range r.active {
+		if r.active[i].event == timed {
+			r.active[i].ticksLeft = ticks
+			return
+		}
+	}
+	r.active = append(r.active, activeEvent{event: timed, ticksLeft: ticks})
+}
+
+// Tick ends expired events and fires a new event every interval
+func (r *MapEventRegistry) Tick(event tl.Event) {
+	if event.Type != tl.EventNone {
+		return
+	}
+
+	remaining := r.active[:0]
+	for _, active := range r.active {
+		active.ticksLeft--
+		if active.ticksLeft <= 0 {
+			active.event.End(r.level, r.state)
+			continue
+		}
+		remaining = append(remaining, active)
+	}
+	r.active = remaining
+
+	r.ticks++
+	if r.ticks < eventIntervalSeconds*r.fps {
+		return
+	}
+	r.ticks = 0
+	if next := r.Select(r.random); next != nil {
+		r.Fire(next)
+	}
+}
+
+// Draw does nothing, events are announced through the notifier
+func (r *MapEventRegistry) Draw(screen *tl.Screen) {}
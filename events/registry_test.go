@@ -0,0 +1,73 @@
+package events
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+type testWorld struct {
+	outage    bool
+	enemies   int
+	drops     int
+	evacuated bool
+}
+
+func (w *testWorld) SetPowerOutage(outage bool)  { w.outage = outage }
+func (w *testWorld) SpawnEnemies(count int)      { w.enemies += count }
+func (w *testWorld) PlaceSupplyDrops(count int)  { w.drops += count }
+func (w *testWorld) SetEvacuated(evacuated bool) { w.evacuated = evacuated }
+
+func TestWeightedSelection(t *testing.T) {
+	const trials = 1000
+	registry := NewMapEventRegistry(DefaultEvents(), 10, nil, &testWorld{})
+	random := rand.New(rand.NewSource(1))
+
+	counts := make(map[string]int)
+	for i := 0; i < trials; i++ {
+		counts[registry.Select(random).Name()]++
+	}
+
+	total := 0
+	for _, event := range DefaultEvents() {
+		total += event.Weight()
+	}
+	for _, event := range DefaultEvents() {
+		expected := float64(event.Weight()) / float64(total)
+		observed := float64(counts[event.Name()]) / trials
+		if math.Abs(observed-expected) > 0.1 {
+			t.Errorf("%s selected %.1f%% of the time, expected %.1f%% within 10%%",
+				event.Name(), observed*100, expected*100)
+		}
+	}
+}
+
+func TestTimedEventEnds(t *testing.T) {
+	world := &testWorld{}
+	registry := NewMapEventRegistry(nil, 10, nil, world)
+
+	registry.Fire(NewPowerOutageEvent(1))
+	if !world.outage {
+		t.Fatalf("power outage did not cut the power")
+	}
+	for i := 0; i < powerOutageSeconds*10; i++ {
+		registry.Tick(tl.Event{Type: tl.EventNone})
+	}
+	if world.outage {
+		t.Errorf("power outage still running after %d seconds", powerOutageSeconds)
+	}
+}
+
+func TestEventFiresEachInterval(t *testing.T) {
+	world := &testWorld{}
+	registry := NewMapEventRegistry([]WeightedMapEvent{NewAirdropEvent(1)}, 10, nil, world)
+
+	for i := 0; i < eventIntervalSeconds*10; i++ {
+		registry.Tick(tl.Event{Type: tl.EventNone})
+	}
+	if world.drops != airdropCount {
+		t.Errorf("%d supply drops placed after one interval instead of %d", world.drops, airdropCount)
+	}
+}
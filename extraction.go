@@ -0,0 +1,63 @@
+package main
+
+import (
+    "math/rand"
+    "sort"
+
+    "github.com/Ariemeth/frame_assault/entities"
+    "github.com/Ariemeth/frame_assault/mission"
+    tl "github.com/Ariemeth/termloop"
+)
+
+// landingZoneCount is how many landing zones are placed on each map
+const landingZoneCount = 3
+
+// missionObjectives reports the quests completed out of those on offer
+type missionObjectives struct {
+    missions *mission.Manager
+    total    int
+}
+
+// Progress returns the completed and total number of quests
+func (o *missionObjectives) Progress() (int, int) {
+    return o.missions.CompletedCount(), o.total
+}
+
+// roadIntersections returns every road cell where a street crosses an
+// avenue, sorted so picking from them only depends on the random source.
+// Looking two cells out on each side steps over the second lane of an
+// avenue, so only crossings have road in all four directions.
+func roadIntersections(roads *RoadSystem) [][2]int {
+    intersections := make([][2]int, 0)
+    for _, cell := range roads.RoadCells() {
+        x, y := cell[0], cell[1]
+        if roads.HasRoad(x-2, y) && roads.HasRoad(x+2, y) && roads.HasRoad(x, y-2) && roads.HasRoad(x, y+2) {
+            intersections = append(intersections, cell)
+        }
+    }
+    sort.Slice(intersections, func(i, j int) bool {
+        if intersections[i][0] != intersections[j][0] {
+            return intersections[i][0] < intersections[j][0]
+        }
+        return intersections[i][1] < intersections[j][1]
+    })
+    return intersections
+}
+
+// placeLandingZones puts landingZoneCount landing zones at random road
+// intersections, calling onExtract when the player is picked up from one
+func placeLandingZones(roads *RoadSystem, player entities.Sensor, objectives entities.Objectives,
+    onExtract func(), level *tl.BaseLevel) []*entities.LandingZone {
+    intersections := roadIntersections(roads)
+    zones := make([]*entities.LandingZone, 0, landingZoneCount)
+    for _, i := range rand.Perm(len(intersections)) {
+        if len(zones) == landingZoneCount {
+            break
+        }
+        pos := intersections[i]
+        zone := entities.NewLandingZone(pos[0], pos[1], player, objectives, onExtract)
+        level.AddEntity(zone)
+        zones = append(zones, zone)
+    }
+    return zones
+}
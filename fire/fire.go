@@ -0,0 +1,233 @@
+// Package fire spreads fire from destroyed buildings to the buildings
+// around them
+package fire
+
+import (
+	"math/rand"
+
+	"github.com/Ariemeth/frame_assault/building"
+	"github.com/Ariemeth/frame_assault/eventbus"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// spreadChance is the chance a destroyed building sets each of its
+	// neighbours alight
+	spreadChance = 0.3
+	// spreadRange is the widest gap in cells fire can jump between buildings
+	spreadRange = 12
+	// buildingBurnDamage is the structure a burning building loses per tick
+	buildingBurnDamage = 1
+	// flameFlickerTicks is how many ticks each flame color is shown for
+	flameFlickerTicks = 3
+)
+
+// BurnTimer burns a building every tick until it is destroyed or the fire
+// is put out
+type BurnTimer struct {
+	building *building.Building
+	damage   int
+	out      bool
+}
+
+// NewBurnTimer sets b alight, burning damage structure per tick
+func NewBurnTimer(b *building.Building, damage int) *BurnTimer {
+	return &BurnTimer{building: b, damage: damage}
+}
+
+// Building returns the building on fire
+func (t *BurnTimer) Building() *building.Building {
+	return t.building
+}
+
+// Burning returns true until the fire is put out or the building is gone
+func (t *BurnTimer) Burning() bool {
+	return !t.out && t.building.Structure() > 0
+}
+
+// Extinguish puts the fire out
+func (t *BurnTimer) Extinguish() {
+	t.out = true
+}
+
+// Tick burns the building for one tick
+func (t *BurnTimer) Tick() {
+	if t.Burning() {
+		t.building.TakeHit(t.damage)
+	}
+}
+
+// Firefighter can put out a fire beside it by using up a repair kit
+type Firefighter interface {
+	Position() (int, int)
+	UseRepairKit() bool
+}
+
+// PropagationSystem sets the neighbours of destroyed buildings alight,
+// which can chain across the city as burning buildings are destroyed in
+// turn. A firefighter next to a burning building can put it out with X.
+type PropagationSystem struct {
+	buildings   *building.Manager
+	fires       []*BurnTimer
+	chance      func() float64
+	firefighter Firefighter
+	ticks       int
+}
+
+// NewPropagationSystem creates a fire system for the city's buildings
+func NewPropagationSystem(buildings *building.Manager) *PropagationSystem {
+	return &PropagationSystem{
+		buildings: buildings,
+		chance:    rand.Float64,
+	}
+}
+
+// AttachFirefighter sets who can put fires out
+func (s *PropagationSystem) AttachFirefighter(firefighter Firefighter) {
+	s.firefighter = firefighter
+}
+
+// HandleEvent rolls to set each neighbour of a destroyed building alight
+func (s *PropagationSystem) HandleEvent(event eventbus.Event) {
+	burned, ok := event.(eventbus.BuildingBurnedEvent)
+	if !ok {
+		return
+	}
+	for _, neighbour := range s.neighbours(burned) {
+		if s.chance() < spreadChance {
+			s.Ignite(neighbour)
+		}
+	}
+}
+
+// Ignite sets b alight, returning false if it is destroyed or already
+// burning
+func (s *PropagationSystem) Ignite(b *building.Building) bool {
+	if b.Structure() == 0 || s.fireAt(b) != nil {
+		return false
+	}
+	s.fires = append(s.fires, NewBurnTimer(b, buildingBurnDamage))
+	return true
+}
+
+// Burning returns the number of buildings on fire
+func (s *PropagationSystem) Burning() int {
+	count := 0
+	for _, fire := range s.fires {
+		if fire.Burning() {
+			count++
+		}
+	}
+	return count
+}
+
+// fireAt returns the fire burning b or nil
+func (s *PropagationSystem) fireAt(b *building.Building) *BurnTimer {
+	for _, fire := range s.fires {
+		if fire.Building() == b && fire.Burning() {
+			return fire
+		}
+	}
+	return nil
+}
+
+// neighbours returns the nearest standing building within spreadRange on
+// each of the four sides of the destroyed building
+func (s *PropagationSystem) neighbours(burned eventbus.BuildingBurnedEvent) []*building.Building {
+	nearest := make([]*building.Building, 4)
+	gaps := make([]int, 4)
+	for _, b := range s.buildings.Buildings() {
+		if int(b.ID()) == burned.ID || b.Structure() == 0 {
+			continue
+		}
+		x, y := b.Position()
+		width, height := b.Size()
+		side, gap := -1, 0
+		overlapsX := x < burned.X+burned.Width && burned.X < x+width
+		overlapsY := y < burned.Y+burned.Height && burned.Y < y+height
+		switch {
+		case overlapsY && x >= burned.X+burned.Width:
+			side, gap = 0, x-(burned.X+burned.Width)
+		case overlapsY && x+width <= burned.X:
+			side, gap = 1, burned.X-(x+width)
+		case overlapsX && y >= burned.Y+burned.Height:
+			side, gap = 2, y-(burned.Y+burned.Height)
+		case overlapsX && y+height <= burned.Y:
+			side, gap = 3, burned.Y-(y+height)
+		}
+		if side < 0 || gap > spreadRange {
+			continue
+		}
+		if nearest[side] == nil || gap < gaps[side] {
+			nearest[side], gaps[side] = b, gap
+		}
+	}
+	found := make([]*building.Building, 0, 4)
+	for _, b := range nearest {
+		if b != nil {
+			found = append(found, b)
+		}
+	}
+	return found
+}
+
+// Extinguish puts out a fire in a building touching x,y using one of the
+// firefighter's repair kits. Returns true if a fire was put out.
+func (s *PropagationSystem) Extinguish(x, y int) bool {
+	for _, fire := range s.fires {
+		if !fire.Burning() {
+			continue
+		}
+		b := fire.Building()
+		if !b.Contains(x+1, y) && !b.Contains(x-1, y) && !b.Contains(x, y+1) && !b.Contains(x, y-1) {
+			continue
+		}
+		if s.firefighter == nil || !s.firefighter.UseRepairKit() {
+			return false
+		}
+		fire.Extinguish()
+		return true
+	}
+	return false
+}
+
+// Tick burns every burning building once per frame and puts out the fire
+// beside the firefighter when X is pressed
+func (s *PropagationSystem) Tick(event tl.Event) {
+	if event.Type == tl.EventKey && (event.Ch == 'x' || event.Ch == 'X') && s.firefighter != nil {
+		s.Extinguish(s.firefighter.Position())
+		return
+	}
+	if event.Type != tl.EventNone {
+		return
+	}
+	s.ticks++
+	// Fires started by buildings burning down this tick are appended
+	// while looping, so loop by index
+	for i := 0; i < len(s.fires); i++ {
+		s.fires[i].Tick()
+	}
+	burning := s.fires[:0]
+	for _, fire := range s.fires {
+		if fire.Burning() {
+			burning = append(burning, fire)
+		}
+	}
+	s.fires = burning
+}
+
+// Draw flickers flames along the roof of every burning building
+func (s *PropagationSystem) Draw(screen *tl.Screen) {
+	colors := []tl.Attr{tl.ColorRed | tl.AttrBold, tl.ColorYellow | tl.AttrBold}
+	for _, fire := range s.fires {
+		if !fire.Burning() {
+			continue
+		}
+		x, y := fire.Building().Position()
+		width, _ := fire.Building().Size()
+		for i := 0; i < width; i += 2 {
+			color := colors[(i/2+s.ticks/flameFlickerTicks)%len(colors)]
+			screen.RenderCell(x+i, y, &tl.Cell{Fg: color, Bg: tl.ColorBlack, Ch: '^'})
+		}
+	}
+}
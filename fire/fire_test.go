@@ -0,0 +1,117 @@
+package fire
+
+import (
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/building"
+	"github.com/Ariemeth/frame_assault/eventbus"
+	tl "github.com/Ariemeth/termloop"
+)
+
+// newRow creates a fire system for a row of homes 2 cells apart, with a
+// last home too far away for fire to reach, spreading fire whenever
+// chance is below spreadChance
+func newRow(chance float64) (*PropagationSystem, []*building.Building) {
+	home, _ := building.TypeByName("Home")
+	buildings := building.NewManager(nil)
+	bus := eventbus.New()
+	buildings.SetEventBus(bus)
+	row := []*building.Building{
+		building.NewBuilding(0, 0, 4, 4, home),
+		building.NewBuilding(6, 0, 4, 4, home),
+		building.NewBuilding(12, 0, 4, 4, home),
+		building.NewBuilding(12+4+spreadRange+1, 0, 4, 4, home),
+	}
+	for _, b := range row {
+		buildings.Add(b)
+	}
+	fires := NewPropagationSystem(buildings)
+	fires.chance = func() float64 { return chance }
+	bus.Subscribe(fires.HandleEvent)
+	return fires, row
+}
+
+// burnOut ticks the fires for long enough to burn down a building
+func burnOut(fires *PropagationSystem, b *building.Building) {
+	ticks := b.Structure() / buildingBurnDamage
+	for i := 0; i < ticks; i++ {
+		fires.Tick(tl.Event{Type: tl.EventNone})
+	}
+}
+
+func TestFireChainsAcrossNeighbours(t *testing.T) {
+	fires, row := newRow(0)
+
+	row[0].TakeHit(row[0].Structure())
+	if fires.Burning() != 1 || fires.fireAt(row[1]) == nil {
+		t.Fatalf("%d buildings burning after the first was destroyed, want only its neighbour", fires.Burning())
+	}
+
+	burnOut(fires, row[1])
+	if row[1].Structure() != 0 {
+		t.Errorf("burning building has %d structure left, want 0", row[1].Structure())
+	}
+	if fires.fireAt(row[2]) == nil {
+		t.Error("fire did not spread on to the next building")
+	}
+
+	burnOut(fires, row[2])
+	if fires.Burning() != 0 || row[3].Structure() == 0 {
+		t.Errorf("fire jumped to a building %d cells away", spreadRange+1)
+	}
+}
+
+func TestFireStopsWhenNeighboursStayIntact(t *testing.T) {
+	fires, row := newRow(1)
+	full := row[1].Structure()
+
+	row[0].TakeHit(row[0].Structure())
+	for i := 0; i < full; i++ {
+		fires.Tick(tl.Event{Type: tl.EventNone})
+	}
+
+	if fires.Burning() != 0 {
+		t.Errorf("%d buildings burning, want none", fires.Burning())
+	}
+	for _, b := range row[1:] {
+		if b.Structure() != full {
+			t.Errorf("building %d has %d structure, want %d", b.ID(), b.Structure(), full)
+		}
+	}
+}
+
+// firefighter stands at x,y carrying kits repair kits
+type firefighter struct {
+	x, y int
+	kits int
+}
+
+func (f *firefighter) Position() (int, int) {
+	return f.x, f.y
+}
+
+func (f *firefighter) UseRepairKit() bool {
+	if f.kits == 0 {
+		return false
+	}
+	f.kits--
+	return true
+}
+
+func TestExtinguishUsesRepairKit(t *testing.T) {
+	fires, row := newRow(1)
+	fires.Ignite(row[1])
+	player := &firefighter{x: 10, y: 1}
+	fires.AttachFirefighter(player)
+
+	fires.Tick(tl.Event{Type: tl.EventKey, Ch: 'x'})
+	if fires.Burning() != 1 {
+		t.Error("fire put out without a repair kit")
+	}
+
+	player.kits = 1
+	fires.Tick(tl.Event{Type: tl.EventKey, Ch: 'x'})
+	if fires.Burning() != 0 || player.kits != 0 {
+		t.Errorf("%d buildings burning with %d kits left after extinguishing, want 0 and 0", fires.Burning(), player.kits)
+	}
+}
@@ -0,0 +1,194 @@
+// Package fogmemory hides the parts of the city the player cannot see,
+// showing a dim memory of what was there when last seen
+package fogmemory
+
+import (
+	"github.com/Ariemeth/frame_assault/util"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// defaultSightRadius is how far the viewer sees in cells
+	defaultSightRadius = 12
+)
+
+// fogCell is drawn over cells that have never been seen
+var fogCell = tl.Cell{Bg: tl.ColorBlack, Fg: tl.ColorBlack, Ch: ' '}
+
+// MemoryCell is how a cell looked when it was last seen
+type MemoryCell struct {
+	Cell     tl.Cell
+	Recorded bool
+}
+
+// Memory holds the last seen appearance of every cell in the city
+type Memory struct {
+	grid [][]MemoryCell
+}
+
+// NewMemory creates a memory of a width by height city with nothing
+// recorded
+func NewMemory(width, height int) *Memory {
+	grid := make([][]MemoryCell, width)
+	for x := range grid {
+		grid[x] = make([]MemoryCell, height)
+	}
+	return &Memory{grid: grid}
+}
+
+// Record stores cell as the appearance of x,y. Cells outside the city are
+// ignored.
+func (m *Memory) Record(x, y int, cell tl.Cell) {
+	if !m.inBounds(x, y) {
+		return
+	}
+	m.grid[x][y] = MemoryCell{Cell: cell, Recorded: true}
+}
+
+// Recall returns the dimmed appearance recorded for x,y and whether
+// anything was recorded there
+func (m *Memory) Recall(x, y int) (tl.Cell, bool) {
+	if !m.inBounds(x, y) || !m.grid[x][y].Recorded {
+		return tl.Cell{}, false
+	}
+	cell := m.grid[x][y].Cell
+	cell.Fg |= util.AttrDim
+	// Bold backgrounds are the bright variant of their color
+	cell.Bg &^= tl.AttrBold
+	return cell, true
+}
+
+// Size returns the width and height of the city remembered
+func (m *Memory) Size() (int, int) {
+	if len(m.grid) == 0 {
+		return 0, 0
+	}
+	return len(m.grid), len(m.grid[0])
+}
+
+// inBounds returns true if x,y lies within the city
+func (m *Memory) inBounds(x, y int) bool {
+	width, height := m.Size()
+	return x >= 0 && x < width && y >= 0 && y < height
+}
+
+// Viewer is the entity whose sight lifts the fog
+type Viewer interface {
+	Position() (int, int)
+	CanSee(x, y int) bool
+}
+
+// Lighting adjusts the colors of a cell drawn at x,y
+type Lighting interface {
+	Light(cell *tl.Cell, x, y int) *tl.Cell
+}
+
+// cellRenderer is the part of tl.Screen used to draw the fog
+type cellRenderer interface {
+	RenderCell(x, y int, c *tl.Cell)
+}
+
+// FogOfWar covers every cell out of the viewer's sight. Explored cells
+// show their remembered appearance dimmed, unexplored cells are black.
+type FogOfWar struct {
+	viewer      Viewer
+	lighting    Lighting
+	memory      *Memory
+	explored    [][]bool
+	sightRadius int
+}
+
+// NewFogOfWar creates fog over a width by height city lifted by viewer's
+// sight. Cells drawn through the fog's Light are passed on to lighting,
+// which may be nil.
+func NewFogOfWar(viewer Viewer, lighting Lighting, width, height int) *FogOfWar {
+	explored := make([][]bool, width)
+	for x := range explored {
+		explored[x] = make([]bool, height)
+	}
+	return &FogOfWar{
+		viewer:      viewer,
+		lighting:    lighting,
+		memory:      NewMemory(width, height),
+		explored:    explored,
+		sightRadius: defaultSightRadius,
+	}
+}
+
+// Memory returns the remembered appearance of the city
+func (f *FogOfWar) Memory() *Memory {
+	return f.memory
+}
+
+// Visible returns true if the viewer can currently see x,y. The cells
+// around the viewer are always visible.
+func (f *FogOfWar) Visible(x, y int) bool {
+	vx, vy := f.viewer.Position()
+	dx, dy := x-vx, y-vy
+	distance := dx*dx + dy*dy
+	if distance <= 2 {
+		return true
+	}
+	return distance <= f.sightRadius*f.sightRadius && f.viewer.CanSee(x, y)
+}
+
+// Explored returns true once the viewer has seen x,y
+func (f *FogOfWar) Explored(x, y int) bool {
+	return f.memory.inBounds(x, y) && f.explored[x][y]
+}
+
+// Light applies any lighting to a cell being drawn at x,y, remembering
+// how it looks while it is visible. Roads and buildings draw through it.
+func (f *FogOfWar) Light(cell *tl.Cell, x, y int) *tl.Cell {
+	if f.lighting != nil {
+		cell = f.lighting.Light(cell, x, y)
+	}
+	if f.Visible(x, y) {
+		f.memory.Record(x, y, *cell)
+	}
+	return cell
+}
+
+// reveal marks every cell the viewer can see as explored
+func (f *FogOfWar) reveal() {
+	vx, vy := f.viewer.Position()
+	for x := vx - f.sightRadius; x <= vx+f.sightRadius; x++ {
+		for y := vy - f.sightRadius; y <= vy+f.sightRadius; y++ {
+			if f.memory.inBounds(x, y) && f.Visible(x, y) {
+				f.explored[x][y] = true
+			}
+		}
+	}
+}
+
+// Draw covers the cells out of sight
+func (f *FogOfWar) Draw(screen *tl.Screen) {
+	f.render(screen)
+}
+
+// render draws remembered cells where explored and fog elsewhere
+func (f *FogOfWar) render(screen cellRenderer) {
+	width, height := f.memory.Size()
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			if f.Visible(x, y) {
+				continue
+			}
+			if f.explored[x][y] {
+				if cell, ok := f.memory.Recall(x, y); ok {
+					screen.RenderCell(x, y, &cell)
+					continue
+				}
+			}
+			cell := fogCell
+			screen.RenderCell(x, y, &cell)
+		}
+	}
+}
+
+// Tick reveals the cells in sight once per frame
+func (f *FogOfWar) Tick(event tl.Event) {
+	if event.Type == tl.EventNone {
+		f.reveal()
+	}
+}
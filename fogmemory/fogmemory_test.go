@@ -0,0 +1,87 @@
+package fogmemory
+
+import (
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/util"
+	tl "github.com/Ariemeth/termloop"
+)
+
+type testViewer struct {
+	x, y int
+}
+
+func (v *testViewer) Position() (int, int) {
+	return v.x, v.y
+}
+
+func (v *testViewer) CanSee(x, y int) bool {
+	return true
+}
+
+type testScreen struct {
+	cells map[[2]int]tl.Cell
+}
+
+func (s *testScreen) RenderCell(x, y int, c *tl.Cell) {
+	s.cells[[2]int{x, y}] = *c
+}
+
+func TestExploredCellIsRememberedDim(t *testing.T) {
+	viewer := &testViewer{x: 10, y: 10}
+	fog := NewFogOfWar(viewer, nil, 100, 60)
+	road := tl.Cell{Bg: tl.ColorBlue, Fg: tl.ColorBlue, Ch: ' '}
+
+	fog.Tick(tl.Event{Type: tl.EventNone})
+	fog.Light(&road, 12, 10)
+	if !fog.Explored(12, 10) {
+		t.Fatal("cell in sight was not explored")
+	}
+
+	viewer.x, viewer.y = 60, 40
+	fog.Tick(tl.Event{Type: tl.EventNone})
+	screen := &testScreen{cells: make(map[[2]int]tl.Cell)}
+	fog.render(screen)
+
+	cell, ok := screen.cells[[2]int{12, 10}]
+	if !ok {
+		t.Fatal("nothing was drawn over the explored cell")
+	}
+	if cell.Bg != tl.ColorBlue || cell.Fg&util.AttrDim == 0 {
+		t.Errorf("explored cell drawn as %+v, want the road dimmed", cell)
+	}
+	if unexplored := screen.cells[[2]int{90, 50}]; unexplored != fogCell {
+		t.Errorf("unexplored cell drawn as %+v, want fog", unexplored)
+	}
+	if _, drawn := screen.cells[[2]int{61, 40}]; drawn {
+		t.Error("fog was drawn over a visible cell")
+	}
+}
+
+func TestFogIsDrawnOverTheCityAndUnderOverlays(t *testing.T) {
+	base := tl.NewBaseLevel(tl.Cell{})
+	fog := NewFogOfWar(&testViewer{}, nil, 40, 20)
+	road, hud, bullet := tl.NewEntity(0, 0, 1, 1), tl.NewEntity(0, 0, 1, 1), tl.NewEntity(0, 0, 1, 1)
+	base.AddEntity(road)
+	base.AddEntity(fog)
+	base.AddEntity(hud)
+	level := NewLevel(base, fog)
+	level.AddOverlay(hud)
+	// Entities added while playing still go under the fog
+	base.AddEntity(bullet)
+
+	names := map[tl.Drawable]string{road: "road", bullet: "bullet", fog: "fog", hud: "hud"}
+	want := []tl.Drawable{road, bullet, fog, hud}
+	layers := level.layers()
+	if len(layers) != len(want) {
+		t.Fatalf("drew %d layers, want %d", len(layers), len(want))
+	}
+	for i := range want {
+		if layers[i] != want[i] {
+			t.Errorf("layer %d is the %s, want the %s", i, names[layers[i]], names[want[i]])
+		}
+	}
+	if width, height := fog.Memory().Size(); width != 40 || height != 20 {
+		t.Errorf("fog covers %dx%d, want the 40x20 city", width, height)
+	}
+}
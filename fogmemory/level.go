@@ -0,0 +1,53 @@
+package fogmemory
+
+import (
+	tl "github.com/Ariemeth/termloop"
+)
+
+// Level draws a level with its fog as the last layer of the city. Every
+// entity of the level is drawn under the fog, including those added while
+// playing, apart from the overlays which are drawn above it.
+type Level struct {
+	*tl.BaseLevel
+	fog      *FogOfWar
+	overlays map[tl.Drawable]bool
+}
+
+// NewLevel layers fog over level. The fog should be one of the level's
+// entities so it ticks with them.
+func NewLevel(level *tl.BaseLevel, fog *FogOfWar) *Level {
+	return &Level{BaseLevel: level, fog: fog, overlays: make(map[tl.Drawable]bool)}
+}
+
+// AddOverlay has entities of the level drawn above the fog, in the order
+// they were added to the level
+func (l *Level) AddOverlay(entities ...tl.Drawable) {
+	for _, entity := range entities {
+		l.overlays[entity] = true
+	}
+}
+
+// Draw draws the city, then the fog over it, then the overlays
+func (l *Level) Draw(screen *tl.Screen) {
+	// A copy of the level draws the layers with the level's offset
+	layered := *l.BaseLevel
+	layered.Entities = l.layers()
+	layered.Draw(screen)
+}
+
+// layers returns the level's entities in the order they are drawn
+func (l *Level) layers() []tl.Drawable {
+	layers := make([]tl.Drawable, 0, len(l.Entities)+1)
+	for _, entity := range l.Entities {
+		if entity != tl.Drawable(l.fog) && !l.overlays[entity] {
+			layers = append(layers, entity)
+		}
+	}
+	layers = append(layers, l.fog)
+	for _, entity := range l.Entities {
+		if l.overlays[entity] {
+			layers = append(layers, entity)
+		}
+	}
+	return layers
+}
@@ -1,8 +1,12 @@
 module github.com/Ariemeth/frame_assault
 
-go 1.18
+go 1.21
 
-require github.com/Ariemeth/termloop v0.0.0-20181112204055-0f8867e43cbb
+require (
+	github.com/Ariemeth/termloop v0.0.0-20181112204055-0f8867e43cbb
+	github.com/gorilla/websocket v1.5.3
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/mattn/go-runewidth v0.0.9 // indirect
@@ -0,0 +1,138 @@
+// Package hazard provides environmental hazards that damage the city and
+// the mechs fighting in it
+package hazard
+
+import (
+	"github.com/Ariemeth/frame_assault/damagelog"
+	"github.com/Ariemeth/frame_assault/display"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// acidRainTicks is how many hazard ticks a storm lasts
+	acidRainTicks = 20
+	// acidDamage is the structure an exposed mech loses per hazard tick
+	acidDamage = 1
+	// buildingAcidDamage is the structure a building loses per hazard tick
+	buildingAcidDamage = 1
+	// acidRainWarning is shown when a storm starts
+	acidRainWarning = "[ACID RAIN]"
+)
+
+// Notifier shows hazard warnings to the player
+type Notifier interface {
+	AddPriorityMessage(message string, priority display.Priority)
+}
+
+// exposedTarget is a mech the rain can damage
+type exposedTarget interface {
+	Hit(int, damagelog.EntityID)
+	Position() (int, int)
+	IsDestroyed() bool
+}
+
+// shelter is a building that shields mechs but is corroded itself
+type shelter interface {
+	Contains(x, y int) bool
+	TakeHit(int)
+}
+
+// AcidRain damages every exposed mech and every building once per hazard
+// tick while a storm is active. A hazard tick is one second of frames.
+type AcidRain struct {
+	level     *tl.BaseLevel
+	notifier  Notifier
+	fps       int
+	frames    int
+	ticksLeft int
+}
+
+// NewAcidRain creates an acid rain hazard over level ticking once every
+// fps frames
+func NewAcidRain(level *tl.BaseLevel, fps int) *AcidRain {
+	if fps < 1 {
+		fps = 1
+	}
+	return &AcidRain{level: level, fps: fps}
+}
+
+// AttachNotifier sets where the acid rain warning is shown
+func (rain *AcidRain) AttachNotifier(notifier Notifier) {
+	rain.notifier = notifier
+}
+
+// Start begins a storm
+func (rain *AcidRain) Start() {
+	rain.ticksLeft = acidRainTicks
+	rain.frames = 0
+	if rain.notifier != nil {
+		rain.notifier.AddPriorityMessage(acidRainWarning, display.PriorityCritical)
+	}
+}
+
+// Active returns true while a storm is falling
+func (rain *AcidRain) Active() bool {
+	return rain.ticksLeft > 0
+}
+
+// Tick counts frames and corrodes the level once every hazard tick
+func (rain *AcidRain) Tick(event tl.Event) {
+	if event.Type != tl.EventNone || !rain.Active() {
+		return
+	}
+	rain.frames++
+	if rain.frames < rain.fps {
+		return
+	}
+	rain.frames = 0
+	rain.ticksLeft--
+	rain.corrode()
+}
+
+// corrode damages buildings and any mech not sheltered by one
+func (rain *AcidRain) corrode() {
+	if rain.level == nil {
+		return
+	}
+	shelters := make([]shelter, 0)
+	targets := make([]exposedTarget, 0)
+	for _, entity := range rain.level.Entities {
+		switch e := entity.(type) {
+		case shelter:
+			shelters = append(shelters, e)
+		case exposedTarget:
+			if !e.IsDestroyed() {
+				targets = append(targets, e)
+			}
+		}
+	}
+
+	for _, s := range shelters {
+		s.TakeHit(buildingAcidDamage)
+	}
+	for _, target := range targets {
+		if !sheltered(target, shelters) {
+			target.Hit(acidDamage, damagelog.Environment)
+		}
+	}
+}
+
+// sheltered returns true if target is inside or pressed against one of
+// the shelters. Mechs are too large to fit through a door, so standing
+// against a wall counts as taking cover.
+func sheltered(target exposedTarget, shelters []shelter) bool {
+	x, y := target.Position()
+	for _, s := range shelters {
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				if s.Contains(x+dx, y+dy) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// Draw does nothing, the storm is announced through the notifier
+func (rain *AcidRain) Draw(screen *tl.Screen) {}
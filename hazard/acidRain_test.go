@@ -0,0 +1,52 @@
+package hazard
+
+import (
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/building"
+	"github.com/Ariemeth/frame_assault/mech"
+	tl "github.com/Ariemeth/termloop"
+)
+
+func TestAcidRainDamagesExposedMechs(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	exposed := mech.NewMech("Mech A", 10, 30, 30, tl.ColorRed, 'A')
+	covered := mech.NewMech("Mech B", 10, 4, 10, tl.ColorRed, 'B')
+	home := building.NewBuilding(5, 5, 8, 6, building.Types[0])
+	level.AddEntity(exposed)
+	level.AddEntity(covered)
+	level.AddEntity(home)
+
+	rain := NewAcidRain(level, 1)
+	rain.Start()
+	for tick := 1; tick <= 3; tick++ {
+		rain.Tick(tl.Event{Type: tl.EventNone})
+		if exposed.StructureLeft() != 10-tick*acidDamage {
+			t.Errorf("exposed mech has %d structure after %d ticks", exposed.StructureLeft(), tick)
+		}
+	}
+	if covered.StructureLeft() != 10 {
+		t.Errorf("sheltered mech took damage, %d structure left", covered.StructureLeft())
+	}
+	if home.Structure() >= 100 {
+		t.Errorf("building was not damaged by acid rain")
+	}
+}
+
+func TestAcidRainStops(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	target := mech.NewMech("Mech A", 100, 30, 30, tl.ColorRed, 'A')
+	level.AddEntity(target)
+
+	rain := NewAcidRain(level, 1)
+	rain.Start()
+	for i := 0; i < acidRainTicks+5; i++ {
+		rain.Tick(tl.Event{Type: tl.EventNone})
+	}
+	if rain.Active() {
+		t.Errorf("acid rain still active after %d ticks", acidRainTicks+5)
+	}
+	if target.StructureLeft() != 100-acidRainTicks*acidDamage {
+		t.Errorf("mech has %d structure after a full storm", target.StructureLeft())
+	}
+}
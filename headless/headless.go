@@ -0,0 +1,71 @@
+// Package headless runs the game without a terminal so AI behaviour and
+// balance can be tested automatically
+package headless
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Ariemeth/frame_assault/timing"
+	tl "github.com/Ariemeth/termloop"
+)
+
+// Ticker is ticked once per frame, usually the game's screen
+type Ticker interface {
+	Tick(event tl.Event)
+}
+
+// Summary is the outcome of a headless run
+type Summary struct {
+	Ticks              int   `json:"ticks"`
+	EnemiesKilled      int   `json:"enemies_killed"`
+	DamageDealt        int   `json:"damage_dealt"`
+	AICalls            int64 `json:"ai_calls"`
+	BuildingsDestroyed int   `json:"buildings_destroyed"`
+}
+
+// Simulation ticks the game at a steady frame rate without ever drawing it
+type Simulation struct {
+	game     Ticker
+	governor *timing.TickGovernor
+	ticks    int
+}
+
+// NewSimulation creates a simulation ticking game fps times a second
+func NewSimulation(game Ticker, fps float64) *Simulation {
+	return &Simulation{game: game, governor: timing.NewTickGovernor(fps)}
+}
+
+// Ticks returns how many frames have been run
+func (s *Simulation) Ticks() int {
+	return s.ticks
+}
+
+// Step runs one frame
+func (s *Simulation) Step() {
+	s.game.Tick(tl.Event{Type: tl.EventNone})
+	s.ticks++
+}
+
+// Run steps the game at the simulation's frame rate until duration of wall
+// clock time has passed, the governor sleeping off what is left of each
+// frame
+func (s *Simulation) Run(duration time.Duration) {
+	deadline := time.Now().Add(duration)
+	for time.Now().Before(deadline) {
+		s.Step()
+		s.governor.MaySleep()
+	}
+}
+
+// WriteSummary writes summary to w as JSON
+func WriteSummary(w io.Writer, summary Summary) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(summary); err != nil {
+		return fmt.Errorf("error writing headless summary: %v", err)
+	}
+	return nil
+}
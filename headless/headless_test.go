@@ -0,0 +1,48 @@
+package headless
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+// countingEntity counts its ticks and draws
+type countingEntity struct {
+	ticks, draws int
+}
+
+func (e *countingEntity) Tick(event tl.Event)    { e.ticks++ }
+func (e *countingEntity) Draw(screen *tl.Screen) { e.draws++ }
+
+func TestHeadlessRunSummary(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	entity := &countingEntity{}
+	level.AddEntity(entity)
+
+	simulation := NewSimulation(level, 30)
+	for i := 0; i < 100; i++ {
+		simulation.Step()
+	}
+	if entity.ticks != 100 || entity.draws != 0 {
+		t.Errorf("entity ticked %d times and drew %d times, want 100 ticks and no draws", entity.ticks, entity.draws)
+	}
+
+	var out bytes.Buffer
+	if err := WriteSummary(&out, Summary{Ticks: simulation.Ticks()}); err != nil {
+		t.Fatal(err)
+	}
+	var summary map[string]interface{}
+	if err := json.Unmarshal(out.Bytes(), &summary); err != nil {
+		t.Fatalf("summary is not JSON: %v\n%s", err, out.String())
+	}
+	for _, key := range []string{"ticks", "enemies_killed", "damage_dealt", "ai_calls", "buildings_destroyed"} {
+		if _, ok := summary[key]; !ok {
+			t.Errorf("summary is missing %q:\n%s", key, out.String())
+		}
+	}
+	if summary["ticks"] != 100.0 {
+		t.Errorf("summary has %v ticks, want 100", summary["ticks"])
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+    "github.com/Ariemeth/frame_assault/building"
+    "github.com/Ariemeth/frame_assault/npc"
+    "github.com/Ariemeth/frame_assault/util"
+)
+
+// helpInteractRange is how close in cells a civilian must be for the help
+// box to show the interaction controls
+const helpInteractRange = 2
+
+// newInteractionCheck returns whether a civilian, the quest giver or a
+// standing building is beside x,y
+func newInteractionCheck(civilians []*ComputerUserEntity, giver *npc.QuestGiver, buildings *building.Manager) func(x, y int) bool {
+    return func(x, y int) bool {
+        if giver != nil {
+            giverX, giverY := giver.Position()
+            if util.Distance(x, y, giverX, giverY, util.Euclidean) <= helpInteractRange {
+                return true
+            }
+        }
+        for _, civilian := range civilians {
+            civilianX, civilianY := civilian.Position()
+            if util.Distance(x, y, civilianX, civilianY, util.Euclidean) <= helpInteractRange {
+                return true
+            }
+        }
+        for _, b := range buildings.Buildings() {
+            if b.Structure() > 0 && (b.Contains(x+1, y) || b.Contains(x-1, y) || b.Contains(x, y+1) || b.Contains(x, y-1)) {
+                return true
+            }
+        }
+        return false
+    }
+}
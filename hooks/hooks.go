@@ -0,0 +1,64 @@
+// Package hooks lets mods change how entities are drawn without changing
+// the game's source. Hooks are registered by entity type name at startup:
+//
+//	"Mech"         every mech without a more specific hook
+//	"PlayerMech"   the player's mech
+//	"EnemyMech"    enemy mechs
+//	"Building"     buildings
+//	"ComputerUser" civilians
+package hooks
+
+import (
+	"sync"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+// DrawHook draws entity in place of its default rendering, usually by
+// calling screen.RenderCell itself
+type DrawHook func(entity tl.Drawable, screen *tl.Screen)
+
+// Registry holds the draw hook registered for each entity type
+type Registry struct {
+	mu    sync.RWMutex
+	hooks map[string]DrawHook
+}
+
+// Global is the registry entities check when they are drawn
+var Global = NewRegistry()
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{hooks: make(map[string]DrawHook)}
+}
+
+// Register sets the hook drawing entities of entityType, replacing any
+// hook already registered. A nil hook removes it.
+func (r *Registry) Register(entityType string, hook DrawHook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if hook == nil {
+		delete(r.hooks, entityType)
+		return
+	}
+	r.hooks[entityType] = hook
+}
+
+// Get returns the hook registered for entityType
+func (r *Registry) Get(entityType string) (DrawHook, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	hook, ok := r.hooks[entityType]
+	return hook, ok
+}
+
+// Draw calls the global hook for entityType on entity, returning false if
+// there is none and the entity should draw itself
+func Draw(entityType string, entity tl.Drawable, screen *tl.Screen) bool {
+	hook, ok := Global.Get(entityType)
+	if !ok {
+		return false
+	}
+	hook(entity, screen)
+	return true
+}
@@ -0,0 +1,22 @@
+package hooks
+
+import (
+	"testing"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+func TestRegistryRegisterAndRemove(t *testing.T) {
+	registry := NewRegistry()
+	if _, ok := registry.Get("Mech"); ok {
+		t.Fatal("empty registry returned a hook")
+	}
+	registry.Register("Mech", func(entity tl.Drawable, screen *tl.Screen) {})
+	if _, ok := registry.Get("Mech"); !ok {
+		t.Error("registered hook was not found")
+	}
+	registry.Register("Mech", nil)
+	if _, ok := registry.Get("Mech"); ok {
+		t.Error("hook was not removed")
+	}
+}
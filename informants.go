@@ -0,0 +1,126 @@
+package main
+
+import (
+    "errors"
+    "strconv"
+
+    "github.com/Ariemeth/frame_assault/eventbus"
+    "github.com/Ariemeth/frame_assault/intel"
+    "github.com/Ariemeth/frame_assault/mech"
+    "github.com/Ariemeth/frame_assault/util"
+    tl "github.com/Ariemeth/termloop"
+)
+
+const (
+    // informantRange is how close the player must be to a civilian to ask
+    // them for intel, in cells
+    informantRange = 2
+    // civilianSensorRadius is how far civilians can see enemies, in cells
+    civilianSensorRadius = 15
+)
+
+// errTooFar is returned when the player is too far away to be heard
+var errTooFar = errors.New("cannot hear you from there")
+
+// ProvideIntel tips off the player at playerX,playerY about the nearest
+// enemy the user can see, as long as the city is friendly to the player
+func (c *ComputerUserEntity) ProvideIntel(playerX, playerY int) (*intel.TipOff, error) {
+    x, y := c.Position()
+    if informantDistance(x, y, playerX, playerY) > informantRange*informantRange {
+        return nil, errTooFar
+    }
+    karma := 0
+    if c.buildings != nil {
+        karma = c.buildings.Karma()
+    }
+    if err := intel.CheckAttitude(karma); err != nil {
+        return nil, err
+    }
+    if c.level == nil {
+        return nil, intel.ErrNothingSeen
+    }
+    contacts := make([]intel.Contact, 0)
+    for _, entity := range c.level.Entities {
+        if enemy, ok := entity.(*mech.EnemyMech); ok && !enemy.IsDestroyed() {
+            contacts = append(contacts, enemy)
+        }
+    }
+    return intel.Spot(x, y, civilianSensorRadius, contacts)
+}
+
+// informantDistance returns the squared distance between a civilian at
+// x1,y1 and the player at x2,y2
+func informantDistance(x1, y1, x2, y2 int) int {
+    dx, dy := x1-x2, y1-y2
+    return dx*dx + dy*dy
+}
+
+// informants sell the player tips on where enemies are. Pressing Ctrl+T
+// beside a civilian offers them a safety guarantee in return for a tip,
+// which is published for the mini map to mark.
+type informants struct {
+    player    *mech.PlayerMech
+    civilians []*ComputerUserEntity
+    bus       *eventbus.Bus
+    notifier  util.Notifier
+}
+
+// newInformants creates the informants among civilians that player can buy
+// tips from, publishing the tips on bus
+func newInformants(player *mech.PlayerMech, civilians []*ComputerUserEntity, bus *eventbus.Bus,
+    notifier util.Notifier) *informants {
+    return &informants{player: player, civilians: civilians, bus: bus, notifier: notifier}
+}
+
+// nearest returns the civilian closest to x,y within informantRange, nil
+// if there is none
+func (i *informants) nearest(x, y int) *ComputerUserEntity {
+    var nearest *ComputerUserEntity
+    bestDistance := 0
+    for _, civilian := range i.civilians {
+        cx, cy := civilian.Position()
+        distance := informantDistance(cx, cy, x, y)
+        if distance > informantRange*informantRange || civilian.inside != 0 {
+            continue
+        }
+        if nearest == nil || distance < bestDistance {
+            nearest, bestDistance = civilian, distance
+        }
+    }
+    return nearest
+}
+
+// buyIntel offers the nearest civilian a safety guarantee for a tip
+func (i *informants) buyIntel() {
+    x, y := i.player.Position()
+    civilian := i.nearest(x, y)
+    if civilian == nil {
+        i.notifier.AddMessage("No one nearby to ask for intel")
+        return
+    }
+    if i.player.BountyPoints() < intel.SafetyGuaranteeCost {
+        i.notifier.AddMessage("A safety guarantee costs " + strconv.Itoa(intel.SafetyGuaranteeCost))
+        return
+    }
+    tip, err := civilian.ProvideIntel(x, y)
+    if err != nil {
+        i.notifier.AddMessage(civilian.user.Name + " " + err.Error())
+        return
+    }
+    i.player.AddBountyPoints(-intel.SafetyGuaranteeCost)
+    i.bus.Publish(*tip)
+    i.notifier.AddMessage(civilian.user.Name + ": \"I saw " + string(tip.EnemyID) + " near " +
+        strconv.Itoa(tip.LastKnownX) + "," + strconv.Itoa(tip.LastKnownY) + "\"")
+    logger.Info("intel bought", "event_type", "intel", "informant", civilian.user.Name,
+        "enemy", string(tip.EnemyID), "confidence", tip.Confidence)
+}
+
+// Draw does nothing, tips are shown on the mini map
+func (i *informants) Draw(screen *tl.Screen) {}
+
+// Tick buys intel when Ctrl+T is pressed
+func (i *informants) Tick(event tl.Event) {
+    if event.Type == tl.EventKey && event.Key == tl.KeyCtrlT {
+        i.buyIntel()
+    }
+}
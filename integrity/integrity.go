@@ -0,0 +1,49 @@
+// Package integrity signs saved game state so tampering with it can be
+// detected
+package integrity
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/Ariemeth/frame_assault/worldstate"
+)
+
+// OfflineSecret signs state during offline play, when there is no server
+// to hand out a secret
+const OfflineSecret = "frame-assault-offline"
+
+// ComputeHash returns the hex encoded HMAC-SHA256 of state's JSON, keyed
+// with secret, leaving out any signature the state already carries. Maps
+// encode with their keys sorted, so equal states always hash the same.
+func ComputeHash(state *worldstate.WorldState, secret string) string {
+	unsigned := *state
+	unsigned.Integrity = ""
+	data, err := json.Marshal(&unsigned)
+	if err != nil {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify returns true if hash is the hash of state signed with secret
+func Verify(state *worldstate.WorldState, hash, secret string) bool {
+	expected := ComputeHash(state, secret)
+	return expected != "" && hmac.Equal([]byte(expected), []byte(hash))
+}
+
+// Sign stores the hash of state signed with secret in the state, ready to
+// be saved
+func Sign(state *worldstate.WorldState, secret string) {
+	state.Integrity = ComputeHash(state, secret)
+}
+
+// Check returns true if the signature stored in state matches the rest of
+// it
+func Check(state *worldstate.WorldState, secret string) bool {
+	return Verify(state, state.Integrity, secret)
+}
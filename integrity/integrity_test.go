@@ -0,0 +1,72 @@
+package integrity
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/worldstate"
+)
+
+// newState creates a world state with every field filled in
+func newState() *worldstate.WorldState {
+	state := worldstate.New()
+	state.Record(1, 40)
+	state.Record(2, 0)
+	state.Inventory = []worldstate.SavedItem{{Kind: "armor", Value: "Plating"}}
+	state.InstalledParts = []worldstate.SavedPart{{Type: "armor", Effect: "+10 structure", Applied: true}}
+	state.Waypoints = []worldstate.SavedWaypoint{{Name: "home", X: 4, Y: 7}}
+	return state
+}
+
+func TestOriginalHashVerifies(t *testing.T) {
+	state := newState()
+	hash := ComputeHash(state, OfflineSecret)
+	if !Verify(state, hash, OfflineSecret) {
+		t.Error("hash of an untouched state does not verify")
+	}
+	if Verify(state, hash, "another secret") {
+		t.Error("hash verifies with the wrong secret")
+	}
+}
+
+func TestSignedStateSurvivesSaving(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "world_state.json")
+	state := newState()
+	Sign(state, OfflineSecret)
+	if err := worldstate.Save(path, state); err != nil {
+		t.Fatalf("saving the signed state: %v", err)
+	}
+
+	loaded, err := worldstate.Load(path)
+	if err != nil {
+		t.Fatalf("loading the signed state: %v", err)
+	}
+	if !Check(loaded, OfflineSecret) {
+		t.Error("signed state failed its check after a save and load")
+	}
+	loaded.Structures[1] = 100
+	if Check(loaded, OfflineSecret) {
+		t.Error("state edited after saving passed its check")
+	}
+}
+
+func TestModifiedStateChangesHash(t *testing.T) {
+	hash := ComputeHash(newState(), OfflineSecret)
+	edits := map[string]func(*worldstate.WorldState){
+		"structures":      func(s *worldstate.WorldState) { s.Structures[1] = 41 },
+		"destroyed":       func(s *worldstate.WorldState) { s.Destroyed[1] = true },
+		"inventory":       func(s *worldstate.WorldState) { s.Inventory[0].Value = "Servos" },
+		"installed parts": func(s *worldstate.WorldState) { s.InstalledParts[0].Applied = false },
+		"waypoints":       func(s *worldstate.WorldState) { s.Waypoints[0].X = 5 },
+	}
+	for field, edit := range edits {
+		state := newState()
+		edit(state)
+		if ComputeHash(state, OfflineSecret) == hash {
+			t.Errorf("changing the %s left the hash unchanged", field)
+		}
+		if Verify(state, hash, OfflineSecret) {
+			t.Errorf("state with changed %s verifies against the original hash", field)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+// Package intel lets civilians tell the player where they have seen enemy
+// mechs, in return for the player's protection
+package intel
+
+import (
+	"errors"
+	"math"
+
+	"github.com/Ariemeth/frame_assault/damagelog"
+)
+
+const (
+	// SafetyGuaranteeCost is the bounty points a civilian asks to be kept
+	// safe in return for a tip
+	SafetyGuaranteeCost = 200
+	// FriendlyKarma is the karma from which civilians will deal with the
+	// player
+	FriendlyKarma = 0
+	// HostileKarma is the karma below which civilians refuse to talk at all
+	HostileKarma = -30
+	// FadeConfidence is the confidence below which a tip is shown faded
+	FadeConfidence = 0.5
+	// minConfidence is the confidence in a sighting at the edge of sight
+	minConfidence = 0.1
+)
+
+var (
+	// ErrHostile is returned when the city has turned against the player
+	ErrHostile = errors.New("refuses to talk to you")
+	// ErrWary is returned when civilians do not yet trust the player
+	ErrWary = errors.New("does not trust you enough")
+	// ErrNothingSeen is returned when no enemy is in sight
+	ErrNothingSeen = errors.New("has not seen any enemies")
+)
+
+// TipOff is where a civilian last saw an enemy mech, and how sure they are
+type TipOff struct {
+	EnemyID    damagelog.EntityID
+	LastKnownX int
+	LastKnownY int
+	Confidence float64
+}
+
+// Contact is an enemy a civilian may have seen
+type Contact interface {
+	Name() string
+	Position() (int, int)
+}
+
+// CheckAttitude returns nil if civilians will trade intel with a player
+// who has earned karma
+func CheckAttitude(karma int) error {
+	switch {
+	case karma < HostileKarma:
+		return ErrHostile
+	case karma < FriendlyKarma:
+		return ErrWary
+	}
+	return nil
+}
+
+// Spot returns a tip on the nearest of contacts within sensorRadius of
+// x,y. Confidence falls from 1 beside the civilian to minConfidence at the
+// edge of their sight.
+func Spot(x, y, sensorRadius int, contacts []Contact) (*TipOff, error) {
+	var nearest Contact
+	bestDistance := 0.0
+	for _, contact := range contacts {
+		cx, cy := contact.Position()
+		distance := math.Hypot(float64(cx-x), float64(cy-y))
+		if distance > float64(sensorRadius) {
+			continue
+		}
+		if nearest == nil || distance < bestDistance {
+			nearest, bestDistance = contact, distance
+		}
+	}
+	if nearest == nil {
+		return nil, ErrNothingSeen
+	}
+	confidence := 1.0
+	if sensorRadius > 0 {
+		confidence -= (1 - minConfidence) * bestDistance / float64(sensorRadius)
+	}
+	cx, cy := nearest.Position()
+	return &TipOff{
+		EnemyID:    damagelog.EntityID(nearest.Name()),
+		LastKnownX: cx,
+		LastKnownY: cy,
+		Confidence: confidence,
+	}, nil
+}
@@ -0,0 +1,37 @@
+package intel
+
+import (
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/mech"
+	"github.com/Ariemeth/frame_assault/mech/movement"
+	tl "github.com/Ariemeth/termloop"
+)
+
+func TestTipMatchesEnemyInSensorRange(t *testing.T) {
+	near := mech.NewEnemyMech("Mech A", 5, 13, 14, tl.ColorRed, 'A', movement.NewRandomWalkStrategy())
+	far := mech.NewEnemyMech("Mech B", 5, 40, 40, tl.ColorRed, 'B', movement.NewRandomWalkStrategy())
+
+	tip, err := Spot(10, 10, 8, []Contact{far, near})
+	if err != nil {
+		t.Fatalf("no tip on an enemy in sight: %v", err)
+	}
+	if tip.EnemyID != "Mech A" || tip.LastKnownX != 13 || tip.LastKnownY != 14 {
+		t.Errorf("tip is %+v, want Mech A at 13,14", tip)
+	}
+	if tip.Confidence <= minConfidence || tip.Confidence >= 1 {
+		t.Errorf("confidence %.2f in a sighting 5 cells away, want between %.1f and 1", tip.Confidence, minConfidence)
+	}
+
+	if _, err := Spot(10, 10, 8, []Contact{far}); err != ErrNothingSeen {
+		t.Errorf("tip on an enemy out of sight returned %v, want ErrNothingSeen", err)
+	}
+}
+
+func TestHostileCiviliansRefuse(t *testing.T) {
+	for karma, want := range map[int]error{0: nil, 50: nil, -10: ErrWary, -31: ErrHostile} {
+		if err := CheckAttitude(karma); err != want {
+			t.Errorf("attitude at karma %d is %v, want %v", karma, err, want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+// Package layout fits the city to the terminal it is played in
+package layout
+
+import "math"
+
+const (
+	// MinWidth and MinHeight are the smallest terminal the game is playable in
+	MinWidth  = 40
+	MinHeight = 20
+)
+
+// Scaler shrinks level dimensions to fit a terminal, keeping their aspect
+// ratio. Levels that already fit are left at their full size.
+type Scaler struct {
+	termW, termH int
+}
+
+// NewScaler creates a scaler for a terminal termW columns by termH rows
+func NewScaler(termW, termH int) Scaler {
+	return Scaler{termW: termW, termH: termH}
+}
+
+// TooSmall returns true if the terminal is below the minimum playable size
+func (s Scaler) TooSmall() bool {
+	return s.termW < MinWidth || s.termH < MinHeight
+}
+
+// factor returns how much a baseW by baseH level must shrink to fit the
+// terminal, 1 if it already fits or the terminal size is unknown
+func (s Scaler) factor(baseW, baseH int) float64 {
+	if s.termW <= 0 || s.termH <= 0 || baseW <= 0 || baseH <= 0 {
+		return 1
+	}
+	return math.Min(1, math.Min(float64(s.termW)/float64(baseW), float64(s.termH)/float64(baseH)))
+}
+
+// ScaleLevel returns the size of a baseW by baseH level shrunk by the same
+// factor on both axes until it fits the terminal
+func (s Scaler) ScaleLevel(baseW, baseH int) (scaledW, scaledH int) {
+	f := s.factor(baseW, baseH)
+	return scale(baseW, f), scale(baseH, f)
+}
+
+// ScaleLength shrinks a length within a baseW by baseH level, such as the
+// spacing of its roads or the size of its buildings, by the same factor as
+// ScaleLevel so the city keeps roughly the same density. Lengths never
+// shrink below 1.
+func (s Scaler) ScaleLength(length, baseW, baseH int) int {
+	return scale(length, s.factor(baseW, baseH))
+}
+
+// scale multiplies n by f, rounding to the nearest whole cell but no
+// smaller than 1
+func scale(n int, f float64) int {
+	scaled := int(math.Round(float64(n) * f))
+	if scaled < 1 {
+		return 1
+	}
+	return scaled
+}
@@ -0,0 +1,32 @@
+package layout
+
+import "testing"
+
+func TestScaleLevel(t *testing.T) {
+	tests := []struct {
+		name          string
+		termW, termH  int
+		wantW, wantH  int
+		wantBuildingW int
+		wantTooSmall  bool
+	}{
+		{"large terminal keeps the full level", 200, 80, 100, 60, 8, false},
+		{"80x24 terminal is limited by its height", 80, 24, 40, 24, 3, false},
+		{"tiny terminal is still scaled but too small", 30, 15, 25, 15, 2, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := NewScaler(test.termW, test.termH)
+			w, h := s.ScaleLevel(100, 60)
+			if w != test.wantW || h != test.wantH {
+				t.Errorf("scaled level is %dx%d, want %dx%d", w, h, test.wantW, test.wantH)
+			}
+			if got := s.ScaleLength(8, 100, 60); got != test.wantBuildingW {
+				t.Errorf("scaled building width is %d, want %d", got, test.wantBuildingW)
+			}
+			if s.TooSmall() != test.wantTooSmall {
+				t.Errorf("TooSmall is %v, want %v", s.TooSmall(), test.wantTooSmall)
+			}
+		})
+	}
+}
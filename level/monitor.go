@@ -0,0 +1,116 @@
+// Package level watches the game level for problems that build up over a
+// long game
+package level
+
+import (
+	"log/slog"
+
+	"github.com/Ariemeth/frame_assault/lifecycle"
+	"github.com/Ariemeth/frame_assault/projectile"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// DefaultWarnThreshold is the entity count above which a warning is logged
+	DefaultWarnThreshold = 200
+	// DefaultHardLimit is the entity count above which bullets are culled
+	DefaultHardLimit = 500
+)
+
+// EntityCountMonitor keeps an eye on how many entities are in a level.
+// Above the warn threshold it logs a warning, once until the count drops
+// back, and above the hard limit it removes the oldest bullets until the
+// count is back under the limit. Mechs, buildings and everything else that
+// is not a bullet are never removed.
+type EntityCountMonitor struct {
+	level         *tl.BaseLevel
+	logger        *slog.Logger
+	warnThreshold int
+	hardLimit     int
+	warned        bool
+}
+
+// NewEntityCountMonitor creates a monitor of level logging to logger with
+// the default thresholds
+func NewEntityCountMonitor(level *tl.BaseLevel, logger *slog.Logger) *EntityCountMonitor {
+	return &EntityCountMonitor{
+		level:         level,
+		logger:        logger,
+		warnThreshold: DefaultWarnThreshold,
+		hardLimit:     DefaultHardLimit,
+	}
+}
+
+// SetThresholds sets the counts above which a warning is logged and above
+// which bullets are culled
+func (m *EntityCountMonitor) SetThresholds(warn, hard int) {
+	m.warnThreshold = warn
+	m.hardLimit = hard
+}
+
+// Count returns the number of entities in the level
+func (m *EntityCountMonitor) Count() int {
+	return len(m.level.Entities)
+}
+
+// AddEntity adds d to the level and checks the new entity count
+func (m *EntityCountMonitor) AddEntity(d tl.Drawable) {
+	m.level.AddEntity(d)
+	m.check()
+}
+
+// check warns when the count first passes the warn threshold and culls
+// bullets while it is over the hard limit
+func (m *EntityCountMonitor) check() {
+	count := m.Count()
+	if count <= m.warnThreshold {
+		m.warned = false
+	} else if !m.warned {
+		m.warned = true
+		if m.logger != nil {
+			m.logger.Warn("entity count over threshold",
+				"event_type", "entity_count",
+				"count", count,
+				"threshold", m.warnThreshold)
+		}
+	}
+	if count > m.hardLimit {
+		m.cull(count - m.hardLimit)
+	}
+}
+
+// cull removes up to n bullets from the level, oldest first, and returns
+// how many were removed
+func (m *EntityCountMonitor) cull(n int) int {
+	bullets := make([]*projectile.Bullet, 0, n)
+	for _, entity := range m.level.Entities {
+		if len(bullets) == n {
+			break
+		}
+		if bullet, ok := entity.(*projectile.Bullet); ok {
+			bullets = append(bullets, bullet)
+		}
+	}
+	for _, bullet := range bullets {
+		bullet.Cull()
+		lifecycle.RemoveEntity(m.level, bullet)
+	}
+	if len(bullets) > 0 && m.logger != nil {
+		m.logger.Warn("culled bullets over entity limit",
+			"event_type", "entity_cull",
+			"culled", len(bullets),
+			"limit", m.hardLimit)
+	}
+	return len(bullets)
+}
+
+// Tick checks the entity count once per frame, catching entities added
+// straight to the level
+func (m *EntityCountMonitor) Tick(event tl.Event) {
+	if event.Type == tl.EventNone {
+		m.check()
+	}
+}
+
+// Draw does nothing, the monitor only logs
+func (m *EntityCountMonitor) Draw(screen *tl.Screen) {}
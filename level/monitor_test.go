@@ -0,0 +1,55 @@
+package level
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/projectile"
+	tl "github.com/Ariemeth/termloop"
+)
+
+func TestEntityCountMonitorWarnsOnce(t *testing.T) {
+	var out bytes.Buffer
+	level := tl.NewBaseLevel(tl.Cell{})
+	monitor := NewEntityCountMonitor(level, slog.New(slog.NewTextHandler(&out, nil)))
+
+	for i := 0; i < 210; i++ {
+		monitor.AddEntity(tl.NewEntity(i, 0, 1, 1))
+	}
+	monitor.Tick(tl.Event{Type: tl.EventNone})
+
+	if monitor.Count() != 210 {
+		t.Errorf("count is %d, want 210", monitor.Count())
+	}
+	if warnings := strings.Count(out.String(), "entity count over threshold"); warnings != 1 {
+		t.Errorf("warned %d times, want once:\n%s", warnings, out.String())
+	}
+}
+
+func TestEntityCountMonitorCullsOldestBullets(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	monitor := NewEntityCountMonitor(level, nil)
+	monitor.SetThresholds(2, 4)
+
+	first := projectile.NewBullet(0, 0, 10, 0, projectile.DefaultColor, projectile.DefaultSymbol, level)
+	monitor.AddEntity(first)
+	mech := tl.NewEntity(0, 0, 1, 1)
+	monitor.AddEntity(mech)
+	for i := 0; i < 3; i++ {
+		monitor.AddEntity(projectile.NewBullet(0, 0, 10, 0, projectile.DefaultColor, projectile.DefaultSymbol, level))
+	}
+
+	if monitor.Count() != 4 {
+		t.Fatalf("count is %d, want the hard limit of 4", monitor.Count())
+	}
+	for _, entity := range level.Entities {
+		if entity == first {
+			t.Error("the oldest bullet was not culled")
+		}
+	}
+	if level.Entities[0] != mech {
+		t.Error("a non bullet entity was culled")
+	}
+}
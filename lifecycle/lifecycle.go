@@ -0,0 +1,126 @@
+// Package lifecycle lets tests, mods and analytics watch entities being
+// created, ticked and destroyed without changing the entities' code.
+// Hooks are called synchronously from the constructor, Tick or removal
+// that triggers them:
+//
+//	OnCreate   mechs, bullets, buildings, vehicles, snares, supply drops,
+//	           waypoints and civilians as they are made
+//	OnTick     the same entities every time they tick
+//	OnDestroy  any entity removed from a level with RemoveEntity
+package lifecycle
+
+import (
+	"sync"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+// Hooks are the callbacks told about an entity's lifecycle. Any may be nil.
+type Hooks struct {
+	OnCreate  func(entity tl.Drawable)
+	OnTick    func(entity tl.Drawable)
+	OnDestroy func(entity tl.Drawable)
+}
+
+// Registry holds the hooks watching every entity
+type Registry struct {
+	mu    sync.RWMutex
+	hooks Hooks
+}
+
+// Global is the registry entities report to unless given their own hooks
+var Global = &Registry{}
+
+// Register sets the hooks, replacing any already registered. Zero Hooks
+// removes them.
+func (r *Registry) Register(hooks Hooks) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hooks = hooks
+}
+
+// Hooks returns the registered hooks
+func (r *Registry) Hooks() Hooks {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.hooks
+}
+
+// Observer calls the hooks watching a single entity, the global ones
+// unless WithHooks overrides them
+type Observer struct {
+	hooks *Hooks
+}
+
+// Option changes the hooks an Observer calls
+type Option func(*Observer)
+
+// WithHooks watches an entity with hooks in place of the global ones
+func WithHooks(hooks Hooks) Option {
+	return func(o *Observer) {
+		o.hooks = &hooks
+	}
+}
+
+// NewObserver creates an observer with opts applied
+func NewObserver(opts ...Option) Observer {
+	var o Observer
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Hooks returns the hooks the observer calls
+func (o Observer) Hooks() Hooks {
+	if o.hooks != nil {
+		return *o.hooks
+	}
+	return Global.Hooks()
+}
+
+// observed is implemented by entities with their own observer
+type observed interface {
+	LifecycleObserver() Observer
+}
+
+// hooksFor returns the hooks watching entity
+func hooksFor(entity tl.Drawable) Hooks {
+	if o, ok := entity.(observed); ok {
+		return o.LifecycleObserver().Hooks()
+	}
+	return Global.Hooks()
+}
+
+// NotifyCreate calls the OnCreate hook watching entity
+func NotifyCreate(entity tl.Drawable) {
+	if hook := hooksFor(entity).OnCreate; hook != nil {
+		hook(entity)
+	}
+}
+
+// NotifyTick calls the OnTick hook watching entity
+func NotifyTick(entity tl.Drawable) {
+	if hook := hooksFor(entity).OnTick; hook != nil {
+		hook(entity)
+	}
+}
+
+// NotifyDestroy calls the OnDestroy hook watching entity
+func NotifyDestroy(entity tl.Drawable) {
+	if hook := hooksFor(entity).OnDestroy; hook != nil {
+		hook(entity)
+	}
+}
+
+// remover is a level entities can be removed from
+type remover interface {
+	RemoveEntity(d tl.Drawable)
+}
+
+// RemoveEntity removes entity from level and calls the OnDestroy hook
+// watching it
+func RemoveEntity(level remover, entity tl.Drawable) {
+	level.RemoveEntity(entity)
+	NotifyDestroy(entity)
+}
@@ -0,0 +1,40 @@
+// Package logging provides structured logging for game events
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// Supported log output formats
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Logger wraps slog.Logger to emit structured game events
+type Logger struct {
+	*slog.Logger
+}
+
+// NewJSONLogger creates a Logger that writes machine-readable JSON lines to w
+func NewJSONLogger(w io.Writer) *Logger {
+	return &Logger{Logger: slog.New(slog.NewJSONHandler(w, nil))}
+}
+
+// NewTextLogger creates a Logger that writes human-readable key=value lines to w
+func NewTextLogger(w io.Writer) *Logger {
+	return &Logger{Logger: slog.New(slog.NewTextHandler(w, nil))}
+}
+
+// New creates a Logger for the named format writing to w
+func New(format string, w io.Writer) (*Logger, error) {
+	switch format {
+	case FormatJSON:
+		return NewJSONLogger(w), nil
+	case FormatText:
+		return NewTextLogger(w), nil
+	}
+	return nil, fmt.Errorf("unknown log format %q, expected %s or %s", format, FormatJSON, FormatText)
+}
@@ -0,0 +1,49 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestJSONLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewJSONLogger(&buf)
+
+	logger.Info("event", "mech_name", "testMech", "position_x", 3)
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("json logger wrote invalid json %q: %v", buf.String(), err)
+	}
+	if entry["mech_name"] != "testMech" {
+		t.Errorf("mech_name is %v instead of testMech", entry["mech_name"])
+	}
+	if entry["position_x"] != float64(3) {
+		t.Errorf("position_x is %v instead of 3", entry["position_x"])
+	}
+}
+
+func TestTextLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewTextLogger(&buf)
+
+	logger.Info("event", "mech_name", "testMech")
+
+	if !strings.Contains(buf.String(), "mech_name=testMech") {
+		t.Errorf("text logger output %q is missing mech_name=testMech", buf.String())
+	}
+}
+
+func TestNewUnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := New("xml", &buf); err == nil {
+		t.Errorf("New accepted unknown format xml")
+	}
+	for _, format := range []string{FormatJSON, FormatText} {
+		if _, err := New(format, &buf); err != nil {
+			t.Errorf("New rejected format %s: %v", format, err)
+		}
+	}
+}
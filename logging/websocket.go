@@ -0,0 +1,231 @@
+package logging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/Ariemeth/frame_assault/eventbus"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// wsQueueSize is how many events can wait to be sent before new ones
+	// are dropped
+	wsQueueSize = 1000
+	// wsMaxPending is how many events are kept while disconnected, oldest
+	// dropped first
+	wsMaxPending = 10000
+
+	// Reconnection backoff bounds
+	wsMinBackoff = 100 * time.Millisecond
+	wsMaxBackoff = 5 * time.Second
+	// wsWriteTimeout bounds how long a single send may take
+	wsWriteTimeout = 2 * time.Second
+)
+
+// wsMessage is the JSON shape of each streamed event
+type wsMessage struct {
+	Timestamp int64       `json:"timestamp"`
+	Type      string      `json:"type"`
+	Data      interface{} `json:"data"`
+}
+
+// WebSocketLogger streams every event published on the event bus to a
+// WebSocket server as JSON. Events are sent from a background goroutine
+// so the game loop never waits on the network, and are held in memory
+// while the connection is down.
+type WebSocketLogger struct {
+	url     string
+	events  chan []byte
+	flushes chan chan error
+	done    chan struct{}
+	wg      sync.WaitGroup
+
+	// Owned by the sending goroutine
+	conn    *websocket.Conn
+	pending [][]byte
+	backoff time.Duration
+	retryAt time.Time
+
+	mu      sync.Mutex
+	dropped int
+	closed  bool
+}
+
+// NewWebSocketLogger creates a logger streaming events to the WebSocket
+// server at url. The connection is made in the background and retried
+// with backoff whenever it is lost.
+func NewWebSocketLogger(url string) *WebSocketLogger {
+	w := &WebSocketLogger{
+		url:     url,
+		events:  make(chan []byte, wsQueueSize),
+		flushes: make(chan chan error),
+		done:    make(chan struct{}),
+		backoff: wsMinBackoff,
+	}
+	w.wg.Add(1)
+	go w.run()
+	return w
+}
+
+// HandleEvent queues event to be sent, dropping it if the queue is full
+func (w *WebSocketLogger) HandleEvent(event eventbus.Event) {
+	message, err := json.Marshal(wsMessage{
+		Timestamp: time.Now().UnixMilli(),
+		Type:      eventType(event),
+		Data:      event,
+	})
+	if err != nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return
+	}
+	select {
+	case w.events <- message:
+	default:
+		w.dropped++
+	}
+}
+
+// Dropped returns how many events were lost to a full queue or buffer
+func (w *WebSocketLogger) Dropped() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.dropped
+}
+
+// Flush sends every queued event, connecting first if needed. Returns an
+// error if any event could not be delivered.
+func (w *WebSocketLogger) Flush() error {
+	result := make(chan error)
+	select {
+	case w.flushes <- result:
+		return <-result
+	case <-w.done:
+		return errors.New("websocket logger is closed")
+	}
+}
+
+// Close stops streaming and closes the connection. Events not yet
+// flushed are discarded.
+func (w *WebSocketLogger) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	w.closed = true
+	close(w.done)
+	w.mu.Unlock()
+
+	w.wg.Wait()
+	if w.conn != nil {
+		return w.conn.Close()
+	}
+	return nil
+}
+
+// run sends queued events until the logger is closed
+func (w *WebSocketLogger) run() {
+	defer w.wg.Done()
+	for {
+		var retry <-chan time.Time
+		if len(w.pending) > 0 {
+			retry = time.After(time.Until(w.retryAt))
+		}
+		select {
+		case message := <-w.events:
+			w.buffer(message)
+			w.send(false)
+		case <-retry:
+			w.send(false)
+		case result := <-w.flushes:
+			w.drain()
+			result <- w.send(true)
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// buffer holds message until it can be sent
+func (w *WebSocketLogger) buffer(message []byte) {
+	w.pending = append(w.pending, message)
+	if over := len(w.pending) - wsMaxPending; over > 0 {
+		w.pending = w.pending[over:]
+		w.mu.Lock()
+		w.dropped += over
+		w.mu.Unlock()
+	}
+}
+
+// drain moves every queued event into the pending buffer
+func (w *WebSocketLogger) drain() {
+	for {
+		select {
+		case message := <-w.events:
+			w.buffer(message)
+		default:
+			return
+		}
+	}
+}
+
+// send writes the pending events, reconnecting when the backoff allows or
+// force is set
+func (w *WebSocketLogger) send(force bool) error {
+	if len(w.pending) == 0 {
+		return nil
+	}
+	if w.conn == nil {
+		if !force && time.Now().Before(w.retryAt) {
+			return nil
+		}
+		conn, _, err := websocket.DefaultDialer.Dial(w.url, nil)
+		if err != nil {
+			w.retryLater()
+			return fmt.Errorf("error connecting to %s: %v", w.url, err)
+		}
+		w.conn = conn
+		w.backoff = wsMinBackoff
+	}
+	for len(w.pending) > 0 {
+		w.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+		if err := w.conn.WriteMessage(websocket.TextMessage, w.pending[0]); err != nil {
+			w.conn.Close()
+			w.conn = nil
+			w.retryLater()
+			return fmt.Errorf("error sending event: %v", err)
+		}
+		w.pending = w.pending[1:]
+	}
+	return nil
+}
+
+// retryLater schedules the next connection attempt, doubling the backoff
+func (w *WebSocketLogger) retryLater() {
+	w.retryAt = time.Now().Add(w.backoff)
+	w.backoff *= 2
+	if w.backoff > wsMaxBackoff {
+		w.backoff = wsMaxBackoff
+	}
+}
+
+// eventType names an event by its Go type
+func eventType(event eventbus.Event) string {
+	t := reflect.TypeOf(event)
+	if t == nil {
+		return "nil"
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
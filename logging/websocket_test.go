@@ -0,0 +1,81 @@
+package logging
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Ariemeth/frame_assault/eventbus"
+	"github.com/gorilla/websocket"
+)
+
+func TestWebSocketLoggerStreamsEvents(t *testing.T) {
+	received := make(chan []byte, 10)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			_, message, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			received <- message
+		}
+	}))
+	defer server.Close()
+
+	logger := NewWebSocketLogger("ws" + strings.TrimPrefix(server.URL, "http"))
+	defer logger.Close()
+	bus := eventbus.New()
+	bus.Subscribe(logger.HandleEvent)
+
+	before := time.Now().UnixMilli()
+	bus.Publish(eventbus.MechDestroyedEvent{Name: "Enemy A", X: 3, Y: 4})
+	bus.Publish(eventbus.PowerOutageEvent{})
+	if err := logger.Flush(); err != nil {
+		t.Fatalf("flush failed: %v", err)
+	}
+
+	expected := []string{"MechDestroyedEvent", "PowerOutageEvent"}
+	for i, eventType := range expected {
+		var message struct {
+			Timestamp int64                  `json:"timestamp"`
+			Type      string                 `json:"type"`
+			Data      map[string]interface{} `json:"data"`
+		}
+		select {
+		case raw := <-received:
+			if err := json.Unmarshal(raw, &message); err != nil {
+				t.Fatalf("event %d was invalid json %q: %v", i, raw, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("event %d was never received", i)
+		}
+		if message.Type != eventType {
+			t.Errorf("event %d has type %q instead of %q", i, message.Type, eventType)
+		}
+		if message.Timestamp < before {
+			t.Errorf("event %d has timestamp %d before it was published", i, message.Timestamp)
+		}
+		if message.Data == nil {
+			t.Errorf("event %d has no data object", i)
+		}
+	}
+}
+
+func TestWebSocketLoggerBuffersWhileDisconnected(t *testing.T) {
+	logger := NewWebSocketLogger("ws://127.0.0.1:1/unreachable")
+	defer logger.Close()
+
+	logger.HandleEvent(eventbus.ExplosionEvent{X: 1, Y: 2})
+	if err := logger.Flush(); err == nil {
+		t.Errorf("flush to an unreachable server reported success")
+	}
+}
@@ -1,100 +1,68 @@
 package main
 
 import (
+    "context"
+    "errors"
     "flag"
     "fmt"
+    "io"
     "log"
+    "math"
     "math/rand"
+    "net/http"
+    "os"
     "time"
 
+    "github.com/Ariemeth/frame_assault/achievements"
     "github.com/Ariemeth/frame_assault/ai"
+    "github.com/Ariemeth/frame_assault/audio"
+    "github.com/Ariemeth/frame_assault/balance"
+    "github.com/Ariemeth/frame_assault/bestiary"
+    "github.com/Ariemeth/frame_assault/blueprint"
+    "github.com/Ariemeth/frame_assault/bounty"
+    "github.com/Ariemeth/frame_assault/building"
+    "github.com/Ariemeth/frame_assault/camera"
+    "github.com/Ariemeth/frame_assault/challenge"
+    "github.com/Ariemeth/frame_assault/config"
+    "github.com/Ariemeth/frame_assault/coop"
+    "github.com/Ariemeth/frame_assault/cover"
+    "github.com/Ariemeth/frame_assault/damagelog"
+    "github.com/Ariemeth/frame_assault/difficulty"
     "github.com/Ariemeth/frame_assault/display"
+    "github.com/Ariemeth/frame_assault/entities"
+    "github.com/Ariemeth/frame_assault/eventbus"
+    "github.com/Ariemeth/frame_assault/events"
+    "github.com/Ariemeth/frame_assault/fire"
+    "github.com/Ariemeth/frame_assault/fogmemory"
+    "github.com/Ariemeth/frame_assault/hazard"
+    "github.com/Ariemeth/frame_assault/headless"
+    "github.com/Ariemeth/frame_assault/hooks"
+    "github.com/Ariemeth/frame_assault/level"
+    "github.com/Ariemeth/frame_assault/lifecycle"
+    "github.com/Ariemeth/frame_assault/logging"
     "github.com/Ariemeth/frame_assault/mech"
     "github.com/Ariemeth/frame_assault/mech/movement"
     "github.com/Ariemeth/frame_assault/mech/weapon"
+    "github.com/Ariemeth/frame_assault/morale"
+    "github.com/Ariemeth/frame_assault/mission"
+    "github.com/Ariemeth/frame_assault/naming"
+    "github.com/Ariemeth/frame_assault/npc"
+    "github.com/Ariemeth/frame_assault/npcspawn"
+    "github.com/Ariemeth/frame_assault/poi"
+    "github.com/Ariemeth/frame_assault/power"
+    "github.com/Ariemeth/frame_assault/projectile"
+    "github.com/Ariemeth/frame_assault/replay"
+    "github.com/Ariemeth/frame_assault/roadgraph"
+    "github.com/Ariemeth/frame_assault/spawnzones"
+    "github.com/Ariemeth/frame_assault/statecheck"
+    "github.com/Ariemeth/frame_assault/stats"
+    "github.com/Ariemeth/frame_assault/timing"
+    "github.com/Ariemeth/frame_assault/util"
+    "github.com/Ariemeth/frame_assault/util/debug"
+    "github.com/Ariemeth/frame_assault/waves"
     tl "github.com/Ariemeth/termloop"
 )
 
-// BuildingType represents different types of buildings
-type BuildingType struct {
-    name     string
-    color    tl.Attr
-    char     rune
-    maxCount int
-}
-
-var buildingTypes = []BuildingType{
-    {"Hospital", tl.ColorRed, 'H', 1},
-    {"School", tl.ColorYellow, 'S', 2},
-    {"Bank", tl.ColorGreen, 'B', 2},
-    {"Grocery", tl.ColorCyan, 'G', 3},
-    {"Police", tl.ColorBlue, 'P', 2},
-    {"Library", tl.ColorMagenta, 'L', 2},
-    {"Mall", tl.ColorWhite, 'M', 2},
-    {"Restaurant", tl.ColorRed, 'R', 4},
-    {"Theater", tl.ColorYellow, 'T', 2},
-    {"Gym", tl.ColorGreen, 'Y', 3},
-    {"Home", tl.ColorWhite, 'H', 8}, // Adding residential homes
-}
-
-// Building represents a city building with a specific purpose
-type Building struct {
-    *tl.Entity
-    buildingType BuildingType
-    width        int
-    height       int
-}
-
-func NewBuilding(x, y, width, height int, buildingType BuildingType) *Building {
-    building := &Building{
-        Entity:       tl.NewEntity(x, y, width, height),
-        buildingType: buildingType,
-        width:        width,
-        height:       height,
-    }
-    return building
-}
-
-func (b *Building) Draw(s *tl.Screen) {
-    x, y := b.Position()
-    
-    // Draw building outline and fill
-    for i := 0; i < b.width; i++ {
-        for j := 0; j < b.height; j++ {
-            // Draw building outline
-            if i == 0 || i == b.width-1 || j == 0 || j == b.height-1 {
-                s.RenderCell(x+i, y+j, &tl.Cell{
-                    Bg: b.buildingType.color,
-                    Fg: tl.ColorBlack,
-                    Ch: '█',
-                })
-            } else {
-                // Fill building interior
-                s.RenderCell(x+i, y+j, &tl.Cell{
-                    Bg: b.buildingType.color,
-                    Fg: tl.ColorBlack,
-                    Ch: ' ',
-                })
-            }
-        }
-    }
-    
-    // Draw building name in the center
-    name := b.buildingType.name
-    startX := x + (b.width-len(name))/2
-    startY := y + b.height/2
-    
-    for i, ch := range name {
-        if startX+i < x+b.width-1 { // Ensure we don't write outside building bounds
-            s.RenderCell(startX+i, startY, &tl.Cell{
-                Bg: b.buildingType.color,
-                Fg: tl.ColorBlack,
-                Ch: ch,
-            })
-        }
-    }
-}
-
 // mechConfig defines the configuration for creating an enemy mech
 type mechConfig struct {
     name     string
@@ -139,7 +107,7 @@ func getValidPatrolPoints(x, y int, level *tl.BaseLevel) ([][2]int, error) {
         }
 
         // Check for collisions with buildings
-        if !hasCollision(point1[0], point1[1], level) && !hasCollision(point2[0], point2[1], level) {
+        if !spawnzones.HasCollision(point1[0], point1[1], level) && !spawnzones.HasCollision(point2[0], point2[1], level) {
             return [][2]int{point1, point2}, nil
         }
     }
@@ -153,77 +121,83 @@ func isPointInBounds(x, y int) bool {
         y >= minCoordinate && y <= maxLevelHeight
 }
 
-// hasCollision checks if a point collides with any physical entity
-func hasCollision(x, y int, level *tl.BaseLevel) bool {
-    for _, entity := range level.Entities {
-        if entity == nil {
-            continue
+// findEnemySpawn picks a starting position beside a road and a movement
+// strategy for an enemy, preferring a patrol route that avoids buildings.
+func findEnemySpawn(r *rand.Rand, game *tl.Game, level *tl.BaseLevel, roads *RoadSystem, snares *entities.SnareManager) (movement.Strategy, int, int) {
+    // Keep trying different positions until we find a valid one
+    var strategy movement.Strategy
+    var finalX, finalY int
+
+    for attempts := 0; attempts < 10; attempts++ {
+        // Start beside a road, or anywhere nearby if none is free
+        x, y, err := spawnzones.FindRoadAdjacentSpawnPoint(roads, level, r)
+        if err != nil {
+            x = -15 + r.Intn(30)
+            y = -15 + r.Intn(30)
         }
 
-        physical, ok := entity.(tl.Physical)
-        if !ok {
+        // Try to get valid patrol points
+        patrolPoints, err := getValidPatrolPoints(x, y, level)
+        if err != nil {
+            if attempts == 9 { // Last attempt, fallback to random walk
+                strategy = movement.NewRandomWalkStrategy()
+                finalX, finalY = x, y // Use last attempted position
+                if game != nil {
+                    game.Log("Failed to find valid patrol points after %d attempts, using random walk", attempts+1)
+                }
+            }
             continue
         }
 
-        // Get entity position and size
-        eX, eY := physical.Position()
-        if eX == x && eY == y {
-            return true
+        // Create patrol strategy with valid points
+        patrolStrategy, err := movement.NewPatrolStrategy(patrolPoints)
+        if err != nil {
+            if game != nil {
+                game.Log("Failed to create patrol strategy: %v, falling back to random walk", err)
+            }
+            strategy = movement.NewRandomWalkStrategy()
+        } else {
+            // Some patrols know where snares have been set and route around them
+            if snares != nil && r.Float64() < snareAwarenessChance {
+                patrolStrategy.SetWalkable(func(x, y int) bool {
+                    return !snares.IsSnared(x, y)
+                })
+            }
+            strategy = patrolStrategy
         }
+        finalX, finalY = x, y // Use position where valid patrol points were found
+        break
     }
-    return false
+
+    // If no strategy was created (shouldn't happen due to random walk fallback)
+    if strategy == nil {
+        strategy = movement.NewRandomWalkStrategy()
+    }
+
+    return strategy, finalX, finalY
 }
 
-// GenerateEnemyMechs creates a slice of mechs to be used as enemies
-func GenerateEnemyMechs(number int, game *tl.Game, level *tl.BaseLevel) []*mech.EnemyMech {
+// GenerateEnemyMechs creates a slice of mechs to be used as enemies.
+// The last enemy is a sniper on overwatch, suppressing the zone around its
+// spawn against the target nearest it.
+func GenerateEnemyMechs(number int, game *tl.Game, level *tl.BaseLevel, roads *RoadSystem, snares *entities.SnareManager, names *naming.Generator,
+    target func(x, y int) weapon.Target) []*mech.EnemyMech {
     enemyMechs := make([]*mech.EnemyMech, number)
     r := rand.New(rand.NewSource(time.Now().UnixNano()))
 
     for i := 0; i < number; i++ {
-        // Keep trying different positions until we find a valid one
-        var strategy movement.Strategy
-        var finalX, finalY int
-
-        for attempts := 0; attempts < 10; attempts++ {
-            // Random starting position
-            x := -15 + r.Intn(30)
-            y := -15 + r.Intn(30)
-
-            // Try to get valid patrol points
-            patrolPoints, err := getValidPatrolPoints(x, y, level)
-            if err != nil {
-                if attempts == 9 { // Last attempt, fallback to random walk
-                    strategy = movement.NewRandomWalkStrategy()
-                    finalX, finalY = x, y // Use last attempted position
-                    if game != nil {
-                        game.Log("Failed to find valid patrol points after %d attempts, using random walk", attempts+1)
-                    }
-                }
-                continue
-            }
-
-            // Create patrol strategy with valid points
-            patrolStrategy, err := movement.NewPatrolStrategy(patrolPoints)
-            if err != nil {
-                if game != nil {
-                    game.Log("Failed to create patrol strategy: %v, falling back to random walk", err)
-                }
-                strategy = movement.NewRandomWalkStrategy()
-            } else {
-                strategy = patrolStrategy
-            }
-            finalX, finalY = x, y // Use position where valid patrol points were found
-            break
-        }
-
-        // If no strategy was created (shouldn't happen due to random walk fallback)
-        if strategy == nil {
-            strategy = movement.NewRandomWalkStrategy()
+        strategy, finalX, finalY := findEnemySpawn(r, game, level, roads, snares)
+        if i == number-1 {
+            strategy = movement.NewOverwatchStrategy([4]int{
+                finalX - sniperZoneRadius, finalY - sniperZoneRadius,
+                finalX + sniperZoneRadius, finalY + sniperZoneRadius,
+            }, func() weapon.Target { return target(finalX, finalY) })
         }
 
         // Create enemy mech using configuration
         config := enemyMechConfigs[i%len(enemyMechConfigs)]
         m := mech.NewEnemyMech(config.name, i, finalX, finalY, tl.ColorRed, config.symbol, strategy)
+        m.SetName(names.NextName(config.symbol))
         m.AddWeapon(config.weapon())
         m.AttachGame(game)
         enemyMechs[i] = m
@@ -232,10 +206,66 @@ func GenerateEnemyMechs(number int, game *tl.Game, level *tl.BaseLevel) []*mech.
     return enemyMechs
 }
 
+// newWaveEnemyFactory returns a factory creating wave reinforcements that
+// report to the given notifier and logger. Reinforcements arrive mid-game
+// so their structure and damage are scaled by the time elapsed.
+func newWaveEnemyFactory(game *tl.Game, level *tl.BaseLevel, roads *RoadSystem, snares *entities.SnareManager, names *naming.Generator,
+    bus *eventbus.Bus, notifier *display.Notification, explosions *display.Explosions,
+    scaler *difficulty.Scaler, elapsedTicks func() int, damageLog *damagelog.Log, encounters mech.EncounterRecorder, speed mech.SpeedSource, tuning mech.EnemyTuning) waves.EnemyFactory {
+    r := rand.New(rand.NewSource(time.Now().UnixNano()))
+    return func(config waves.MechConfig, index int) *mech.EnemyMech {
+        strategy, x, y := findEnemySpawn(r, game, level, roads, snares)
+        elapsed := elapsedTicks()
+        structure := scaler.Scale(config.Structure, elapsed)
+        m := mech.NewEnemyMech(config.Name, structure, x, y, tl.ColorRed, config.Symbol, strategy)
+        m.SetName(names.NextName(config.Symbol))
+        w := config.Weapon()
+        w.UpgradeDamage(scaler.Scale(w.Damage(), elapsed) - w.Damage())
+        m.AddWeapon(w)
+        m.AttachNotifier(notifier)
+        m.AttachLogger(logger)
+        m.SubscribeTo(bus)
+        m.AttachExploder(explosions)
+        m.AttachClock(elapsedTicks)
+        m.AttachSpeed(speed)
+        m.AttachTuning(tuning)
+        m.AttachDamageLog(damageLog)
+        m.AttachEncounters(encounters)
+        return m
+    }
+}
+
+// newBountyRegistry places the boss bounty on the enemy called boss and
+// the regular bounty on every other enemy, whatever name it is given when
+// it appears
+func newBountyRegistry(boss string) *bounty.Registry {
+    registry := bounty.NewRegistry()
+    registry.SetDefaultBounty(bounty.BountyEntry{Points: bounty.RegularPoints, Description: "Hostile mech"})
+    registry.SetBounty(boss, bounty.BountyEntry{Points: bounty.BossPoints, Description: "Overwatch sniper"})
+    return registry
+}
+
+// newDifficultyScaler returns the configured enemy scaler or the defaults
+func newDifficultyScaler(cfg *config.Game) *difficulty.Scaler {
+    if cfg == nil || cfg.Difficulty.ScalingPeriod == 0 {
+        return difficulty.NewScaler(difficulty.DefaultScalingPeriod, difficulty.DefaultScalingFactor)
+    }
+    return difficulty.NewScaler(cfg.Difficulty.ScalingPeriod, cfg.Difficulty.ScalingFactor)
+}
+
+// loadWaves returns the configured enemy waves or the defaults
+func loadWaves(cfg *config.Game) ([]waves.Wave, error) {
+    if cfg == nil || len(cfg.Waves) == 0 {
+        return waves.DefaultWaves(), nil
+    }
+    return waves.FromConfig(cfg.Waves)
+}
+
 // RoadSystem represents a collection of road tiles managed by a single entity
 type RoadSystem struct {
     *tl.Entity
-    roads map[int]map[int]bool
+    roads    map[int]map[int]bool
+    lighting building.Lighting
 }
 
 func NewRoadSystem() *RoadSystem {
@@ -252,18 +282,34 @@ func (r *RoadSystem) AddRoad(x, y int) {
     r.roads[x][y] = true
 }
 
+// SetLighting sets the lighting applied when roads are drawn
+func (r *RoadSystem) SetLighting(lighting building.Lighting) {
+    r.lighting = lighting
+}
+
 func (r *RoadSystem) Draw(s *tl.Screen) {
     for x, yMap := range r.roads {
         for y := range yMap {
-            s.RenderCell(x, y, &tl.Cell{
+            cell := &tl.Cell{
                 Bg: tl.ColorBlue,
                 Fg: tl.ColorBlue,
                 Ch: ' ',
-            })
+            }
+            if r.lighting != nil {
+                cell = r.lighting.Light(cell, x, y)
+            }
+            s.RenderCell(x, y, cell)
         }
     }
 }
 
+// RemoveRoad removes the road tile at x,y if there is one
+func (r *RoadSystem) RemoveRoad(x, y int) {
+    if yMap, exists := r.roads[x]; exists {
+        delete(yMap, y)
+    }
+}
+
 func (r *RoadSystem) HasRoad(x, y int) bool {
     if yMap, exists := r.roads[x]; exists {
         return yMap[y]
@@ -271,6 +317,17 @@ func (r *RoadSystem) HasRoad(x, y int) bool {
     return false
 }
 
+// RoadCells returns the coordinates of every road tile
+func (r *RoadSystem) RoadCells() [][2]int {
+    cells := make([][2]int, 0)
+    for x, yMap := range r.roads {
+        for y := range yMap {
+            cells = append(cells, [2]int{x, y})
+        }
+    }
+    return cells
+}
+
 func (r *RoadSystem) HasRoadInArea(x, y, width, height int) bool {
     for i := x; i < x+width; i++ {
         for j := y; j < y+height; j++ {
@@ -282,31 +339,53 @@ func (r *RoadSystem) HasRoadInArea(x, y, width, height int) bool {
     return false
 }
 
-const (
-    levelWidth     = 100
-    levelHeight    = 60
+// The city's dimensions, shrunk by fitLevelToTerminal on small terminals
+var (
+    levelWidth     = baseLevelWidth
+    levelHeight    = baseLevelHeight
     avenueSpacing  = 20
     streetSpacing  = 12
-    buildingMargin = 2
     buildingWidth  = 8
     buildingHeight = 6
+    maxLevelWidth  = levelWidth - 1
+    maxLevelHeight = levelHeight - 1
+
+    // Residential district dimensions
+    residentialStartX = 40
+    residentialStartY = 10
+    residentialWidth  = 40
+    residentialHeight = 30
+)
+
+const (
+    baseLevelWidth  = 100
+    baseLevelHeight = 60
+    buildingMargin = 2
     gameFPS       = 10
     minCoordinate = 0
-    maxLevelWidth = levelWidth - 1
-    maxLevelHeight = levelHeight - 1
+    snareAwarenessChance = 0.2 // Chance a patrolling enemy avoids snares
+    vehicleSpeed = 0.5 // Cells a car drives per frame
+    challengeStructureMultiplier = 2 // Structure gained by each challenge respawn
+    sniperZoneRadius = 10 // Cells around the sniper's spawn it suppresses
+    explosionParticles = 12 // Particles thrown out by an explosion
+    explosionLifetime = 8 // Frames the longest lived explosion particle lasts
+    evacuationSeconds = 120 // Time civilians have to reach a shelter once the alarm sounds
+    speedStep = 0.25 // Change in game speed for each + or - press
+    minSpeedMultiplier = 0.25
+    maxSpeedMultiplier = 4.0
     
     // Time constants
     realSecondsPerGameDay = 180.0  // 3 minutes real time = 24 hours game time
     gameHoursPerRealSecond = 24.0 / realSecondsPerGameDay
     gameHoursPerFrame = gameHoursPerRealSecond / gameFPS
+    streetLightSpacing = 8
+    rainyDayChance = 0.3
+    // balanceReportHours is the game time between balance reports, a
+    // minute of real time at normal speed
+    balanceReportHours = gameHoursPerRealSecond * 60
+    balanceReportDir = "reports"
     timeDisplayX = 1
     timeDisplayY = 1
-    
-    // Residential district constants
-    residentialStartX = 40
-    residentialStartY = 10
-    residentialWidth = 40
-    residentialHeight = 30
 )
 
 // isInResidentialArea checks if a position is within the residential district
@@ -316,19 +395,19 @@ func isInResidentialArea(x, y int) bool {
 }
 
 // placeResidentialBuildings places homes in the residential district
-func placeResidentialBuildings(buildingCounts map[string]int, level *tl.BaseLevel) {
+func placeResidentialBuildings(buildingCounts map[string]int, buildings *building.Manager, level *tl.BaseLevel) {
     // Find the home building type
-    var homeType BuildingType
-    for _, bt := range buildingTypes {
-        if bt.name == "Home" {
+    var homeType building.Type
+    for _, bt := range building.Types {
+        if bt.Name == "Home" {
             homeType = bt
             break
         }
     }
     
     // Check if we've reached the maximum number of homes
-    if buildingCounts[homeType.name] >= homeType.maxCount {
-        log.Printf("Warning: Maximum number of homes (%d) already reached\n", homeType.maxCount)
+    if buildingCounts[homeType.Name] >= homeType.MaxCount {
+        logger.Warn("maximum number of homes already reached", "max_count", homeType.MaxCount)
         return
     }
     
@@ -336,26 +415,94 @@ func placeResidentialBuildings(buildingCounts map[string]int, level *tl.BaseLeve
     for x := residentialStartX; x < residentialStartX+residentialWidth-buildingWidth; x += buildingWidth + 2 {
         for y := residentialStartY; y < residentialStartY+residentialHeight-buildingHeight; y += buildingHeight + 2 {
             // Stop if we've reached the maximum number of homes
-            if buildingCounts[homeType.name] >= homeType.maxCount {
-                log.Printf("Info: Placed maximum number of homes (%d)\n", homeType.maxCount)
+            if buildingCounts[homeType.Name] >= homeType.MaxCount {
+                logger.Info("placed maximum number of homes", "max_count", homeType.MaxCount)
                 return
             }
             
-            if !hasCollision(x, y, level) {
-                building := NewBuilding(x, y, buildingWidth, buildingHeight, homeType)
-                level.AddEntity(building)
-                buildingCounts[homeType.name]++
+            if !spawnzones.HasCollision(x, y, level) && !overlapsBuilding(x, y, buildingWidth, buildingHeight, buildings) {
+                home := building.NewBuilding(x, y, buildingWidth, buildingHeight, homeType)
+                buildings.Add(home)
+                level.AddEntity(home)
+                buildingCounts[homeType.Name]++
             }
         }
     }
     
     // Log if we couldn't place all homes
-    if buildingCounts[homeType.name] < homeType.maxCount {
-        log.Printf("Warning: Only placed %d/%d homes due to space constraints\n", 
-            buildingCounts[homeType.name], homeType.maxCount)
+    if buildingCounts[homeType.Name] < homeType.MaxCount {
+        logger.Warn("homes not fully placed due to space constraints",
+            "placed", buildingCounts[homeType.Name],
+            "max_count", homeType.MaxCount)
+    }
+}
+
+// placeCityHall builds the city hall at the center of the map, clearing
+// the roads beneath it
+func placeCityHall(roadSystem *RoadSystem, buildings *building.Manager, level *tl.BaseLevel) {
+    generator := building.NewBigBuildingGenerator(buildings, buildingWidth, buildingHeight)
+    cityHall := building.CityHall
+    x := levelWidth/2 - cityHall.Width()*buildingWidth/2
+    y := levelHeight/2 - cityHall.Height()*buildingHeight/2
+    parts := generator.Generate(x, y, levelWidth, levelHeight, cityHall)
+    if parts == nil {
+        logger.Warn("city hall does not fit on the map", "x", x, "y", y)
+        return
+    }
+    for _, part := range parts {
+        px, py := part.Position()
+        for i := 0; i < buildingWidth; i++ {
+            for j := 0; j < buildingHeight; j++ {
+                roadSystem.RemoveRoad(px+i, py+j)
+            }
+        }
+        level.AddEntity(part)
     }
 }
 
+// vehicleRoutes returns the streets and avenues cars drive back and forth
+// along
+func vehicleRoutes() [][4]int {
+    return [][4]int{
+        {0, buildingMargin + 3*streetSpacing, levelWidth - 1, buildingMargin + 3*streetSpacing},
+        {avenueSpacing, 0, avenueSpacing, levelHeight - 1},
+    }
+}
+
+// placeVehicles puts a car on each vehicle route
+func placeVehicles(level *tl.BaseLevel, drops *entities.DropManager) {
+    for _, route := range vehicleRoutes() {
+        vehicle := entities.NewVehicle(route[0], route[1], route[2], route[3], vehicleSpeed, level)
+        vehicle.AttachDropManager(drops)
+        level.AddEntity(vehicle)
+    }
+}
+
+// radioTowerPositions are where the capturable radio towers stand
+var radioTowerPositions = [][2]int{{30, 20}, {92, 45}}
+
+// placeRadioTowers adds the radio towers, each boosting or jamming the
+// player's radar depending on who holds it
+func placeRadioTowers(player *mech.PlayerMech, level *tl.BaseLevel) {
+    for _, pos := range radioTowerPositions {
+        tower := entities.NewRadioTower(pos[0], pos[1], player)
+        player.AttachRadioTower(tower)
+        level.AddEntity(tower)
+    }
+}
+
+// overlapsBuilding returns true if the area at x,y overlaps a placed building
+func overlapsBuilding(x, y, width, height int, buildings *building.Manager) bool {
+    for _, b := range buildings.Buildings() {
+        bx, by := b.Position()
+        bw, bh := b.Size()
+        if x < bx+bw && x+width > bx && y < by+bh && y+height > by {
+            return true
+        }
+    }
+    return false
+}
+
 // getValidBuildingPositions returns a list of valid positions for building placement
 func getValidBuildingPositions(roadSystem *RoadSystem) [][2]int {
     valid := make([][2]int, 0)
@@ -371,13 +518,17 @@ func getValidBuildingPositions(roadSystem *RoadSystem) [][2]int {
 }
 
 // tryPlaceBuilding attempts to place a building at the given location
-func tryPlaceBuilding(x, y int, buildingCounts map[string]int, level *tl.BaseLevel) bool {
-    for tries := 0; tries < len(buildingTypes)*2; tries++ {
-        buildingType := buildingTypes[rand.Intn(len(buildingTypes))]
-        if buildingCounts[buildingType.name] < buildingType.maxCount {
-            building := NewBuilding(x, y, buildingWidth, buildingHeight, buildingType)
-            level.AddEntity(building)
-            buildingCounts[buildingType.name]++
+func tryPlaceBuilding(x, y int, buildingCounts map[string]int, buildings *building.Manager, level *tl.BaseLevel) bool {
+    if overlapsBuilding(x, y, buildingWidth, buildingHeight, buildings) {
+        return false
+    }
+    for tries := 0; tries < len(building.Types)*2; tries++ {
+        buildingType := building.Types[rand.Intn(len(building.Types))]
+        if buildingCounts[buildingType.Name] < buildingType.MaxCount {
+            b := building.NewBuilding(x, y, buildingWidth, buildingHeight, buildingType)
+            buildings.Add(b)
+            level.AddEntity(b)
+            buildingCounts[buildingType.Name]++
             return true
         }
     }
@@ -385,9 +536,16 @@ func tryPlaceBuilding(x, y int, buildingCounts map[string]int, level *tl.BaseLev
 }
 
 // placeBuildings places buildings in valid positions
-func placeBuildings(roadSystem *RoadSystem, buildingCounts map[string]int, level *tl.BaseLevel) {
-    // First place residential buildings
-    placeResidentialBuildings(buildingCounts, level)
+func placeBuildings(roadSystem *RoadSystem, buildingCounts map[string]int, buildings *building.Manager, level *tl.BaseLevel) {
+    // The city hall takes the center of the map before anything else
+    placeCityHall(roadSystem, buildings, level)
+
+    // The power plant and a shelter must always be built
+    placeRequiredBuilding(building.PowerPlantName, roadSystem, buildingCounts, buildings, level)
+    placeRequiredBuilding(building.ShelterName, roadSystem, buildingCounts, buildings, level)
+
+    // Then place residential buildings
+    placeResidentialBuildings(buildingCounts, buildings, level)
     
     // Then place commercial and public buildings outside residential area
     validPositions := getValidBuildingPositions(roadSystem)
@@ -396,10 +554,83 @@ func placeBuildings(roadSystem *RoadSystem, buildingCounts map[string]int, level
         if isInResidentialArea(pos[0], pos[1]) {
             continue
         }
-        tryPlaceBuilding(pos[0], pos[1], buildingCounts, level)
+        tryPlaceBuilding(pos[0], pos[1], buildingCounts, buildings, level)
     }
 }
 
+// placeRequiredBuilding builds the building type called name on the first
+// free lot outside the residential area
+func placeRequiredBuilding(name string, roadSystem *RoadSystem, buildingCounts map[string]int, buildings *building.Manager, level *tl.BaseLevel) {
+    buildingType, _ := building.TypeByName(name)
+    for _, pos := range getValidBuildingPositions(roadSystem) {
+        if isInResidentialArea(pos[0], pos[1]) || overlapsBuilding(pos[0], pos[1], buildingWidth, buildingHeight, buildings) {
+            continue
+        }
+        b := building.NewBuilding(pos[0], pos[1], buildingWidth, buildingHeight, buildingType)
+        buildings.Add(b)
+        level.AddEntity(b)
+        buildingCounts[buildingType.Name]++
+        return
+    }
+    logger.Warn("no room for a required building", "building", name)
+}
+
+// findBuilding returns the first building called name or nil
+func findBuilding(buildings *building.Manager, name string) *building.Building {
+    for _, b := range buildings.Buildings() {
+        if b.Name() == name {
+            return b
+        }
+    }
+    return nil
+}
+
+// placeBlueprint builds kind beside player, trying each side of them in
+// turn, and reports the outcome through notifier
+func placeBlueprint(builder *blueprint.Builder, kind string, player *mech.PlayerMech, level *tl.BaseLevel,
+    buildings *building.Manager, notifier util.Notifier) {
+    x, y := player.Position()
+    spots := [][2]int{
+        {x + 2, y - blueprint.Height/2},
+        {x - blueprint.Width - 1, y - blueprint.Height/2},
+        {x - blueprint.Width/2, y + 2},
+        {x - blueprint.Width/2, y - blueprint.Height - 1},
+    }
+    var err error
+    for _, spot := range spots {
+        if err = builder.PlaceBuilding(kind, spot[0], spot[1], level, buildings); err == nil {
+            notifier.AddMessage(kind + " built")
+            logger.Info("building built", "event_type", "blueprint", "building", kind, "x", spot[0], "y", spot[1])
+            return
+        }
+        if errors.Is(err, blueprint.ErrInsufficientFunds) {
+            break
+        }
+    }
+    notifier.AddMessage("Cannot build " + kind + ": " + errors.Unwrap(err).Error())
+}
+
+// markPointsOfInterest registers the hospital, police station and power
+// plant as the city's high value points of interest
+func markPointsOfInterest(buildings *building.Manager) *poi.Registry {
+    pois := poi.NewRegistry()
+    for _, name := range []string{"Hospital", "Police", building.PowerPlantName} {
+        for _, b := range buildings.Buildings() {
+            if b.Name() != name {
+                continue
+            }
+            x, y := b.Position()
+            pois.Register(poi.PointOfInterest{
+                EntityID:       poi.EntityID(b.ID()),
+                Label:          name,
+                StrategicValue: poi.HighValue,
+                Position:       [2]int{x, y},
+            })
+        }
+    }
+    return pois
+}
+
 // createRoadSystem creates and returns a road system with vertical and horizontal roads
 func createRoadSystem() *RoadSystem {
     roadSystem := NewRoadSystem()
@@ -430,19 +661,70 @@ func createHorizontalRoads(roadSystem *RoadSystem) {
 // initBuildingCounts initializes a map to track building counts
 func initBuildingCounts() map[string]int {
     counts := make(map[string]int)
-    for _, bt := range buildingTypes {
-        counts[bt.name] = 0
+    for _, bt := range building.Types {
+        counts[bt.Name] = 0
     }
     return counts
 }
 
 // createManhattanLayout creates the city layout with roads and buildings
-func createManhattanLayout(level *tl.BaseLevel) {
+func createManhattanLayout(level *tl.BaseLevel) (*RoadSystem, *building.Manager) {
     roadSystem := createRoadSystem()
     level.AddEntity(roadSystem)
     
+    buildings := building.NewManager(roadSystem)
     buildingCounts := initBuildingCounts()
-    placeBuildings(roadSystem, buildingCounts, level)
+    placeBuildings(roadSystem, buildingCounts, buildings, level)
+    return roadSystem, buildings
+}
+
+// setupLighting lights the city by the time of day, placing street lights
+// on road cells beside buildings
+func setupLighting(clock display.Clock, roads *RoadSystem, buildings *building.Manager) *display.LightingSystem {
+    lighting := display.NewLightingSystem(clock)
+    roads.SetLighting(lighting)
+    for _, b := range buildings.Buildings() {
+        b.SetLighting(lighting)
+    }
+
+    for _, cell := range roads.RoadCells() {
+        x, y := cell[0], cell[1]
+        if (x+y)%streetLightSpacing != 0 {
+            continue
+        }
+        for _, b := range buildings.Buildings() {
+            if b.Contains(x+1, y) || b.Contains(x-1, y) || b.Contains(x, y+1) || b.Contains(x, y-1) {
+                lighting.AddStreetLight(x, y)
+                break
+            }
+        }
+    }
+    return lighting
+}
+
+// scheduleAcidRain picks a random hour of the coming day for a chance of
+// acid rain, then schedules the following day once that hour passes
+func scheduleAcidRain(ts *TimeSystem, rain *hazard.AcidRain) {
+    ts.Schedule(rand.Float64()*24.0, func() {
+        if rand.Float64() < rainyDayChance {
+            rain.Start()
+        }
+        scheduleAcidRain(ts, rain)
+    })
+}
+
+// scheduleBalanceReport writes a balance report balanceReportHours from
+// now, then schedules the next one
+func (gs *GameState) scheduleBalanceReport(ts *TimeSystem) {
+    hour := math.Mod(ts.GameHours()+balanceReportHours, 24.0)
+    ts.Schedule(hour, func() {
+        stats := gs.balance.Stats(gs.damageLog, float64(gs.elapsedTicks)/gameFPS)
+        report := gs.balanceReporter.Report(hour, stats)
+        if _, err := gs.balanceReporter.Save(report, time.Now()); err != nil {
+            logger.Warn("failed to save balance report", "error", err)
+        }
+        gs.scheduleBalanceReport(ts)
+    })
 }
 
 // TimeSystemInterface defines the interface for time systems
@@ -456,6 +738,41 @@ type TimeSystem struct {
     *tl.Entity
     gameHours    float64
     frameCounter int
+    scheduled    []scheduledEvent
+    speed        float64 // Game speed multiplier scaling the time advance
+}
+
+// scheduledEvent is a callback waiting for a time of day
+type scheduledEvent struct {
+    hour float64
+    fn   func()
+}
+
+// Schedule runs fn once, the next time the clock reaches hour
+func (ts *TimeSystem) Schedule(hour float64, fn func()) {
+    ts.scheduled = append(ts.scheduled, scheduledEvent{hour: hour, fn: fn})
+}
+
+// runScheduled calls every callback whose hour passed between from and to
+func (ts *TimeSystem) runScheduled(from, to float64) {
+    due := make([]func(), 0)
+    waiting := ts.scheduled[:0]
+    for _, event := range ts.scheduled {
+        passed := from < event.hour && event.hour <= to
+        if to < from { // the clock wrapped past midnight
+            passed = event.hour > from || event.hour <= to
+        }
+        if passed {
+            due = append(due, event.fn)
+        } else {
+            waiting = append(waiting, event)
+        }
+    }
+    ts.scheduled = waiting
+    // Run after updating the list so callbacks can schedule again
+    for _, fn := range due {
+        fn()
+    }
 }
 
 // NewTimeSystem creates a new time system starting at 6:00 AM
@@ -463,10 +780,21 @@ func NewTimeSystem(level *tl.BaseLevel) *TimeSystem {
     ts := &TimeSystem{
         Entity:     tl.NewEntity(timeDisplayX, timeDisplayY, 20, 1),
         gameHours:  6.0, // Start at 6 AM
+        speed:      1.0,
     }
     return ts
 }
 
+// SetSpeed sets the game speed multiplier scaling how fast time passes
+func (ts *TimeSystem) SetSpeed(speed float64) {
+    ts.speed = speed
+}
+
+// GameHours returns the time of day in hours from 0 to 24
+func (ts *TimeSystem) GameHours() float64 {
+    return ts.gameHours
+}
+
 // FormatGameTime converts game hours to a 12-hour time string
 func (ts *TimeSystem) FormatGameTime() string {
     hours := int(ts.gameHours) % 24
@@ -489,10 +817,12 @@ func (ts *TimeSystem) FormatGameTime() string {
 // Tick updates the game time
 func (ts *TimeSystem) Tick(event tl.Event) {
     ts.frameCounter++
-    ts.gameHours += gameHoursPerFrame
+    previous := ts.gameHours
+    ts.gameHours += gameHoursPerFrame * ts.speed
     if ts.gameHours >= 24.0 {
         ts.gameHours -= 24.0
     }
+    ts.runScheduled(previous, ts.gameHours)
 }
 
 // Relationship represents a connection between the user and another person
@@ -542,6 +872,20 @@ type ComputerUser struct {
     PocketMoney         float64
     Properties          []Property
     Cars                []Car
+    Archetype           *ai.ArchetypeTemplate
+    morale              int
+}
+
+// Prompt returns the prompt describing the user in env to the language
+// model
+func (u *ComputerUser) Prompt(env ai.EnvironmentInfo) string {
+    return ai.FormatNPCPrompt(ai.NPCProfile{
+        Name:              u.Name,
+        Age:               u.Age,
+        Occupation:        u.Occupation,
+        PersonalityTraits: u.PersonalityTraits,
+        Environment:       env,
+    }, u.Archetype)
 }
 
 // NewComputerUser creates a new instance of ComputerUser with the provided details
@@ -558,6 +902,7 @@ func NewComputerUser(name string, age int, nationality string) *ComputerUser {
         HealthIssues:       make([]string, 0),
         Properties:         make([]Property, 0),
         Cars:              make([]Car, 0),
+        morale:            morale.Start,
     }
 }
 
@@ -606,6 +951,15 @@ var (
     firstNames = []string{"John", "Jane", "Mike", "Sarah", "David", "Emma"}
     lastNames  = []string{"Smith", "Johnson", "Williams", "Brown", "Jones", "Garcia"}
     standardActivities = []string{"Work", "Exercise", "Leisure"}
+
+    // incomeArchetypes names the typical personality archetype for each income level
+    incomeArchetypes = map[IncomeLevel]string{
+        LowIncome:    "Cautious",
+        MiddleIncome: "Balanced",
+        HighIncome:   "Aggressive",
+    }
+    // npcArchetypes holds the archetypes loaded from the archetype file
+    npcArchetypes map[string]ai.ArchetypeTemplate
 )
 
 // generateRandomName creates a random full name
@@ -694,6 +1048,11 @@ func generateUserByIncomeLevel(level IncomeLevel) *ComputerUser {
     possibleOccupations := occupations[level]
     user.Occupation = possibleOccupations[rand.Intn(len(possibleOccupations))]
     
+    if archetype, ok := npcArchetypes[incomeArchetypes[level]]; ok {
+        user.Archetype = &archetype
+        user.PersonalityTraits = append(user.PersonalityTraits, archetype.Name)
+    }
+    
     user.DailyRoutine = DailyRoutine{
         WakeUpTime: standardWakeTime,
         SleepTime:  standardSleepTime,
@@ -762,8 +1121,39 @@ type ComputerUserEntity struct {
     user *ComputerUser
     symbol rune
     color tl.Attr
+    buildings *building.Manager
+    inside building.ID // building currently occupied, 0 when outside
+    notifier util.Notifier
+    fleePath [][2]int
+    fleeTicks int
+    evacuation *building.EvacuationManager
+    // The language model is consulted on the scheduler's timetable
+    ollama    *ai.OllamaClient
+    aiCtx     context.Context
+    scheduler *ai.QueryScheduler
+    clock     display.Clock
+    // queue limits how many civilians query the model at once
+    queue     *ai.RequestQueue
+    responses chan ai.AIResult
+    querying  bool
+    response  ai.NPCResponse
+    // shelter picks where to run when the user is frightened into fleeing
+    shelter   *npc.ShelterSearch
+    // surroundings describes the city the model is told about
+    surroundings *ai.EnvironmentBuilder
+    // level is searched for the enemies the user can tip the player off about
+    level     *tl.BaseLevel
 }
 
+const (
+    // Civilian morale behavior constants
+    panicSymbol = '!'
+    fleePathLength = 15
+    fleeMoveDelay = 3 // Ticks between steps while fleeing
+    rallyChance = 0.005 // Chance per tick a confident civilian shouts
+    civilianBlastRadius = 2 // Cells from an explosion a civilian outside is hurt
+)
+
 // NewComputerUserEntity creates a new computer user entity for rendering
 func NewComputerUserEntity(user *ComputerUser, x, y int) *ComputerUserEntity {
     // Different symbols and colors based on income level
@@ -783,94 +1173,376 @@ func NewComputerUserEntity(user *ComputerUser, x, y int) *ComputerUserEntity {
         color = tl.ColorRed
     }
     
-    return &ComputerUserEntity{
+    entity := &ComputerUserEntity{
         Entity: tl.NewEntity(x, y, 1, 1),
         user:   user,
         symbol: symbol,
         color:  color,
     }
+    lifecycle.NotifyCreate(entity)
+    return entity
 }
 
 // Draw implements the termloop.Drawable interface
 func (c *ComputerUserEntity) Draw(screen *tl.Screen) {
+    if hooks.Draw("ComputerUser", c, screen) {
+        return
+    }
     x, y := c.Position()
+    symbol := c.symbol
+    if morale.Panicked(c.user.morale) {
+        symbol = panicSymbol
+    }
     screen.RenderCell(x, y, &tl.Cell{
         Fg: c.color,
-        Ch: c.symbol,
+        Ch: symbol,
     })
 }
 
 // Tick implements the termloop.Drawable interface
 func (c *ComputerUserEntity) Tick(event tl.Event) {
-    // For now, users stay in place
+    lifecycle.NotifyTick(c)
+    if event.Type != tl.EventNone {
+        return
+    }
+    if c.inside != 0 {
+        c.user.morale = morale.AfterShelter(c.user.morale)
+    }
+    c.consultAI()
+    if c.shelter != nil {
+        c.shelter.Tick()
+    }
+
+    switch {
+    case morale.Panicked(c.user.morale):
+        // Frozen in place
+    case c.evacuation != nil && c.evacuation.Active():
+        c.seekShelter()
+    case morale.Fleeing(c.user.morale) && c.shelterTarget() != nil:
+        c.stepToward(c.shelterTarget())
+    case morale.Fleeing(c.user.morale):
+        c.flee()
+    case morale.Rallying(c.user.morale) && c.notifier != nil && rand.Float64() < rallyChance:
+        c.notifier.AddMessage(c.user.Name + ": \"Stay strong!\"")
+    }
     // TODO: Implement movement patterns based on daily routine
 }
 
+// AttachAI has the user consult client through queue whenever scheduler
+// says a query is due at the time shown by clock
+func (c *ComputerUserEntity) AttachAI(ctx context.Context, client *ai.OllamaClient, queue *ai.RequestQueue,
+    scheduler *ai.QueryScheduler, clock display.Clock) {
+    c.ollama = client
+    c.aiCtx = ctx
+    c.queue = queue
+    c.scheduler = scheduler
+    c.clock = clock
+    c.responses = make(chan ai.AIResult, 1)
+    if c.buildings != nil {
+        c.shelter = npc.NewShelterSearch(client, c.buildings)
+    }
+}
+
+// consultAI collects the answer to the last query and queues the next one
+// when the scheduler allows. Queries are answered by the queue's workers
+// so the game never waits on the model.
+func (c *ComputerUserEntity) consultAI() {
+    if c.queue == nil {
+        return
+    }
+    select {
+    case result := <-c.responses:
+        c.querying = false
+        if result.Err != nil {
+            logger.Debug("AI query failed", "user", c.user.Name, "error", result.Err)
+            break
+        }
+        c.response = result.Response
+        c.scheduler.Record(c.user, result.Response)
+        if result.Response.ActionType == ai.ActionTalk && c.notifier != nil && result.Response.Dialogue != "" {
+            c.notifier.AddMessage(c.user.Name + ": \"" + result.Response.Dialogue + "\"")
+        }
+    default:
+    }
+    if c.querying || !c.scheduler.ShouldQuery(c.user, c.clock.GameHours()) {
+        return
+    }
+    // A frightened user told to flee asks where to find shelter
+    alerted := morale.Fleeing(c.user.morale)
+    threat := morale.Max - c.user.morale
+    x, y := c.Position()
+    c.querying = c.queue.Submit(ai.AIRequest{
+        Ctx:    c.aiCtx,
+        Prompt: c.user.Prompt(c.environment()),
+        FollowUp: func(ctx context.Context, response ai.NPCResponse) {
+            if response.ActionType == ai.ActionFlee && alerted && c.shelter != nil {
+                c.shelter.Search(ctx, x, y, threat)
+            }
+        },
+        ResponseCh: c.responses,
+    })
+}
+
+// environment returns what the model is told about the city around the
+// user
+func (c *ComputerUserEntity) environment() ai.EnvironmentInfo {
+    if c.surroundings == nil {
+        return ai.EnvironmentInfo{}
+    }
+    return c.surroundings.Build()
+}
+
+// shelterTarget returns the building the user was advised to run to, nil
+// if none
+func (c *ComputerUserEntity) shelterTarget() *building.Building {
+    if c.shelter == nil || c.inside != 0 {
+        return nil
+    }
+    return c.shelter.Target()
+}
+
+// Morale returns the user's morale from 0 to 100
+func (c *ComputerUserEntity) Morale() int {
+    return c.user.morale
+}
+
+// AttachNotifier sets where the user's shouts are displayed
+func (c *ComputerUserEntity) AttachNotifier(notifier util.Notifier) {
+    c.notifier = notifier
+}
+
+// HandleEvent lowers morale in response to the fighting nearby
+func (c *ComputerUserEntity) HandleEvent(event eventbus.Event) {
+    switch e := event.(type) {
+    case eventbus.ExplosionEvent:
+        x, y := c.Position()
+        distance := util.Distance(x, y, e.X, e.Y, util.Euclidean)
+        before := c.user.morale
+        c.user.morale = morale.AfterExplosion(c.user.morale, distance)
+        if c.user.morale < before && c.inside == 0 {
+            c.fleePath = movement.FleePath(x, y, e.X, e.Y, fleePathLength)
+        }
+    case eventbus.MechDestroyedEvent:
+        c.user.morale = morale.AfterDestruction(c.user.morale)
+        if c.inside == 0 {
+            x, y := c.Position()
+            c.fleePath = movement.FleePath(x, y, e.X, e.Y, fleePathLength)
+        }
+    }
+}
+
+// flee steps along the flee path away from the last threat. Users already
+// sheltering in a building stay inside.
+func (c *ComputerUserEntity) flee() {
+    if c.inside != 0 || len(c.fleePath) == 0 {
+        return
+    }
+    c.fleeTicks++
+    if c.fleeTicks < fleeMoveDelay {
+        return
+    }
+    c.fleeTicks = 0
+    next := c.fleePath[0]
+    c.fleePath = c.fleePath[1:]
+    x, y := c.Position()
+    c.step(util.Sign(next[0]-x), util.Sign(next[1]-y), nil)
+}
+
+// seekShelter steps toward the nearest shelter with room during an
+// evacuation. Users inside any other building come out and head there too.
+func (c *ComputerUserEntity) seekShelter() {
+    if c.evacuation.IsSheltered(c) {
+        return
+    }
+    c.LeaveBuilding()
+    x, y := c.Position()
+    if shelter := c.evacuation.NearestShelter(x, y); shelter != nil {
+        c.stepToward(shelter)
+    }
+}
+
+// stepToward moves the user a step closer to the center of b, at the same
+// pace as fleeing
+func (c *ComputerUserEntity) stepToward(b *building.Building) {
+    c.fleeTicks++
+    if c.fleeTicks < fleeMoveDelay {
+        return
+    }
+    c.fleeTicks = 0
+    x, y := c.Position()
+    bx, by := b.Position()
+    bw, bh := b.Size()
+    c.step(util.Sign(bx+bw/2-x), util.Sign(by+bh/2-y), b)
+}
+
+// step moves the user by dx,dy, or along just one of them when the
+// diagonal is blocked, staying put if every way is blocked. Walking into
+// destination enters it rather than being blocked by it.
+func (c *ComputerUserEntity) step(dx, dy int, destination *building.Building) {
+    x, y := c.Position()
+    for _, move := range [][2]int{{dx, dy}, {dx, 0}, {0, dy}} {
+        if move == [2]int{} {
+            continue
+        }
+        if !c.blocked(x+move[0], y+move[1], destination) {
+            c.SetPosition(x+move[0], y+move[1])
+            return
+        }
+    }
+}
+
+// blocked returns true if a standing building other than destination, or
+// any other entity, takes up x,y. Users can always walk out of the
+// building they are standing in.
+func (c *ComputerUserEntity) blocked(x, y int, destination *building.Building) bool {
+    if destination != nil && destination.Contains(x, y) {
+        return false
+    }
+    if c.buildings != nil {
+        cx, cy := c.Position()
+        for _, b := range c.buildings.Buildings() {
+            if b.Structure() > 0 && b.Contains(x, y) && !b.Contains(cx, cy) {
+                return true
+            }
+        }
+    }
+    return spawnzones.HasCollision(x, y, c.level)
+}
+
+// subscribeMorale has every civilian react to explosions and destroyed
+// mechs, reporting each civilian out in the open caught by an explosion
+func subscribeMorale(bus *eventbus.Bus, civilians []*ComputerUserEntity) {
+    bus.Subscribe(func(event eventbus.Event) {
+        for _, civilian := range civilians {
+            civilian.HandleEvent(event)
+        }
+        if explosion, ok := event.(eventbus.ExplosionEvent); ok {
+            for _, civilian := range civilians {
+                if civilian.CaughtIn(explosion) {
+                    x, y := civilian.Position()
+                    bus.Publish(eventbus.CivilianHitEvent{Attacker: explosion.Attacker, X: x, Y: y})
+                }
+            }
+        }
+    })
+}
+
+// CaughtIn returns true if the user is out in the open within
+// civilianBlastRadius of the explosion
+func (c *ComputerUserEntity) CaughtIn(explosion eventbus.ExplosionEvent) bool {
+    if c.inside != 0 {
+        return false
+    }
+    x, y := c.Position()
+    return util.Distance(x, y, explosion.X, explosion.Y, util.Euclidean) <= civilianBlastRadius
+}
+
 // Collide implements termloop.Physical interface
 func (c *ComputerUserEntity) Collide(collision tl.Physical) {
-    // Handle collisions if needed
+    // Walking into a building enters it if there is room
+    if b, ok := collision.(*building.Building); ok && c.buildings != nil && c.inside == 0 {
+        // During an evacuation only a shelter with room will do
+        if c.evacuation != nil && c.evacuation.Active() && !c.evacuation.Shelter(c, b.ID()) {
+            return
+        }
+        if c.buildings.Enter(b.ID(), c) {
+            c.inside = b.ID()
+        }
+    }
 }
 
-// placeComputerUsers places computer users near their homes
-func placeComputerUsers(users []*ComputerUser, level *tl.BaseLevel) {
-    const (
-        maxAttempts = 10
-        userSize = 1 // Size of user entity
-    )
+// LeaveBuilding exits the building the user is currently in
+func (c *ComputerUserEntity) LeaveBuilding() {
+    if c.buildings == nil || c.inside == 0 {
+        return
+    }
+    c.buildings.Exit(c.inside)
+    c.inside = 0
+}
+
+// placeComputerUsers places computer users on the road outside their homes
+func placeComputerUsers(users []*ComputerUser, buildings *building.Manager, roads *RoadSystem, level *tl.BaseLevel) []*ComputerUserEntity {
+    // maxCandidates is how many buildings are tried for each user before
+    // giving up on placing them
+    const maxCandidates = 5
+
+    homes := make([]*building.Building, 0)
+    for _, b := range buildings.Buildings() {
+        if b.Name() == "Home" {
+            homes = append(homes, b)
+        }
+    }
+    if len(homes) == 0 {
+        homes = buildings.Buildings()
+    }
+    graph := roadgraph.NewGraph(roads.RoadCells())
 
+    placed := make([]*ComputerUserEntity, 0, len(users))
     for i, user := range users {
-        // Calculate initial position
-        x := residentialStartX + (i * (buildingWidth + 2)) + 2
-        y := residentialStartY + residentialHeight - 2
-        
-        // Ensure position is within bounds
-        if x >= residentialStartX+residentialWidth {
-            x = residentialStartX + (i % 4) * (buildingWidth + 2) + 2
-            y = residentialStartY + residentialHeight - 4
-        }
-        
-        // Check for collisions and adjust position if needed
-        attempts := 0
-        for hasCollision(x, y, level) && attempts < maxAttempts {
-            x += 1
-            if x >= residentialStartX+residentialWidth {
-                x = residentialStartX
-                y -= 1
-                // Ensure y stays within residential area
-                if y < residentialStartY {
-                    y = residentialStartY + residentialHeight - 2
-                }
+        var lastErr error
+        found := false
+        for attempt := 0; attempt < maxCandidates && attempt < len(homes) && !found; attempt++ {
+            home := homes[(i+attempt)%len(homes)]
+            x, y, err := npcspawn.FindAdjacentSpawnCell(home, graph)
+            if err != nil {
+                lastErr = err
+                continue
+            }
+            if spawnzones.HasCollision(x, y, level) {
+                lastErr = fmt.Errorf("error placing computer user: %d,%d is occupied", x, y)
+                continue
             }
-            attempts++
-        }
-        
-        // Only place user if a valid position was found
-        if !hasCollision(x, y, level) {
             userEntity := NewComputerUserEntity(user, x, y)
+            userEntity.buildings = buildings
+            userEntity.level = level
             level.AddEntity(userEntity)
-        } else {
-            // Log warning if unable to place user
-            log.Printf("Warning: Unable to place computer user %d after %d attempts\n", i, maxAttempts)
+            placed = append(placed, userEntity)
+            found = true
+        }
+        if !found {
+            logger.Warn("unable to place computer user", "user_index", i, "error", lastErr)
         }
     }
+    return placed
+}
+
+// logger records structured game events; replaced once flags are parsed
+var logger = logging.NewTextLogger(io.Discard)
+
+// initLogger opens the log destination and creates the structured logger
+func initLogger(format, path string) (*logging.Logger, error) {
+    var w io.Writer = os.Stderr
+    if path != "" {
+        f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+        if err != nil {
+            return nil, fmt.Errorf("error opening log file: %v", err)
+        }
+        w = f
+    }
+    return logging.New(format, w)
 }
 
 const (
+    defaultLogFormat = logging.FormatText
+    defaultLogFile = "frame_assault.log"
+    defaultArchetypeFile = "npc_archetypes.json"
     defaultOllamaHost = "10.1.1.212:11434"
     defaultOllamaModel = "llama3.2:latest"
     testPrompt = "Say hello!"
+    defaultConfigPoll = 5 * time.Second
 )
 
-// initOllama initializes and tests the Ollama client
-func initOllama(host, model string) *ai.OllamaClient {
+// initOllama initializes and tests the Ollama client, returning nil if
+// Ollama cannot be reached
+func initOllama(ctx context.Context, host, model string) *ai.OllamaClient {
     ollama := ai.NewOllamaClient(host, model)
     
-    response, err := ollama.GenerateResponse(testPrompt)
+    response, err := ollama.GenerateResponse(ctx, testPrompt)
     if err != nil {
-        log.Printf("Warning: Failed to connect to Ollama: %v", err)
-    } else {
-        log.Printf("Ollama test response: %s", response)
+        logger.Warn("failed to connect to Ollama", "host", host, "error", err)
+        return nil
     }
+    logger.Info("Ollama test response", "host", host, "model", model, "response", response)
     
     return ollama
 }
@@ -883,11 +1555,333 @@ func getSafeSpawnPosition() (x, y int) {
     return x, y
 }
 
+// loadBuildingStats loads the lifetime building destruction counts,
+// returning nil if there is nowhere to keep them
+func loadBuildingStats() *stats.BuildingStats {
+    path, err := stats.DefaultPath()
+    if err != nil {
+        logger.Warn("building stats disabled", "error", err)
+        return nil
+    }
+    buildingStats, err := stats.Load(path)
+    if err != nil {
+        logger.Warn("failed to load building stats", "file", path, "error", err)
+    }
+    return buildingStats
+}
+
+// loadBestiary loads the enemy types fought in earlier games, returning
+// nil if there is nowhere to keep them
+func loadBestiary() *bestiary.Bestiary {
+    path, err := bestiary.DefaultPath()
+    if err != nil {
+        logger.Warn("bestiary disabled", "error", err)
+        return nil
+    }
+    b, err := bestiary.Load(path)
+    if err != nil {
+        logger.Warn("failed to load bestiary", "file", path, "error", err)
+    }
+    return b
+}
+
 // GameState holds the global game state including AI components
 type GameState struct {
-    ollama *ai.OllamaClient
-    game   *tl.Game
-    level  *tl.BaseLevel
+    ollama    *ai.OllamaClient
+    // eventStream sends every bus event to a WebSocket, nil when disabled
+    eventStream *logging.WebSocketLogger
+    // aiCtx cancels in-flight AI calls on shutdown
+    aiCtx     context.Context
+    // aiQueue answers the civilians' AI queries a few at a time
+    aiQueue   *ai.RequestQueue
+    game      *tl.Game
+    level     *tl.BaseLevel
+    // screenLevel is the level shown while playing, level layered under
+    // the fog of war when it is on
+    screenLevel tl.Level
+    roads     *RoadSystem
+    buildings *building.Manager
+    lighting  *display.LightingSystem
+    power     *power.GridSystem
+    player    *mech.PlayerMech
+    drops     *entities.DropManager
+    civilians []*ComputerUserEntity
+    // environment tells civilians' model about the state of the city, nil
+    // without the model
+    environment *ai.EnvironmentBuilder
+    // spawnEnemy creates a reinforcement for map events
+    spawnEnemy waves.EnemyFactory
+    // elapsedTicks counts frames since the game started
+    elapsedTicks int
+    // undosRemaining are the player's undos left for the rest of the game
+    undosRemaining int
+    waypoints      *display.Waypoints
+    settings  worldSettings
+    // layoutSeed rebuilds the same city layout every life
+    layoutSeed int64
+    livesUsed int
+    dead      bool
+    // names gives every enemy mech a unique name
+    names *naming.Generator
+    // spectator follows the surviving entities once the player is destroyed
+    spectator *camera.Spectator
+    // speedMultiplier scales the game clock, enemies, bullets and AI timeouts
+    speedMultiplier float64
+    clock           *TimeSystem
+    // achievements persist across lives and games, nil when disabled
+    achievements     *achievements.Manager
+    achievementPopup *display.AchievementPopup
+    // buildingStats count destroyed buildings across games, nil when disabled
+    buildingStats *stats.BuildingStats
+    // bestiary records every enemy type fought across games, nil when disabled
+    bestiary *bestiary.Bestiary
+    // discovered are the enemy types fought this game
+    discovered map[string]bool
+    // damageLog records every hit of the session
+    damageLog *damagelog.Log
+    // coop lets a second player join over the network, nil when disabled
+    coop       *coop.Server
+    coopPlayer *mech.PlayerMech
+    // balance tracks the session for the balance reports written every minute
+    balance         *balance.Tracker
+    balanceReporter *balance.Reporter
+    // configUpdates receives configuration pushed while the game runs
+    configUpdates chan *config.Game
+    waves         *waves.Manager
+    // difficulty adapts the enemies to how well the player is doing
+    difficulty *ai.DifficultyAdapter
+    // enemyMoveDelayChange and enemyHitRateBonus adjust every enemy this
+    // game, set by live tuning and the difficulty adapter
+    enemyMoveDelayChange int
+    enemyHitRateBonus    float64
+    // replay records the last 30 seconds of the current life
+    replay *replay.ShortReplay
+}
+
+// saveReplay writes the last 30 seconds of play to path as an ASCII replay
+func (gs *GameState) saveReplay(path string) error {
+    file, err := os.Create(path)
+    if err != nil {
+        return fmt.Errorf("error creating replay file: %v", err)
+    }
+    defer file.Close()
+    return replay.ExportASCII(gs.replay, file)
+}
+
+// headlessSummary returns the totals of a headless run of ticks frames
+func (gs *GameState) headlessSummary(ticks int) headless.Summary {
+    stats := gs.balance.Stats(gs.damageLog, float64(ticks)/gameFPS)
+    summary := headless.Summary{
+        Ticks:         ticks,
+        EnemiesKilled: stats.PlayerKills,
+        DamageDealt:   stats.PlayerDamage,
+    }
+    if gs.ollama != nil {
+        summary.AICalls = gs.ollama.Calls()
+    }
+    for _, b := range gs.buildings.Buildings() {
+        if b.Structure() == 0 {
+            summary.BuildingsDestroyed++
+        }
+    }
+    return summary
+}
+
+// Encounter records the first combat with each enemy type in the bestiary
+func (gs *GameState) Encounter(enemy *mech.EnemyMech) {
+    if gs.discovered[enemy.Model()] {
+        return
+    }
+    gs.discovered[enemy.Model()] = true
+    if gs.bestiary == nil || gs.clock == nil {
+        return
+    }
+    if !gs.bestiary.Record(bestiary.NewEntry(enemy, gs.clock.GameHours())) {
+        return
+    }
+    if err := gs.bestiary.Save(); err != nil {
+        logger.Warn("failed to save bestiary", "error", err)
+    }
+}
+
+// bestiaryModels returns every enemy mech type in the order they are listed
+// in the bestiary
+func bestiaryModels() []string {
+    models := make([]string, len(enemyMechConfigs))
+    for i, config := range enemyMechConfigs {
+        models[i] = config.name
+    }
+    return models
+}
+
+// ElapsedTicks returns the number of frames since the game started
+func (gs *GameState) ElapsedTicks() int {
+    return gs.elapsedTicks
+}
+
+// RewindTick winds the elapsed frames back by one when the player undoes
+// a move
+func (gs *GameState) RewindTick() {
+    if gs.elapsedTicks > 0 {
+        gs.elapsedTicks--
+    }
+}
+
+// Tick counts elapsed frames and ends the life once the player is destroyed
+func (gs *GameState) Tick(event tl.Event) {
+    if event.Type == tl.EventNone {
+        gs.elapsedTicks++
+        select {
+        case cfg := <-gs.configUpdates:
+            gs.ApplyConfig(cfg)
+        default:
+        }
+    }
+    if event.Type == tl.EventKey {
+        switch event.Ch {
+        case '+', '=':
+            gs.SetSpeedMultiplier(gs.speedMultiplier + speedStep)
+        case '-':
+            gs.SetSpeedMultiplier(gs.speedMultiplier - speedStep)
+        }
+    }
+    // The spectator camera shows the game over screen when it is done
+    spectating := gs.spectator != nil && gs.spectator.Active()
+    if gs.player != nil && gs.player.IsDestroyed() && !gs.dead && !spectating {
+        gs.playerDied()
+    }
+}
+
+// SpeedMultiplier returns how many times faster than normal the game runs
+func (gs *GameState) SpeedMultiplier() float64 {
+    return gs.speedMultiplier
+}
+
+// SetSpeedMultiplier sets the game speed, clamped between
+// minSpeedMultiplier and maxSpeedMultiplier, and applies it to the clock,
+// the bullets in flight and the AI timeout. Enemies read it each move.
+func (gs *GameState) SetSpeedMultiplier(multiplier float64) {
+    multiplier = math.Max(minSpeedMultiplier, math.Min(maxSpeedMultiplier, multiplier))
+    gs.speedMultiplier = multiplier
+    if gs.clock != nil {
+        gs.clock.SetSpeed(multiplier)
+    }
+    projectile.SetSpeed(gs.level, multiplier)
+    if gs.ollama != nil {
+        gs.ollama.SetTimeout(time.Duration(float64(ai.DefaultTimeout) / multiplier))
+    }
+}
+
+// MoveDelayChange returns what is added to every enemy's ticks between
+// moves this game
+func (gs *GameState) MoveDelayChange() int {
+    return gs.enemyMoveDelayChange
+}
+
+// HitRateBonus returns what is added to the hit rate of every enemy's
+// weapons this game
+func (gs *GameState) HitRateBonus() float64 {
+    return gs.enemyHitRateBonus
+}
+
+// ApplyConfig hot-patches the game with an updated configuration: weapon
+// damage and hit rates, the size of the next wave, the frame rate and the
+// enemies' moves and aim
+func (gs *GameState) ApplyConfig(cfg *config.Game) {
+    for name, stats := range cfg.Weapons {
+        tuning := weapon.Tuning{Damage: stats.Damage, HitRate: stats.HitRate}
+        weapon.SetTuning(name, tuning)
+        for _, entity := range gs.level.Entities {
+            switch m := entity.(type) {
+            case *mech.PlayerMech:
+                m.RetuneWeapons(name, tuning)
+            case *mech.EnemyMech:
+                m.RetuneWeapons(name, tuning)
+            }
+        }
+    }
+    if cfg.NextWaveMechs > 0 && gs.waves != nil {
+        gs.waves.SetNextWaveMechCount(cfg.NextWaveMechs)
+    }
+    if cfg.FPS > 0 {
+        gs.game.Screen().SetFps(float64(cfg.FPS))
+    }
+    if cfg.EnemyMoveDelayChange != nil {
+        gs.enemyMoveDelayChange = *cfg.EnemyMoveDelayChange
+    }
+    if cfg.EnemyHitRateBonus != nil {
+        gs.enemyHitRateBonus = *cfg.EnemyHitRateBonus
+    }
+    logger.Info("configuration updated", "event_type", "config_update",
+        "weapons", len(cfg.Weapons), "next_wave_mechs", cfg.NextWaveMechs, "fps", cfg.FPS,
+        "enemy_move_delay_change", gs.enemyMoveDelayChange, "enemy_hit_rate_bonus", gs.enemyHitRateBonus)
+}
+
+// playerTarget returns the player nearest x,y for enemies to fire at,
+// passing over a co-op player once destroyed, nil before the player is
+// created
+func (gs *GameState) playerTarget(x, y int) weapon.Target {
+    if gs.player == nil {
+        return nil
+    }
+    if gs.coopPlayer == nil || gs.coopPlayer.IsDestroyed() {
+        return gs.player
+    }
+    if gs.player.IsDestroyed() || gs.coopPlayer.DistanceToPoint(x, y) < gs.player.DistanceToPoint(x, y) {
+        return gs.coopPlayer
+    }
+    return gs.player
+}
+
+// Draw does nothing, the game state is not drawn
+func (gs *GameState) Draw(screen *tl.Screen) {}
+
+// SetPowerOutage cuts the city's power or turns it back on
+func (gs *GameState) SetPowerOutage(outage bool) {
+    if gs.power == nil {
+        return
+    }
+    if outage {
+        gs.power.CutPower()
+    } else {
+        gs.power.RestorePower()
+    }
+}
+
+// SpawnEnemies brings count reinforcements into the city
+func (gs *GameState) SpawnEnemies(count int) {
+    if gs.spawnEnemy == nil {
+        return
+    }
+    configs := waves.DefaultWaves()[0].MechConfigs()
+    for i := 0; i < count; i++ {
+        enemy := gs.spawnEnemy(configs[rand.Intn(len(configs))], i)
+        enemy.AttachGame(gs.game)
+        enemy.SetLevel(gs.level)
+        gs.level.AddEntity(enemy)
+        if gs.player != nil {
+            gs.player.AddEnemy(enemy.Mech)
+        }
+        if gs.coopPlayer != nil {
+            gs.coopPlayer.AddEnemy(enemy.Mech)
+        }
+    }
+}
+
+// PlaceSupplyDrops drops count supply drops onto the roads
+func (gs *GameState) PlaceSupplyDrops(count int) {
+    if gs.drops == nil {
+        return
+    }
+    for i := 0; i < count; i++ {
+        gs.drops.Spawn()
+    }
+}
+
+// SetEvacuated sounds the alarm sending civilians to the shelters or
+// silences it again
+func (gs *GameState) SetEvacuated(evacuated bool) {
+    gs.buildings.SetAlarm(evacuated)
 }
 
 // NewGameState creates a new game state instance
@@ -895,71 +1889,570 @@ func NewGameState(ollama *ai.OllamaClient) *GameState {
     game := tl.NewGame()
     game.Screen().SetFps(gameFPS)
     
-    level := tl.NewBaseLevel(tl.Cell{
+    return &GameState{
+        ollama:          ollama,
+        game:            game,
+        level:           newLevel(),
+        layoutSeed:      time.Now().UnixNano(),
+        names:           naming.NewNamingGenerator(),
+        speedMultiplier: 1.0,
+        undosRemaining:  mech.MaxUndos,
+        discovered:      make(map[string]bool),
+    }
+}
+
+// newLevel creates an empty level for the city to be built in
+func newLevel() *tl.BaseLevel {
+    return tl.NewBaseLevel(tl.Cell{
         Bg: tl.ColorBlack,
         Fg: tl.ColorBlack,
         Ch: ' ',
     })
-    
-    return &GameState{
-        ollama: ollama,
-        game:   game,
-        level:  level,
-    }
 }
 
-func main() {
-    // Initialize random seed
-    rand.Seed(time.Now().UnixNano())
+// buildWorld populates the game state's level with the city, its
+// inhabitants, the player and the systems running the game
+func (gs *GameState) buildWorld() {
+    // Every life starts with a fresh set of enemies
+    gs.names.Reset()
 
-    // Parse command line arguments
-    ollamaHost := flag.String("ollama-host", defaultOllamaHost, "Ollama API host address")
-    ollamaModel := flag.String("ollama-model", defaultOllamaModel, "Ollama model name")
-    flag.Parse()
-
-    // Initialize Ollama client and game state
-    ollama := initOllama(*ollamaHost, *ollamaModel)
-    gameState := NewGameState(ollama)
-
-    // Create Manhattan-like layout
-    createManhattanLayout(gameState.level)
+    // Create Manhattan-like layout, the same one every life
+    rand.Seed(gs.layoutSeed)
+    gs.roads, gs.buildings = createManhattanLayout(gs.level)
+    rand.Seed(time.Now().UnixNano())
 
     // Create the notification display
-    notification := display.NewNotification(25, 0, 45, 6, gameState.level)
+    notification := display.NewNotification(25, 0, 45, 6, gs.level)
+    // Critical notifications also ring the terminal bell unless it is off
+    alerts := display.NewMultiPriorityNotifier(notification)
+    if !gs.settings.noBell {
+        bell := audio.NewBellNotifier()
+        alerts.Add(bell)
+        gs.level.AddEntity(bell)
+    }
     
     // Create and add time system
-    timeSystem := NewTimeSystem(gameState.level)
-    gameState.level.AddEntity(timeSystem)
+    timeSystem := NewTimeSystem(gs.level)
+    gs.level.AddEntity(timeSystem)
+    gs.clock = timeSystem
+    gs.SetSpeedMultiplier(gs.speedMultiplier)
+    gs.lighting = setupLighting(timeSystem, gs.roads, gs.buildings)
     
     // Generate and place computer users
     users := GenerateComputerUsers(8)
-    placeComputerUsers(users, gameState.level)
+    gs.civilians = placeComputerUsers(users, gs.buildings, gs.roads, gs.level)
+    bus := eventbus.New()
+    for _, civilian := range gs.civilians {
+        civilian.AttachNotifier(notification)
+    }
+    subscribeMorale(bus, gs.civilians)
+    if gs.eventStream != nil {
+        bus.Subscribe(gs.eventStream.HandleEvent)
+    }
+    bus.Subscribe(gs.balance.HandleEvent)
+    gs.scheduleBalanceReport(timeSystem)
+
+    // Civilians run for the shelters whenever the alarm sounds
+    evacuation := building.NewEvacuationManager(gs.buildings)
+    gs.buildings.SetEvacuation(evacuation, evacuationSeconds*gameFPS)
+    for _, civilian := range gs.civilians {
+        civilian.evacuation = evacuation
+        evacuation.Register(civilian)
+    }
+    gs.level.AddEntity(evacuation)
+
+    // Civilians consult the language model when it is available, and are
+    // told where the city's important buildings stand until they fall
+    if gs.ollama != nil && gs.aiQueue != nil {
+        scheduler := ai.NewQueryScheduler()
+        pois := markPointsOfInterest(gs.buildings)
+        bus.Subscribe(pois.HandleEvent)
+        gs.environment = ai.NewEnvironmentBuilder(pois, gs.buildings)
+        for _, civilian := range gs.civilians {
+            civilian.surroundings = gs.environment
+            civilian.AttachAI(gs.aiCtx, gs.ollama, gs.aiQueue, scheduler, timeSystem)
+        }
+    }
+
+    // The power plant keeps the lights on and the doors unlocked
+    gs.power = power.NewGridSystem(bus)
+    if plant := findBuilding(gs.buildings, building.PowerPlantName); plant != nil {
+        gs.power.AttachPlant(plant)
+    }
+    bus.Subscribe(gs.lighting.HandleEvent)
+    bus.Subscribe(gs.buildings.HandleEvent)
+    gs.level.AddEntity(gs.power)
     
+    // Destroyed mechs and buildings burst into particles
+    explosions := display.NewExplosions(explosionParticles, explosionLifetime, gs.level)
+    gs.buildings.SetExploder(explosions)
+    if gs.buildingStats != nil {
+        gs.buildings.SetDestructionRecorder(gs.buildingStats)
+    }
+
+    // Destroyed buildings can set the buildings around them alight
+    fires := fire.NewPropagationSystem(gs.buildings)
+    gs.buildings.SetEventBus(bus)
+    bus.Subscribe(fires.HandleEvent)
+    gs.level.AddEntity(fires)
+
     // Create the enemy mechs
-    enemies := GenerateEnemyMechs(8, gameState.game, gameState.level)
+    snares := entities.NewSnareManager(gs.level)
+    enemies := GenerateEnemyMechs(8, gs.game, gs.level, gs.roads, snares, gs.names, gs.playerTarget)
     enemyMechs := make([]*mech.Mech, len(enemies))
     for i, enemy := range enemies {
-        enemy.SetLevel(gameState.level)
+        enemy.SetLevel(gs.level)
         enemy.AttachNotifier(notification)
-        gameState.level.AddEntity(enemy)
+        enemy.AttachLogger(logger)
+        enemy.SubscribeTo(bus)
+        enemy.AttachExploder(explosions)
+        enemy.AttachTarget(gs.playerTarget)
+        enemy.AttachClock(gs.ElapsedTicks)
+        enemy.AttachSpeed(gs)
+        enemy.AttachTuning(gs)
+        enemy.AttachDamageLog(gs.damageLog)
+        enemy.AttachEncounters(gs)
+        enemy.SetPeers(enemies)
+        gs.level.AddEntity(enemy)
         enemyMechs[i] = enemy.Mech
     }
     
     // Create the player mech
     x, y := getSafeSpawnPosition()
-    player := mech.NewPlayerMech("Player", 10, x, y, gameState.level)
-    player.AttachGame(gameState.game)
+    player := mech.NewPlayerMech(string(playerID), 10, x, y, gs.level, gs.settings.player)
+    player.AttachGame(gs.game)
     player.SetEnemyList(enemyMechs)
-    player.AttachNotifier(notification)
-    gameState.level.AddEntity(player)
-    player.AddWeapon(weapon.CreateRifle())
+    player.AttachNotifier(alerts)
+    player.AttachLogger(logger)
+    player.AttachEventBus(bus)
+    player.AttachExploder(explosions)
+    player.AttachClock(gs.ElapsedTicks)
+    player.AttachTickCounter(gs)
+    player.SetUndosRemaining(gs.undosRemaining)
+    player.AttachDamageLog(gs.damageLog)
+    player.SetFOVAngle(gs.settings.fovAngle)
+    shortReplay := replay.NewShortReplay(gs.game.Screen(), gs.level)
+    shortReplay.SetBounds(levelWidth, levelHeight)
+    shortReplay.AttachEnemies(player.EnemyPositions)
+    player.AttachRecorder(shortReplay)
+    gs.level.AddEntity(shortReplay)
+    gs.replay = shortReplay
+    // The sniper on overwatch is the last enemy generated
+    player.AttachBounties(newBountyRegistry(enemies[len(enemies)-1].Name()))
+    gs.drops = entities.NewDropManager(gs.level, gs.roads)
+    player.AttachDropManager(gs.drops)
+    player.AttachSnareManager(snares)
+    player.AttachPowerGrid(gs.power)
+    placeVehicles(gs.level, gs.drops)
+    placeRadioTowers(player, gs.level)
+    gs.player = player
+    if gs.environment != nil {
+        gs.environment.AttachEnemies(player)
+    }
+    player.AttachSpeedControl(gs, gameFPS)
+
+    // Watch the city carry on for a while after the player is destroyed
+    gs.spectator = camera.NewSpectator(gs.level, gameFPS, gs.playerDied)
+    spectated := make([]tl.Drawable, 0, len(enemies)+len(gs.civilians))
+    for _, enemy := range enemies {
+        spectated = append(spectated, enemy)
+    }
+    for _, civilian := range gs.civilians {
+        spectated = append(spectated, civilian)
+    }
+    gs.spectator.SetEntities(spectated)
+    player.AttachSpectator(gs.spectator)
+    gs.level.AddEntity(gs.spectator)
+
+    // A civilian near the start hands out quests
+    missions := mission.NewManager()
+    quests := newQuests(enemies[0].Name())
+    // Once an objective is done the player can be flown out from a landing
+    // zone, which shows on the mini map when half the quests are done. The
+    // zones go under the player so the player is drawn over them.
+    objectives := &missionObjectives{missions: missions, total: len(quests)}
+    landingZones := placeLandingZones(gs.roads, player, objectives, gs.playerExtracted, gs.level)
+    // The player goes first so Backspace declining a quest is not also
+    // taken as an undo
+    gs.level.AddEntity(player)
+    giver := placeQuestGiver(player, quests, missions, notification, gs.level)
+    gs.level.AddEntity(newMissionTracker(missions, player, gs.buildings, bus))
+    if gs.coop != nil {
+        gs.joinCoopPlayer(x, y, enemyMechs)
+    }
+
+    // Fog hides what the player cannot see, remembering roads and buildings
+    var fogLevel *fogmemory.Level
+    gs.screenLevel = gs.level
+    if gs.settings.fogOfWar {
+        fog := fogmemory.NewFogOfWar(player, gs.lighting, levelWidth, levelHeight)
+        gs.roads.SetLighting(fog)
+        for _, b := range gs.buildings.Buildings() {
+            b.SetLighting(fog)
+        }
+        gs.level.AddEntity(fog)
+        fogLevel = fogmemory.NewLevel(gs.level, fog)
+        gs.screenLevel = fogLevel
+    }
+
+    player.EquipLoadout(gs.settings.player.Loadout)
+    player.EquipSmartBomb(weapon.CreateSmartBomb())
     
+    // Create the wave manager for enemy reinforcements
+    gs.level.AddEntity(gs)
+    gs.spawnEnemy = newWaveEnemyFactory(gs.game, gs.level, gs.roads, snares, gs.names, bus, notification, explosions,
+        newDifficultyScaler(gs.settings.config), gs.ElapsedTicks, gs.damageLog, gs, gs, gs)
+    waveManager := waves.NewManager(gs.settings.waves, gameFPS, gs.spawnEnemy, player)
+    waveManager.Attach(gs.level, gs.game)
+    gs.level.AddEntity(waveManager)
+    gs.waves = waveManager
+
+    // Enemies toughen up while the player cruises and ease off while they
+    // struggle, keeping what was learned across lives
+    if gs.difficulty == nil {
+        gs.difficulty = ai.NewDifficultyAdapter(string(playerID), gameFPS)
+    }
+    bus.Subscribe(gs.difficulty.HandleEvent)
+    gs.level.AddEntity(newDifficultyDirector(gs, player, gs.difficulty))
+
     // Create the player status display
-    playerStatus := display.NewPlayer(0, 0, player, timeSystem, gameState.level)
-    gameState.level.AddEntity(playerStatus)
-    gameState.level.AddEntity(notification)
+    hudStart := len(gs.level.Entities)
+    playerStatus := display.NewPlayer(0, 0, player, timeSystem, gs.level)
+    gs.level.AddEntity(playerStatus)
+    gs.level.AddEntity(display.NewWaveIndicator(0, 14, waveManager, gs.level))
+    miniMap := display.NewMiniMap(0, 18, player, gs.level)
+    bus.Subscribe(miniMap.HandleEvent)
+    player.AttachGraveyard(miniMap)
+    miniMap.AttachBuildings(gs.buildings)
+    miniMap.AttachNearestEnemy(func() display.EnemyContact {
+        if enemy := player.NearestEnemy(); enemy != nil {
+            return enemy
+        }
+        return nil
+    })
+    coverAdvisor := cover.NewAdvisor(gs.buildings)
+    player.AttachCoverAdvisor(coverAdvisor)
+    gs.level.AddEntity(coverAdvisor)
+    gs.level.AddEntity(miniMap)
+
+    // The player can mark places to come back to with named waypoints
+    waypointName := display.NewTextInput(25, 12, display.WaypointNameLength, gs.level)
+    gs.waypoints = display.NewWaypoints(player, waypointName, gs.level)
+    player.AttachOverlay(waypointName)
+    miniMap.AttachWaypoints(gs.waypoints)
+    gs.level.AddEntity(gs.waypoints)
+    miniMap.AttachLandingZones(landingZones)
+
+    // Late in the game the player can put up buildings of their own
+    builder := blueprint.NewBuilder(player, gs.roads)
+    options := make([]display.BlueprintOption, len(blueprint.Blueprints))
+    for i, bp := range blueprint.Blueprints {
+        options[i] = display.BlueprintOption{Name: bp.Kind, Cost: bp.Cost}
+    }
+    blueprints := display.NewBlueprintMenu(25, 12, options, func(kind string) {
+        placeBlueprint(builder, kind, player, gs.level, gs.buildings, notification)
+    }, gs.level)
+    player.AttachOverlay(blueprints)
+    gs.level.AddEntity(blueprints)
+
+    // Bounty points are spent in the shop
+    rewards := make([]display.ShopOption, len(bounty.Rewards))
+    for i, reward := range bounty.Rewards {
+        rewards[i] = display.ShopOption{Name: reward.String(), Cost: reward.Cost()}
+    }
+    shop := display.NewShopOverlay(25, 12, rewards, player.BountyPoints, func(option int) {
+        player.RedeemBounty(bounty.Rewards[option])
+    }, gs.level)
+    player.AttachOverlay(shop)
+    gs.level.AddEntity(shop)
+
+    // Friendly civilians trade tips on enemy positions for protection
+    gs.level.AddEntity(newInformants(player, gs.civilians, bus, notification))
+
+    // Report the player's feats to the achievements they unlock
+    if gs.achievements != nil {
+        gs.achievementPopup = display.NewAchievementPopup(25, 7, gs.level)
+        tracker := newAchievementTracker(gs, timeSystem, waveManager)
+        bus.Subscribe(tracker.HandleEvent)
+        gs.level.AddEntity(tracker)
+        gs.level.AddEntity(gs.achievementPopup)
+    }
+
+    // Destroyed enemies come back tougher in challenge mode
+    killFeedY := 31
+    if gs.settings.challenge {
+        respawns := challenge.NewRespawnMode(challengeStructureMultiplier, gameFPS, player, gs.level)
+        gs.level.AddEntity(respawns)
+        gs.level.AddEntity(display.NewChallengePanel(0, 31, respawns, gs.level))
+        killFeedY = 35
+    }
+
+    // The last few destructions are listed below the minimap
+    killFeed := display.NewKillFeed(0, killFeedY, timeSystem, gs.damageLog, gs.level)
+    bus.Subscribe(killFeed.HandleEvent)
+    gs.level.AddEntity(killFeed)
+
+    // The controls that fit what the player is doing are listed along the
+    // bottom, with every control a ? away
+    help := display.NewContextualHelp(gs.level)
+    player.AttachHelp(help, newInteractionCheck(gs.civilians, giver, gs.buildings))
+    player.AttachOverlay(help)
+    gs.level.AddEntity(help)
+
+    // New players are shown around the AI-driven citizens the first time
+    if gs.settings.onboardingFile != "" && !display.OnboardingDone(gs.settings.onboardingFile) {
+        intro := display.NewOnboardingSequence(25, 10, gs.settings.onboardingFile, gameFPS, gs.level)
+        if len(gs.civilians) > 0 {
+            intro.AttachNPC(gs.civilians[0])
+        }
+        var model display.ResponseGenerator
+        if gs.ollama != nil {
+            model = gs.ollama
+        }
+        intro.AttachModel(gs.aiCtx, model, gs.settings.ollamaHost, gs.settings.ollamaModel)
+        player.AttachOverlay(intro)
+        gs.level.AddEntity(intro)
+    }
+
+    if gs.settings.debugInspector {
+        inspector := display.NewEntityInspector(25, 6, gs.level)
+        player.AttachInspector(inspector)
+        gs.level.AddEntity(inspector)
+        overlay := display.NewDebugOverlay(72, 0, projectile.BulletCounter(gs.level), gs.level)
+        overlay.AttachSpeed(gs)
+        overlay.AttachAIMetrics(ai.DefaultParseMetrics)
+        overlay.AttachFrameMeter(timing.NewTickGovernor(gameFPS))
+        gs.level.AddEntity(overlay)
+    }
+    gs.level.AddEntity(display.NewEvacuationTimer(72, 1, evacuation, gameFPS, gs.level))
+    fires.AttachFirefighter(player)
+    gs.level.AddEntity(display.NewFireWarning(72, 2, fires, gs.level))
+    partsInventory := display.NewPartsInventory(player, gs.level)
+    player.AttachPartsInventory(partsInventory)
+    gs.level.AddEntity(partsInventory)
+    gs.level.AddEntity(notification)
+    hud := gs.level.Entities[hudStart:]
+
+    // Acid rain falls at a random hour on rainy days
+    acidRain := hazard.NewAcidRain(gs.level, gameFPS)
+    acidRain.AttachNotifier(alerts)
+    gs.level.AddEntity(acidRain)
+    scheduleAcidRain(timeSystem, acidRain)
+
+    // Fire a random map event every minute
+    mapEvents := events.NewMapEventRegistry(events.DefaultEvents(), gameFPS, gs.level, gs)
+    mapEvents.AttachNotifier(alerts)
+    gs.level.AddEntity(mapEvents)
+
+    // Log teleports and impossible damage while validating movement
+    gs.level.AddEntity(statecheck.NewWatcher(gs.level, logger.Logger))
+
+    // Warn about and cull runaway entity counts before they slow the game
+    entityMonitor := level.NewEntityCountMonitor(gs.level, logger.Logger)
+    if cfg := gs.settings.config; cfg != nil && cfg.EntityWarnThreshold > 0 && cfg.EntityHardLimit > 0 {
+        entityMonitor.SetThresholds(cfg.EntityWarnThreshold, cfg.EntityHardLimit)
+    }
+    entityMonitor.AddEntity(entityMonitor)
+
+    // Bullet time tints everything drawn before it blue
+    screenEffect := display.NewScreenEffect(player, gs.level)
+    gs.level.AddEntity(screenEffect)
+
+    // The HUD and thermal contacts show through the fog
+    if fogLevel != nil {
+        thermal := mech.NewThermalOverlay(player)
+        gs.level.AddEntity(thermal)
+        fogLevel.AddOverlay(hud...)
+        fogLevel.AddOverlay(thermal, screenEffect)
+    }
+}
+
+func main() {
+    // Initialize random seed
+    rand.Seed(time.Now().UnixNano())
+
+    // Parse command line arguments
+    ollamaHost := flag.String("ollama-host", defaultOllamaHost, "Ollama API host address")
+    ollamaModel := flag.String("ollama-model", defaultOllamaModel, "Ollama model name")
+    logFormat := flag.String("log-format", defaultLogFormat, "Log output format (json|text)")
+    logFile := flag.String("log-file", defaultLogFile, "Log output file, empty for stderr")
+    configFile := flag.String("config", "", "Game configuration YAML file")
+    debugInspector := flag.Bool("debug-inspector", false, "Enable the F9 entity inspector")
+    fovAngle := flag.Float64("fov-angle", 180, "Player field of view in degrees (360 for all round vision)")
+    archetypeFile := flag.String("archetypes", defaultArchetypeFile, "NPC archetype JSON file")
+    debugMovement := flag.Bool("debug-movement", false, "Draw enemy paths and log anomalous state changes")
+    editorMode := flag.Bool("editor", false, "Start the map editor instead of the game")
+    mapFile := flag.String("map-file", defaultMapFile, "Layout file opened by the map editor")
+    worldFile := flag.String("world-file", defaultWorldFile, "File the city's damage is saved to between lives")
+    challengeMode := flag.Bool("challenge", false, "Respawn destroyed enemies with doubled structure")
+    fogOfWar := flag.Bool("fog-of-war", false, "Hide the city out of sight, showing a dim memory of explored areas")
+    wsLog := flag.String("ws-log", "", "WebSocket URL game events are streamed to")
+    coopMode := flag.Bool("coop", false, "Wait for a second player to join over the network")
+    coopAddr := flag.String("coop-addr", defaultCoopAddr, "TCP address co-op players connect to")
+    configURL := flag.String("config-url", "", "URL polled for configuration changes while the game runs")
+    configPoll := flag.Duration("config-poll", defaultConfigPoll, "How often to poll -config-url")
+    headlessRun := flag.Duration("headless", 0, "Run the game without a terminal for this long, then print a JSON summary")
+    noBell := flag.Bool("no-bell", false, "Do not ring the terminal bell on critical notifications")
+    metricsAddr := flag.String("metrics-addr", "", "Address AI parse metrics are served on at /metrics/ai, empty to disable")
+    replayExport := flag.String("replay-export", "", "File the last 30 seconds are written to as an ASCII replay on exit")
+    printControls := flag.Bool("controls", false, "Print the control reference as a Markdown table and exit")
+    flag.Parse()
+
+    if *printControls {
+        fmt.Print(display.ControlTable())
+        return
+    }
+
+    var err error
+    logger, err = initLogger(*logFormat, *logFile)
+    if err != nil {
+        log.Fatal(err)
+    }
+
+    debug.MovementValidation = *debugMovement
+    fitLevelToTerminal(display.NewTerminalSizeDetector(os.Stdout))
+
+    if *editorMode {
+        if err := runEditor(*mapFile); err != nil {
+            log.Fatal(err)
+        }
+        return
+    }
+
+    var gameConfig *config.Game
+    if *configFile != "" {
+        gameConfig, err = config.Load(*configFile)
+        if err != nil {
+            log.Fatal(err)
+        }
+    }
+    if gameConfig != nil && gameConfig.MaxBullets > 0 {
+        weapon.MaxConcurrentBullets = gameConfig.MaxBullets
+    }
+    enemyWaves, err := loadWaves(gameConfig)
+    if err != nil {
+        log.Fatal(err)
+    }
+    npcArchetypes, err = ai.LoadArchetypes(*archetypeFile)
+    if err != nil {
+        logger.Warn("failed to load NPC archetypes", "file", *archetypeFile, "error", err)
+    }
+
+    // Initialize Ollama client and game state
+    // Cancelled on shutdown so in-flight AI calls return immediately
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    ollama := initOllama(ctx, *ollamaHost, *ollamaModel)
+    gameState := NewGameState(ollama)
+    gameState.aiCtx = ctx
+    if ollama != nil {
+        gameState.aiQueue = ai.NewRequestQueue(ollama, ai.DefaultQueueWorkers)
+        // Cancel in-flight calls first so closing does not wait them out,
+        // however main returns
+        defer func() {
+            cancel()
+            gameState.aiQueue.Close()
+        }()
+    }
+    if *wsLog != "" {
+        gameState.eventStream = logging.NewWebSocketLogger(*wsLog)
+        defer gameState.eventStream.Close()
+    }
+    gameState.achievements = newAchievementManager()
+    gameState.buildingStats = loadBuildingStats()
+    gameState.bestiary = loadBestiary()
+    gameState.damageLog = damagelog.NewLog()
+    gameState.balance = balance.NewTracker(string(playerID))
+    gameState.balanceReporter = balance.NewReporter(balanceReportDir, logger.Logger)
+    if *configURL != "" {
+        remote := config.NewRemoteConfig()
+        defer remote.Stop()
+        gameState.configUpdates = make(chan *config.Game)
+        go remote.PollForUpdates(*configURL, *configPoll, gameConfig, gameState.configUpdates)
+    }
+    if *metricsAddr != "" {
+        go serveMetrics(*metricsAddr)
+    }
+    if *coopMode {
+        gameState.coop, err = coop.Listen(*coopAddr)
+        if err != nil {
+            log.Fatal(err)
+        }
+        defer gameState.coop.Close()
+    }
+
+    onboardingFile, err := display.DefaultOnboardingPath()
+    if err != nil {
+        logger.Warn("onboarding disabled", "error", err)
+    }
 
-    // Set the level and start the game
-    gameState.game.Screen().SetLevel(gameState.level)
+    gameState.settings = worldSettings{
+        config:         gameConfig,
+        waves:          enemyWaves,
+        fovAngle:       *fovAngle,
+        debugInspector: *debugInspector,
+        lives:          livesFromConfig(gameConfig),
+        worldFile:      *worldFile,
+        challenge:      *challengeMode,
+        fogOfWar:       *fogOfWar,
+        noBell:         *noBell,
+        onboardingFile: onboardingFile,
+        ollamaHost:     *ollamaHost,
+        ollamaModel:    *ollamaModel,
+        player:         mech.DefaultPlayerConfig(),
+    }
+
+    if *headlessRun > 0 {
+        // Headless runs are tests, so they earn no achievements, leave the
+        // lifetime building stats alone and never ring the bell
+        gameState.achievements = nil
+        gameState.buildingStats = nil
+        gameState.bestiary = nil
+        gameState.settings.noBell = true
+        gameState.settings.onboardingFile = ""
+        gameState.buildWorld()
+        gameState.game.Screen().SetLevel(gameState.screenLevel)
+        simulation := headless.NewSimulation(gameState.game.Screen(), gameFPS)
+        simulation.Run(*headlessRun)
+        if err := headless.WriteSummary(os.Stdout, gameState.headlessSummary(simulation.Ticks())); err != nil {
+            logger.Warn("failed to write headless summary", "error", err)
+        }
+        return
+    }
+
+    // Choose a loadout, then build the city and start the game
+    loadoutLevel := newLevel()
+    loadoutLevel.AddEntity(display.NewLoadoutScreen(func(config mech.PlayerConfig) {
+        gameState.settings.player = config
+        gameState.buildWorld()
+        gameState.game.Screen().SetLevel(gameState.screenLevel)
+    }))
+    gameState.game.Screen().SetLevel(loadoutLevel)
     gameState.game.Start()
+    cancel()
+    if *replayExport != "" && gameState.replay != nil {
+        if err := gameState.saveReplay(*replayExport); err != nil {
+            logger.Warn("failed to export replay", "file", *replayExport, "error", err)
+        }
+    }
+    if gameState.buildingStats != nil {
+        if err := gameState.buildingStats.Save(); err != nil {
+            logger.Warn("failed to save building stats", "error", err)
+        }
+    }
+    if gameState.eventStream != nil {
+        if err := gameState.eventStream.Flush(); err != nil {
+            logger.Warn("failed to flush streamed events", "error", err)
+        }
+    }
+}
+
+// serveMetrics serves the AI parse metrics over HTTP at addr until the game
+// exits
+func serveMetrics(addr string) {
+    mux := http.NewServeMux()
+    mux.Handle("/metrics/ai", ai.DefaultParseMetrics.PrometheusHandler())
+    if err := http.ListenAndServe(addr, mux); err != nil {
+        logger.Warn("metrics server stopped", "addr", addr, "error", err)
+    }
 }
@@ -0,0 +1,116 @@
+// Package mapdata saves and loads city layouts built in the map editor
+package mapdata
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// BuildingTile is a building placed on the map
+type BuildingTile struct {
+	X      int    `json:"x"`
+	Y      int    `json:"y"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Type   string `json:"type"`
+}
+
+// Contains returns true if x,y lies within the building footprint
+func (tile BuildingTile) Contains(x, y int) bool {
+	return x >= tile.X && x < tile.X+tile.Width && y >= tile.Y && y < tile.Y+tile.Height
+}
+
+// Layout is a city map of road cells and buildings
+type Layout struct {
+	Roads     [][2]int       `json:"roads"`
+	Buildings []BuildingTile `json:"buildings"`
+}
+
+// NewLayout creates an empty layout
+func NewLayout() *Layout {
+	return &Layout{
+		Roads:     make([][2]int, 0),
+		Buildings: make([]BuildingTile, 0),
+	}
+}
+
+// HasRoad returns true if x,y is a road cell
+func (l *Layout) HasRoad(x, y int) bool {
+	for _, road := range l.Roads {
+		if road[0] == x && road[1] == y {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildingAt returns the building covering x,y and whether there is one
+func (l *Layout) BuildingAt(x, y int) (BuildingTile, bool) {
+	for _, tile := range l.Buildings {
+		if tile.Contains(x, y) {
+			return tile, true
+		}
+	}
+	return BuildingTile{}, false
+}
+
+// AddRoad clears x,y and makes it a road cell
+func (l *Layout) AddRoad(x, y int) {
+	l.Clear(x, y)
+	l.Roads = append(l.Roads, [2]int{x, y})
+}
+
+// AddBuilding clears the building footprint and places tile on it
+func (l *Layout) AddBuilding(tile BuildingTile) {
+	for i := 0; i < tile.Width; i++ {
+		for j := 0; j < tile.Height; j++ {
+			l.Clear(tile.X+i, tile.Y+j)
+		}
+	}
+	l.Buildings = append(l.Buildings, tile)
+}
+
+// Clear removes the road cell or building at x,y
+func (l *Layout) Clear(x, y int) {
+	roads := l.Roads[:0]
+	for _, road := range l.Roads {
+		if road[0] != x || road[1] != y {
+			roads = append(roads, road)
+		}
+	}
+	l.Roads = roads
+
+	buildings := l.Buildings[:0]
+	for _, tile := range l.Buildings {
+		if !tile.Contains(x, y) {
+			buildings = append(buildings, tile)
+		}
+	}
+	l.Buildings = buildings
+}
+
+// SaveLayout writes layout to path as JSON
+func SaveLayout(path string, layout *Layout) error {
+	data, err := json.MarshalIndent(layout, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding layout: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("error writing layout: %v", err)
+	}
+	return nil
+}
+
+// LoadLayout reads a JSON layout from path
+func LoadLayout(path string) (*Layout, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading layout: %v", err)
+	}
+	layout := NewLayout()
+	if err := json.Unmarshal(data, layout); err != nil {
+		return nil, fmt.Errorf("error parsing layout: %v", err)
+	}
+	return layout, nil
+}
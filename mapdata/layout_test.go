@@ -0,0 +1,52 @@
+package mapdata
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveAndLoadHospital(t *testing.T) {
+	layout := NewLayout()
+	layout.AddRoad(0, 0)
+	layout.AddRoad(1, 0)
+	layout.AddBuilding(BuildingTile{X: 2, Y: 2, Width: 8, Height: 6, Type: "Hospital"})
+
+	path := filepath.Join(t.TempDir(), "layout.json")
+	if err := SaveLayout(path, layout); err != nil {
+		t.Fatalf("failed to save layout: %v", err)
+	}
+	loaded, err := LoadLayout(path)
+	if err != nil {
+		t.Fatalf("failed to load layout: %v", err)
+	}
+
+	tile, ok := loaded.BuildingAt(5, 4)
+	if !ok {
+		t.Fatalf("hospital missing after reload")
+	}
+	if tile.Type != "Hospital" {
+		t.Errorf("reloaded building is a %s instead of a Hospital", tile.Type)
+	}
+	if !loaded.HasRoad(1, 0) || len(loaded.Roads) != 2 {
+		t.Errorf("reloaded roads are %v", loaded.Roads)
+	}
+}
+
+func TestPlacingReplacesTiles(t *testing.T) {
+	layout := NewLayout()
+	layout.AddRoad(3, 3)
+	layout.AddBuilding(BuildingTile{X: 2, Y: 2, Width: 4, Height: 4, Type: "Bank"})
+	if layout.HasRoad(3, 3) {
+		t.Errorf("road under a new building was kept")
+	}
+
+	layout.AddRoad(4, 4)
+	if _, ok := layout.BuildingAt(2, 2); ok {
+		t.Errorf("building under a new road was kept")
+	}
+
+	layout.Clear(4, 4)
+	if layout.HasRoad(4, 4) {
+		t.Errorf("cleared road remains")
+	}
+}
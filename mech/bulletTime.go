@@ -0,0 +1,83 @@
+package mech
+
+const (
+	// bulletTimeSpeed is the game speed while bullet time is on
+	bulletTimeSpeed = 0.25
+	// bulletTimeSeconds is how long bullet time lasts
+	bulletTimeSeconds = 5
+	// bulletTimeCooldownTicks is how long bullet time takes to recharge
+	bulletTimeCooldownTicks = 300
+)
+
+// SpeedSource reports how many times faster than normal the game runs
+type SpeedSource interface {
+	SpeedMultiplier() float64
+}
+
+// SpeedControl sets how fast the rest of the game runs
+type SpeedControl interface {
+	SpeedSource
+	SetSpeedMultiplier(multiplier float64)
+}
+
+// AttachSpeedControl sets what bullet time slows down, with fps the ticks
+// in a second
+func (pMech *PlayerMech) AttachSpeedControl(control SpeedControl, fps int) {
+	pMech.speedControl = control
+	pMech.fps = fps
+}
+
+// ActivateBulletTime slows everything but the player to bulletTimeSpeed
+// for bulletTimeSeconds unless bullet time is already on or still
+// recharging. Returns true if it was turned on.
+func (pMech *PlayerMech) ActivateBulletTime() bool {
+	if pMech.speedControl == nil || pMech.bulletTimeActive || pMech.bulletTimeCooldown > 0 {
+		return false
+	}
+	pMech.bulletTimeActive = true
+	pMech.bulletTimeRemaining = bulletTimeSeconds * pMech.fps
+	pMech.speedBeforeBulletTime = pMech.speedControl.SpeedMultiplier()
+	pMech.speedControl.SetSpeedMultiplier(bulletTimeSpeed)
+	pMech.logAndNotify("bullet_time", "Bullet time")
+	return true
+}
+
+// BulletTimeActive returns true while the game is slowed by bullet time
+func (pMech *PlayerMech) BulletTimeActive() bool {
+	return pMech.bulletTimeActive
+}
+
+// BulletTimeStatus returns the ticks of bullet time left and the ticks of
+// cooldown that follow or remain
+func (pMech *PlayerMech) BulletTimeStatus() (remaining, cooldown int) {
+	if pMech.bulletTimeActive {
+		return pMech.bulletTimeRemaining, bulletTimeCooldownTicks
+	}
+	return 0, pMech.bulletTimeCooldown
+}
+
+// endBulletTime restores the game speed from before bullet time and
+// starts its cooldown
+func (pMech *PlayerMech) endBulletTime() {
+	if !pMech.bulletTimeActive {
+		return
+	}
+	pMech.bulletTimeActive = false
+	pMech.bulletTimeRemaining = 0
+	pMech.bulletTimeCooldown = bulletTimeCooldownTicks
+	pMech.speedControl.SetSpeedMultiplier(pMech.speedBeforeBulletTime)
+}
+
+// tickBulletTime counts down bullet time and then its cooldown
+func (pMech *PlayerMech) tickBulletTime() {
+	if pMech.bulletTimeActive {
+		pMech.bulletTimeRemaining--
+		if pMech.bulletTimeRemaining <= 0 {
+			pMech.endBulletTime()
+		}
+		return
+	}
+	if pMech.bulletTimeCooldown > 0 {
+		pMech.bulletTimeCooldown--
+	}
+}
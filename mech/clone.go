@@ -0,0 +1,77 @@
+package mech
+
+import (
+	"github.com/Ariemeth/frame_assault/entities"
+	"github.com/Ariemeth/frame_assault/mech/weapon"
+	tl "github.com/Ariemeth/termloop"
+)
+
+// Clone returns a copy of the mech at the same position with the same
+// structure, weapons and attachments. The copy has its own entity and its
+// own weapons, so hitting, moving or firing it leaves the original as it
+// was. Its previous position starts at 0,0.
+func (m *Mech) Clone() *Mech {
+	clone := *m
+	x, y := m.entity.Position()
+	clone.entity = tl.NewEntity(x, y, 1, 1)
+	clone.entity.SetCell(0, 0, &tl.Cell{Fg: m.color, Ch: m.symbol})
+	clone.prevX, clone.prevY = 0, 0
+	clone.weapons = make([]weapon.Weapon, len(m.weapons))
+	for i, w := range m.weapons {
+		clone.weapons[i] = w.Clone()
+	}
+	if m.KnownPlayerPosition != nil {
+		known := *m.KnownPlayerPosition
+		clone.KnownPlayerPosition = &known
+	}
+	return &clone
+}
+
+// Clone returns a copy of the enemy with its own copy of the base mech and
+// of its movement, for setting up tests and scenarios. The copy reports to
+// the same peers and, if the enemy is subscribed to its event bus, holds a
+// subscription of its own that Unsubscribe ends.
+func (e *EnemyMech) Clone() *EnemyMech {
+	clone := *e
+	clone.Mech = e.Mech.Clone()
+	if e.moveStrategy != nil {
+		clone.moveStrategy = e.moveStrategy.Clone()
+	}
+	if e.patrolStrategy == e.moveStrategy {
+		clone.patrolStrategy = clone.moveStrategy
+	} else if e.patrolStrategy != nil {
+		clone.patrolStrategy = e.patrolStrategy.Clone()
+	}
+	clone.peerList = append([]*EnemyMech(nil), e.peerList...)
+	clone.subscription = 0
+	if e.bus != nil && e.subscription != 0 {
+		clone.SubscribeTo(e.bus)
+	}
+	return &clone
+}
+
+// Clone returns a copy of the player with its own copy of the base mech,
+// salvaged parts and smart bomb, for setting up tests and scenarios. The
+// copy fights the same enemies and keeps the player's attachments.
+func (pMech *PlayerMech) Clone() *PlayerMech {
+	clone := *pMech
+	clone.Mech = *pMech.Mech.Clone()
+	clone.enemies = append([]*Mech(nil), pMech.enemies...)
+	clone.radioTowers = append([]*entities.RadioTower(nil), pMech.radioTowers...)
+	clone.prevPositions = append([][2]int(nil), pMech.prevPositions...)
+	clone.prevStructures = append([]int(nil), pMech.prevStructures...)
+	clone.overlays = append([]Overlay(nil), pMech.overlays...)
+	clone.parts = make([]*MechPart, len(pMech.parts))
+	for i, part := range pMech.parts {
+		copied := *part
+		clone.parts[i] = &copied
+	}
+	if pMech.smartBomb != nil {
+		bomb := *pMech.smartBomb
+		bomb.Weapon = pMech.smartBomb.Weapon.Clone()
+		clone.smartBomb = &bomb
+	}
+	clone.firedAt = nil
+	clone.AttachSplashTargets(clone.splashTargets)
+	return &clone
+}
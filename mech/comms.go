@@ -0,0 +1,153 @@
+package mech
+
+import (
+	"github.com/Ariemeth/frame_assault/building"
+	"github.com/Ariemeth/frame_assault/mech/movement"
+	"github.com/Ariemeth/frame_assault/mech/weapon"
+	"github.com/Ariemeth/frame_assault/util"
+)
+
+const (
+	// broadcastRange is how far in cells an enemy's radio reaches its peers
+	broadcastRange = 25
+
+	// broadcastTimeoutTicks is how long a relayed player position is acted
+	// on without a fresh broadcast
+	broadcastTimeoutTicks = 30
+
+	// detectionDistanceMode measures detection range along the grid the
+	// player moves on
+	detectionDistanceMode = util.Manhattan
+)
+
+// AlertState is whether an enemy is on its normal patrol or has reached
+// the player's last reported position
+type AlertState int
+
+const (
+	// StatePatrol follows the enemy's own movement strategy, or heads for
+	// a reported player position
+	StatePatrol AlertState = iota
+	// StateAlert has arrived where the player was last reported
+	StateAlert
+)
+
+// String returns the state's name
+func (s AlertState) String() string {
+	if s == StateAlert {
+		return "Alert"
+	}
+	return "Patrol"
+}
+
+// SetPeers sets the enemies this enemy relays the player's position to
+func (e *EnemyMech) SetPeers(peers []*EnemyMech) {
+	e.peerList = peers
+}
+
+// AttachTarget sets how the enemy finds the player to watch for and
+// report, target returning the player nearest x,y
+func (e *EnemyMech) AttachTarget(target func(x, y int) weapon.Target) {
+	e.target = target
+}
+
+// AlertState returns whether the enemy is patrolling or alerted
+func (e *EnemyMech) AlertState() AlertState {
+	return e.alertState
+}
+
+// BroadcastPosition relays the player position x,y to every peer within
+// broadcastRange
+func (e *EnemyMech) BroadcastPosition(x, y int) {
+	for _, peer := range e.peerList {
+		if peer == e || peer.IsDestroyed() {
+			continue
+		}
+		if e.IsInRange(peer.Mech, broadcastRange) {
+			peer.ReceivePositionBroadcast(x, y)
+		}
+	}
+}
+
+// ReceivePositionBroadcast records the player position x,y reported by a
+// peer. A patrolling enemy sets off straight toward it.
+func (e *EnemyMech) ReceivePositionBroadcast(x, y int) {
+	e.KnownPlayerPosition = &[2]int{x, y}
+	e.broadcastTicks = 0
+	if e.alertState != StatePatrol {
+		return
+	}
+	if overwatch, ok := e.moveStrategy.(*movement.OverwatchStrategy); ok && overwatch.Overwatching() {
+		return
+	}
+	if e.patrolStrategy == nil {
+		e.patrolStrategy = e.moveStrategy
+	}
+	e.moveStrategy = movement.NewSeekStrategy(x, y)
+}
+
+// detectPlayer broadcasts the player's position to peers when the player
+// is within detection range, counted in grid steps, and in line of sight
+func (e *EnemyMech) detectPlayer() {
+	if e.target == nil || len(e.peerList) == 0 {
+		return
+	}
+	target := e.target(e.Position())
+	if target == nil || target.IsDestroyed() {
+		return
+	}
+	// Stealthed players slip past enemy sensors
+	if hidden, ok := target.(stealther); ok && hidden.StealthActive() {
+		return
+	}
+	tx, ty := target.Position()
+	ex, ey := e.Position()
+	if util.Distance(ex, ey, tx, ty, detectionDistanceMode) > float64(e.DetectionRange()) || !e.hasLineOfSight(tx, ty) {
+		return
+	}
+	e.BroadcastPosition(tx, ty)
+}
+
+// hasLineOfSight returns true if no building stands between the enemy and
+// x,y
+func (e *EnemyMech) hasLineOfSight(x, y int) bool {
+	if e.level == nil {
+		return true
+	}
+	ex, ey := e.Position()
+	for _, entity := range e.level.Entities {
+		b, ok := entity.(*building.Building)
+		if !ok {
+			continue
+		}
+		bx, by := b.Position()
+		bw, bh := b.Size()
+		for _, cell := range util.Line(ex, ey, x, y) {
+			if cell[0] >= bx && cell[0] < bx+bw && cell[1] >= by && cell[1] < by+bh {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// updateAlert raises the alert on reaching the reported player position
+// and forgets the position once broadcasts stop arriving
+func (e *EnemyMech) updateAlert() {
+	if e.KnownPlayerPosition == nil {
+		return
+	}
+	e.broadcastTicks++
+	if e.broadcastTicks >= broadcastTimeoutTicks {
+		e.KnownPlayerPosition = nil
+		e.alertState = StatePatrol
+		if e.patrolStrategy != nil {
+			e.moveStrategy = e.patrolStrategy
+			e.patrolStrategy = nil
+		}
+		return
+	}
+	if x, y := e.Position(); e.alertState == StatePatrol && x == e.KnownPlayerPosition[0] && y == e.KnownPlayerPosition[1] {
+		e.alertState = StateAlert
+	}
+}
@@ -0,0 +1,47 @@
+package mech
+
+import "github.com/Ariemeth/frame_assault/mech/weapon"
+
+// empWeaponName is the name of the weapon the EMP key fires
+const empWeaponName = "EMP"
+
+// emp returns the EMP fitted to the mech, nil without one
+func (pMech *PlayerMech) emp() *weapon.Weapon {
+	for i := range pMech.weapons {
+		if pMech.weapons[i].Name() == empWeaponName {
+			return &pMech.weapons[i]
+		}
+	}
+	return nil
+}
+
+// fireEMP pulses the fitted EMP alone at the last enemy attacked,
+// splashing the enemies around it
+func (pMech *PlayerMech) fireEMP() {
+	emp := pMech.emp()
+	if emp == nil {
+		pMech.logAndNotify("emp", "No EMP fitted")
+		return
+	}
+	target := pMech.lastTarget
+	if target == nil || target.IsDestroyed() {
+		pMech.logAndNotify("emp", "No target for the EMP")
+		return
+	}
+	if !pMech.IsInRange(target, float64(emp.Range())) {
+		pMech.logAndNotify("emp", target.Name()+" is out of EMP range")
+		return
+	}
+
+	pMech.breakStealth()
+	alive := pMech.aliveEnemies()
+	x, y := pMech.entity.Position()
+	emp.SetPosition(x, y)
+	pMech.recordShot(target)
+	pMech.fireWeapon(emp, emp.RangeTo(target), target, pMech.streakBonus-pMech.movementPenalty)
+	for _, enemy := range alive {
+		if enemy.IsDestroyed() {
+			pMech.registerKill(enemy)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package mech
+
+import "github.com/Ariemeth/frame_assault/mech/movement"
+
+// EncounterRecorder is told the first time each enemy is in combat
+type EncounterRecorder interface {
+	Encounter(enemy *EnemyMech)
+}
+
+// AttachEncounters sets who is told when the enemy first sees combat
+func (e *EnemyMech) AttachEncounters(encounters EncounterRecorder) {
+	e.encounters = encounters
+}
+
+// Model returns the enemy's mech type, such as "Mech C"
+func (e *EnemyMech) Model() string {
+	return e.model
+}
+
+// WeaponType returns the name of the enemy's main weapon, empty if it is
+// unarmed
+func (e *EnemyMech) WeaponType() string {
+	if len(e.weapons) == 0 {
+		return ""
+	}
+	return e.weapons[0].Name()
+}
+
+// StrategyType returns how the enemy moves about the city
+func (e *EnemyMech) StrategyType() string {
+	switch e.moveStrategy.(type) {
+	case *movement.OverwatchStrategy:
+		return "overwatch"
+	case *movement.PatrolStrategy:
+		return "patrol"
+	case *movement.RandomWalkStrategy:
+		return "random walk"
+	}
+	return "unknown"
+}
+
+// checkEncounter tells the recorder the first time the enemy has been hit
+// or fired a shot
+func (e *EnemyMech) checkEncounter() {
+	if e.encountered || e.encounters == nil {
+		return
+	}
+	if e.structure < e.maxStructure || e.shotsFired > 0 {
+		e.encountered = true
+		e.encounters.Encounter(e)
+	}
+}
@@ -1,7 +1,14 @@
 package mech
 
 import (
+	"math"
+
+	"github.com/Ariemeth/frame_assault/eventbus"
+	"github.com/Ariemeth/frame_assault/hooks"
+	"github.com/Ariemeth/frame_assault/lifecycle"
 	"github.com/Ariemeth/frame_assault/mech/movement"
+	"github.com/Ariemeth/frame_assault/mech/weapon"
+	"github.com/Ariemeth/frame_assault/util"
 	"github.com/Ariemeth/frame_assault/util/debug"
 	tl "github.com/Ariemeth/termloop"
 )
@@ -10,14 +17,62 @@ const (
 	// moveDelayTicks represents how many ticks to wait between moves
 	// Since we're running at 2 FPS, setting this to 4 means moving every 2 seconds
 	moveDelayTicks = 4
+
+	// defaultDetectionRange is how far an enemy spots the player in cells
+	defaultDetectionRange = 12
+
+	// Debug path overlay glyphs
+	pathWaypointGlyph  = '○'
+	pathConnectorGlyph = '·'
 )
 
+// EnemyTuning adjusts every enemy of a game on top of its own stats
+type EnemyTuning interface {
+	// MoveDelayChange is added to the ticks between moves, negative for
+	// faster enemies
+	MoveDelayChange() int
+	// HitRateBonus is added to the hit rate of the enemy's weapons
+	HitRateBonus() float64
+}
+
+// cellRenderer is the part of tl.Screen used to draw overlays
+type cellRenderer interface {
+	RenderCell(x, y int, c *tl.Cell)
+}
+
 // EnemyMech represents an autonomous enemy mech
 type EnemyMech struct {
 	*Mech
 	moveStrategy movement.Strategy
 	moveDelay   int
 	tickCount   int
+	// powerOut halves the detection range while the city is dark
+	powerOut bool
+	// lastStructure is the structure seen last tick, used to notice hits
+	lastStructure int
+	// peerList are the enemies sent the player's position when spotted
+	peerList []*EnemyMech
+	target   func(x, y int) weapon.Target
+	// alertState tracks the response to a relayed player position
+	alertState     AlertState
+	broadcastTicks int
+	// patrolStrategy is the normal movement resumed once the alert ends
+	patrolStrategy movement.Strategy
+	// facingX and facingY are the direction of the last move
+	facingX int
+	facingY int
+	// model is the enemy's mech type, kept when it is given its own name
+	model string
+	// encounters is told the first time the enemy is in combat
+	encounters  EncounterRecorder
+	encountered bool
+	// subscription is the enemy's handler on the event bus, 0 when it is
+	// not listening
+	subscription eventbus.Subscription
+	// speed is the game speed the enemy moves at
+	speed SpeedSource
+	// tuning adjusts the enemy's moves and aim, nil to leave them as they are
+	tuning EnemyTuning
 }
 
 // NewEnemyMech creates a new enemy mech instance
@@ -27,13 +82,117 @@ func NewEnemyMech(name string, maxStructure, x, y int, color tl.Attr, symbol run
 		moveStrategy: strategy,
 		moveDelay:    moveDelayTicks,
 		tickCount:    0,
+		lastStructure: maxStructure,
+		model:        name,
+	}
+}
+
+// AttachSpeed sets the game speed the enemy moves at, a speed of 2
+// halving the ticks between moves. Without it the enemy moves at normal
+// speed.
+func (e *EnemyMech) AttachSpeed(speed SpeedSource) {
+	e.speed = speed
+}
+
+// AttachTuning sets the game's adjustments to the enemy's moves and aim
+func (e *EnemyMech) AttachTuning(tuning EnemyTuning) {
+	e.tuning = tuning
+}
+
+// MoveDelay returns the ticks between moves at the current game speed,
+// changed by the attached tuning
+func (e *EnemyMech) MoveDelay() int {
+	moveDelay := e.moveDelay
+	if e.tuning != nil {
+		moveDelay += e.tuning.MoveDelayChange()
+	}
+	if moveDelay < 1 {
+		moveDelay = 1
+	}
+	if e.speed == nil || e.speed.SpeedMultiplier() <= 0 {
+		return moveDelay
+	}
+	delay := int(math.Round(float64(moveDelay) / e.speed.SpeedMultiplier()))
+	if delay < 1 {
+		return 1
+	}
+	return delay
+}
+
+// DetectionRange returns how far the enemy spots the player in cells,
+// halved while the city's power is out
+func (e *EnemyMech) DetectionRange() int {
+	if e.powerOut {
+		return defaultDetectionRange / 2
+	}
+	return defaultDetectionRange
+}
+
+// HandleEvent shortens the enemy's detection range during power outages
+func (e *EnemyMech) HandleEvent(event eventbus.Event) {
+	switch event.(type) {
+	case eventbus.PowerOutageEvent:
+		e.powerOut = true
+	case eventbus.PowerRestoredEvent:
+		e.powerOut = false
+	}
+}
+
+// SubscribeTo attaches bus and has the enemy react to the events on it
+// until it is unsubscribed
+func (e *EnemyMech) SubscribeTo(bus *eventbus.Bus) {
+	e.AttachEventBus(bus)
+	e.subscription = bus.Subscribe(e.HandleEvent)
+}
+
+// Unsubscribe stops the enemy reacting to events on its bus
+func (e *EnemyMech) Unsubscribe() {
+	if e.bus == nil || e.subscription == 0 {
+		return
 	}
+	e.bus.Unsubscribe(e.subscription)
+	e.subscription = 0
+}
+
+// Respawn creates a fresh copy of the enemy at x,y with maxStructure,
+// keeping its name, look, movement, weapons and attachments. The enemy
+// respawned stops listening on the event bus in favor of its copy.
+func (e *EnemyMech) Respawn(x, y, maxStructure int) *EnemyMech {
+	respawned := NewEnemyMech(e.name, maxStructure, x, y, e.color, e.symbol, e.moveStrategy)
+	respawned.game = e.game
+	respawned.notifier = e.notifier
+	respawned.logger = e.logger
+	respawned.bus = e.bus
+	respawned.exploder = e.exploder
+	respawned.clock = e.clock
+	respawned.speed = e.speed
+	respawned.tuning = e.tuning
+	respawned.damageLog = e.damageLog
+	respawned.powerOut = e.powerOut
+	respawned.peerList = e.peerList
+	respawned.target = e.target
+	respawned.model = e.model
+	respawned.encounters = e.encounters
+	if e.bus != nil {
+		respawned.SubscribeTo(e.bus)
+	}
+	e.Unsubscribe()
+	e.ForEachWeapon(func(w *weapon.Weapon) {
+		fresh := *w
+		fresh.Refill()
+		fresh.Repair(weapon.MaxCondition)
+		respawned.AddWeapon(fresh)
+	})
+	respawned.SetLevel(e.level)
+	return respawned
 }
 
 // Tick handles the enemy mech's autonomous behavior
 func (e *EnemyMech) Tick(event tl.Event) {
+	lifecycle.NotifyTick(e)
 	// Call base Mech's Tick first
 	e.Mech.Tick(event)
+	e.checkEncounter()
 
 	// Only move if the mech is not destroyed
 	if !e.IsDestroyed() {
@@ -44,9 +203,30 @@ func (e *EnemyMech) Tick(event tl.Event) {
 			e.game.Log("Enemy %s tick: count=%d", e.Name(), e.tickCount)
 		}
 
+		// Overwatch mechs break cover once they are hit
+		overwatch, onOverwatch := e.moveStrategy.(*movement.OverwatchStrategy)
+		if onOverwatch && e.structure < e.lastStructure && overwatch.Overwatching() {
+			overwatch.Engage()
+			e.logAndNotify("overwatch", e.name+" breaks overwatch")
+		}
+		e.lastStructure = e.structure
+		e.updateAlert()
+
 		// Process movement every moveTickRate ticks
-		if e.tickCount >= e.moveDelay {
+		if e.tickCount >= e.MoveDelay() {
 			e.tickCount = 0
+			e.detectPlayer()
+
+			// Overwatch mechs hold position, firing into their zone
+			if onOverwatch && overwatch.Overwatching() {
+				e.suppress(overwatch)
+				return
+			}
+
+			// Slowed mechs skip some of their moves
+			if !e.canStep() {
+				return
+			}
 			
 			// Get current position
 			currentX, currentY := e.Position()
@@ -66,9 +246,105 @@ func (e *EnemyMech) Tick(event tl.Event) {
 			
 			// Store current position as previous
 			e.prevX, e.prevY = currentX, currentY
+			e.facingX, e.facingY = util.Sign(newX-currentX), util.Sign(newY-currentY)
 			
 			// Update position
 			e.entity.SetPosition(newX, newY)
 		}
 	}
 }
+
+// suppress fires at the overwatch's target if it is in the level, inside
+// the suppressed zone and within reach of one of the mech's weapons
+func (e *EnemyMech) suppress(overwatch *movement.OverwatchStrategy) {
+	target := overwatch.Target()
+	if target == nil || target.IsDestroyed() || e.level == nil {
+		return
+	}
+	for _, entity := range e.level.Entities {
+		if !standsFor(entity, target) {
+			continue
+		}
+		x, y := target.Position()
+		if overwatch.InZone(x, y) && e.AnyWeaponInRange(int(e.DistanceToPoint(x, y))) {
+			e.attack(target, e.hitRateBonus())
+		}
+		return
+	}
+}
+
+// hitRateBonus returns what the attached tuning adds to the enemy's hit
+// rate
+func (e *EnemyMech) hitRateBonus() float64 {
+	if e.tuning == nil {
+		return 0
+	}
+	return e.tuning.HitRateBonus()
+}
+
+// remotePlayer is a level entity controlling a player mech from
+// elsewhere, such as a co-op player over the network
+type remotePlayer interface {
+	Player() *PlayerMech
+}
+
+// standsFor returns true if entity is target or controls it
+func standsFor(entity tl.Drawable, target weapon.Target) bool {
+	if interface{}(entity) == interface{}(target) {
+		return true
+	}
+	remote, ok := entity.(remotePlayer)
+	return ok && weapon.Target(remote.Player()) == target
+}
+
+// Label returns a tag describing what the enemy is doing, empty if
+// nothing notable
+func (e *EnemyMech) Label() string {
+	if overwatch, ok := e.moveStrategy.(*movement.OverwatchStrategy); ok && overwatch.Overwatching() {
+		return "[OVERWATCH]"
+	}
+	return ""
+}
+
+// Draw draws the mech and, when movement debugging is enabled, its planned path.
+func (e *EnemyMech) Draw(screen *tl.Screen) {
+	if hooks.Draw("EnemyMech", e, screen) {
+		return
+	}
+	e.Mech.Draw(screen)
+	if debug.MovementValidation && !e.IsDestroyed() {
+		e.drawPath(screen)
+	}
+}
+
+// drawPath renders the strategy's planned path without affecting collisions
+func (e *EnemyMech) drawPath(screen cellRenderer) {
+	for pos, ch := range pathCells(e.moveStrategy.VisualPath()) {
+		screen.RenderCell(pos[0], pos[1], &tl.Cell{Fg: tl.ColorCyan, Ch: ch})
+	}
+}
+
+// pathCells maps each cell of a path overlay to its glyph. Points are marked
+// as waypoints and consecutive points, including the loop back to the first,
+// are joined by connectors.
+func pathCells(points [][2]int) map[[2]int]rune {
+	cells := make(map[[2]int]rune)
+	if len(points) == 0 {
+		return cells
+	}
+
+	segments := len(points) - 1
+	if len(points) > 2 {
+		segments = len(points)
+	}
+	for i := 0; i < segments; i++ {
+		from, to := points[i], points[(i+1)%len(points)]
+		for _, cell := range util.Line(from[0], from[1], to[0], to[1]) {
+			cells[cell] = pathConnectorGlyph
+		}
+	}
+	for _, point := range points {
+		cells[point] = pathWaypointGlyph
+	}
+	return cells
+}
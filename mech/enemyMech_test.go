@@ -0,0 +1,271 @@
+package mech
+
+import (
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/eventbus"
+	"github.com/Ariemeth/frame_assault/mech/movement"
+	"github.com/Ariemeth/frame_assault/mech/weapon"
+	"github.com/Ariemeth/frame_assault/util/debug"
+	tl "github.com/Ariemeth/termloop"
+)
+
+type testScreen struct {
+	cells map[[2]int]rune
+}
+
+func (s *testScreen) RenderCell(x, y int, c *tl.Cell) {
+	s.cells[[2]int{x, y}] = c.Ch
+}
+
+func TestPatrolPathOverlay(t *testing.T) {
+	debug.MovementValidation = true
+	defer func() { debug.MovementValidation = false }()
+
+	strategy, err := movement.NewPatrolStrategy([][2]int{{10, 5}, {14, 5}})
+	if err != nil {
+		t.Fatalf("unable to create patrol strategy: %v", err)
+	}
+	enemy := NewEnemyMech("testMech", 2, 10, 5, tl.ColorRed, 'T', strategy)
+	enemy.Tick(tl.Event{})
+
+	screen := &testScreen{cells: make(map[[2]int]rune)}
+	enemy.drawPath(screen)
+
+	expected := map[[2]int]rune{
+		{10, 5}: pathWaypointGlyph,
+		{11, 5}: pathConnectorGlyph,
+		{12, 5}: pathConnectorGlyph,
+		{13, 5}: pathConnectorGlyph,
+		{14, 5}: pathWaypointGlyph,
+	}
+	if len(screen.cells) != len(expected) {
+		t.Errorf("path overlay drew %d cells instead of %d", len(screen.cells), len(expected))
+	}
+	for pos, ch := range expected {
+		if screen.cells[pos] != ch {
+			t.Errorf("(%d,%d) drew %q instead of %q", pos[0], pos[1], screen.cells[pos], ch)
+		}
+	}
+}
+
+func TestRandomWalkHasNoOverlay(t *testing.T) {
+	enemy := NewEnemyMech("testMech", 2, 0, 0, tl.ColorRed, 'T', movement.NewRandomWalkStrategy())
+
+	screen := &testScreen{cells: make(map[[2]int]rune)}
+	enemy.drawPath(screen)
+	if len(screen.cells) != 0 {
+		t.Errorf("random walk drew %d overlay cells", len(screen.cells))
+	}
+}
+
+func TestOverwatchHoldsPositionAndFiresIntoZone(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	player := NewPlayerMech("Player", 10, 30, 30, level, DefaultPlayerConfig())
+	level.AddEntity(player)
+	overwatch := movement.NewOverwatchStrategy([4]int{5, 0, 10, 5},
+		func() weapon.Target { return player })
+	sniper := NewEnemyMech("Sniper", 5, 0, 0, tl.ColorRed, 'S', overwatch)
+	sniper.AddWeapon(weapon.Create(100, 1, "test rifle", 1.0))
+	sniper.SetLevel(level)
+	level.AddEntity(sniper)
+
+	tick := tl.Event{Type: tl.EventNone}
+	for i := 0; i < moveDelayTicks*5; i++ {
+		sniper.Tick(tick)
+	}
+	if x, y := sniper.Position(); x != 0 || y != 0 {
+		t.Errorf("overwatch mech moved to %d,%d", x, y)
+	}
+	if player.StructureLeft() != 10 {
+		t.Fatalf("overwatch fired at a target outside its zone")
+	}
+
+	player.entity.SetPosition(7, 3)
+	for i := 0; i < moveDelayTicks; i++ {
+		sniper.Tick(tick)
+	}
+	if player.StructureLeft() >= 10 {
+		t.Error("overwatch did not fire at the target in its zone")
+	}
+	if x, y := sniper.Position(); x != 0 || y != 0 {
+		t.Errorf("overwatch mech moved to %d,%d while firing", x, y)
+	}
+	if sniper.Label() != "[OVERWATCH]" {
+		t.Errorf("overwatch mech labeled %q", sniper.Label())
+	}
+
+	sniper.Hit(1, "Player")
+	sniper.Tick(tick)
+	if overwatch.State() != movement.StateCombat || sniper.Label() != "" {
+		t.Error("overwatch mech did not enter combat after being hit")
+	}
+}
+
+// stepRightStrategy moves one cell right every move
+type stepRightStrategy struct{}
+
+func (stepRightStrategy) NextMove(x, y int) (int, int) { return x + 1, y }
+
+func (stepRightStrategy) VisualPath() [][2]int { return nil }
+
+func (s stepRightStrategy) Clone() movement.Strategy { return s }
+
+func TestGameSpeedScalesEnemyMovement(t *testing.T) {
+	distance := func(speed float64) int {
+		enemy := NewEnemyMech("testMech", 2, 0, 5, tl.ColorRed, 'T', stepRightStrategy{})
+		enemy.AttachSpeed(&gameSpeed{multiplier: speed})
+		for i := 0; i < moveDelayTicks*4; i++ {
+			enemy.Tick(tl.Event{Type: tl.EventNone})
+		}
+		x, _ := enemy.Position()
+		return x
+	}
+
+	normal, fast := distance(1.0), distance(2.0)
+	if normal == 0 {
+		t.Fatalf("enemy did not move at normal speed")
+	}
+	if fast != normal*2 {
+		t.Errorf("enemy moved %d cells at 2x speed and %d at 1x", fast, normal)
+	}
+}
+
+// enemyTuning adjusts enemies as the game state does
+type enemyTuning struct {
+	moveDelayChange int
+	hitRateBonus    float64
+}
+
+func (t *enemyTuning) MoveDelayChange() int  { return t.moveDelayChange }
+func (t *enemyTuning) HitRateBonus() float64 { return t.hitRateBonus }
+
+func TestTuningIsKeptPerGame(t *testing.T) {
+	tuned := NewEnemyMech("Tuned", 2, 0, 5, tl.ColorRed, 'T', stepRightStrategy{})
+	tuning := &enemyTuning{moveDelayChange: -2}
+	tuned.AttachTuning(tuning)
+	other := NewEnemyMech("Other", 2, 0, 5, tl.ColorRed, 'O', stepRightStrategy{})
+
+	if tuned.MoveDelay() != moveDelayTicks-2 {
+		t.Errorf("tuned enemy waits %d ticks between moves, want %d", tuned.MoveDelay(), moveDelayTicks-2)
+	}
+	if other.MoveDelay() != moveDelayTicks {
+		t.Errorf("enemy of another game waits %d ticks between moves, want %d", other.MoveDelay(), moveDelayTicks)
+	}
+	if respawned := tuned.Respawn(0, 5, 2); respawned.MoveDelay() != moveDelayTicks-2 {
+		t.Errorf("respawned enemy waits %d ticks between moves, want %d", respawned.MoveDelay(), moveDelayTicks-2)
+	}
+
+	tuning.moveDelayChange = 0
+	if tuned.MoveDelay() != moveDelayTicks {
+		t.Errorf("enemy waits %d ticks between moves after the tuning was reset, want %d", tuned.MoveDelay(), moveDelayTicks)
+	}
+}
+
+func TestDetectionRangeIsCountedInGridSteps(t *testing.T) {
+	spotted := func(x, y int) bool {
+		player := NewPlayerMech("Player", 10, x, y, nil, DefaultPlayerConfig())
+		spotter := NewEnemyMech("Spotter", 5, 0, 0, tl.ColorRed, 'S', stepRightStrategy{})
+		spotter.AttachTarget(func(x, y int) weapon.Target { return player })
+		peer := NewEnemyMech("Peer", 5, 0, 10, tl.ColorRed, 'A', movement.NewRandomWalkStrategy())
+		peers := []*EnemyMech{spotter, peer}
+		spotter.SetPeers(peers)
+		peer.SetPeers(peers)
+		for i := 0; i < moveDelayTicks; i++ {
+			spotter.Tick(tl.Event{Type: tl.EventNone})
+		}
+		return peer.KnownPlayerPosition != nil
+	}
+
+	// 8,8 is 11.3 cells away in a straight line but 16 steps on the grid
+	if spotted(8, 8) {
+		t.Error("enemy spotted a player 16 grid steps away, beyond its detection range")
+	}
+	if !spotted(6, 6) {
+		t.Error("enemy did not spot a player 12 grid steps away")
+	}
+}
+
+func TestPeersReceivePlayerPosition(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	player := NewPlayerMech("Player", 10, 5, 0, level, DefaultPlayerConfig())
+	level.AddEntity(player)
+
+	spotter := NewEnemyMech("Spotter", 5, 0, 0, tl.ColorRed, 'S', stepRightStrategy{})
+	spotter.SetLevel(level)
+	spotter.AttachTarget(func(x, y int) weapon.Target { return player })
+	peers := []*EnemyMech{
+		spotter,
+		NewEnemyMech("Peer1", 5, 0, 10, tl.ColorRed, 'A', movement.NewRandomWalkStrategy()),
+		NewEnemyMech("Peer2", 5, 10, 10, tl.ColorRed, 'B', movement.NewRandomWalkStrategy()),
+	}
+	for _, enemy := range peers {
+		enemy.SetPeers(peers)
+	}
+
+	for i := 0; i < moveDelayTicks; i++ {
+		spotter.Tick(tl.Event{Type: tl.EventNone})
+	}
+	for _, peer := range peers[1:] {
+		known := peer.KnownPlayerPosition
+		if known == nil {
+			t.Fatalf("%s did not receive the player's position", peer.Name())
+		}
+		if known[0] != 5 || known[1] != 0 {
+			t.Errorf("%s received player position %d,%d instead of 5,0", peer.Name(), known[0], known[1])
+		}
+	}
+	if spotter.KnownPlayerPosition != nil {
+		t.Error("spotter relayed the player's position to itself")
+	}
+
+	// A peer heads for the reported cell
+	peer := peers[1]
+	before := peer.DistanceToPoint(5, 0)
+	for i := 0; i < moveDelayTicks*4; i++ {
+		peer.Tick(tl.Event{Type: tl.EventNone})
+	}
+	if after := peer.DistanceToPoint(5, 0); after >= before {
+		t.Errorf("%s is %.1f cells from the reported position after moving, was %.1f", peer.Name(), after, before)
+	}
+
+	// Without fresh broadcasts the position is forgotten
+	for i := 0; i < broadcastTimeoutTicks; i++ {
+		peer.Tick(tl.Event{Type: tl.EventNone})
+	}
+	if peer.KnownPlayerPosition != nil || peer.AlertState() != StatePatrol {
+		t.Error("peer kept the player's position after broadcasts stopped")
+	}
+}
+
+func TestRespawnMovesTheBusSubscription(t *testing.T) {
+	bus := eventbus.New()
+	enemy := NewEnemyMech("A I", 4, 0, 0, tl.ColorRed, 'A', movement.NewRandomWalkStrategy())
+	enemy.SubscribeTo(bus)
+	enemy.Hit(enemy.StructureLeft(), "Player")
+
+	respawned := enemy.Respawn(0, 0, 8)
+	bus.Publish(eventbus.PowerOutageEvent{})
+	if enemy.powerOut {
+		t.Error("the destroyed enemy still receives events after respawning")
+	}
+	if !respawned.powerOut {
+		t.Error("the respawned enemy does not receive events")
+	}
+}
+
+func TestCloneUnsubscribesOnItsOwn(t *testing.T) {
+	bus := eventbus.New()
+	enemy := NewEnemyMech("A I", 4, 0, 0, tl.ColorRed, 'A', movement.NewRandomWalkStrategy())
+	enemy.SubscribeTo(bus)
+
+	clone := enemy.Clone()
+	clone.Unsubscribe()
+	bus.Publish(eventbus.PowerOutageEvent{})
+	if clone.powerOut {
+		t.Error("the clone still receives events after unsubscribing")
+	}
+	if !enemy.powerOut {
+		t.Error("unsubscribing the clone stopped the original receiving events")
+	}
+}
@@ -0,0 +1,43 @@
+package mech
+
+// combatRange is how close in cells an enemy must be for the player to be
+// in combat
+const combatRange = 10
+
+// HelpDisplay shows the controls that fit what the player is doing
+type HelpDisplay interface {
+	SetSituation(inCombat, canInteract bool)
+}
+
+// AttachHelp sets the help display told each tick whether the player is in
+// combat and whether canInteract finds a civilian or building beside it
+func (pMech *PlayerMech) AttachHelp(help HelpDisplay, canInteract func(x, y int) bool) {
+	pMech.help = help
+	pMech.canInteract = canInteract
+}
+
+// InCombat returns true while an enemy still fighting is within
+// combatRange of the player
+func (pMech *PlayerMech) InCombat() bool {
+	for _, enemy := range pMech.enemies {
+		if enemy.IsDestroyed() {
+			continue
+		}
+		if pMech.IsInRange(enemy, combatRange) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateHelp tells the help display what the player is doing
+func (pMech *PlayerMech) updateHelp() {
+	if pMech.help == nil {
+		return
+	}
+	canInteract := false
+	if pMech.canInteract != nil {
+		canInteract = pMech.canInteract(pMech.entity.Position())
+	}
+	pMech.help.SetSituation(pMech.InCombat(), canInteract)
+}
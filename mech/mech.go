@@ -3,7 +3,15 @@ package mech
 
 import (
 	"strconv"
-
+	"strings"
+
+	"github.com/Ariemeth/frame_assault/damagelog"
+	"github.com/Ariemeth/frame_assault/effects"
+	"github.com/Ariemeth/frame_assault/entities"
+	"github.com/Ariemeth/frame_assault/eventbus"
+	"github.com/Ariemeth/frame_assault/lifecycle"
+	"github.com/Ariemeth/frame_assault/hooks"
+	"github.com/Ariemeth/frame_assault/logging"
 	"github.com/Ariemeth/frame_assault/mech/weapon"
 	"github.com/Ariemeth/frame_assault/util/debug"
 	"github.com/Ariemeth/frame_assault/util"
@@ -16,12 +24,38 @@ type Mech struct {
 	maxStructure int
 	weapons      []weapon.Weapon
 	name         string
+	color        tl.Attr
+	symbol       rune
 	entity       *tl.Entity
 	prevX        int
 	prevY        int
 	game         *tl.Game
 	level        *tl.BaseLevel
 	notifier     util.Notifier
+	logger       *logging.Logger
+	bus          *eventbus.Bus
+	exploder     Exploder
+	slowed       effects.SlowedEffect
+	stunned      effects.StunnedEffect
+	// slowedSteps counts moves attempted while slowed
+	slowedSteps int
+	// clock returns the game tick used to limit each weapon's fire rate
+	clock func() int
+	// splashTargets returns the targets a splashing weapon can catch
+	// around the one it is fired at
+	splashTargets func() []weapon.Target
+	// damageLog records every hit the mech takes
+	damageLog *damagelog.Log
+	// shotsFired counts every weapon fired by the mech
+	shotsFired int
+	// damageMultiplier multiplies the damage of every hit while an ability
+	// boosts it, leaving the weapons' own damage unchanged
+	damageMultiplier int
+	// observer calls the lifecycle hooks watching the mech
+	observer lifecycle.Observer
+	// KnownPlayerPosition is where peers last reported the player, nil
+	// when there is no recent report
+	KnownPlayerPosition *[2]int
 }
 
 const (
@@ -31,19 +65,35 @@ const (
 	minCoordinate = -maxLevelWidth // Allow negative coordinates up to level width
 )
 
+// Exploder shows an explosion where a mech is destroyed
+type Exploder interface {
+	Explode(x, y int)
+}
+
 // NewMech is used to create a new instance of a mech with default structure.
-func NewMech(name string, maxStructure, x, y int, color tl.Attr, symbol rune) *Mech {
+// opts can watch the mech with its own lifecycle hooks.
+func NewMech(name string, maxStructure, x, y int, color tl.Attr, symbol rune, opts ...lifecycle.Option) *Mech {
 	newMech := Mech{
 		name:         name,
 		structure:    maxStructure,
 		maxStructure: maxStructure,
+		color:        color,
+		symbol:       symbol,
 		entity:       tl.NewEntity(x, y, 1, 1),
+		observer:     lifecycle.NewObserver(opts...),
 	}
 
 	newMech.entity.SetCell(0, 0, &tl.Cell{Fg: color, Ch: symbol})
+	lifecycle.NotifyCreate(&newMech)
 	return &newMech
 }
 
+// LifecycleObserver returns what calls the lifecycle hooks watching the
+// mech
+func (m *Mech) LifecycleObserver() lifecycle.Observer {
+	return m.observer
+}
+
 // AttachGame is used to attach the termloop game struct for logging
 func (m *Mech) AttachGame(game *tl.Game) {
 	m.game = game
@@ -53,9 +103,26 @@ func (m *Mech) AttachGame(game *tl.Game) {
 func (m *Mech) SetLevel(level *tl.BaseLevel) {
 	m.level = level
 	// Update all weapons with the new level
-	for i := range m.weapons {
-		m.weapons[i].SetLevel(level)
-	}
+	m.ForEachWeapon(func(w *weapon.Weapon) {
+		w.SetLevel(level)
+	})
+}
+
+// AttachClock sets the game tick counter weapons are fired against. Without
+// a clock weapons ignore their fire rate.
+func (m *Mech) AttachClock(clock func() int) {
+	m.clock = clock
+}
+
+// AttachSplashTargets sets the targets that splashing weapons catch when
+// fired near them. Without it splashing weapons only hit their target.
+func (m *Mech) AttachSplashTargets(targets func() []weapon.Target) {
+	m.splashTargets = targets
+}
+
+// AttachDamageLog sets the log every hit the mech takes is recorded in
+func (m *Mech) AttachDamageLog(log *damagelog.Log) {
+	m.damageLog = log
 }
 
 // AttachNotifier is used to attach a notification display
@@ -63,21 +130,96 @@ func (m *Mech) AttachNotifier(notifier util.Notifier) {
 	m.notifier = notifier
 }
 
+// AttachLogger is used to attach a structured logger for game events
+func (m *Mech) AttachLogger(logger *logging.Logger) {
+	m.logger = logger
+}
+
+// AttachEventBus sets the bus the mech publishes its destruction to
+func (m *Mech) AttachEventBus(bus *eventbus.Bus) {
+	m.bus = bus
+}
+
+// AttachExploder sets what shows the mech's explosion when it is destroyed
+func (m *Mech) AttachExploder(exploder Exploder) {
+	m.exploder = exploder
+}
+
+// publish sends event on the attached bus if there is one
+func (m *Mech) publish(event eventbus.Event) {
+	if m.bus != nil {
+		m.bus.Publish(event)
+	}
+}
+
 // Name returns the name of the mech
 func (m Mech) Name() string {
 	return m.name
 }
 
+// SetName renames the mech
+func (m *Mech) SetName(name string) {
+	m.name = name
+	m.ForEachWeapon(func(w *weapon.Weapon) {
+		w.SetOwner(damagelog.EntityID(name))
+	})
+}
+
 // Weapons returns the mechs weapons
 func (m Mech) Weapons() []weapon.Weapon {
 	return m.weapons
 }
 
+// RetuneWeapons applies tuning to every weapon the mech has called name
+func (m *Mech) RetuneWeapons(name string, tuning weapon.Tuning) {
+	m.ForEachWeapon(func(w *weapon.Weapon) {
+		if strings.EqualFold(w.Name(), name) {
+			tuning.Apply(w)
+		}
+	})
+}
+
+// ForEachWeapon calls fn with each of the mech's weapons, which fn may
+// change
+func (m *Mech) ForEachWeapon(fn func(w *weapon.Weapon)) {
+	for i := range m.weapons {
+		fn(&m.weapons[i])
+	}
+}
+
+// ForEachActiveWeapon calls fn with each weapon that can fire, skipping
+// those with an empty magazine and those worn down until they jam
+func (m *Mech) ForEachActiveWeapon(fn func(w *weapon.Weapon)) {
+	m.ForEachWeapon(func(w *weapon.Weapon) {
+		if (w.MagazineSize() > 0 && w.Ammo() == 0) || w.Jammed() {
+			return
+		}
+		fn(w)
+	})
+}
+
+// AnyWeaponInRange returns true if at least one active weapon can reach a
+// target distance cells away
+func (m *Mech) AnyWeaponInRange(distance int) bool {
+	inRange := false
+	m.ForEachActiveWeapon(func(w *weapon.Weapon) {
+		if distance <= w.Range() {
+			inRange = true
+		}
+	})
+	return inRange
+}
+
 // StructureLeft Retrieves the amount of remaining structure a mech has.
 func (m Mech) StructureLeft() int {
 	return m.structure
 }
 
+// MaxStructure returns the structure of the mech when undamaged.
+func (m Mech) MaxStructure() int {
+	return m.maxStructure
+}
+
 // Size returns the height and width of the mech
 func (m Mech) Size() (int, int) {
 	return m.entity.Size()
@@ -88,10 +230,42 @@ func (m Mech) Position() (int, int) {
 	return m.entity.Position()
 }
 
+// DistanceTo returns the straight line distance from the mech to other
+func (m *Mech) DistanceTo(other *Mech) float64 {
+	x, y := other.Position()
+	return m.DistanceToPoint(x, y)
+}
+
+// DistanceToPoint returns the straight line distance from the mech to x,y
+func (m *Mech) DistanceToPoint(x, y int) float64 {
+	mx, my := m.entity.Position()
+	return util.Distance(mx, my, x, y, util.Euclidean)
+}
+
+// IsInRange returns true if target is no more than rangeLimit away
+func (m *Mech) IsInRange(target *Mech, rangeLimit float64) bool {
+	return m.DistanceTo(target) <= rangeLimit
+}
+
 // Collide is used called to see if the mech collided with another physical object
 func (m *Mech) Collide(collision tl.Physical) {
+	// Snares are stepped on rather than blocking the mech
+	if snare, ok := collision.(*entities.Snare); ok {
+		if snare.Trigger(m) {
+			m.logAndNotify("snared", m.name+" is caught in a snare")
+		}
+		return
+	}
 	// Check if it's a Rectangle we're colliding with
 	if _, ok := collision.(*tl.Rectangle); ok {
+		m.entity.SetPosition(m.prevX, m.prevY)
+		// or a vehicle or its wreck
+	} else if _, ok := collision.(*entities.Vehicle); ok {
+		m.entity.SetPosition(m.prevX, m.prevY)
+	} else if _, ok := collision.(*entities.VehicleWreck); ok {
+		m.entity.SetPosition(m.prevX, m.prevY)
+		// or a radio tower
+	} else if _, ok := collision.(*entities.RadioTower); ok {
 		m.entity.SetPosition(m.prevX, m.prevY)
 		// or if it is another mech
 	} else if _, ok := collision.(*Mech); ok {
@@ -101,6 +275,9 @@ func (m *Mech) Collide(collision tl.Physical) {
 
 // Draw passes the draw call to entity.
 func (m *Mech) Draw(screen *tl.Screen) {
+	if hooks.Draw("Mech", m, screen) {
+		return
+	}
 	if m.StructureLeft() > 0 {
 		m.entity.Draw(screen)
 	}
@@ -110,6 +287,10 @@ func (m *Mech) Draw(screen *tl.Screen) {
 // type of event.
 func (m *Mech) Tick(event tl.Event) {
 	m.prevX, m.prevY = m.entity.Position()
+	m.tickWeapons()
+	if event.Type == tl.EventNone {
+		m.tickEffects()
+	}
 
 	// Update level reference if needed
 	if m.level == nil && m.game != nil && m.game.Screen() != nil {
@@ -119,11 +300,72 @@ func (m *Mech) Tick(event tl.Event) {
 	}
 }
 
-// logAndNotify sends a message to both game log and notifier if they exist
-func (m *Mech) logAndNotify(message string) {
-	if m.game != nil {
-		m.game.Log(message)
+// Slow applies a slowed status effect, replacing any current one
+func (m *Mech) Slow(effect effects.SlowedEffect) {
+	m.slowed = effect
+	m.slowedSteps = 0
+}
+
+// Slowed returns true while the mech is under a slowed effect
+func (m *Mech) Slowed() bool {
+	return m.slowed.Active()
+}
+
+// Stun applies a stunned status effect, replacing any current one
+func (m *Mech) Stun(effect effects.StunnedEffect) {
+	m.stunned = effect
+}
+
+// Stunned returns true while the mech is stunned and unable to move
+func (m *Mech) Stunned() bool {
+	return m.stunned.Active()
+}
+
+// tickEffects counts down the mech's status effects
+func (m *Mech) tickEffects() {
+	m.slowed.Tick()
+	m.stunned.Tick()
+}
+
+// canStep returns true if the mech may make the move it is attempting.
+// A stunned mech cannot move and a slowed mech only completes one in
+// every SpeedDivisor moves.
+func (m *Mech) canStep() bool {
+	if m.stunned.Active() {
+		return false
+	}
+	if !m.slowed.Active() {
+		return true
+	}
+	m.slowedSteps++
+	return m.slowedSteps%m.slowed.SpeedDivisor == 0
+}
+
+// tickWeapons advances the reload timers of every weapon
+func (m *Mech) tickWeapons() {
+	m.ForEachWeapon(func(w *weapon.Weapon) {
+		w.Tick()
+	})
+}
+
+// logEvent writes a structured event tagged with the mech's name and position
+func (m *Mech) logEvent(eventType, message string, args ...any) {
+	if m.logger == nil {
+		return
 	}
+	x, y := m.entity.Position()
+	fields := append([]any{
+		"mech_name", m.name,
+		"position_x", x,
+		"position_y", y,
+		"event_type", eventType,
+	}, args...)
+	m.logger.Info(message, fields...)
+}
+
+// logAndNotify sends a message to both the event log and notifier if they exist
+func (m *Mech) logAndNotify(eventType, message string, args ...any) {
+	m.logEvent(eventType, message, args...)
 	if m.notifier != nil {
 		m.notifier.AddMessage(message)
 	}
@@ -134,24 +376,48 @@ func (m *Mech) removeFromLevel() {
 	if m.game == nil || m.game.Screen() == nil {
 		return
 	}
-	m.game.Screen().Level().RemoveEntity(m)
+	lifecycle.RemoveEntity(m.game.Screen().Level(), m)
 }
 
-// Hit is called when a mech is hit
-func (m *Mech) Hit(damage int) {
+// Hit is called when a mech is hit by attacker
+func (m *Mech) Hit(damage int, attacker damagelog.EntityID) {
 	if m.structure <= 0 {
 		return
 	}
+	if m.damageLog != nil {
+		tick := 0
+		if m.clock != nil {
+			tick = m.clock()
+		}
+		m.damageLog.RecordHit(attacker, damagelog.EntityID(m.name), damage, tick)
+	}
 
 	m.structure -= damage
-	m.logAndNotify(m.name + " takes " + strconv.Itoa(damage))
+	m.logAndNotify("damage", m.name+" takes "+strconv.Itoa(damage),
+		"damage", damage, "structure", m.structure)
 
 	if m.structure <= 0 {
-		m.logAndNotify(m.name + " has been destroyed")
+		m.logAndNotify("destroyed", m.name+" has been destroyed")
+		x, y := m.entity.Position()
+		m.publish(eventbus.MechDestroyedEvent{Name: m.name, X: x, Y: y})
+		if m.exploder != nil {
+			m.exploder.Explode(x, y)
+		}
 		m.removeFromLevel()
 	}
 }
 
+// Repair restores structure up to the mech's maximum
+func (m *Mech) Repair(amount int) {
+	if m.structure <= 0 {
+		return
+	}
+	m.structure += amount
+	if m.structure > m.maxStructure {
+		m.structure = m.maxStructure
+	}
+}
+
 // IsDestroyed returns true is the target is destroyed, false otherwise.
 func (m Mech) IsDestroyed() bool {
 	return m.structure <= 0
@@ -163,35 +429,92 @@ func (m *Mech) AddWeapon(w weapon.Weapon) {
 	if m.level != nil {
 		w.SetLevel(m.level)
 	}
+	w.SetOwner(damagelog.EntityID(m.name))
 	m.weapons = append(m.weapons, w)
 }
 
 // Fire tells the Mech to fire at a Target
 func (m *Mech) Fire(rangeToTarget int, target weapon.Target) {
 	x, y := m.entity.Position()
-	for _, w := range m.weapons {
+	m.ForEachWeapon(func(w *weapon.Weapon) {
 		// Update weapon position before firing
 		w.SetPosition(x, y)
-		result := w.Fire(rangeToTarget, target)
-		if result == false {
-			m.notifier.AddMessage("Missed " + target.Name())
+		m.fireWeapon(w, rangeToTarget, target, 0)
+	})
+}
+
+// fireAt fires every weapon at a Target, each measuring the range
+// with its own distance mode and hitting with its accuracy raised by
+// accuracyBonus. Returns whether each weapon hit.
+func (m *Mech) fireAt(target weapon.Target, accuracyBonus float64) []bool {
+	x, y := m.entity.Position()
+	hits := make([]bool, 0, len(m.weapons))
+	m.ForEachWeapon(func(w *weapon.Weapon) {
+		// Weapons still cycling, or held while too many bullets fly, sit
+		// the attack out rather than miss
+		if m.clock != nil && !w.Ready(m.clock()) || w.Throttled() {
+			return
 		}
+		w.SetPosition(x, y)
+		hits = append(hits, m.fireWeapon(w, w.RangeTo(target), target, accuracyBonus))
+	})
+	return hits
+}
+
+// fireWeapon fires a single weapon, splashing the targets around the
+// impact if the weapon splashes, and reports a miss. Returns true if the
+// target was hit.
+func (m *Mech) fireWeapon(w *weapon.Weapon, rangeToTarget int, target weapon.Target, accuracyBonus float64) bool {
+	if w.Jammed() {
+		m.logAndNotify("jam", w.Name()+" jammed!", "weapon", w.Name())
+		return false
+	}
+	if m.clock != nil && !w.Ready(m.clock()) || w.Throttled() {
+		return false
+	}
+	m.publish(eventbus.WeaponFiredEvent{Attacker: m.name, Weapon: w.Name(), Range: rangeToTarget})
+	m.shotsFired++
+	// Collect splash victims before the hit can remove the target
+	var victims []weapon.Target
+	if w.SplashRadius() > 0 && m.splashTargets != nil {
+		victims = w.SplashVictims(target, m.splashTargets())
+	}
+	if multiplier := m.DamageMultiplier(); multiplier > 1 {
+		target = amplifiedTarget{target: target, multiplier: multiplier}
+	}
+	var result bool
+	if m.clock != nil {
+		result = w.FireWithAccuracyAtTick(rangeToTarget, target, w.Accuracy()+w.StabilityBonus()+accuracyBonus, m.clock())
+	} else {
+		result = w.FireWithAccuracy(rangeToTarget, target, w.Accuracy()+w.StabilityBonus()+accuracyBonus)
 	}
+	w.Splash(victims)
+	if result == false {
+		m.logAndNotify("miss", "Missed "+target.Name(),
+			"weapon", w.Name(), "target", target.Name(), "range", rangeToTarget)
+	}
+	return result
 }
 
-func (m *Mech) attack(target weapon.Target) {
+// attack fires every weapon at target with accuracyBonus added to their
+// accuracy. Returns whether each weapon hit.
+func (m *Mech) attack(target weapon.Target, accuracyBonus float64) []bool {
 	if target == nil {
-		return
+		return nil
 	}
 	if target.IsDestroyed() {
-		return
+		return nil
 	}
 
 	targetX, targetY := target.Position()
-	distance := util.CalculateDistance(m.prevX, m.prevY, targetX, targetY)
-	m.Fire((int)(distance), target)
-	m.game.Log("distance " + strconv.Itoa((int)(distance)))
-	m.game.Log("firer (%d,%d), target (%d,%d)", m.prevX, m.prevY, targetX, targetY)
+	distance := m.DistanceToPoint(targetX, targetY)
+	hits := m.fireAt(target, accuracyBonus)
+	m.logEvent("attack", "attacking "+target.Name(),
+		"target", target.Name(),
+		"target_x", targetX,
+		"target_y", targetY,
+		"distance", (int)(distance))
+	return hits
 }
 
 // isValidMove checks if a move to the new position is valid
@@ -199,8 +522,9 @@ func (m *Mech) isValidMove(newX, newY int) bool {
 	// Check game boundaries
 	if newX < minCoordinate || newX > maxLevelWidth ||
 		newY < minCoordinate || newY > maxLevelHeight {
-		if debug.MovementValidation && m.game != nil {
-			m.game.Log("%s attempted to move out of bounds to (%d,%d)", m.name, newX, newY)
+		if debug.MovementValidation {
+			m.logEvent("invalid_move", "attempted to move out of bounds",
+				"target_x", newX, "target_y", newY)
 		}
 		return false
 	}
@@ -220,13 +544,19 @@ func (m *Mech) isValidMove(newX, newY int) bool {
 				continue
 			}
 
+			// Snares are meant to be walked onto
+			if _, isSnare := entity.(*entities.Snare); isSnare {
+				continue
+			}
+
 			// Get entity position
 			eX, eY := physical.Position()
 			
 			// If entity is at target position, collision detected
 			if eX == newX && eY == newY {
-				if debug.MovementValidation && m.game != nil {
-					m.game.Log("%s attempted to move into occupied position (%d,%d)", m.name, newX, newY)
+				if debug.MovementValidation {
+					m.logEvent("invalid_move", "attempted to move into occupied position",
+						"target_x", newX, "target_y", newY)
 				}
 				return false
 			}
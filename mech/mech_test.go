@@ -1,8 +1,14 @@
 package mech
 
 import (
+	"bytes"
+	"encoding/json"
+	"strings"
 	"testing"
 
+	"github.com/Ariemeth/frame_assault/entities"
+	"github.com/Ariemeth/frame_assault/lifecycle"
+	"github.com/Ariemeth/frame_assault/logging"
 	"github.com/Ariemeth/frame_assault/mech/weapon"
 	tl "github.com/Ariemeth/termloop"
 )
@@ -30,6 +36,34 @@ func TestNewMech(t *testing.T) {
 	}
 }
 
+func TestNewMechCallsOnCreateHook(t *testing.T) {
+	defer lifecycle.Global.Register(lifecycle.Hooks{})
+	created := 0
+	lifecycle.Global.Register(lifecycle.Hooks{OnCreate: func(entity tl.Drawable) { created++ }})
+
+	m := NewMech("testMech", 2, 0, 0, tl.ColorRed, 'T')
+	if created != 1 {
+		t.Errorf("OnCreate called %d times for one mech, want once", created)
+	}
+
+	// Hooks given to a mech replace the global ones for it alone
+	destroyed := make([]tl.Drawable, 0)
+	own := NewMech("ownHooks", 2, 0, 0, tl.ColorRed, 'O', lifecycle.WithHooks(lifecycle.Hooks{
+		OnDestroy: func(entity tl.Drawable) { destroyed = append(destroyed, entity) },
+	}))
+	if created != 1 {
+		t.Errorf("global OnCreate called for a mech with its own hooks")
+	}
+	level := tl.NewBaseLevel(tl.Cell{})
+	level.AddEntity(m)
+	level.AddEntity(own)
+	lifecycle.RemoveEntity(level, m)
+	lifecycle.RemoveEntity(level, own)
+	if len(destroyed) != 1 || destroyed[0] != own {
+		t.Errorf("own OnDestroy saw %v, want only the mech it watches", destroyed)
+	}
+}
+
 func TestHit(t *testing.T) {
 
 	const mechName string = "testMech"
@@ -41,13 +75,13 @@ func TestHit(t *testing.T) {
 			mechName)
 	}
 
-	mech1.Hit(0)
+	mech1.Hit(0, "Mech 2")
 	if mech1.structure != structure {
 		t.Errorf("%s took damage when it was hit with 0",
 			mechName)
 	}
 
-	mech1.Hit(structure)
+	mech1.Hit(structure, "Mech 2")
 	if mech1.structure != 0 {
 		t.Errorf("%s was not destroyed by taking %d damage",
 			mechName,
@@ -153,6 +187,54 @@ func TestMechFireOutOfRange(t *testing.T) {
 	}
 }
 
+func TestHitLogsCombatEvents(t *testing.T) {
+	const mechName string = "testMech"
+	const structure int = 2
+
+	var buf bytes.Buffer
+	mech1 := NewMech(mechName, structure, 3, 4, tl.ColorRed, 'T')
+	mech1.AttachLogger(logging.NewJSONLogger(&buf))
+
+	mech1.Hit(structure, "Mech 2")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("%s logged %d events instead of 2: %q", mechName, len(lines), buf.String())
+	}
+
+	eventTypes := []string{"damage", "destroyed"}
+	for i, line := range lines {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("unable to parse log line %q: %v", line, err)
+		}
+		if entry["mech_name"] != mechName {
+			t.Errorf("mech_name is %v instead of %s", entry["mech_name"], mechName)
+		}
+		if entry["position_x"] != float64(3) || entry["position_y"] != float64(4) {
+			t.Errorf("position is (%v,%v) instead of (3,4)", entry["position_x"], entry["position_y"])
+		}
+		if entry["event_type"] != eventTypes[i] {
+			t.Errorf("event_type is %v instead of %s", entry["event_type"], eventTypes[i])
+		}
+	}
+}
+
+func TestDistanceTo(t *testing.T) {
+	m := NewMech("testMech", 2, 0, 0, tl.ColorRed, 'T')
+	other := NewMech("testMech2", 2, 3, 4, tl.ColorRed, 'T')
+
+	if d := m.DistanceTo(other); d != 5.0 {
+		t.Errorf("distance from 0,0 to 3,4 is %v, want 5", d)
+	}
+	if d := m.DistanceToPoint(3, 4); d != 5.0 {
+		t.Errorf("distance to the point 3,4 is %v, want 5", d)
+	}
+	if !m.IsInRange(other, 5) || m.IsInRange(other, 4.9) {
+		t.Error("a mech 5 away is not in range 5 alone")
+	}
+}
+
 func TestMechPosition(t *testing.T) {
 
 }
@@ -168,3 +250,102 @@ func TestMechDraw(t *testing.T) {
 func TestMechCollide(t *testing.T) {
 
 }
+
+func TestMechSteppingOnSnareIsSlowed(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	snares := entities.NewSnareManager(level)
+	if _, err := snares.Place(1, 0); err != nil {
+		t.Fatalf("failed to place snare: %v", err)
+	}
+
+	m := NewMech("Test", 10, 1, 0, tl.ColorRed, 'T')
+	level.AddEntity(m)
+	level.Tick(tl.Event{Type: tl.EventNone})
+
+	if !m.Slowed() {
+		t.Errorf("mech stepping on a snare was not slowed")
+	}
+	if snares.IsSnared(1, 0) {
+		t.Errorf("triggered snare is still active")
+	}
+	for _, entity := range level.Entities {
+		if _, ok := entity.(*entities.Snare); ok {
+			t.Errorf("triggered snare was not removed from the level")
+		}
+	}
+}
+
+func TestVehicleImpactDamagesMechAndVehicle(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	vehicle := entities.NewVehicle(0, 0, 10, 0, 1.0, level)
+	m := NewMech("Test", 10, 1, 0, tl.ColorRed, 'T')
+	level.AddEntity(vehicle)
+	level.AddEntity(m)
+
+	level.Tick(tl.Event{Type: tl.EventNone})
+
+	damage := int(vehicle.Speed() * entities.VehicleImpactFactor)
+	if m.StructureLeft() != 10-damage {
+		t.Errorf("mech has %d structure after the impact instead of %d", m.StructureLeft(), 10-damage)
+	}
+	if vehicle.HP != 10-damage {
+		t.Errorf("vehicle has %d HP after the impact instead of %d", vehicle.HP, 10-damage)
+	}
+	if !m.Stunned() {
+		t.Errorf("mech was not stunned by the impact")
+	}
+}
+
+func TestCloneDoesNotShareState(t *testing.T) {
+	original := NewMech("Mech A", 20, 3, 4, tl.ColorRed, 'A')
+	original.AddWeapon(weapon.CreateRifle())
+	original.AddWeapon(weapon.CreateShotgun())
+
+	clone := original.Clone()
+	clone.Hit(5, "test")
+	clone.weapons[0].SetDamage(99)
+	clone.entity.SetPosition(10, 10)
+
+	if original.StructureLeft() != 20 {
+		t.Errorf("original has %d structure after its clone was hit, want 20", original.StructureLeft())
+	}
+	if clone.StructureLeft() != 15 {
+		t.Errorf("clone has %d structure after a hit for 5, want 15", clone.StructureLeft())
+	}
+	if len(clone.Weapons()) != 2 {
+		t.Fatalf("clone has %d weapons, want 2", len(clone.Weapons()))
+	}
+	if original.Weapons()[0].Damage() == 99 {
+		t.Error("changing the clone's weapon changed the original's")
+	}
+	if x, y := original.Position(); x != 3 || y != 4 {
+		t.Errorf("original moved to %d,%d with its clone", x, y)
+	}
+}
+
+func TestForEachActiveWeaponSkipsEmptyWeapons(t *testing.T) {
+	target := NewMech("target", 10, 1, 0, tl.ColorRed, 'T')
+	empty := weapon.CreateWithMagazine(20, 1, "test pistol", 1.0, 1, 10)
+	empty.Fire(1, target)
+	if empty.Ammo() != 0 {
+		t.Fatalf("test pistol has %d shots left after firing its only one", empty.Ammo())
+	}
+
+	m := NewMech("testMech", 10, 0, 0, tl.ColorRed, 'T')
+	m.AddWeapon(empty)
+	m.AddWeapon(weapon.Create(5, 1, "test claw", 1.0))
+
+	active := make([]string, 0)
+	m.ForEachActiveWeapon(func(w *weapon.Weapon) {
+		active = append(active, w.Name())
+	})
+	if len(active) != 1 || active[0] != "test claw" {
+		t.Errorf("active weapons are %v, want only the test claw", active)
+	}
+	if !m.AnyWeaponInRange(5) {
+		t.Error("no weapon in range 5 with the test claw loaded")
+	}
+	if m.AnyWeaponInRange(10) {
+		t.Error("the empty test pistol counted as in range 10")
+	}
+}
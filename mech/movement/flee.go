@@ -0,0 +1,32 @@
+package movement
+
+import (
+	"math"
+)
+
+// FleePath returns length cells leading directly away from threatX,threatY
+// starting next to x,y. Returns nil if the threat is at x,y.
+func FleePath(x, y, threatX, threatY, length int) [][2]int {
+	dx := float64(x - threatX)
+	dy := float64(y - threatY)
+	distance := math.Sqrt(dx*dx + dy*dy)
+	if distance == 0 {
+		return nil
+	}
+	dx /= distance
+	dy /= distance
+
+	path := make([][2]int, 0, length)
+	for step := 1; len(path) < length; step++ {
+		cell := [2]int{
+			clampToGameBounds(x+int(math.Round(dx*float64(step))), minCoordinate, maxLevelWidth),
+			clampToGameBounds(y+int(math.Round(dy*float64(step))), minCoordinate, maxLevelHeight),
+		}
+		if len(path) > 0 && path[len(path)-1] == cell {
+			// Pinned against the edge of the map
+			break
+		}
+		path = append(path, cell)
+	}
+	return path
+}
@@ -0,0 +1,106 @@
+package movement
+
+import (
+	"github.com/Ariemeth/frame_assault/mech/weapon"
+	"github.com/Ariemeth/frame_assault/util"
+)
+
+// OverwatchState is whether an overwatching mech is still holding its
+// position
+type OverwatchState int
+
+const (
+	// StateOverwatch holds position, firing into the suppressed zone
+	StateOverwatch OverwatchState = iota
+	// StateCombat leaves the position to close in on the target
+	StateCombat
+)
+
+// String returns the state's name
+func (s OverwatchState) String() string {
+	if s == StateCombat {
+		return "Combat"
+	}
+	return "Overwatch"
+}
+
+// OverwatchStrategy keeps a mech in place suppressing a zone until it is
+// attacked, after which it advances on its target
+type OverwatchStrategy struct {
+	// suppressionRect is the zone x1, y1, x2, y2 fired into, inclusive
+	suppressionRect [4]int
+	targetFn        func() weapon.Target
+	state           OverwatchState
+}
+
+// NewOverwatchStrategy creates an overwatch suppressing rect against the
+// target returned by targetFn
+func NewOverwatchStrategy(rect [4]int, targetFn func() weapon.Target) *OverwatchStrategy {
+	if rect[0] > rect[2] {
+		rect[0], rect[2] = rect[2], rect[0]
+	}
+	if rect[1] > rect[3] {
+		rect[1], rect[3] = rect[3], rect[1]
+	}
+	return &OverwatchStrategy{suppressionRect: rect, targetFn: targetFn}
+}
+
+// State returns whether the mech is on overwatch or in combat
+func (s *OverwatchStrategy) State() OverwatchState {
+	return s.state
+}
+
+// Overwatching returns true while the mech holds its position
+func (s *OverwatchStrategy) Overwatching() bool {
+	return s.state == StateOverwatch
+}
+
+// Engage ends the overwatch, sending the mech into combat
+func (s *OverwatchStrategy) Engage() {
+	s.state = StateCombat
+}
+
+// Target returns the target the overwatch is watching for, nil if none
+func (s *OverwatchStrategy) Target() weapon.Target {
+	if s.targetFn == nil {
+		return nil
+	}
+	return s.targetFn()
+}
+
+// InZone returns true if x,y lies within the suppressed zone
+func (s *OverwatchStrategy) InZone(x, y int) bool {
+	r := s.suppressionRect
+	return x >= r[0] && x <= r[2] && y >= r[1] && y <= r[3]
+}
+
+// NextMove implements Strategy interface. The mech stays put on overwatch
+// and steps toward its target once in combat.
+func (s *OverwatchStrategy) NextMove(currentX, currentY int) (newX, newY int) {
+	if s.state == StateOverwatch {
+		return currentX, currentY
+	}
+	target := s.Target()
+	if target == nil || target.IsDestroyed() {
+		return currentX, currentY
+	}
+	targetX, targetY := target.Position()
+	return currentX + util.Sign(targetX-currentX), currentY + util.Sign(targetY-currentY)
+}
+
+// Clone implements Strategy interface. The clone watches the same zone
+// for the same target and starts in the same state.
+func (s *OverwatchStrategy) Clone() Strategy {
+	clone := *s
+	return &clone
+}
+
+// VisualPath implements Strategy interface, outlining the suppressed zone
+// while on overwatch
+func (s *OverwatchStrategy) VisualPath() [][2]int {
+	if s.state != StateOverwatch {
+		return nil
+	}
+	r := s.suppressionRect
+	return [][2]int{{r[0], r[1]}, {r[2], r[1]}, {r[2], r[3]}, {r[0], r[3]}}
+}
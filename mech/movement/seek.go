@@ -0,0 +1,32 @@
+package movement
+
+import (
+	"github.com/Ariemeth/frame_assault/util"
+)
+
+// SeekStrategy moves a mech a cell at a time straight to a point and holds
+// there once it arrives
+type SeekStrategy struct {
+	x, y int
+}
+
+// NewSeekStrategy creates a strategy heading for x,y
+func NewSeekStrategy(x, y int) *SeekStrategy {
+	return &SeekStrategy{x: x, y: y}
+}
+
+// NextMove implements Strategy interface
+func (s *SeekStrategy) NextMove(currentX, currentY int) (newX, newY int) {
+	return currentX + util.Sign(s.x-currentX), currentY + util.Sign(s.y-currentY)
+}
+
+// Clone implements Strategy interface
+func (s *SeekStrategy) Clone() Strategy {
+	clone := *s
+	return &clone
+}
+
+// VisualPath implements Strategy interface, marking the point sought
+func (s *SeekStrategy) VisualPath() [][2]int {
+	return [][2]int{{s.x, s.y}}
+}
@@ -25,6 +25,11 @@ const (
 type Strategy interface {
 	// NextMove calculates the next x,y position based on current position
 	NextMove(currentX, currentY int) (newX, newY int)
+	// VisualPath returns the ordered points of the planned path for the
+	// debug overlay. Returning nil disables visualization.
+	VisualPath() [][2]int
+	// Clone returns a copy of the strategy that moves independently of it
+	Clone() Strategy
 }
 
 // RandomWalkStrategy makes the mech move randomly in any direction
@@ -105,6 +110,24 @@ func (s *RandomWalkStrategy) NextMove(currentX, currentY int) (newX, newY int) {
 	return newX, newY
 }
 
+// VisualPath implements Strategy interface. Random walks have no planned path.
+func (s *RandomWalkStrategy) VisualPath() [][2]int {
+	return nil
+}
+
+// Clone implements Strategy interface. The clone carries on in the same
+// direction but makes its own random choices from then on.
+func (s *RandomWalkStrategy) Clone() Strategy {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &RandomWalkStrategy{
+		rng:       rand.New(rand.NewSource(time.Now().UnixNano())),
+		direction: s.direction,
+		stepX:     s.stepX,
+		stepY:     s.stepY,
+	}
+}
+
 // PatrolStrategy makes the mech patrol between points
 type PatrolStrategy struct {
 	points     [][2]int
@@ -114,6 +137,8 @@ type PatrolStrategy struct {
 	targetX    int
 	targetY    int
 	direction  float64
+	// isWalkable reports cells the patrol should route around when set
+	isWalkable func(x, y int) bool
 }
 
 // validatePoint checks if a point is within game boundaries
@@ -150,13 +175,24 @@ func NewPatrolStrategy(points [][2]int) (*PatrolStrategy, error) {
 	}, nil
 }
 
+// SetWalkable sets a callback reporting which cells the patrol may enter.
+// A patrol about to enter an unwalkable cell turns toward its next point.
+func (s *PatrolStrategy) SetWalkable(isWalkable func(x, y int) bool) {
+	s.isWalkable = isWalkable
+}
+
+// nextPoint sets the target to the following patrol point
+func (s *PatrolStrategy) nextPoint() {
+	s.currPoint = (s.currPoint + 1) % len(s.points)
+	s.targetX = s.points[s.currPoint][0]
+	s.targetY = s.points[s.currPoint][1]
+}
+
 // updateTarget moves to the next patrol point if current target is reached
 func (s *PatrolStrategy) updateTarget(currentX, currentY int) {
 	// Check if we've reached the current target
 	if currentX == s.targetX && currentY == s.targetY {
-		s.currPoint = (s.currPoint + 1) % len(s.points)
-		s.targetX = s.points[s.currPoint][0]
-		s.targetY = s.points[s.currPoint][1]
+		s.nextPoint()
 	}
 
 	// Calculate direction to target
@@ -194,6 +230,29 @@ func (s *PatrolStrategy) NextMove(currentX, currentY int) (newX, newY int) {
 	// Clamp to game boundaries
 	newX = clampToGameBounds(newX, minCoordinate, maxLevelWidth)
 	newY = clampToGameBounds(newY, minCoordinate, maxLevelHeight)
+
+	if s.isWalkable != nil && !s.isWalkable(newX, newY) {
+		s.nextPoint()
+		return currentX, currentY
+	}
 	
 	return newX, newY
 }
+
+// Clone implements Strategy interface. The clone heads for the same patrol
+// point with its own copy of the route.
+func (s *PatrolStrategy) Clone() Strategy {
+	clone := *s
+	clone.points = append([][2]int(nil), s.points...)
+	return &clone
+}
+
+// VisualPath implements Strategy interface, returning the patrol waypoints
+// starting with the current target.
+func (s *PatrolStrategy) VisualPath() [][2]int {
+	path := make([][2]int, 0, len(s.points))
+	for i := range s.points {
+		path = append(path, s.points[(s.currPoint+i)%len(s.points)])
+	}
+	return path
+}
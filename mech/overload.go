@@ -0,0 +1,109 @@
+package mech
+
+import (
+	"github.com/Ariemeth/frame_assault/damagelog"
+	"github.com/Ariemeth/frame_assault/mech/weapon"
+)
+
+const (
+	// overloadDurationTicks is how long overload doubles the player's damage
+	overloadDurationTicks = 20
+	// overloadCooldownTicks is how long overload takes to recharge
+	overloadCooldownTicks = 200
+	// overloadSelfDamageAmount is the structure the player loses when
+	// overload ends
+	overloadSelfDamageAmount = 10
+	// overloadDamageMultiplier multiplies the damage of every hit while
+	// overload is on
+	overloadDamageMultiplier = 2
+)
+
+// amplifiedTarget multiplies the damage of every hit on a target, leaving
+// the weapons that fired at it unchanged
+type amplifiedTarget struct {
+	target     weapon.Target
+	multiplier int
+}
+
+// Hit passes damage times the multiplier on to the target
+func (a amplifiedTarget) Hit(damage int, attacker damagelog.EntityID) {
+	a.target.Hit(damage*a.multiplier, attacker)
+}
+
+// Name returns the name of the target
+func (a amplifiedTarget) Name() string {
+	return a.target.Name()
+}
+
+// IsDestroyed returns true if the target is destroyed
+func (a amplifiedTarget) IsDestroyed() bool {
+	return a.target.IsDestroyed()
+}
+
+// Position returns the x,y location of the target
+func (a amplifiedTarget) Position() (int, int) {
+	return a.target.Position()
+}
+
+// DamageMultiplier returns what the damage of the mech's hits is
+// multiplied by, 1 unless an ability is boosting it
+func (m *Mech) DamageMultiplier() int {
+	if m.damageMultiplier < 1 {
+		return 1
+	}
+	return m.damageMultiplier
+}
+
+// ActivateOverload doubles the player's damage for overloadDurationTicks
+// unless overload is already on or still recharging. Returns true if it
+// was turned on.
+func (pMech *PlayerMech) ActivateOverload() bool {
+	if pMech.overloadActive || pMech.overloadCooldown > 0 {
+		return false
+	}
+	pMech.overloadActive = true
+	pMech.overloadDuration = overloadDurationTicks
+	pMech.damageMultiplier = overloadDamageMultiplier
+	pMech.logAndNotify("overload", "Overload active")
+	return true
+}
+
+// OverloadActive returns true while overload is doubling the player's
+// damage
+func (pMech *PlayerMech) OverloadActive() bool {
+	return pMech.overloadActive
+}
+
+// OverloadStatus returns the ticks of overload left and the ticks of
+// cooldown that follow or remain
+func (pMech *PlayerMech) OverloadStatus() (remaining, cooldown int) {
+	if pMech.overloadActive {
+		return pMech.overloadDuration, overloadCooldownTicks
+	}
+	return 0, pMech.overloadCooldown
+}
+
+// endOverload returns the player's damage to normal, burns
+// overloadSelfDamage of its structure and starts the cooldown
+func (pMech *PlayerMech) endOverload() {
+	pMech.overloadActive = false
+	pMech.overloadDuration = 0
+	pMech.damageMultiplier = 1
+	pMech.overloadCooldown = overloadCooldownTicks
+	pMech.logAndNotify("overload", "Overload burned out", "self_damage", pMech.overloadSelfDamage)
+	pMech.Hit(pMech.overloadSelfDamage, damagelog.EntityID(pMech.name))
+}
+
+// tickOverload counts down overload and then its cooldown
+func (pMech *PlayerMech) tickOverload() {
+	if pMech.overloadActive {
+		pMech.overloadDuration--
+		if pMech.overloadDuration <= 0 {
+			pMech.endOverload()
+		}
+		return
+	}
+	if pMech.overloadCooldown > 0 {
+		pMech.overloadCooldown--
+	}
+}
@@ -0,0 +1,214 @@
+package mech
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/Ariemeth/frame_assault/mech/weapon"
+)
+
+// PartType is the kind of upgrade a mech part provides
+type PartType int
+
+const (
+	// PartArmor adds structure
+	PartArmor PartType = iota
+	// PartActuator adds speed
+	PartActuator
+	// PartWeaponMod adds weapon damage
+	PartWeaponMod
+)
+
+// String returns the display name of the part type
+func (t PartType) String() string {
+	switch t {
+	case PartArmor:
+		return "Armor"
+	case PartActuator:
+		return "Actuator"
+	case PartWeaponMod:
+		return "Weapon Mod"
+	}
+	return "Unknown"
+}
+
+// MechPart is a salvaged component that can be installed on the player's
+// mech to improve its stats
+type MechPart struct {
+	Name      string
+	Type      PartType
+	Structure int
+	Speed     int
+	Damage    int
+	Equipped  bool
+}
+
+// Effect describes the stat changes the part makes, such as "+10 structure"
+func (part MechPart) Effect() string {
+	effects := make([]string, 0, 3)
+	if part.Structure != 0 {
+		effects = append(effects, signed(part.Structure)+" structure")
+	}
+	if part.Speed != 0 {
+		effects = append(effects, signed(part.Speed)+" speed")
+	}
+	if part.Damage != 0 {
+		effects = append(effects, signed(part.Damage)+" damage")
+	}
+	return strings.Join(effects, ", ")
+}
+
+// signed formats n with a leading + when it is positive
+func signed(n int) string {
+	if n > 0 {
+		return "+" + strconv.Itoa(n)
+	}
+	return strconv.Itoa(n)
+}
+
+// salvageParts are the parts that can be recovered from destroyed enemies
+var salvageParts = []MechPart{
+	{Name: "Plating", Type: PartArmor, Structure: 5},
+	{Name: "Reactive Armor", Type: PartArmor, Structure: 10},
+	{Name: "Servo", Type: PartActuator, Speed: 1},
+	{Name: "Barrel Mod", Type: PartWeaponMod, Damage: 1},
+	{Name: "Targeting Chip", Type: PartWeaponMod, Damage: 2, Structure: -3},
+}
+
+// PartByName returns a copy of the salvageable part called name of type
+// partType, false if no such part exists
+func PartByName(name, partType string) (*MechPart, bool) {
+	for _, part := range salvageParts {
+		if part.Name == name && part.Type.String() == partType {
+			found := part
+			return &found, true
+		}
+	}
+	return nil, false
+}
+
+// salvagePart returns a copy of a random salvageable part
+func salvagePart() *MechPart {
+	part := salvageParts[rand.Intn(len(salvageParts))]
+	return &part
+}
+
+// Stats are the player mech's stats a part can change
+type Stats struct {
+	Structure int
+	Speed     int
+	Damage    int
+}
+
+// With returns the stats after installing part
+func (s Stats) With(part *MechPart) Stats {
+	if part == nil || part.Equipped {
+		return s
+	}
+	s.Structure += part.Structure
+	s.Speed += part.Speed
+	s.Damage += part.Damage
+	return s
+}
+
+// AttachPartsInventory enables opening the parts inventory with I
+func (pMech *PlayerMech) AttachPartsInventory(view PartsView) {
+	pMech.partsView = view
+	view.SetParts(pMech.parts)
+}
+
+// Parts returns the parts the player has salvaged
+func (pMech *PlayerMech) Parts() []*MechPart {
+	return pMech.parts
+}
+
+// Speed returns how many cells the player moves per step
+func (pMech *PlayerMech) Speed() int {
+	return 1 + pMech.speedBonus
+}
+
+// Stats returns the player's current structure, speed and weapon damage
+func (pMech *PlayerMech) Stats() Stats {
+	stats := Stats{Structure: pMech.MaxStructure(), Speed: pMech.Speed()}
+	if len(pMech.weapons) > 0 {
+		stats.Damage = pMech.weapons[0].Damage()
+	}
+	return stats
+}
+
+// AddInventoryItem adds part to the player's inventory
+func (pMech *PlayerMech) AddInventoryItem(part *MechPart) {
+	pMech.parts = append(pMech.parts, part)
+	if pMech.partsView != nil {
+		pMech.partsView.SetParts(pMech.parts)
+	}
+}
+
+// salvage recovers a random part from a destroyed enemy
+func (pMech *PlayerMech) salvage() {
+	part := salvagePart()
+	pMech.AddInventoryItem(part)
+	pMech.logAndNotify("salvage", "Salvaged "+part.Name, "part", part.Name)
+}
+
+// InstallPart fits part to the mech, applying its stat changes. It returns
+// false if the part is not in the inventory or is already equipped.
+func (pMech *PlayerMech) InstallPart(part *MechPart) bool {
+	if part == nil || part.Equipped || pMech.partIndex(part) < 0 {
+		return false
+	}
+	pMech.applyPart(part, 1)
+	part.Equipped = true
+	pMech.logAndNotify("install_part", "Installed "+part.Name, "part", part.Name)
+	return true
+}
+
+// DiscardPart removes part from the inventory, first removing its stat
+// changes if it is equipped
+func (pMech *PlayerMech) DiscardPart(part *MechPart) bool {
+	index := pMech.partIndex(part)
+	if index < 0 {
+		return false
+	}
+	if part.Equipped {
+		pMech.applyPart(part, -1)
+		part.Equipped = false
+	}
+	pMech.parts = append(pMech.parts[:index], pMech.parts[index+1:]...)
+	if pMech.partsView != nil {
+		pMech.partsView.SetParts(pMech.parts)
+	}
+	pMech.logAndNotify("discard_part", "Discarded "+part.Name, "part", part.Name)
+	return true
+}
+
+// partIndex returns the position of part in the inventory or -1
+func (pMech *PlayerMech) partIndex(part *MechPart) int {
+	for i, p := range pMech.parts {
+		if p == part {
+			return i
+		}
+	}
+	return -1
+}
+
+// applyPart adds the part's stat changes to the mech, or removes them when
+// sign is -1. Structure never drops below 1 so a part cannot destroy the mech.
+func (pMech *PlayerMech) applyPart(part *MechPart, sign int) {
+	pMech.maxStructure += sign * part.Structure
+	pMech.structure += sign * part.Structure
+	if pMech.maxStructure < 1 {
+		pMech.maxStructure = 1
+	}
+	if pMech.structure > pMech.maxStructure {
+		pMech.structure = pMech.maxStructure
+	}
+	if pMech.structure < 1 {
+		pMech.structure = 1
+	}
+	pMech.speedBonus += sign * part.Speed
+	pMech.ForEachWeapon(func(w *weapon.Weapon) {
+		w.UpgradeDamage(sign * part.Damage)
+	})
+}
@@ -1,42 +1,781 @@
 package mech
 
 import (
+	"math"
+	"strconv"
 	"strings"
 
+	"github.com/Ariemeth/frame_assault/bounty"
+	"github.com/Ariemeth/frame_assault/damagelog"
+	"github.com/Ariemeth/frame_assault/entities"
+	"github.com/Ariemeth/frame_assault/eventbus"
+	"github.com/Ariemeth/frame_assault/hooks"
+	"github.com/Ariemeth/frame_assault/lifecycle"
+	"github.com/Ariemeth/frame_assault/mech/weapon"
+	"github.com/Ariemeth/frame_assault/util"
 	tl "github.com/Ariemeth/termloop"
 )
 
+const (
+	// repairKitAmount is the structure restored by a repair kit
+	repairKitAmount = 30
+	// maxRepairKits is how many unused repair kits the player can carry
+	maxRepairKits = 3
+	// upgradeDamageAmount is the damage added by a weapon upgrade
+	upgradeDamageAmount = 1
+	// defaultFOVAngle is the width of the player's field of view in degrees
+	defaultFOVAngle = 180.0
+	// defaultSensorRange is how far the player's radar reaches in cells
+	defaultSensorRange = 20
+	// streakBonusPerHit is the accuracy added by each consecutive hit
+	streakBonusPerHit = 0.02
+	// maxStreakBonus caps the accuracy bonus from a hit streak
+	maxStreakBonus = 0.20
+	// movementPenalty is the accuracy lost for movementPenaltyTicks after moving
+	movementPenalty      = 0.15
+	movementPenaltyTicks = 3
+	// graveClearRange is how close the player must walk to a grave to clear it
+	graveClearRange = 3
+)
+
+// Inspector is a debug overlay that can display an entity's details
+type Inspector interface {
+	Open(entity interface{})
+	Close()
+	IsOpen() bool
+}
+
+// Overlay is a text box or menu that takes the keyboard while it is open
+type Overlay interface {
+	IsOpen() bool
+}
+
 //PlayerMech represents a player controlled mech
 type PlayerMech struct {
 	Mech
 	level   *tl.BaseLevel
 	enemies []*Mech
+	kills     int
+	drops     *entities.DropManager
+	snares    *entities.SnareManager
+	smartBomb  *weapon.SmartBomb
+	inspector  Inspector
+	lastTarget *Mech
+	bounties     *bounty.Registry
+	bountyPoints int
+	recorder     ActionRecorder
+	// firedAt is the target of a shot fired this tick, recorded for replays
+	firedAt       *[2]int
+	lastStructure int
+	// fovAngle is the field of view width in degrees
+	fovAngle float64
+	// fovDirection is the facing in radians, 0 facing right
+	fovDirection float64
+	sensorRange  int
+	radioTowers  []*entities.RadioTower
+	// hitStreak counts consecutive hits, each raising streakBonus
+	hitStreak   int
+	streakBonus float64
+	power       PowerGrid
+	xp          int
+	spectator   Spectator
+	// speedBonus is the extra cells moved per step from installed parts
+	speedBonus int
+	parts      []*MechPart
+	partsView  PartsView
+	// thermalActive shows every enemy through walls for thermalTicks, after
+	// which thermal imaging recharges for thermalCooldown ticks
+	thermalActive   bool
+	thermalTicks    int
+	thermalCooldown int
+	// movementPenalty is taken off the accuracy of shots fired on the move
+	movementPenalty      float64
+	movementPenaltyTicks int
+	// repairKits are the repair kits carried for putting out fires
+	repairKits int
+	// stealthActive hides the player from enemy sensors for stealthTicks,
+	// after which stealth recharges for stealthCooldown ticks
+	stealthActive   bool
+	stealthTicks    int
+	stealthCooldown int
+	// approachX and approachY are the direction of this tick's step
+	approachX int
+	approachY int
+	// engagements are the enemies stepped into since the last tick
+	engagements []engagement
+	graveyard Graveyard
+	coverAdvisor CoverAdvisor
+	// prevPositions are the cells the player moved from, last move last,
+	// with the structure it had in each in prevStructures
+	prevPositions  [][2]int
+	prevStructures []int
+	undosRemaining int
+	tickCounter    TickCounter
+	dialogue       Dialogue
+	overlays       []Overlay
+	help           HelpDisplay
+	// overloadActive doubles the player's damage for overloadDuration
+	// ticks, after which the player takes overloadSelfDamage and overload
+	// recharges for overloadCooldown ticks
+	overloadActive     bool
+	overloadDuration   int
+	overloadCooldown   int
+	overloadSelfDamage int
+	// bulletTimeActive slows the rest of the game through speedControl
+	// for bulletTimeRemaining ticks, after which bullet time recharges for
+	// bulletTimeCooldown ticks
+	bulletTimeActive      bool
+	bulletTimeRemaining   int
+	bulletTimeCooldown    int
+	speedBeforeBulletTime float64
+	speedControl          SpeedControl
+	fps                   int
+	canInteract    func(x, y int) bool
+	// shield soaks up hits before the structure does, up to maxShield
+	shield    int
+	maxShield int
+}
+
+// CoverAdvisor points the player to cover from an attacker
+type CoverAdvisor interface {
+	Advise(playerX, playerY, attackerX, attackerY int)
+}
+
+// Graveyard marks where enemies were destroyed until the player visits
+type Graveyard interface {
+	Graves() map[damagelog.EntityID][2]int
+	ClearGrave(id damagelog.EntityID)
+}
+
+// PowerGrid is the city power the player can restore at a power plant
+type PowerGrid interface {
+	RestorePower()
+}
+
+// PartsView is an overlay listing the parts the player has salvaged
+type PartsView interface {
+	SetParts(parts []*MechPart)
+	Toggle()
+	IsOpen() bool
+}
+
+// Spectator takes over the camera once the player is destroyed
+type Spectator interface {
+	Start()
+}
+
+// PlayerConfig is the look and starting weapons chosen for the player's mech
+type PlayerConfig struct {
+	Symbol  rune
+	Color   tl.Attr
+	Loadout weapon.Loadout
+}
+
+// DefaultPlayerConfig returns a red M armed with the first loadout
+func DefaultPlayerConfig() PlayerConfig {
+	return PlayerConfig{Symbol: 'M', Color: tl.ColorRed, Loadout: weapon.Loadouts[0]}
 }
 
-// NewPlayerMech is used to create a new instance of a mech with default structure.
-func NewPlayerMech(name string, maxStructure, x, y int, level *tl.BaseLevel) *PlayerMech {
-	newMech := NewMech(name, maxStructure, x, y, tl.ColorRed, 'M')
+// NewPlayerMech is used to create a new instance of a mech with default
+// structure, drawn with the symbol and color of config.
+func NewPlayerMech(name string, maxStructure, x, y int, level *tl.BaseLevel, config PlayerConfig) *PlayerMech {
+	newMech := NewMech(name, maxStructure, x, y, config.Color, config.Symbol)
 	newMech.SetLevel(level)
 
 	newPlayerMech := PlayerMech{
-		Mech:  *newMech,
-		level: level,
+		Mech:     *newMech,
+		level:       level,
+		fovAngle:    defaultFOVAngle,
+		sensorRange: defaultSensorRange,
+		undosRemaining: MaxUndos,
+		overloadSelfDamage: overloadSelfDamageAmount,
+		maxShield:          PlayerMaxShield,
 	}
+	newPlayerMech.AttachSplashTargets(newPlayerMech.splashTargets)
 
 	return &newPlayerMech
 }
 
+// EquipLoadout adds every weapon of loadout to the mech
+func (pMech *PlayerMech) EquipLoadout(loadout weapon.Loadout) {
+	for _, w := range loadout.Weapons() {
+		pMech.AddWeapon(w)
+	}
+}
+
 //SetEnemyList sets the list of enemies the player can interact
 func (pMech *PlayerMech) SetEnemyList(enemies []*Mech) {
 	pMech.enemies = enemies
 }
 
+// AddEnemy adds a newly arrived enemy to the list the player can interact with
+func (pMech *PlayerMech) AddEnemy(enemy *Mech) {
+	pMech.enemies = append(pMech.enemies, enemy)
+}
+
+// AttachDropManager sets the manager used to award supply drops for kills
+func (pMech *PlayerMech) AttachDropManager(drops *entities.DropManager) {
+	pMech.drops = drops
+}
+
+// AttachSnareManager enables placing snares with N
+func (pMech *PlayerMech) AttachSnareManager(snares *entities.SnareManager) {
+	pMech.snares = snares
+}
+
+// placeSnare spends bounty points to set a snare in the cell behind the player
+func (pMech *PlayerMech) placeSnare() {
+	if pMech.snares == nil {
+		return
+	}
+	if pMech.bountyPoints < entities.SnareCost {
+		pMech.logAndNotify("snare", "Not enough bounty for a snare",
+			"points", pMech.bountyPoints, "cost", entities.SnareCost)
+		return
+	}
+
+	x, y := pMech.entity.Position()
+	x -= int(math.Round(math.Cos(pMech.fovDirection)))
+	y -= int(math.Round(math.Sin(pMech.fovDirection)))
+	if _, err := pMech.snares.Place(x, y); err != nil {
+		pMech.logAndNotify("snare", "Cannot place snare: "+err.Error())
+		return
+	}
+	pMech.bountyPoints -= entities.SnareCost
+	pMech.logAndNotify("snare", "Snare placed",
+		"snare_x", x, "snare_y", y, "points", pMech.bountyPoints)
+}
+
+// step moves the player by dx,dy and turns to face direction. Slowed
+// players still turn but only complete some of their moves.
+func (pMech *PlayerMech) step(dx, dy int, direction float64) {
+	pMech.fovDirection = direction
+	if !pMech.canStep() {
+		return
+	}
+	pMech.rememberMove()
+	speed := pMech.Speed()
+	pMech.entity.SetPosition(pMech.prevX+dx*speed, pMech.prevY+dy*speed)
+	pMech.approachX, pMech.approachY = util.Sign(dx), util.Sign(dy)
+}
+
+// ActionRecorder records the player's state each tick for replays
+type ActionRecorder interface {
+	RecordTick(pos [2]int, firedAt *[2]int, hit bool)
+}
+
+// AttachRecorder sets the recorder the player's actions are saved to
+func (pMech *PlayerMech) AttachRecorder(recorder ActionRecorder) {
+	pMech.recorder = recorder
+	pMech.lastStructure = pMech.StructureLeft()
+}
+
+// recordTick saves the player's position, any shot fired and whether
+// damage was taken since the last tick
+func (pMech *PlayerMech) recordTick() {
+	if pMech.recorder == nil {
+		return
+	}
+	x, y := pMech.entity.Position()
+	hit := pMech.StructureLeft() < pMech.lastStructure
+	pMech.recorder.RecordTick([2]int{x, y}, pMech.firedAt, hit)
+	pMech.firedAt = nil
+	pMech.lastStructure = pMech.StructureLeft()
+}
+
+// recordShot notes that the player fired at target this tick
+func (pMech *PlayerMech) recordShot(target weapon.Target) {
+	x, y := target.Position()
+	pMech.firedAt = &[2]int{x, y}
+}
+
+// AttachBounties sets the registry used to pay out bounties on kills
+func (pMech *PlayerMech) AttachBounties(bounties *bounty.Registry) {
+	pMech.bounties = bounties
+}
+
+// BountyPoints returns the unspent bounty points
+func (pMech *PlayerMech) BountyPoints() int {
+	return pMech.bountyPoints
+}
+
+// AddBountyPoints adds points earned outside of combat, such as quest pay
+func (pMech *PlayerMech) AddBountyPoints(points int) {
+	pMech.bountyPoints += points
+}
+
+// XP returns the experience the player has earned
+func (pMech *PlayerMech) XP() int {
+	return pMech.xp
+}
+
+// AddXP adds earned experience
+func (pMech *PlayerMech) AddXP(xp int) {
+	pMech.xp += xp
+}
+
+// collectBounty adds the bounty on enemy to the player's points
+func (pMech *PlayerMech) collectBounty(enemy *Mech) {
+	if pMech.bounties == nil || enemy == nil {
+		return
+	}
+	entry, ok := pMech.bounties.GetBounty(enemy.Name())
+	if !ok {
+		return
+	}
+	pMech.bountyPoints += entry.Points
+	pMech.logAndNotify("bounty", "Bounty collected: "+strconv.Itoa(entry.Points),
+		"target", enemy.Name(), "points", entry.Points, "total", pMech.bountyPoints)
+}
+
+// RedeemBounty spends bounty points on reward, returning false if the
+// player cannot afford it
+func (pMech *PlayerMech) RedeemBounty(reward bounty.Reward) bool {
+	if pMech.bountyPoints < reward.Cost() {
+		pMech.logAndNotify("bounty", "Not enough bounty for "+reward.String(),
+			"points", pMech.bountyPoints, "cost", reward.Cost())
+		return false
+	}
+	switch reward {
+	case bounty.RestoreStructure:
+		pMech.Repair(bounty.RestoreStructureAmount)
+	case bounty.ResupplyAmmo:
+		pMech.ForEachWeapon(func(w *weapon.Weapon) {
+			w.Refill()
+		})
+	case bounty.RechargeShield:
+		pMech.RechargeShield()
+	default:
+		return false
+	}
+	pMech.bountyPoints -= reward.Cost()
+	pMech.logAndNotify("bounty", "Redeemed "+reward.String(),
+		"cost", reward.Cost(), "points", pMech.bountyPoints)
+	return true
+}
+
+// EquipSmartBomb gives the player a smart bomb fired with F5
+func (pMech *PlayerMech) EquipSmartBomb(bomb *weapon.SmartBomb) {
+	bomb.SetLevel(pMech.level)
+	bomb.SetOwner(damagelog.EntityID(pMech.name))
+	pMech.smartBomb = bomb
+}
+
+// SmartBomb returns the equipped smart bomb or nil
+func (pMech *PlayerMech) SmartBomb() *weapon.SmartBomb {
+	return pMech.smartBomb
+}
+
+// fireSmartBomb launches the smart bomb at the weakest enemy in range and
+// in view
+func (pMech *PlayerMech) fireSmartBomb() {
+	if pMech.smartBomb == nil {
+		return
+	}
+	if pMech.smartBomb.Ammo() <= 0 {
+		pMech.logAndNotify("smart_bomb", "Smart bomb reloading")
+		return
+	}
+
+	// The bomb only seeks enemies the player can see
+	alive := make([]*Mech, 0, len(pMech.enemies))
+	candidates := make([]weapon.StructuredTarget, 0, len(pMech.enemies))
+	for _, enemy := range pMech.enemies {
+		if enemy.IsDestroyed() {
+			continue
+		}
+		alive = append(alive, enemy)
+		if x, y := enemy.Position(); pMech.CanSee(x, y) {
+			candidates = append(candidates, enemy)
+		}
+	}
+
+	x, y := pMech.entity.Position()
+	pMech.smartBomb.SetPosition(x, y)
+	target := pMech.smartBomb.Fire(candidates)
+	if target == nil {
+		pMech.logAndNotify("smart_bomb", "No smart bomb target in range")
+		return
+	}
+	pMech.logEvent("smart_bomb", "smart bomb fired at "+target.Name(), "target", target.Name())
+	pMech.recordShot(target)
+	pMech.publishExplosion(target)
+
+	for _, enemy := range alive {
+		if enemy.IsDestroyed() {
+			pMech.registerKill(enemy)
+		}
+	}
+}
+
+// publishExplosion announces an explosive detonating at target
+func (pMech *PlayerMech) publishExplosion(target weapon.Target) {
+	x, y := target.Position()
+	pMech.publish(eventbus.ExplosionEvent{Attacker: pMech.name, X: x, Y: y})
+}
+
+// AttachOverlay adds a text box or menu the player's keys go to instead
+// of commanding the mech while it is open
+func (pMech *PlayerMech) AttachOverlay(overlay Overlay) {
+	pMech.overlays = append(pMech.overlays, overlay)
+}
+
+// AttachInspector enables opening the entity inspector with F9
+func (pMech *PlayerMech) AttachInspector(inspector Inspector) {
+	pMech.inspector = inspector
+}
+
+// adjacentEntity returns the first level entity within one cell of the player
+func (pMech *PlayerMech) adjacentEntity() tl.Drawable {
+	if pMech.level == nil {
+		return nil
+	}
+	x, y := pMech.entity.Position()
+	for _, entity := range pMech.level.Entities {
+		if entity == pMech || entity == nil {
+			continue
+		}
+		physical, ok := entity.(tl.Physical)
+		if !ok {
+			continue
+		}
+		eX, eY := physical.Position()
+		w, h := physical.Size()
+		if x >= eX-1 && x <= eX+w && y >= eY-1 && y <= eY+h {
+			return entity
+		}
+	}
+	return nil
+}
+
+// toggleInspector opens the inspector on an adjacent entity or closes it
+func (pMech *PlayerMech) toggleInspector() {
+	if pMech.inspector.IsOpen() {
+		pMech.inspector.Close()
+		return
+	}
+	if entity := pMech.adjacentEntity(); entity != nil {
+		pMech.inspector.Open(entity)
+	}
+}
+
+// SetFOVAngle sets the width of the player's field of view in degrees.
+// 360 or more gives all round vision.
+func (pMech *PlayerMech) SetFOVAngle(degrees float64) {
+	pMech.fovAngle = degrees
+}
+
+// FOVAngle returns the width of the player's field of view in degrees
+func (pMech *PlayerMech) FOVAngle() float64 {
+	return pMech.fovAngle
+}
+
+// CanSee returns true if x,y falls within the player's field of view cone
+func (pMech *PlayerMech) CanSee(x, y int) bool {
+	px, py := pMech.entity.Position()
+	halfAngle := pMech.fovAngle / 2 * math.Pi / 180
+	return util.IsInCone(px, py, pMech.fovDirection, halfAngle, x, y)
+}
+
+// SensorRange returns how far the player's radar reaches before jamming
+func (pMech *PlayerMech) SensorRange() int {
+	return pMech.sensorRange
+}
+
+// AddSensorRange permanently extends the player's radar
+func (pMech *PlayerMech) AddSensorRange(amount int) {
+	pMech.sensorRange += amount
+}
+
+// AttachRadioTower adds a tower that jams the radar while enemy controlled
+func (pMech *PlayerMech) AttachRadioTower(tower *entities.RadioTower) {
+	pMech.radioTowers = append(pMech.radioTowers, tower)
+}
+
+// RadioTowers returns the towers attached to the player
+func (pMech *PlayerMech) RadioTowers() []*entities.RadioTower {
+	return pMech.radioTowers
+}
+
+// RadarRange returns the sensor range less the jamming from enemy
+// controlled radio towers
+func (pMech *PlayerMech) RadarRange() int {
+	radar := pMech.sensorRange
+	for _, tower := range pMech.radioTowers {
+		if tower.Owner() == entities.EnemyControlled {
+			radar -= entities.TowerJamPenalty
+		}
+	}
+	if radar < 0 {
+		return 0
+	}
+	return radar
+}
+
+// EnemyPositions returns the positions of the enemies still fighting
+func (pMech *PlayerMech) EnemyPositions() [][2]int {
+	positions := make([][2]int, 0, len(pMech.enemies))
+	for _, enemy := range pMech.enemies {
+		if !enemy.IsDestroyed() {
+			x, y := enemy.Position()
+			positions = append(positions, [2]int{x, y})
+		}
+	}
+	return positions
+}
+
+// NearestEnemy returns the closest enemy still fighting within radar
+// range, or nil if there is none
+func (pMech *PlayerMech) NearestEnemy() *Mech {
+	var nearest *Mech
+	closest := float64(pMech.RadarRange())
+	for _, enemy := range pMech.enemies {
+		if enemy.IsDestroyed() {
+			continue
+		}
+		if distance := pMech.DistanceTo(enemy); distance <= closest {
+			nearest, closest = enemy, distance
+		}
+	}
+	return nearest
+}
+
+// Kills returns the number of enemies the player has destroyed
+func (pMech *PlayerMech) Kills() int {
+	return pMech.kills
+}
+
+// Collide handles the player running into supply drops before falling
+// back to the base mech collision handling.
+func (pMech *PlayerMech) Collide(collision tl.Physical) {
+	if drop, ok := collision.(*entities.SupplyDrop); ok {
+		if drop.Landed() {
+			pMech.CollectDrop(drop)
+		}
+		return
+	}
+	// Stepping into an enemy attacks it rather than walking through it.
+	// Collisions are checked concurrently, so the attack waits for the
+	// next tick.
+	if enemy, ok := collision.(*EnemyMech); ok {
+		if dx, dy := pMech.ApproachVector(); dx != 0 || dy != 0 {
+			pMech.engagements = append(pMech.engagements, engagement{enemy: enemy, fromBehind: pMech.behind(enemy)})
+			pMech.entity.SetPosition(pMech.prevX, pMech.prevY)
+		}
+		return
+	}
+	if bay, ok := collision.(weaponRepairer); ok {
+		pMech.repairWeapons(bay.WeaponRepairRate())
+	}
+	if plant, ok := collision.(powerSource); ok && plant.RestoresPower() && pMech.power != nil {
+		pMech.power.RestorePower()
+	}
+	pMech.Mech.Collide(collision)
+}
+
+// weaponRepairer is implemented by structures that repair weapons on contact
+type weaponRepairer interface {
+	WeaponRepairRate() int
+}
+
+// powerSource is implemented by structures that can restore the city's power
+type powerSource interface {
+	RestoresPower() bool
+}
+
+// AttachPowerGrid sets the grid restored by visiting a power plant
+func (pMech *PlayerMech) AttachPowerGrid(grid PowerGrid) {
+	pMech.power = grid
+}
+
+// AttachSpectator sets the camera that takes over when the player is
+// destroyed
+func (pMech *PlayerMech) AttachSpectator(spectator Spectator) {
+	pMech.spectator = spectator
+}
+
+// Hit damages the player's mech, handing the camera to the spectator when
+// the hit destroys it
+func (pMech *PlayerMech) Hit(damage int, attacker damagelog.EntityID) {
+	alive := !pMech.IsDestroyed()
+	if damage = pMech.absorb(damage); damage == 0 {
+		return
+	}
+	pMech.Mech.Hit(damage, attacker)
+	// The spectator camera and next life run at the speed from before
+	if pMech.IsDestroyed() {
+		pMech.endBulletTime()
+	}
+	if alive && pMech.IsDestroyed() && pMech.spectator != nil {
+		pMech.spectator.Start()
+	}
+	if !pMech.IsDestroyed() && pMech.structure*2 < pMech.maxStructure {
+		pMech.adviseCover(attacker)
+	}
+}
+
+// AttachCoverAdvisor sets the advisor pointing the badly damaged player to
+// cover
+func (pMech *PlayerMech) AttachCoverAdvisor(advisor CoverAdvisor) {
+	pMech.coverAdvisor = advisor
+}
+
+// adviseCover asks for cover from the enemy called attacker
+func (pMech *PlayerMech) adviseCover(attacker damagelog.EntityID) {
+	if pMech.coverAdvisor == nil {
+		return
+	}
+	for _, enemy := range pMech.enemies {
+		if damagelog.EntityID(enemy.Name()) == attacker && !enemy.IsDestroyed() {
+			x, y := pMech.entity.Position()
+			attackerX, attackerY := enemy.Position()
+			pMech.coverAdvisor.Advise(x, y, attackerX, attackerY)
+			return
+		}
+	}
+}
+
+// repairWeapons restores condition to every equipped weapon
+func (pMech *PlayerMech) repairWeapons(amount int) {
+	if amount <= 0 {
+		return
+	}
+	pMech.ForEachWeapon(func(w *weapon.Weapon) {
+		w.Repair(amount)
+	})
+}
+
+// CollectDrop picks up a supply drop and applies its contents
+func (pMech *PlayerMech) CollectDrop(drop *entities.SupplyDrop) {
+	pMech.unpack(drop.Collect())
+}
+
+// unpack applies the contents of a supply drop. A rare weapon the mech
+// already carries is stripped for a weapon upgrade instead.
+func (pMech *PlayerMech) unpack(contents entities.PackageType) {
+	if contents == entities.RareWeapon && pMech.hasWeapon(weapon.CreateRailgun().Name()) {
+		contents = entities.WeaponUpgrade
+	}
+	switch contents {
+	case entities.AmmoCrate:
+		pMech.ForEachWeapon(func(w *weapon.Weapon) {
+			w.Refill()
+		})
+		if pMech.smartBomb != nil {
+			pMech.smartBomb.Refill()
+		}
+	case entities.RepairKit:
+		// Kits found while undamaged are kept for putting out fires
+		if pMech.StructureLeft() < pMech.MaxStructure() || pMech.repairKits >= maxRepairKits {
+			pMech.Repair(repairKitAmount)
+		} else {
+			pMech.repairKits++
+		}
+	case entities.WeaponUpgrade:
+		if len(pMech.weapons) > 0 {
+			pMech.weapons[0].UpgradeDamage(upgradeDamageAmount)
+		}
+	case entities.RareWeapon:
+		pMech.AddWeapon(weapon.CreateRailgun())
+	}
+	pMech.logAndNotify("supply_drop", "Collected "+contents.String(),
+		"contents", contents.String())
+}
+
+// hasWeapon returns true if the mech carries a weapon called name
+func (pMech *PlayerMech) hasWeapon(name string) bool {
+	for _, w := range pMech.weapons {
+		if w.Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// RepairKits returns the number of repair kits the player is carrying
+func (pMech *PlayerMech) RepairKits() int {
+	return pMech.repairKits
+}
+
+// UseRepairKit uses up a carried repair kit, returning false if the
+// player has none
+func (pMech *PlayerMech) UseRepairKit() bool {
+	if pMech.repairKits == 0 {
+		return false
+	}
+	pMech.repairKits--
+	pMech.logAndNotify("repair_kit", "Used a repair kit", "repair_kits", pMech.repairKits)
+	return true
+}
+
+// registerKill counts a destroyed enemy, collects any bounty on it and
+// awards supply drops at milestones
+func (pMech *PlayerMech) registerKill(enemy *Mech) {
+	pMech.kills++
+	pMech.collectBounty(enemy)
+	pMech.salvage()
+	if pMech.drops == nil {
+		return
+	}
+	if drop := pMech.drops.RecordKill(pMech.kills); drop != nil {
+		pMech.logAndNotify("supply_drop", "Supply drop incoming!", "kills", pMech.kills)
+	}
+}
+
 // Tick is called to process 1 tick of actions based on the
 // type of event.
 func (pMech *PlayerMech) Tick(event tl.Event) {
+	lifecycle.NotifyTick(pMech)
+	pMech.resolveEngagements()
+	pMech.tickWeapons()
+	if pMech.smartBomb != nil {
+		pMech.smartBomb.Tick()
+	}
+	if event.Type == tl.EventNone {
+		pMech.tickEffects()
+		pMech.tickThermal()
+		pMech.tickStealth()
+		pMech.tickOverload()
+		pMech.tickBulletTime()
+		pMech.tickMovementPenalty()
+		pMech.visitGraves()
+		pMech.updateHelp()
+		pMech.approachX, pMech.approachY = 0, 0
+		pMech.recordTick()
+	}
+
 	if event.Type == tl.EventKey { // Is it a keyboard event?
 		pMech.prevX, pMech.prevY = pMech.entity.Position()
 
+		// Keys typed into a text box or menu are not commands
+		for _, overlay := range pMech.overlays {
+			if overlay.IsOpen() {
+				return
+			}
+		}
+
+		// The parts inventory takes over the keyboard while it is open
+		if pMech.partsView != nil {
+			if event.Ch == 'i' || event.Ch == 'I' {
+				pMech.partsView.Toggle()
+				return
+			}
+			if pMech.partsView.IsOpen() {
+				return
+			}
+		}
+
+		// The inspector takes over the keyboard while it is open
+		if pMech.inspector != nil {
+			if event.Key == tl.KeyF9 {
+				pMech.toggleInspector()
+				return
+			}
+			if pMech.inspector.IsOpen() {
+				return
+			}
+		}
+
 		//quick fix to handle keys
 		switch event.Ch {
 		case 'A':
@@ -71,44 +810,279 @@ func (pMech *PlayerMech) Tick(event tl.Event) {
 		case 'h':
 			pMech.attack("H")
 			break
+		case 'N', 'n':
+			pMech.placeSnare()
+		case 'V', 'v':
+			pMech.ActivateStealth()
+		case 'P', 'p':
+			pMech.fireEMP()
 		}
 
 		switch event.Key { // If so, switch on the pressed key.
+		case tl.KeyF5:
+			pMech.fireSmartBomb()
+			break
+		case tl.KeyF6:
+			pMech.RedeemBounty(bounty.RestoreStructure)
+			break
+		case tl.KeyF7:
+			pMech.RedeemBounty(bounty.ResupplyAmmo)
+			break
+		case tl.KeyF8:
+			pMech.ActivateThermal()
+			break
+		case tl.KeyF4:
+			pMech.ActivateOverload()
+			break
+		case tl.KeyF3:
+			pMech.ActivateBulletTime()
+			break
+		case tl.KeyCtrlF:
+			pMech.fireSecondary()
+			break
+		case tl.KeyBackspace, tl.KeyBackspace2:
+			// Backspace declines an open dialogue instead
+			if pMech.dialogue == nil || !pMech.dialogue.IsOpen() {
+				pMech.UndoMove()
+			}
+			break
 		case tl.KeyArrowRight:
-			pMech.entity.SetPosition(pMech.prevX+1, pMech.prevY)
+			pMech.step(1, 0, 0)
 			break
 		case tl.KeyArrowLeft:
-			pMech.entity.SetPosition(pMech.prevX-1, pMech.prevY)
+			pMech.step(-1, 0, math.Pi)
 			break
 		case tl.KeyArrowUp:
-			pMech.entity.SetPosition(pMech.prevX, pMech.prevY-1)
+			pMech.step(0, -1, -math.Pi/2)
 			break
 		case tl.KeyArrowDown:
-			pMech.entity.SetPosition(pMech.prevX, pMech.prevY+1)
+			pMech.step(0, 1, math.Pi/2)
 			break
 		}
+		pMech.checkMovement()
 	}
 }
 
-// Draw passes the draw call to entity.
+// Draw centers the camera on the player and draws the mech, falling back
+// on the Mech draw hook when no PlayerMech hook is registered.
 func (pMech *PlayerMech) Draw(screen *tl.Screen) {
 	screenWidth, screenHeight := screen.Size()
 	x, y := pMech.entity.Position()
 	pMech.level.SetOffset(screenWidth/2-x, screenHeight/2-y)
-	pMech.entity.Draw(screen)
+	if hooks.Draw("PlayerMech", pMech, screen) {
+		return
+	}
+	pMech.Mech.Draw(screen)
+	pMech.drawThermal(screen)
+}
+
+// matchesTargetKey returns true if the enemy called name is selected by
+// key, either as the last letter of "Mech A" or the first of "A II"
+func matchesTargetKey(name, key string) bool {
+	return strings.HasSuffix(name, key) || strings.HasPrefix(name, key+" ")
 }
 
 func (pMech *PlayerMech) getTargetEnemy(name string) *Mech {
+	var outOfView *Mech
 	for i, mech := range pMech.enemies {
-		if strings.HasSuffix(mech.Name(), name) {
-			pMech.game.Log("enemy found: %s", mech.Name())
-			return pMech.enemies[i]
+		// Skip wrecks so reinforcements reusing a letter can be targeted
+		if mech.IsDestroyed() || !matchesTargetKey(mech.Name(), name) {
+			continue
+		}
+		// Several enemies can share a letter, so look on for one in view
+		if x, y := mech.entity.Position(); !pMech.CanSee(x, y) {
+			if outOfView == nil {
+				outOfView = mech
+			}
+			continue
 		}
+		pMech.game.Log("enemy found: %s", mech.Name())
+		return pMech.enemies[i]
+	}
+	if outOfView != nil {
+		pMech.game.Log("enemy out of view: %s", outOfView.Name())
 	}
 	return nil
 }
 
+// Move steps the player by dx,dy as the arrow keys would, for players
+// driven by something other than the keyboard
+func (pMech *PlayerMech) Move(dx, dy int) {
+	pMech.prevX, pMech.prevY = pMech.entity.Position()
+	pMech.step(dx, dy, math.Atan2(float64(dy), float64(dx)))
+	pMech.checkMovement()
+}
+
+// Attack fires at the enemy selected by name as its attack key would
+func (pMech *PlayerMech) Attack(name string) {
+	pMech.attack(name)
+}
+
 func (pMech *PlayerMech) attack(name string) {
 	target := pMech.getTargetEnemy(name)
-	pMech.Mech.attack(target)
+	if target == nil {
+		return
+	}
+	pMech.breakStealth()
+	pMech.attackMech(target)
+}
+
+// attackMech fires every weapon at target, counting the kill if it is
+// destroyed
+func (pMech *PlayerMech) attackMech(target *Mech) {
+	pMech.lastTarget = target
+	pMech.recordShot(target)
+	wasDestroyed := target.IsDestroyed()
+	alive := pMech.aliveEnemies()
+	for _, hit := range pMech.Mech.attack(target, pMech.streakBonus-pMech.movementPenalty) {
+		pMech.updateStreak(hit)
+	}
+	if !wasDestroyed && target.IsDestroyed() {
+		pMech.registerKill(target)
+	}
+	// Count the enemies caught in any splash
+	for _, enemy := range alive {
+		if enemy != target && enemy.IsDestroyed() {
+			pMech.registerKill(enemy)
+		}
+	}
+}
+
+// aliveEnemies returns the enemies still fighting
+func (pMech *PlayerMech) aliveEnemies() []*Mech {
+	alive := make([]*Mech, 0, len(pMech.enemies))
+	for _, enemy := range pMech.enemies {
+		if !enemy.IsDestroyed() {
+			alive = append(alive, enemy)
+		}
+	}
+	return alive
+}
+
+// splashTargets returns the enemies still fighting as targets the
+// player's splashing weapons can catch
+func (pMech *PlayerMech) splashTargets() []weapon.Target {
+	alive := pMech.aliveEnemies()
+	targets := make([]weapon.Target, len(alive))
+	for i, enemy := range alive {
+		targets[i] = enemy
+	}
+	return targets
+}
+
+// AttachGraveyard sets the graves cleared as the player walks by them
+func (pMech *PlayerMech) AttachGraveyard(graveyard Graveyard) {
+	pMech.graveyard = graveyard
+}
+
+// visitGraves clears every grave within graveClearRange of the player
+func (pMech *PlayerMech) visitGraves() {
+	if pMech.graveyard == nil {
+		return
+	}
+	for id, pos := range pMech.graveyard.Graves() {
+		if pMech.DistanceToPoint(pos[0], pos[1]) <= graveClearRange {
+			pMech.graveyard.ClearGrave(id)
+		}
+	}
+}
+
+// MovementPenalty returns the accuracy currently lost to moving
+func (pMech *PlayerMech) MovementPenalty() float64 {
+	return pMech.movementPenalty
+}
+
+// EffectiveAccuracy returns the hit rate of the player's first weapon
+// after its stability bonus and any movement penalty, 0 when unarmed
+func (pMech *PlayerMech) EffectiveAccuracy() float64 {
+	if len(pMech.weapons) == 0 {
+		return 0
+	}
+	w := pMech.weapons[0]
+	return w.Accuracy() + w.StabilityBonus() - pMech.movementPenalty
+}
+
+// checkMovement penalises accuracy for the next few ticks if the player
+// moved this tick
+func (pMech *PlayerMech) checkMovement() {
+	if x, y := pMech.entity.Position(); x != pMech.prevX || y != pMech.prevY {
+		pMech.movementPenalty = movementPenalty
+		pMech.movementPenaltyTicks = movementPenaltyTicks
+	}
+}
+
+// tickMovementPenalty counts the movement penalty down to zero
+func (pMech *PlayerMech) tickMovementPenalty() {
+	if pMech.movementPenaltyTicks == 0 {
+		return
+	}
+	pMech.movementPenaltyTicks--
+	if pMech.movementPenaltyTicks == 0 {
+		pMech.movementPenalty = 0
+	}
+}
+
+// HitStreak returns the number of consecutive hits without a miss
+func (pMech *PlayerMech) HitStreak() int {
+	return pMech.hitStreak
+}
+
+// StreakBonus returns the accuracy added by the current hit streak
+func (pMech *PlayerMech) StreakBonus() float64 {
+	return pMech.streakBonus
+}
+
+// updateStreak extends the hit streak on a hit and resets it on a miss
+func (pMech *PlayerMech) updateStreak(hit bool) {
+	if !hit {
+		pMech.hitStreak = 0
+		pMech.streakBonus = 0
+		return
+	}
+	pMech.hitStreak++
+	pMech.streakBonus = math.Min(float64(pMech.hitStreak)*streakBonusPerHit, maxStreakBonus)
+}
+
+// fireSecondary fires the active weapon's secondary mode at the last enemy
+// attacked while it is in view, splashing any other enemies near the impact
+func (pMech *PlayerMech) fireSecondary() {
+	if len(pMech.weapons) == 0 || pMech.weapons[0].Secondary() == nil {
+		return
+	}
+	target := pMech.lastTarget
+	if target == nil || target.IsDestroyed() {
+		pMech.logAndNotify("secondary_fire", "No target for secondary fire")
+		return
+	}
+
+	secondary := pMech.weapons[0].Secondary()
+	if secondary.Ammo() <= 0 {
+		pMech.logAndNotify("secondary_fire", secondary.Name()+" reloading")
+		return
+	}
+	if x, y := target.Position(); !pMech.CanSee(x, y) {
+		pMech.logAndNotify("secondary_fire", target.Name()+" is out of view")
+		return
+	}
+	if !pMech.IsInRange(target, float64(secondary.Range())) {
+		pMech.logAndNotify("secondary_fire", target.Name()+" is out of "+secondary.Name()+" range")
+		return
+	}
+
+	alive := pMech.aliveEnemies()
+
+	x, y := pMech.entity.Position()
+	secondary.SetPosition(x, y)
+	pMech.recordShot(target)
+	if !secondary.FireWithSplash(secondary.RangeTo(target), target, pMech.splashTargets()) {
+		pMech.logAndNotify("miss", "Missed "+target.Name(),
+			"weapon", secondary.Name(), "target", target.Name())
+	}
+	pMech.publishExplosion(target)
+
+	for _, enemy := range alive {
+		if enemy.IsDestroyed() {
+			pMech.registerKill(enemy)
+		}
+	}
 }
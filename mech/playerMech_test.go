@@ -0,0 +1,644 @@
+package mech
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/bounty"
+	"github.com/Ariemeth/frame_assault/building"
+	"github.com/Ariemeth/frame_assault/damagelog"
+	"github.com/Ariemeth/frame_assault/entities"
+	"github.com/Ariemeth/frame_assault/hooks"
+	"github.com/Ariemeth/frame_assault/mech/movement"
+	"github.com/Ariemeth/frame_assault/mech/weapon"
+	tl "github.com/Ariemeth/termloop"
+)
+
+func TestSecondaryFireUsesGrenadeLauncher(t *testing.T) {
+	player := NewPlayerMech("Player", 10, 0, 0, nil, DefaultPlayerConfig())
+	player.AddWeapon(weapon.CreateRifle())
+
+	target := NewMech("Mech A", 100, 3, 0, tl.ColorRed, 'A')
+	bystander := NewMech("Mech B", 100, 4, 1, tl.ColorRed, 'B')
+	player.SetEnemyList([]*Mech{target, bystander})
+	player.lastTarget = target
+
+	secondary := player.Weapons()[0].Secondary()
+	if secondary == nil {
+		t.Fatalf("rifle has no secondary fire mode")
+	}
+	ammo := secondary.Ammo()
+
+	player.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyCtrlF})
+
+	if damage := 100 - target.StructureLeft(); damage != secondary.Damage() {
+		t.Errorf("target took %d damage instead of the grenade launcher's %d", damage, secondary.Damage())
+	}
+	if damage := 100 - bystander.StructureLeft(); damage != secondary.Damage()/2 {
+		t.Errorf("bystander took %d splash damage instead of %d", damage, secondary.Damage()/2)
+	}
+	if secondary.Ammo() != ammo-1 {
+		t.Errorf("grenade launcher has %d ammo instead of %d", secondary.Ammo(), ammo-1)
+	}
+}
+
+func TestTargetingSkipsEnemiesOutOfView(t *testing.T) {
+	player := NewPlayerMech("Player", 10, 10, 10, nil, DefaultPlayerConfig())
+	player.AddWeapon(weapon.CreateRifle())
+	player.EquipSmartBomb(weapon.CreateSmartBomb())
+
+	// The player faces right, so an enemy to its left is behind it
+	behind := NewMech("Mech A", 100, 7, 10, tl.ColorRed, 'A')
+	player.SetEnemyList([]*Mech{behind})
+	player.lastTarget = behind
+	secondary := player.Weapons()[0].Secondary()
+	ammo := secondary.Ammo()
+
+	player.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyCtrlF})
+	if secondary.Ammo() != ammo {
+		t.Errorf("secondary fire spent ammo on an enemy behind the player")
+	}
+	player.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyF5})
+	if behind.StructureLeft() != 100 {
+		t.Errorf("enemy behind the player took %d damage", 100-behind.StructureLeft())
+	}
+}
+
+func TestTargetingPicksTheEnemyInViewSharingALetter(t *testing.T) {
+	player := NewPlayerMech("Player", 10, 10, 10, nil, DefaultPlayerConfig())
+	player.AttachGame(tl.NewGame())
+
+	// The player faces right, so the first A is behind it
+	behind := NewMech("A I", 100, 7, 10, tl.ColorRed, 'A')
+	ahead := NewMech("A II", 100, 13, 10, tl.ColorRed, 'A')
+	player.SetEnemyList([]*Mech{behind, ahead})
+
+	if player.getTargetEnemy("A") != ahead {
+		t.Error("pressing A did not target the A II in view")
+	}
+	player.SetEnemyList([]*Mech{behind})
+	if target := player.getTargetEnemy("A"); target != nil {
+		t.Errorf("pressing A targeted %s out of view", target.Name())
+	}
+}
+
+func TestFieldOfViewFollowsMovement(t *testing.T) {
+	player := NewPlayerMech("Player", 10, 10, 10, nil, DefaultPlayerConfig())
+
+	if player.CanSee(5, 10) {
+		t.Errorf("cell behind a player facing right is visible")
+	}
+	if !player.CanSee(15, 10) {
+		t.Errorf("cell ahead of a player facing right is not visible")
+	}
+
+	player.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyArrowLeft})
+	if player.CanSee(15, 10) {
+		t.Errorf("cell behind a player facing left is visible")
+	}
+	if !player.CanSee(2, 10) {
+		t.Errorf("cell ahead of a player facing left is not visible")
+	}
+
+	player.SetFOVAngle(360)
+	if !player.CanSee(15, 10) {
+		t.Errorf("cell behind the player is not visible with all round vision")
+	}
+}
+
+func TestDestroyingEnemyCollectsBounty(t *testing.T) {
+	player := NewPlayerMech("Player", 10, 0, 0, nil, DefaultPlayerConfig())
+	registry := bounty.NewRegistry()
+	registry.SetBounty("Mech A", bounty.BountyEntry{Points: bounty.BossPoints, Description: "Boss"})
+	player.AttachBounties(registry)
+
+	boss := NewMech("Mech A", 1, 1, 0, tl.ColorRed, 'A')
+	boss.Hit(1, "Player")
+	player.registerKill(boss)
+	if player.BountyPoints() != bounty.BossPoints {
+		t.Errorf("player has %d bounty points instead of %d", player.BountyPoints(), bounty.BossPoints)
+	}
+
+	unlisted := NewMech("Mech Z", 1, 1, 0, tl.ColorRed, 'Z')
+	player.registerKill(unlisted)
+	if player.BountyPoints() != bounty.BossPoints {
+		t.Errorf("enemy without a bounty changed points to %d", player.BountyPoints())
+	}
+
+	player.Hit(6, "Mech A")
+	if !player.RedeemBounty(bounty.RestoreStructure) {
+		t.Fatalf("could not redeem a structure repair")
+	}
+	if player.StructureLeft() != 9 {
+		t.Errorf("player has %d structure instead of 9 after redeeming a repair", player.StructureLeft())
+	}
+	if player.BountyPoints() != bounty.BossPoints-bounty.RestoreStructure.Cost() {
+		t.Errorf("player has %d bounty points after redeeming", player.BountyPoints())
+	}
+}
+
+func TestRedeemShieldRecharge(t *testing.T) {
+	player := NewPlayerMech("Player", 10, 0, 0, nil, DefaultPlayerConfig())
+	player.AddBountyPoints(bounty.RechargeShield.Cost() - 1)
+	if player.RedeemBounty(bounty.RechargeShield) {
+		t.Fatalf("redeemed a shield recharge with %d points", player.BountyPoints())
+	}
+
+	player.AddBountyPoints(1)
+	if !player.RedeemBounty(bounty.RechargeShield) {
+		t.Fatalf("could not redeem a shield recharge with %d points", bounty.RechargeShield.Cost())
+	}
+	if player.Shield() != player.MaxShield() || player.BountyPoints() != 0 {
+		t.Errorf("player has %d shield and %d points after a recharge, want %d and 0",
+			player.Shield(), player.BountyPoints(), player.MaxShield())
+	}
+}
+
+func TestHitStreakAccuracyBonus(t *testing.T) {
+	player := NewPlayerMech("Player", 10, 0, 0, nil, DefaultPlayerConfig())
+
+	for i := 0; i < 5; i++ {
+		player.updateStreak(true)
+	}
+	if player.HitStreak() != 5 {
+		t.Errorf("hit streak is %d after 5 hits instead of 5", player.HitStreak())
+	}
+	if math.Abs(player.StreakBonus()-0.10) > 1e-9 {
+		t.Errorf("streak bonus is %f after 5 hits instead of 0.10", player.StreakBonus())
+	}
+
+	player.updateStreak(false)
+	if player.HitStreak() != 0 || player.StreakBonus() != 0 {
+		t.Errorf("miss left a streak of %d with bonus %f", player.HitStreak(), player.StreakBonus())
+	}
+
+	for i := 0; i < 20; i++ {
+		player.updateStreak(true)
+	}
+	if math.Abs(player.StreakBonus()-maxStreakBonus) > 1e-9 {
+		t.Errorf("streak bonus is %f after 20 hits instead of the %f cap", player.StreakBonus(), maxStreakBonus)
+	}
+}
+
+func TestThrottledShotKeepsHitStreak(t *testing.T) {
+	defer func(limit int) { weapon.MaxConcurrentBullets = limit }(weapon.MaxConcurrentBullets)
+	weapon.MaxConcurrentBullets = 0
+	player := NewPlayerMech("Player", 10, 0, 0, tl.NewBaseLevel(tl.Cell{}), DefaultPlayerConfig())
+	player.AddWeapon(weapon.Create(10, 1, "test rifle", 1.0))
+	for i := 0; i < 3; i++ {
+		player.updateStreak(true)
+	}
+
+	enemy := NewMech("Mech A", 10, 3, 0, tl.ColorRed, 'A')
+	player.attackMech(enemy)
+	if player.HitStreak() != 3 {
+		t.Errorf("hit streak is %d after a throttled shot, want it kept at 3", player.HitStreak())
+	}
+	if enemy.StructureLeft() != 10 {
+		t.Errorf("throttled shot dealt %d damage", 10-enemy.StructureLeft())
+	}
+}
+
+func TestThermalDetectsEnemiesBehindBuildings(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	player := NewPlayerMech("Player", 10, 10, 0, level, DefaultPlayerConfig())
+	enemy := NewEnemyMech("Mech A", 5, 0, 0, tl.ColorRed, 'A', movement.NewRandomWalkStrategy())
+	enemy.SetLevel(level)
+	level.AddEntity(building.NewBuilding(4, -2, 3, 5, building.Types[0]))
+	player.SetEnemyList([]*Mech{enemy.Mech})
+
+	if enemy.hasLineOfSight(10, 0) {
+		t.Fatal("the building does not stand between the player and the enemy")
+	}
+	if contacts := player.ThermalContacts(); len(contacts) != 0 {
+		t.Errorf("thermal imaging found %v before being activated", contacts)
+	}
+
+	player.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyF8})
+	for i := 0; i < thermalDurationTicks; i++ {
+		contacts := player.ThermalContacts()
+		if len(contacts) != 1 || contacts[0] != [2]int{0, 0} {
+			t.Fatalf("thermal imaging found %v on tick %d, want the enemy at 0,0", contacts, i)
+		}
+		player.Tick(tl.Event{Type: tl.EventNone})
+	}
+
+	if contacts := player.ThermalContacts(); len(contacts) != 0 {
+		t.Errorf("thermal imaging found %v after the active window", contacts)
+	}
+	if player.ActivateThermal() {
+		t.Error("thermal imaging reactivated during its cooldown")
+	}
+	if _, cooldown := player.ThermalStatus(); cooldown != thermalCooldownTicks {
+		t.Errorf("cooldown is %d ticks, want %d", cooldown, thermalCooldownTicks)
+	}
+}
+
+func TestLoadoutsEquipWeapons(t *testing.T) {
+	expected := map[string][]string{
+		"Rifle + Fist":    {"Rifle", "Fist"},
+		"Shotgun + Sword": {"Shotgun", "Sword"},
+		"Dual Fist + EMP": {"Fist", "Fist", "EMP"},
+		"Sniper Rifle":    {"Sniper Rifle"},
+	}
+	if len(weapon.Loadouts) != len(expected) {
+		t.Fatalf("there are %d loadouts, want %d", len(weapon.Loadouts), len(expected))
+	}
+
+	for _, loadout := range weapon.Loadouts {
+		config := DefaultPlayerConfig()
+		config.Loadout = loadout
+		player := NewPlayerMech("Player", 10, 0, 0, nil, config)
+		player.EquipLoadout(config.Loadout)
+
+		names := make([]string, 0)
+		for _, w := range player.Weapons() {
+			names = append(names, w.Name())
+		}
+		want, ok := expected[loadout.Name]
+		if !ok {
+			t.Errorf("unexpected loadout %q", loadout.Name)
+			continue
+		}
+		if strings.Join(names, ",") != strings.Join(want, ",") {
+			t.Errorf("%s equipped %v, want %v", loadout.Name, names, want)
+		}
+	}
+}
+
+func TestSecondRareDropUpgradesInsteadOfAddingARailgun(t *testing.T) {
+	player := NewPlayerMech("Player", 10, 0, 0, nil, DefaultPlayerConfig())
+	player.AddWeapon(weapon.CreateRifle())
+	damage := player.Weapons()[0].Damage()
+
+	player.unpack(entities.RareWeapon)
+	player.unpack(entities.RareWeapon)
+
+	railguns := 0
+	for _, w := range player.Weapons() {
+		if w.Name() == "Railgun" {
+			railguns++
+		}
+	}
+	if railguns != 1 {
+		t.Errorf("two rare drops fitted %d railguns, want 1", railguns)
+	}
+	if got := player.Weapons()[0].Damage(); got != damage+upgradeDamageAmount {
+		t.Errorf("second rare drop left the rifle at %d damage, want %d", got, damage+upgradeDamageAmount)
+	}
+}
+
+func TestEMPSplashesEnemiesAroundItsTarget(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	player := NewPlayerMech("Player", 10, 0, 0, level, DefaultPlayerConfig())
+	player.AddWeapon(weapon.CreateEMP())
+	target := NewMech("Mech A", 10, 1, 0, tl.ColorRed, 'A')
+	nearby := NewMech("Mech B", 10, 2, 1, tl.ColorRed, 'B')
+	distant := NewMech("Mech C", 10, 1, 6, tl.ColorRed, 'C')
+	player.SetEnemyList([]*Mech{target, nearby, distant})
+
+	player.attackMech(target)
+	if target.StructureLeft() == 10 {
+		t.Fatal("the EMP missed its target")
+	}
+	if nearby.StructureLeft() == 10 {
+		t.Error("the EMP did not splash the enemy beside its target")
+	}
+	if distant.StructureLeft() != 10 {
+		t.Errorf("the EMP splashed an enemy %d cells from its target", 6)
+	}
+}
+
+func TestEMPKeyPulsesOnlyTheEMP(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	player := NewPlayerMech("Player", 10, 0, 0, level, DefaultPlayerConfig())
+	player.AddWeapon(weapon.CreateFist())
+	player.AddWeapon(weapon.CreateEMP())
+	target := NewMech("Mech A", 10, 1, 0, tl.ColorRed, 'A')
+	nearby := NewMech("Mech B", 10, 2, 1, tl.ColorRed, 'B')
+	player.SetEnemyList([]*Mech{target, nearby})
+	player.lastTarget = target
+
+	player.Tick(tl.Event{Type: tl.EventKey, Ch: 'p'})
+	if player.shotsFired != 1 {
+		t.Fatalf("the EMP key fired %d shots, want the EMP alone", player.shotsFired)
+	}
+	if nearby.StructureLeft() == 10 {
+		t.Error("the EMP key did not splash the enemy beside the target")
+	}
+}
+
+func TestMovingReducesEffectiveAccuracy(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	player := NewPlayerMech("Player", 10, 0, 0, level, DefaultPlayerConfig())
+	player.AddWeapon(weapon.CreateSniperRifle())
+	still := player.EffectiveAccuracy()
+
+	player.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyArrowRight})
+	if x, _ := player.Position(); x == 0 {
+		t.Fatal("the player did not move")
+	}
+	if moved := player.EffectiveAccuracy(); moved >= still {
+		t.Errorf("accuracy after moving is %.2f, want less than %.2f", moved, still)
+	}
+
+	for i := 0; i < movementPenaltyTicks; i++ {
+		player.Tick(tl.Event{Type: tl.EventNone})
+	}
+	if recovered := player.EffectiveAccuracy(); recovered != still {
+		t.Errorf("accuracy %d ticks after moving is %.2f, want %.2f", movementPenaltyTicks, recovered, still)
+	}
+}
+
+func TestStealthKillFromBehind(t *testing.T) {
+	// The enemy moves right and the player comes at it from the right
+	player := NewPlayerMech("Player", 10, 12, 0, nil, DefaultPlayerConfig())
+	player.AddWeapon(weapon.CreateRifle())
+	enemy := NewEnemyMech("Mech A", 50, 10, 0, tl.ColorRed, 'A', movement.NewRandomWalkStrategy())
+	player.SetEnemyList([]*Mech{enemy.Mech})
+	enemy.facingX, enemy.facingY = 1, 0
+
+	player.Tick(tl.Event{Type: tl.EventKey, Ch: 'v'})
+	if !player.StealthActive() {
+		t.Fatal("stealth did not activate")
+	}
+	player.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyArrowLeft})
+	player.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyArrowLeft})
+	if dx, dy := player.ApproachVector(); dx != -1 || dy != 0 {
+		t.Fatalf("approach vector is %d,%d, want -1,0", dx, dy)
+	}
+	player.Collide(enemy)
+	player.Tick(tl.Event{Type: tl.EventNone})
+
+	if !enemy.IsDestroyed() {
+		t.Errorf("enemy has %d structure left after a stealth kill", enemy.StructureLeft())
+	}
+	if player.Kills() != 1 {
+		t.Errorf("player has %d kills, want 1", player.Kills())
+	}
+	if player.StealthActive() {
+		t.Error("stealth is still active after the kill")
+	}
+	if x, _ := player.Position(); x != 11 {
+		t.Errorf("player is at x %d, want pushed back to 11", x)
+	}
+}
+
+func TestAttackWithoutStealthDealsNormalDamage(t *testing.T) {
+	player := NewPlayerMech("Player", 10, 9, 0, nil, DefaultPlayerConfig())
+	player.AddWeapon(weapon.CreateRifle())
+	enemy := NewEnemyMech("Mech A", 50, 10, 0, tl.ColorRed, 'A', movement.NewRandomWalkStrategy())
+	player.SetEnemyList([]*Mech{enemy.Mech})
+	enemy.facingX, enemy.facingY = 1, 0
+
+	player.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyArrowRight})
+	player.Collide(enemy)
+	player.Tick(tl.Event{Type: tl.EventNone})
+
+	if enemy.IsDestroyed() {
+		t.Error("enemy was destroyed without stealth")
+	}
+	if player.Kills() != 0 {
+		t.Errorf("player has %d kills, want 0", player.Kills())
+	}
+}
+
+func TestDrawHookReplacesPlayerGlyph(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	player := NewPlayerMech("Player", 10, 0, 0, level, DefaultPlayerConfig())
+	var drawn tl.Drawable
+	hooks.Global.Register("PlayerMech", func(entity tl.Drawable, screen *tl.Screen) {
+		drawn = entity
+		x, y := player.Position()
+		screen.RenderCell(x, y, &tl.Cell{Fg: tl.ColorGreen, Ch: '@'})
+	})
+	defer hooks.Global.Register("PlayerMech", nil)
+
+	player.Draw(tl.NewScreen())
+
+	if drawn != player {
+		t.Errorf("draw hook was called with %v instead of the player", drawn)
+	}
+}
+
+func TestMechDrawHookCoversThePlayer(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	player := NewPlayerMech("Player", 10, 0, 0, level, DefaultPlayerConfig())
+	var drawn tl.Drawable
+	hooks.Global.Register("Mech", func(entity tl.Drawable, screen *tl.Screen) {
+		drawn = entity
+	})
+	defer hooks.Global.Register("Mech", nil)
+
+	player.Draw(tl.NewScreen())
+
+	if drawn != &player.Mech {
+		t.Errorf("Mech draw hook was called with %v instead of the player's mech", drawn)
+	}
+}
+
+// fakeGraveyard records the graves cleared by the player
+type fakeGraveyard map[damagelog.EntityID][2]int
+
+func (g fakeGraveyard) Graves() map[damagelog.EntityID][2]int { return g }
+func (g fakeGraveyard) ClearGrave(id damagelog.EntityID)      { delete(g, id) }
+
+func TestVisitingGraveClearsIt(t *testing.T) {
+	player := NewPlayerMech("Player", 10, 0, 0, nil, DefaultPlayerConfig())
+	graves := fakeGraveyard{"Mech A": {5, 0}, "Mech B": {30, 0}}
+	player.AttachGraveyard(graves)
+
+	player.Tick(tl.Event{Type: tl.EventNone})
+	if len(graves) != 2 {
+		t.Fatalf("graves were cleared from 5 cells away: %v", graves)
+	}
+
+	player.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyArrowRight})
+	player.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyArrowRight})
+	player.Tick(tl.Event{Type: tl.EventNone})
+	if _, ok := graves["Mech A"]; ok {
+		t.Error("grave within 3 cells was not cleared")
+	}
+	if _, ok := graves["Mech B"]; !ok {
+		t.Error("distant grave was cleared")
+	}
+}
+
+// fakeCoverAdvisor records the last attacker position it was asked about
+type fakeCoverAdvisor struct {
+	attacker *[2]int
+}
+
+func (a *fakeCoverAdvisor) Advise(playerX, playerY, attackerX, attackerY int) {
+	a.attacker = &[2]int{attackerX, attackerY}
+}
+
+func TestCoverAdvisedBelowHalfStructure(t *testing.T) {
+	player := NewPlayerMech("Player", 10, 0, 0, nil, DefaultPlayerConfig())
+	enemy := NewMech("Mech A", 10, 7, 3, tl.ColorRed, 'A')
+	player.SetEnemyList([]*Mech{enemy})
+	advisor := &fakeCoverAdvisor{}
+	player.AttachCoverAdvisor(advisor)
+
+	player.Hit(5, "Mech A")
+	if advisor.attacker != nil {
+		t.Fatal("cover advised at half structure")
+	}
+	player.Hit(1, "Mech A")
+	if advisor.attacker == nil || *advisor.attacker != [2]int{7, 3} {
+		t.Errorf("cover advised against %v, want the attacker at 7,3", advisor.attacker)
+	}
+}
+
+type fakeTickCounter struct {
+	rewound int
+}
+
+func (c *fakeTickCounter) RewindTick() {
+	c.rewound++
+}
+
+func TestUndoMoveRestoresPositionAndStructure(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	player := NewPlayerMech("Player", 10, 5, 5, level, DefaultPlayerConfig())
+	counter := &fakeTickCounter{}
+	player.AttachTickCounter(counter)
+
+	player.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyArrowRight})
+	player.Hit(4, "Mech A")
+	if x, _ := player.Position(); x != 6 || player.StructureLeft() != 6 {
+		t.Fatalf("player at x %d with %d structure before undo, want 6 and 6", x, player.StructureLeft())
+	}
+
+	player.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyBackspace2})
+	if x, y := player.Position(); x != 5 || y != 5 {
+		t.Errorf("player at %d,%d after undo, want 5,5", x, y)
+	}
+	if player.StructureLeft() != 10 {
+		t.Errorf("player has %d structure after undo, want 10", player.StructureLeft())
+	}
+	if counter.rewound != 1 {
+		t.Errorf("tick counter rewound %d times, want 1", counter.rewound)
+	}
+	if player.UndosRemaining() != MaxUndos-1 {
+		t.Errorf("%d undos remaining, want %d", player.UndosRemaining(), MaxUndos-1)
+	}
+	if player.UndoMove() {
+		t.Error("undid a move that was already undone")
+	}
+}
+
+// damageTarget records the damage of every hit it takes
+type damageTarget struct {
+	hits []int
+}
+
+func (d *damageTarget) Hit(damage int, attacker damagelog.EntityID) { d.hits = append(d.hits, damage) }
+func (d *damageTarget) Name() string                                { return "target" }
+func (d *damageTarget) IsDestroyed() bool                           { return false }
+func (d *damageTarget) Position() (int, int)                        { return 1, 0 }
+
+func TestOverloadDoublesDamageUntilItBurnsOut(t *testing.T) {
+	player := NewPlayerMech("Player", 50, 0, 0, nil, DefaultPlayerConfig())
+	player.AddWeapon(weapon.Create(5, 3, "test", 1))
+	target := &damageTarget{}
+
+	player.Fire(1, target)
+	player.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyF4})
+	if !player.OverloadActive() {
+		t.Fatal("overload did not activate")
+	}
+	player.Fire(1, target)
+	for i := 0; i < overloadDurationTicks; i++ {
+		player.Tick(tl.Event{Type: tl.EventNone})
+	}
+	if player.OverloadActive() {
+		t.Fatal("overload is still active after its duration")
+	}
+	player.Fire(1, target)
+
+	if len(target.hits) != 3 {
+		t.Fatalf("target took %d hits, want 3", len(target.hits))
+	}
+	normal := target.hits[0]
+	if target.hits[1] != normal*2 {
+		t.Errorf("overloaded hit dealt %d damage, want double %d", target.hits[1], normal)
+	}
+	if target.hits[2] != normal {
+		t.Errorf("hit after overload dealt %d damage, want %d", target.hits[2], normal)
+	}
+	if player.Weapons()[0].Damage() != 3 {
+		t.Errorf("weapon damage is %d after overload, want it unchanged at 3", player.Weapons()[0].Damage())
+	}
+	if player.StructureLeft() != 50-overloadSelfDamageAmount {
+		t.Errorf("player has %d structure after overload, want %d", player.StructureLeft(), 50-overloadSelfDamageAmount)
+	}
+	if _, cooldown := player.OverloadStatus(); cooldown != overloadCooldownTicks {
+		t.Errorf("overload cooldown is %d, want %d", cooldown, overloadCooldownTicks)
+	}
+}
+
+// gameSpeed applies speed multipliers to enemies as the game state does
+type gameSpeed struct {
+	multiplier float64
+}
+
+func (g *gameSpeed) SpeedMultiplier() float64 { return g.multiplier }
+func (g *gameSpeed) SetSpeedMultiplier(multiplier float64) {
+	g.multiplier = multiplier
+}
+
+func TestBulletTimeSlowsEnemies(t *testing.T) {
+	speed := &gameSpeed{multiplier: 1}
+	player := NewPlayerMech("Player", 10, 0, 0, nil, DefaultPlayerConfig())
+	player.AttachSpeedControl(speed, 10)
+
+	moved := func() int {
+		enemy := NewEnemyMech("Mech A", 10, 0, 5, tl.ColorRed, 'A', stepRightStrategy{})
+		enemy.moveDelay = 1
+		enemy.AttachSpeed(speed)
+		for i := 0; i < 8; i++ {
+			enemy.Tick(tl.Event{Type: tl.EventNone})
+		}
+		x, _ := enemy.Position()
+		return x
+	}
+
+	if x := moved(); x != 8 {
+		t.Fatalf("enemy moved %d cells in 8 ticks at normal speed, want 8", x)
+	}
+	player.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyF3})
+	if !player.BulletTimeActive() {
+		t.Fatal("bullet time did not activate")
+	}
+	if x := moved(); x != 2 {
+		t.Errorf("enemy moved %d cells in 8 ticks of bullet time, want 2", x)
+	}
+
+	for i := 0; i < bulletTimeSeconds*10; i++ {
+		player.Tick(tl.Event{Type: tl.EventNone})
+	}
+	if player.BulletTimeActive() {
+		t.Fatal("bullet time is still active after 5 seconds")
+	}
+	if speed.multiplier != 1 {
+		t.Errorf("game speed is %v after bullet time, want 1 restored", speed.multiplier)
+	}
+}
+
+func TestShieldAbsorbsHitsBeforeStructure(t *testing.T) {
+	player := NewPlayerMech("Player", 10, 0, 0, nil, DefaultPlayerConfig())
+	player.RechargeShield()
+
+	player.Hit(4, "Mech A")
+	if player.StructureLeft() != 10 || player.Shield() != PlayerMaxShield-4 {
+		t.Fatalf("player has %d structure and %d shield, want the shield to take the hit",
+			player.StructureLeft(), player.Shield())
+	}
+	player.Hit(PlayerMaxShield, "Mech A")
+	if player.Shield() != 0 || player.StructureLeft() != 6 {
+		t.Errorf("player has %d structure and %d shield, want the rest through to structure",
+			player.StructureLeft(), player.Shield())
+	}
+}
@@ -0,0 +1,38 @@
+package mech
+
+import "strconv"
+
+// PlayerMaxShield is the most shield a player's mech can hold. Mechs leave
+// the hangar with an empty shield that a bounty.RechargeShield fills.
+const PlayerMaxShield = 10
+
+// Shield returns the shield left to soak up hits
+func (pMech *PlayerMech) Shield() int {
+	return pMech.shield
+}
+
+// MaxShield returns the most shield the mech can hold
+func (pMech *PlayerMech) MaxShield() int {
+	return pMech.maxShield
+}
+
+// RechargeShield fills the shield
+func (pMech *PlayerMech) RechargeShield() {
+	pMech.shield = pMech.maxShield
+}
+
+// absorb takes damage off the shield first and returns the damage that
+// gets through to the structure
+func (pMech *PlayerMech) absorb(damage int) int {
+	if pMech.shield == 0 || pMech.IsDestroyed() {
+		return damage
+	}
+	absorbed := damage
+	if absorbed > pMech.shield {
+		absorbed = pMech.shield
+	}
+	pMech.shield -= absorbed
+	pMech.logAndNotify("shield", "Shield absorbs "+strconv.Itoa(absorbed),
+		"absorbed", absorbed, "shield", pMech.shield)
+	return damage - absorbed
+}
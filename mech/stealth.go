@@ -0,0 +1,123 @@
+package mech
+
+import "github.com/Ariemeth/frame_assault/damagelog"
+
+const (
+	// stealthDurationTicks is how long stealth lasts unless broken by an attack
+	stealthDurationTicks = 50
+	// stealthCooldownTicks is how long stealth takes to recharge
+	stealthCooldownTicks = 150
+	// stealthKillMessage is shown when the player destroys an enemy from behind
+	stealthKillMessage = "[STEALTH KILL]"
+)
+
+// criticalNotifier is implemented by notifiers that can highlight urgent
+// messages
+type criticalNotifier interface {
+	AddCriticalMessage(message string)
+}
+
+// stealther is implemented by targets that can hide from enemy sensors
+type stealther interface {
+	StealthActive() bool
+}
+
+// ActivateStealth hides the player from enemy sensors unless stealth is
+// already on or still recharging. Returns true if it was turned on.
+func (pMech *PlayerMech) ActivateStealth() bool {
+	if pMech.stealthActive || pMech.stealthCooldown > 0 {
+		return false
+	}
+	pMech.stealthActive = true
+	pMech.stealthTicks = stealthDurationTicks
+	pMech.logAndNotify("stealth", "Stealth active")
+	return true
+}
+
+// StealthActive returns true while the player is hidden
+func (pMech *PlayerMech) StealthActive() bool {
+	return pMech.stealthActive
+}
+
+// breakStealth ends stealth early and starts its cooldown
+func (pMech *PlayerMech) breakStealth() {
+	if !pMech.stealthActive {
+		return
+	}
+	pMech.stealthActive = false
+	pMech.stealthTicks = 0
+	pMech.stealthCooldown = stealthCooldownTicks
+}
+
+// tickStealth counts down stealth and then its cooldown
+func (pMech *PlayerMech) tickStealth() {
+	if pMech.stealthActive {
+		pMech.stealthTicks--
+		if pMech.stealthTicks <= 0 {
+			pMech.breakStealth()
+		}
+		return
+	}
+	if pMech.stealthCooldown > 0 {
+		pMech.stealthCooldown--
+	}
+}
+
+// ApproachVector returns the direction of the player's last step, each
+// axis -1, 0 or 1, or 0,0 if the player has not moved this tick
+func (pMech *PlayerMech) ApproachVector() (dx, dy int) {
+	return pMech.approachX, pMech.approachY
+}
+
+// FacingVector returns the direction of the enemy's last move, each axis
+// -1, 0 or 1. An enemy that has not moved yet faces 0,0.
+func (e *EnemyMech) FacingVector() (dx, dy int) {
+	return e.facingX, e.facingY
+}
+
+// engagement is an enemy the player stepped into, and whether it was
+// facing away at the time
+type engagement struct {
+	enemy      *EnemyMech
+	fromBehind bool
+}
+
+// behind returns true if the player is stepping into enemy while it faces
+// away, the player's approach being opposite to the enemy's last move
+func (pMech *PlayerMech) behind(enemy *EnemyMech) bool {
+	dx, dy := pMech.ApproachVector()
+	fx, fy := enemy.FacingVector()
+	return (dx != 0 || dy != 0) && dx == -fx && dy == -fy
+}
+
+// resolveEngagements engages every enemy stepped into since the last tick
+func (pMech *PlayerMech) resolveEngagements() {
+	engagements := pMech.engagements
+	pMech.engagements = nil
+	for _, e := range engagements {
+		pMech.engage(e.enemy, e.fromBehind)
+	}
+}
+
+// engage handles the player stepping into enemy. A stealthed player
+// coming up from behind destroys it outright, anything else is a normal
+// attack. Either way stealth is broken.
+func (pMech *PlayerMech) engage(enemy *EnemyMech, fromBehind bool) {
+	if enemy.IsDestroyed() {
+		return
+	}
+	if !pMech.stealthActive || !fromBehind {
+		pMech.breakStealth()
+		pMech.attackMech(enemy.Mech)
+		return
+	}
+	pMech.breakStealth()
+	enemy.Hit(enemy.StructureLeft(), damagelog.EntityID(pMech.name))
+	pMech.registerKill(enemy.Mech)
+	pMech.logEvent("stealth_kill", stealthKillMessage+" "+enemy.Name(), "target", enemy.Name())
+	if notifier, ok := pMech.notifier.(criticalNotifier); ok {
+		notifier.AddCriticalMessage(stealthKillMessage + " " + enemy.Name())
+	} else if pMech.notifier != nil {
+		pMech.notifier.AddMessage(stealthKillMessage + " " + enemy.Name())
+	}
+}
@@ -0,0 +1,90 @@
+package mech
+
+import tl "github.com/Ariemeth/termloop"
+
+const (
+	// thermalDurationTicks is how long thermal imaging stays on
+	thermalDurationTicks = 10
+	// thermalCooldownTicks is how long thermal imaging takes to recharge
+	thermalCooldownTicks = 200
+	// thermalGlyph marks an enemy detected by thermal imaging
+	thermalGlyph = '⊙'
+	thermalColor = tl.ColorRed | tl.AttrBold
+)
+
+// ActivateThermal turns on thermal imaging unless it is already on or
+// still recharging. Returns true if it was turned on.
+func (pMech *PlayerMech) ActivateThermal() bool {
+	if pMech.thermalActive || pMech.thermalCooldown > 0 {
+		return false
+	}
+	pMech.thermalActive = true
+	pMech.thermalTicks = thermalDurationTicks
+	pMech.logAndNotify("thermal", "Thermal imaging active")
+	return true
+}
+
+// ThermalActive returns true while thermal imaging is on
+func (pMech *PlayerMech) ThermalActive() bool {
+	return pMech.thermalActive
+}
+
+// ThermalStatus returns the ticks of thermal imaging left and the ticks
+// of cooldown that follow or remain
+func (pMech *PlayerMech) ThermalStatus() (remaining, cooldown int) {
+	if pMech.thermalActive {
+		return pMech.thermalTicks, thermalCooldownTicks
+	}
+	return 0, pMech.thermalCooldown
+}
+
+// tickThermal counts down thermal imaging and then its cooldown
+func (pMech *PlayerMech) tickThermal() {
+	if pMech.thermalActive {
+		pMech.thermalTicks--
+		if pMech.thermalTicks <= 0 {
+			pMech.thermalActive = false
+			pMech.thermalCooldown = thermalCooldownTicks
+		}
+		return
+	}
+	if pMech.thermalCooldown > 0 {
+		pMech.thermalCooldown--
+	}
+}
+
+// ThermalContacts returns the position of every enemy still fighting while
+// thermal imaging is on, whether or not the player can see it
+func (pMech *PlayerMech) ThermalContacts() [][2]int {
+	if !pMech.thermalActive {
+		return nil
+	}
+	return pMech.EnemyPositions()
+}
+
+// drawThermal marks the enemies found by thermal imaging
+func (pMech *PlayerMech) drawThermal(screen cellRenderer) {
+	for _, pos := range pMech.ThermalContacts() {
+		screen.RenderCell(pos[0], pos[1], &tl.Cell{Fg: thermalColor, Ch: thermalGlyph})
+	}
+}
+
+// ThermalOverlay draws the enemies found by the player's thermal imaging
+// as an entity of its own, so they can be drawn above layers the player
+// is drawn under
+type ThermalOverlay struct {
+	player *PlayerMech
+}
+
+// NewThermalOverlay creates an overlay of player's thermal contacts
+func NewThermalOverlay(player *PlayerMech) *ThermalOverlay {
+	return &ThermalOverlay{player: player}
+}
+
+// Draw marks the thermal contacts
+func (o *ThermalOverlay) Draw(screen *tl.Screen) {
+	o.player.drawThermal(screen)
+}
+
+// Tick does nothing, the player counts down thermal imaging
+func (o *ThermalOverlay) Tick(event tl.Event) {}
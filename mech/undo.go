@@ -0,0 +1,81 @@
+package mech
+
+const (
+	// undoHistory is how many of the player's last moves can be undone
+	undoHistory = 10
+	// MaxUndos is how many moves the player can undo in a game
+	MaxUndos = 3
+)
+
+// TickCounter counts the game's elapsed ticks and can wind them back
+type TickCounter interface {
+	RewindTick()
+}
+
+// Dialogue is a dialogue box that takes Backspace while it is open
+type Dialogue interface {
+	IsOpen() bool
+}
+
+// AttachTickCounter sets the game tick counter wound back by undoing a move
+func (pMech *PlayerMech) AttachTickCounter(counter TickCounter) {
+	pMech.tickCounter = counter
+}
+
+// AttachDialogue sets the dialogue box that Backspace declines while open
+func (pMech *PlayerMech) AttachDialogue(dialogue Dialogue) {
+	pMech.dialogue = dialogue
+}
+
+// UndosRemaining returns how many more moves the player can undo
+func (pMech *PlayerMech) UndosRemaining() int {
+	return pMech.undosRemaining
+}
+
+// SetUndosRemaining sets how many more moves the player can undo, carrying
+// what is left of the game's undos over to a new life
+func (pMech *PlayerMech) SetUndosRemaining(undos int) {
+	pMech.undosRemaining = undos
+}
+
+// rememberMove saves where the player is moving from, and the structure
+// it had there, before a step
+func (pMech *PlayerMech) rememberMove() {
+	if len(pMech.prevPositions) == undoHistory {
+		pMech.prevPositions = pMech.prevPositions[1:]
+		pMech.prevStructures = pMech.prevStructures[1:]
+	}
+	pMech.prevPositions = append(pMech.prevPositions, [2]int{pMech.prevX, pMech.prevY})
+	pMech.prevStructures = append(pMech.prevStructures, pMech.structure)
+}
+
+// UndoMove takes the player back to where it was before its last move,
+// repairing any damage taken since, and winds the game back a tick.
+// Returns false if there is no move to undo, the player is destroyed or it
+// has used up its undos.
+func (pMech *PlayerMech) UndoMove() bool {
+	// Moves blocked by a collision left the player where it was
+	x, y := pMech.entity.Position()
+	last := len(pMech.prevPositions) - 1
+	for last >= 0 && pMech.prevPositions[last] == [2]int{x, y} {
+		last--
+	}
+	if last < 0 || pMech.undosRemaining <= 0 || pMech.IsDestroyed() {
+		return false
+	}
+	position, structure := pMech.prevPositions[last], pMech.prevStructures[last]
+	pMech.prevPositions = pMech.prevPositions[:last]
+	pMech.prevStructures = pMech.prevStructures[:last]
+	pMech.undosRemaining--
+
+	pMech.entity.SetPosition(position[0], position[1])
+	pMech.prevX, pMech.prevY = position[0], position[1]
+	if pMech.structure < structure {
+		pMech.structure = structure
+	}
+	if pMech.tickCounter != nil {
+		pMech.tickCounter.RewindTick()
+	}
+	pMech.logAndNotify("undo", "Move undone")
+	return true
+}
@@ -1,21 +1,74 @@
 package weapon
 
+import tl "github.com/Ariemeth/termloop"
+
 // CreateShotgun creates a new shotgun weapon
 func CreateShotgun() Weapon {
-	return Create(3, 2, "Shotgun", .50)
+	shotgun := Create(3, 2, "Shotgun", .50)
+	shotgun.conditionDegradation = 3
+	shotgun.fireRateTicks = 8
+	// Shot spreads out quickly, losing most of its punch
+	shotgun.falloffStart = 0.4
+	shotgun.minDamageFraction = 0.2
+	return shotgun.WithProjectileStyle(tl.ColorRed, '·')
 }
 
-// CreateRifle creates a new rifle weapon
+// CreateRifle creates a new rifle weapon with an underslung grenade launcher
 func CreateRifle() Weapon {
-	return Create(5, 1, "Rifle", .75)
+	rifle := Create(5, 1, "Rifle", .75)
+	grenadeLauncher := createGrenadeLauncherMode()
+	rifle.SetSecondary(&grenadeLauncher)
+	rifle.fireRateTicks = 3
+	rifle.falloffStart = 0.7
+	rifle.minDamageFraction = 0.5
+	return rifle.WithProjectileStyle(tl.ColorYellow|tl.AttrBold, '*')
+}
+
+// createGrenadeLauncherMode creates the rifle's secondary fire mode
+func createGrenadeLauncherMode() Weapon {
+	launcher := CreateWithMagazine(6, 4, "Grenade Launcher", 1.0, 2, 150)
+	launcher.splashRadius = 2
+	launcher.projectile = grenadeProjectile
+	return launcher
 }
 
 // CreateFist creates a new fist weapon
 func CreateFist() Weapon {
-	return Create(1, 1, "Fist", .60)
+	fist := Create(1, 1, "Fist", .60)
+	fist.fireRateTicks = 1
+	return fist
 }
 
 // CreateSword creates a new sword weapon
 func CreateSword() Weapon {
-	return Create(1, 2, "Sword", .80)
+	sword := Create(1, 2, "Sword", .80)
+	sword.fireRateTicks = 2
+	// Swords cut, they do not shoot
+	sword.projectile = meleeProjectile
+	return sword
+}
+
+// CreateRailgun creates a new railgun weapon
+func CreateRailgun() Weapon {
+	railgun := Create(8, 4, "Railgun", .85)
+	// Railgun slugs travel fast enough to hit harder than their base damage
+	railgun.projectileSpeed = 1.5
+	return railgun
+}
+
+// CreateEMP creates a short ranged electromagnetic pulse that hits
+// everything around its target
+func CreateEMP() Weapon {
+	emp := CreateWithMagazine(3, 1, "EMP", 1.0, 1, 100)
+	emp.splashRadius = 2
+	return emp.WithProjectileStyle(tl.ColorCyan, '~')
+}
+
+// CreateSniperRifle creates a slow firing, long ranged, accurate rifle
+func CreateSniperRifle() Weapon {
+	sniper := Create(10, 3, "Sniper Rifle", .90)
+	sniper.fireRateTicks = 10
+	// Rewards holding still to line up a shot
+	sniper.stabilityBonus = 0.10
+	return sniper
 }
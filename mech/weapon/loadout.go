@@ -0,0 +1,25 @@
+package weapon
+
+// Loadout is a combination of weapons a mech can start with
+type Loadout struct {
+	Name        string
+	Description string
+	create      []func() Weapon
+}
+
+// Loadouts lists the weapon combinations the player can start with
+var Loadouts = []Loadout{
+	{"Rifle + Fist", "Balanced: steady fire at range and a fist up close", []func() Weapon{CreateRifle, CreateFist}},
+	{"Shotgun + Sword", "Brawler: close in and hit hard", []func() Weapon{CreateShotgun, CreateSword}},
+	{"Dual Fist + EMP", "Disruptor: pulse groups of enemies then finish them by hand", []func() Weapon{CreateFist, CreateFist, CreateEMP}},
+	{"Sniper Rifle", "Marksman: slow, accurate shots from far away", []func() Weapon{CreateSniperRifle}},
+}
+
+// Weapons creates a new set of the loadout's weapons
+func (l Loadout) Weapons() []Weapon {
+	weapons := make([]Weapon, 0, len(l.create))
+	for _, create := range l.create {
+		weapons = append(weapons, create())
+	}
+	return weapons
+}
@@ -0,0 +1,19 @@
+package weapon
+
+import "strings"
+
+// creators maps weapon names to the functions that create them
+var creators = map[string]func() Weapon{
+	"shotgun": CreateShotgun,
+	"rifle":   CreateRifle,
+	"fist":    CreateFist,
+	"sword":   CreateSword,
+	"railgun": CreateRailgun,
+}
+
+// CreatorByName returns the function creating the named weapon.
+// Names are case insensitive.
+func CreatorByName(name string) (func() Weapon, bool) {
+	create, ok := creators[strings.ToLower(name)]
+	return create, ok
+}
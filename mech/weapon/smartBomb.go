@@ -0,0 +1,98 @@
+package weapon
+
+import (
+	"math"
+
+	"github.com/Ariemeth/frame_assault/projectile"
+	"github.com/Ariemeth/frame_assault/util"
+)
+
+const (
+	// Smart bomb constants
+	smartBombSpeed          = 3.0
+	smartBombMagazineSize   = 1
+	smartBombReloadTicks    = 120
+	smartBombSplashRadius   = 2
+	smartBombSplashFraction = 0.3
+)
+
+// StructuredTarget is a Target that reports its remaining structure
+type StructuredTarget interface {
+	Target
+	// StructureLeft should return the remaining structure of the target.
+	StructureLeft() int
+}
+
+// SmartBomb is a weapon that picks its own target, always hits
+// and damages everything close to the point of detonation.
+type SmartBomb struct {
+	Weapon
+}
+
+// CreateSmartBomb creates a new smart bomb weapon
+func CreateSmartBomb() *SmartBomb {
+	return &SmartBomb{
+		Weapon: CreateWithMagazine(10, 10, "Smart Bomb", 1.0,
+			smartBombMagazineSize, smartBombReloadTicks),
+	}
+}
+
+// SelectTarget returns the candidate with the lowest remaining structure
+// that is within range, or nil if none are.
+func (bomb *SmartBomb) SelectTarget(candidates []StructuredTarget) StructuredTarget {
+	var selected StructuredTarget
+	for _, candidate := range candidates {
+		if candidate == nil || candidate.IsDestroyed() {
+			continue
+		}
+		x, y := candidate.Position()
+		if util.CalculateDistance(bomb.sourceX, bomb.sourceY, x, y) > float64(bomb.maxRange) {
+			continue
+		}
+		if selected == nil || candidate.StructureLeft() < selected.StructureLeft() {
+			selected = candidate
+		}
+	}
+	return selected
+}
+
+// Fire selects the weakest candidate in range and detonates on it,
+// splashing every other candidate near the target.
+// Returns the target hit or nil if nothing was fired.
+func (bomb *SmartBomb) Fire(candidates []StructuredTarget) StructuredTarget {
+	if bomb.currentAmmo <= 0 {
+		return nil
+	}
+	target := bomb.SelectTarget(candidates)
+	if target == nil {
+		return nil
+	}
+	bomb.consumeAmmo()
+
+	targetX, targetY := target.Position()
+	if bomb.level != nil {
+		bullet := projectile.NewBullet(bomb.sourceX, bomb.sourceY, targetX, targetY,
+			projectile.DefaultColor, projectile.DefaultSymbol, bomb.level)
+		bullet.SetSpeed(smartBombSpeed)
+		bomb.level.AddEntity(bullet)
+	}
+
+	// Collect splash victims before the primary hit can remove the target
+	splashDamage := int(math.Round(float64(bomb.damage) * smartBombSplashFraction))
+	victims := make([]StructuredTarget, 0)
+	for _, candidate := range candidates {
+		if candidate == nil || candidate == target || candidate.IsDestroyed() {
+			continue
+		}
+		x, y := candidate.Position()
+		if util.CalculateDistance(targetX, targetY, x, y) <= smartBombSplashRadius {
+			victims = append(victims, candidate)
+		}
+	}
+
+	target.Hit(bomb.damage, bomb.owner)
+	for _, victim := range victims {
+		victim.Hit(splashDamage, bomb.owner)
+	}
+	return target
+}
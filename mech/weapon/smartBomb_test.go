@@ -0,0 +1,88 @@
+package weapon
+
+import (
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/damagelog"
+)
+
+type structuredTestTarget struct {
+	name      string
+	x, y      int
+	structure int
+}
+
+func (target *structuredTestTarget) Hit(damage int, attacker damagelog.EntityID) {
+	target.structure -= damage
+}
+
+func (target *structuredTestTarget) Name() string {
+	return target.name
+}
+
+func (target *structuredTestTarget) IsDestroyed() bool {
+	return target.structure <= 0
+}
+
+func (target *structuredTestTarget) Position() (int, int) {
+	return target.x, target.y
+}
+
+func (target *structuredTestTarget) StructureLeft() int {
+	return target.structure
+}
+
+func TestSmartBombSelectsWeakest(t *testing.T) {
+	bomb := CreateSmartBomb()
+	bomb.SetPosition(0, 0)
+
+	strong := &structuredTestTarget{name: "strong", x: 3, y: 0, structure: 30}
+	weak := &structuredTestTarget{name: "weak", x: 5, y: 0, structure: 20}
+	weakest := &structuredTestTarget{name: "far", x: 50, y: 0, structure: 1}
+	candidates := []StructuredTarget{strong, weak, weakest}
+
+	if selected := bomb.SelectTarget(candidates); selected != weak {
+		t.Errorf("smart bomb selected %v instead of the weakest target in range", selected)
+	}
+}
+
+func TestSmartBombSplash(t *testing.T) {
+	bomb := CreateSmartBomb()
+	bomb.SetPosition(0, 0)
+
+	primary := &structuredTestTarget{name: "primary", x: 5, y: 0, structure: 15}
+	adjacent := &structuredTestTarget{name: "adjacent", x: 6, y: 1, structure: 20}
+	distant := &structuredTestTarget{name: "distant", x: 9, y: 0, structure: 20}
+	candidates := []StructuredTarget{primary, adjacent, distant}
+
+	if target := bomb.Fire(candidates); target != primary {
+		t.Fatalf("smart bomb fired at %v instead of primary", target)
+	}
+
+	if primary.structure != 15-bomb.Damage() {
+		t.Errorf("primary has %d structure instead of %d", primary.structure, 15-bomb.Damage())
+	}
+	if adjacent.structure != 17 {
+		t.Errorf("adjacent target has %d structure instead of 17", adjacent.structure)
+	}
+	if distant.structure != 20 {
+		t.Errorf("distant target took splash damage")
+	}
+}
+
+func TestSmartBombReload(t *testing.T) {
+	bomb := CreateSmartBomb()
+	target := &structuredTestTarget{name: "target", x: 1, y: 0, structure: 100}
+
+	bomb.Fire([]StructuredTarget{target})
+	if bomb.Fire([]StructuredTarget{target}) != nil {
+		t.Errorf("smart bomb fired with an empty magazine")
+	}
+
+	for i := 0; i < smartBombReloadTicks; i++ {
+		bomb.Tick()
+	}
+	if bomb.Ammo() != smartBombMagazineSize {
+		t.Errorf("smart bomb has %d ammo after reloading instead of %d", bomb.Ammo(), smartBombMagazineSize)
+	}
+}
@@ -0,0 +1,45 @@
+package weapon
+
+import (
+	"strings"
+	"sync"
+)
+
+// Tuning overrides a weapon's damage and hit rate while the game runs.
+// Zero values leave the weapon's own stat unchanged.
+type Tuning struct {
+	Damage  int
+	HitRate float64
+}
+
+var (
+	// tuningMu guards tunings, which are set by configuration updates
+	tuningMu sync.RWMutex
+	tunings  = make(map[string]Tuning)
+)
+
+// SetTuning sets the tuning applied to every weapon called name created
+// from now on. Names are case insensitive.
+func SetTuning(name string, tuning Tuning) {
+	tuningMu.Lock()
+	defer tuningMu.Unlock()
+	tunings[strings.ToLower(name)] = tuning
+}
+
+// tuningFor returns the tuning set for weapons called name
+func tuningFor(name string) (Tuning, bool) {
+	tuningMu.RLock()
+	defer tuningMu.RUnlock()
+	tuning, ok := tunings[strings.ToLower(name)]
+	return tuning, ok
+}
+
+// Apply sets the weapon's damage and hit rate to the tuned values
+func (tuning Tuning) Apply(weapon *Weapon) {
+	if tuning.Damage > 0 {
+		weapon.SetDamage(tuning.Damage)
+	}
+	if tuning.HitRate > 0 {
+		weapon.SetHitRate(tuning.HitRate)
+	}
+}
@@ -1,13 +1,31 @@
 package weapon
 
 import (
+	"log/slog"
+	"math"
 	"math/rand"
 	"time"
 
+	"github.com/Ariemeth/frame_assault/damagelog"
 	"github.com/Ariemeth/frame_assault/projectile"
+	"github.com/Ariemeth/frame_assault/util"
 	tl "github.com/Ariemeth/termloop"
 )
 
+// MaxConcurrentBullets is how many bullets may fly at once before weapons
+// hold their fire
+var MaxConcurrentBullets = 50
+
+// projectileKind selects the projectile a weapon fires
+type projectileKind int
+
+const (
+	bulletProjectile projectileKind = iota
+	grenadeProjectile
+	// meleeProjectile weapons strike without firing anything
+	meleeProjectile
+)
+
 // Weapon is weapon with specific characteristics
 type Weapon struct {
 	maxRange, damage int
@@ -15,12 +33,58 @@ type Weapon struct {
 	hitRate          float64
 	level            *tl.BaseLevel
 	sourceX, sourceY int // Position of the weapon holder
+	distanceMode     util.DistanceMode
+	magazineSize     int // 0 means the weapon never needs reloading
+	currentAmmo      int
+	reloadTicks      int
+	reloadCounter    int
+	splashRadius     int
+	projectile       projectileKind
+	secondaryMode    *Weapon
+	projectileSpeed  float64
+	// condition wears from MaxCondition to 0, at which point the weapon jams
+	condition            int
+	conditionDegradation int
+	// fireRateTicks is the fewest game ticks between shots fired at a tick
+	fireRateTicks int
+	lastFireTick  int
+	hasFired      bool
+	// owner is recorded as the attacker of every hit
+	owner damagelog.EntityID
+	// stabilityBonus is added to the hit rate of every shot
+	stabilityBonus float64
+	// falloffStart is the fraction of maxRange beyond which damage falls
+	// off, down to minDamageFraction of full damage at maxRange
+	falloffStart      float64
+	minDamageFraction float64
+	// projectileColor and projectileSymbol are how the weapon's bullets
+	// are drawn
+	projectileColor  tl.Attr
+	projectileSymbol rune
 }
 
+const (
+	// MaxCondition is the condition of a new or fully repaired weapon
+	MaxCondition = 100
+	// wornCondition is the condition at or below which accuracy suffers
+	wornCondition = 25
+	// wornAccuracy is the multiplier applied to the hit rate of a worn weapon
+	wornAccuracy = 0.8
+	// defaultDegradation is the condition lost per shot
+	defaultDegradation = 1
+	// defaultFalloffStart is the fraction of range beyond which damage
+	// falls off
+	defaultFalloffStart = 0.6
+	// defaultMinDamageFraction is the fraction of damage dealt at maximum
+	// range
+	defaultMinDamageFraction = 0.4
+)
+
 // Target is an interface used by objects that can be hit and take damage
 type Target interface {
-	// Hit is called when an object is hit and the amount of damage to be done.
-	Hit(int)
+	// Hit is called when an object is hit with the amount of damage to be
+	// done and who fired the shot.
+	Hit(int, damagelog.EntityID)
 	// Name should return the name of the target.
 	Name() string
 	// IsDestroyed should return true is the target is destroyed, false otherwise.
@@ -33,19 +97,175 @@ type Target interface {
 func Create(maxRange int, damage int, name string,
 	hitRate float64) Weapon {
 
-	return Weapon{maxRange: maxRange, damage: damage, name: name,
-		hitRate: hitRate}
+	weapon := Weapon{maxRange: maxRange, damage: damage, name: name,
+		hitRate: hitRate, distanceMode: util.Euclidean,
+		condition: MaxCondition, conditionDegradation: defaultDegradation,
+		projectileSpeed: projectile.ReferenceSpeed,
+		falloffStart:    defaultFalloffStart, minDamageFraction: defaultMinDamageFraction,
+		projectileColor: projectile.DefaultColor, projectileSymbol: projectile.DefaultSymbol}
+	if tuning, ok := tuningFor(name); ok {
+		tuning.Apply(&weapon)
+	}
+	return weapon
+}
+
+// CreateWithMagazine creates a new Weapon that holds magazineSize shots
+// and takes reloadTicks ticks to reload once fired.
+func CreateWithMagazine(maxRange int, damage int, name string,
+	hitRate float64, magazineSize int, reloadTicks int) Weapon {
+
+	weapon := Create(maxRange, damage, name, hitRate)
+	weapon.magazineSize = magazineSize
+	weapon.currentAmmo = magazineSize
+	weapon.reloadTicks = reloadTicks
+	return weapon
 }
 
 // SetLevel sets the game level reference for creating bullets
 func (weapon *Weapon) SetLevel(level *tl.BaseLevel) {
 	weapon.level = level
+	if weapon.secondaryMode != nil {
+		weapon.secondaryMode.SetLevel(level)
+	}
 }
 
 // SetPosition sets the current position of the weapon holder
 func (weapon *Weapon) SetPosition(x, y int) {
 	weapon.sourceX = x
 	weapon.sourceY = y
+	if weapon.secondaryMode != nil {
+		weapon.secondaryMode.SetPosition(x, y)
+	}
+}
+
+// WithProjectileStyle returns a copy of the weapon whose bullets are drawn
+// as symbol in color
+func (weapon Weapon) WithProjectileStyle(color tl.Attr, symbol rune) Weapon {
+	weapon.projectileColor = color
+	weapon.projectileSymbol = symbol
+	return weapon
+}
+
+// ProjectileStyle returns the color and symbol the weapon's bullets are
+// drawn with
+func (weapon Weapon) ProjectileStyle() (tl.Attr, rune) {
+	return weapon.projectileColor, weapon.projectileSymbol
+}
+
+// SetSecondary attaches an alternate fire mode to the weapon
+func (weapon *Weapon) SetSecondary(secondary *Weapon) {
+	weapon.secondaryMode = secondary
+}
+
+// Secondary returns the alternate fire mode or nil if there is none
+func (weapon Weapon) Secondary() *Weapon {
+	return weapon.secondaryMode
+}
+
+// Clone returns a copy of the weapon, with its own copy of any secondary
+// fire mode, whose ammo and condition change independently of the original
+func (weapon Weapon) Clone() Weapon {
+	clone := weapon
+	if weapon.secondaryMode != nil {
+		secondary := weapon.secondaryMode.Clone()
+		clone.secondaryMode = &secondary
+	}
+	return clone
+}
+
+// Ammo returns the number of shots left before reloading.
+// Weapons without a magazine always report 0.
+func (weapon Weapon) Ammo() int {
+	return weapon.currentAmmo
+}
+
+// MagazineSize returns the number of shots a full magazine holds.
+// Weapons that never reload return 0.
+func (weapon Weapon) MagazineSize() int {
+	return weapon.magazineSize
+}
+
+// Refill fully reloads the weapon and its secondary mode
+func (weapon *Weapon) Refill() {
+	weapon.currentAmmo = weapon.magazineSize
+	weapon.reloadCounter = 0
+	if weapon.secondaryMode != nil {
+		weapon.secondaryMode.Refill()
+	}
+}
+
+// Tick advances the reload timers of the weapon and its secondary mode
+func (weapon *Weapon) Tick() {
+	if weapon.secondaryMode != nil {
+		weapon.secondaryMode.Tick()
+	}
+	if weapon.magazineSize == 0 || weapon.currentAmmo >= weapon.magazineSize {
+		return
+	}
+	weapon.reloadCounter++
+	if weapon.reloadCounter >= weapon.reloadTicks {
+		weapon.reloadCounter = 0
+		weapon.currentAmmo = weapon.magazineSize
+	}
+}
+
+// consumeAmmo uses one shot, returning false if the magazine is empty
+func (weapon *Weapon) consumeAmmo() bool {
+	if weapon.magazineSize == 0 {
+		return true
+	}
+	if weapon.currentAmmo <= 0 {
+		return false
+	}
+	weapon.currentAmmo--
+	return true
+}
+
+// Condition returns the weapon's condition from 100 down to 0
+func (weapon Weapon) Condition() int {
+	return weapon.condition
+}
+
+// Jammed returns true if the weapon is too worn to fire
+func (weapon Weapon) Jammed() bool {
+	return weapon.condition <= 0
+}
+
+// Repair restores condition to the weapon and its secondary mode,
+// up to a maximum of 100
+func (weapon *Weapon) Repair(amount int) {
+	weapon.condition += amount
+	if weapon.condition > MaxCondition {
+		weapon.condition = MaxCondition
+	}
+	if weapon.secondaryMode != nil {
+		weapon.secondaryMode.Repair(amount)
+	}
+}
+
+// degrade wears the weapon by one shot
+func (weapon *Weapon) degrade() {
+	weapon.condition -= weapon.conditionDegradation
+	if weapon.condition < 0 {
+		weapon.condition = 0
+	}
+}
+
+// SetDistanceMode sets how the weapon measures range to a target
+func (weapon *Weapon) SetDistanceMode(mode util.DistanceMode) {
+	weapon.distanceMode = mode
+}
+
+// DistanceMode returns how the weapon measures range to a target
+func (weapon Weapon) DistanceMode() util.DistanceMode {
+	return weapon.distanceMode
+}
+
+// RangeTo returns the range from the weapon holder to the target
+// measured with the weapon's distance mode
+func (weapon Weapon) RangeTo(target Target) int {
+	targetX, targetY := target.Position()
+	return int(util.Distance(weapon.sourceX, weapon.sourceY, targetX, targetY, weapon.distanceMode))
 }
 
 // Name returns the name of the weapon
@@ -63,30 +283,229 @@ func (weapon Weapon) Damage() int {
 	return weapon.damage
 }
 
-// Accuracy returns the accuracy of the weapon
+// Accuracy returns the accuracy of the weapon, reduced once it is worn
 func (weapon Weapon) Accuracy() float64 {
+	if weapon.condition <= wornCondition {
+		return weapon.hitRate * wornAccuracy
+	}
 	return weapon.hitRate
 }
 
+// StabilityBonus returns the accuracy the weapon adds to every shot,
+// which a mech firing on the move loses against its movement penalty
+func (weapon Weapon) StabilityBonus() float64 {
+	return weapon.stabilityBonus
+}
+
+// ProjectileSpeed returns the cells per move of the weapon's bullets
+func (weapon Weapon) ProjectileSpeed() float64 {
+	return weapon.projectileSpeed
+}
+
+// HitDamage returns the damage dealt by a hit after kinetic scaling
+func (weapon Weapon) HitDamage() int {
+	return projectile.KineticDamage(weapon.damage, weapon.projectileSpeed)
+}
+
+// DamageAt returns the damage a hit deals at rangeToTarget. Beyond
+// falloffStart of the weapon's range damage falls off linearly to
+// minDamageFraction at its maximum range, though a hit always deals at
+// least 1 damage. Melee weapons, reaching only the cells beside them,
+// deal their full damage.
+func (weapon Weapon) DamageAt(rangeToTarget int) int {
+	damage := weapon.HitDamage()
+	if weapon.maxRange <= 1 || damage <= 0 || weapon.falloffStart >= 1 {
+		return damage
+	}
+	fraction := float64(rangeToTarget) / float64(weapon.maxRange)
+	if fraction <= weapon.falloffStart {
+		return damage
+	}
+	if fraction > 1 {
+		fraction = 1
+	}
+	t := (fraction - weapon.falloffStart) / (1 - weapon.falloffStart)
+	effective := int(math.Round(float64(damage) * lerp(1.0, weapon.minDamageFraction, t)))
+	if effective < 1 {
+		return 1
+	}
+	return effective
+}
+
+// lerp returns the value t of the way from a to b
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+// SetOwner sets who is recorded as the attacker of the weapon's hits,
+// including those of its secondary mode
+func (weapon *Weapon) SetOwner(owner damagelog.EntityID) {
+	weapon.owner = owner
+	if weapon.secondaryMode != nil {
+		weapon.secondaryMode.SetOwner(owner)
+	}
+}
+
+// Owner returns who is recorded as the attacker of the weapon's hits
+func (weapon Weapon) Owner() damagelog.EntityID {
+	return weapon.owner
+}
+
+// SetDamage sets the damage of the weapon
+func (weapon *Weapon) SetDamage(damage int) {
+	weapon.damage = damage
+}
+
+// SetHitRate sets the chance of the weapon hitting before wear and bonuses
+func (weapon *Weapon) SetHitRate(hitRate float64) {
+	weapon.hitRate = hitRate
+}
+
+// UpgradeDamage permanently increases the damage of the weapon
+func (weapon *Weapon) UpgradeDamage(amount int) {
+	weapon.damage += amount
+}
+
+// spawnProjectile adds the weapon's projectile flying towards x,y to the level
+func (weapon *Weapon) spawnProjectile(targetX, targetY int) {
+	if weapon.level == nil {
+		return
+	}
+	switch weapon.projectile {
+	case meleeProjectile:
+		return
+	case grenadeProjectile:
+		weapon.level.AddEntity(projectile.NewGrenade(weapon.sourceX, weapon.sourceY, targetX, targetY, weapon.level))
+	default:
+		bullet := projectile.NewBullet(weapon.sourceX, weapon.sourceY, targetX, targetY,
+			weapon.projectileColor, weapon.projectileSymbol, weapon.level)
+		bullet.SetSpeed(weapon.projectileSpeed)
+		bullet.SetAttacker(weapon.owner)
+		weapon.level.AddEntity(bullet)
+	}
+}
+
 // Fire is used by an object to fire at a Target.
 // Requires the range to the Target and the Target.
-// Returns true if the target is hit or false if the target is missed.
-func (weapon Weapon) Fire(rangeToTarget int, target Target) bool {
+// Returns true if the target is hit or false if the target is missed
+// or the weapon is jammed.
+func (weapon *Weapon) Fire(rangeToTarget int, target Target) bool {
+	return weapon.FireWithAccuracy(rangeToTarget, target, weapon.Accuracy()+weapon.stabilityBonus)
+}
+
+// FireWithAccuracy fires at a Target like Fire, hitting with the given
+// chance instead of the weapon's own accuracy
+func (weapon *Weapon) FireWithAccuracy(rangeToTarget int, target Target, chanceToHit float64) bool {
 	if rangeToTarget <= weapon.maxRange {
+		if weapon.Throttled() {
+			slog.Debug("bullet limit reached, holding fire",
+				"weapon", weapon.name, "bullets", projectile.BulletCounter(weapon.level).Count())
+			return false
+		}
+		if weapon.Jammed() || !weapon.consumeAmmo() {
+			return false
+		}
+		weapon.degrade()
+
 		r := rand.New(rand.NewSource(time.Now().Unix()))
 		chance := r.Float64()
 
-		// Create bullet regardless of hit/miss
-		if weapon.level != nil {
-			targetX, targetY := target.Position()
-			bullet := projectile.NewBullet(weapon.sourceX, weapon.sourceY, targetX, targetY, weapon.level)
-			weapon.level.AddEntity(bullet)
-		}
+		// Create projectile regardless of hit/miss
+		targetX, targetY := target.Position()
+		weapon.spawnProjectile(targetX, targetY)
 
-		if chance <= weapon.Accuracy() {
-			target.Hit(weapon.damage)
+		if chance <= chanceToHit {
+			// Hits resolve as the shot is fired so kills register immediately,
+			// scaled by the same kinetic multiplier the bullet carries and
+			// falling off with range
+			target.Hit(weapon.DamageAt(rangeToTarget), weapon.owner)
 			return true
 		}
 	}
 	return false
 }
+
+// FireRateTicks returns the fewest game ticks allowed between shots
+func (weapon Weapon) FireRateTicks() int {
+	return weapon.fireRateTicks
+}
+
+// Ready returns true if the weapon's fire rate allows a shot at tick
+func (weapon Weapon) Ready(tick int) bool {
+	return !weapon.hasFired || tick-weapon.lastFireTick >= weapon.fireRateTicks
+}
+
+// FireAtTick fires at a Target like Fire during game tick tick, returning
+// false without firing if the weapon fired too recently
+func (weapon *Weapon) FireAtTick(rangeToTarget int, target Target, tick int) bool {
+	return weapon.FireWithAccuracyAtTick(rangeToTarget, target, weapon.Accuracy()+weapon.stabilityBonus, tick)
+}
+
+// FireWithAccuracyAtTick fires at a Target like FireWithAccuracy during
+// game tick tick, returning false without firing if the weapon fired too
+// recently
+func (weapon *Weapon) FireWithAccuracyAtTick(rangeToTarget int, target Target, chanceToHit float64, tick int) bool {
+	if !weapon.Ready(tick) {
+		return false
+	}
+	if rangeToTarget <= weapon.maxRange {
+		weapon.lastFireTick = tick
+		weapon.hasFired = true
+	}
+	return weapon.FireWithAccuracy(rangeToTarget, target, chanceToHit)
+}
+
+// Throttled returns true while too many bullets are flying in the
+// weapon's level for a new shot
+func (weapon *Weapon) Throttled() bool {
+	return projectile.BulletCounter(weapon.level).Count() >= MaxConcurrentBullets
+}
+
+// FireWithSplash fires at a Target and, if the weapon has a splash radius,
+// damages the nearby targets around the point of impact with Splash.
+// Returns true if the primary target is hit.
+func (weapon *Weapon) FireWithSplash(rangeToTarget int, target Target, nearby []Target) bool {
+	if rangeToTarget > weapon.maxRange || (weapon.magazineSize > 0 && weapon.currentAmmo <= 0) {
+		return false
+	}
+
+	// Collect splash victims before the primary hit can remove the target
+	victims := weapon.SplashVictims(target, nearby)
+	hit := weapon.Fire(rangeToTarget, target)
+	weapon.Splash(victims)
+	return hit
+}
+
+// SplashRadius returns how far from the point of impact the weapon's
+// splash reaches, 0 for weapons that do not splash
+func (weapon *Weapon) SplashRadius() int {
+	return weapon.splashRadius
+}
+
+// SplashVictims returns the nearby targets still standing within the
+// splash radius of target
+func (weapon *Weapon) SplashVictims(target Target, nearby []Target) []Target {
+	victims := make([]Target, 0)
+	if weapon.splashRadius <= 0 {
+		return victims
+	}
+	targetX, targetY := target.Position()
+	for _, other := range nearby {
+		if other == nil || other == target || other.IsDestroyed() {
+			continue
+		}
+		x, y := other.Position()
+		if util.CalculateDistance(targetX, targetY, x, y) <= float64(weapon.splashRadius) {
+			victims = append(victims, other)
+		}
+	}
+	return victims
+}
+
+// Splash hits each victim for half the weapon's damage, rounded up so
+// weak weapons still splash
+func (weapon *Weapon) Splash(victims []Target) {
+	for _, victim := range victims {
+		victim.Hit((weapon.damage+1)/2, weapon.owner)
+	}
+}
@@ -1,12 +1,18 @@
 package weapon
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/damagelog"
+	"github.com/Ariemeth/frame_assault/projectile"
+	tl "github.com/Ariemeth/termloop"
+)
 
 type testTarget struct {
 	DamageTaken int
 }
 
-func (fakeTarget *testTarget) Hit(damage int) {
+func (fakeTarget *testTarget) Hit(damage int, attacker damagelog.EntityID) {
 	fakeTarget.DamageTaken += damage
 }
 
@@ -34,7 +40,7 @@ func TestWeapon(t *testing.T) {
 }
 
 func TestWeaponFire(t *testing.T) {
-	weapon1 := Create(2, 2, "test weapon1", 0.6)
+	weapon1 := Create(2, 2, "test weapon1", 1.0)
 
 	target := testTarget{}
 
@@ -44,7 +50,190 @@ func TestWeaponFire(t *testing.T) {
 	}
 
 	weapon1.Fire(2, &target)
+	if target.DamageTaken != weapon1.DamageAt(2) {
+		t.Errorf("range 2 weapon dealt %d damage at range 2 instead of %d", target.DamageTaken, weapon1.DamageAt(2))
+	}
+}
+
+func TestWeaponMagazine(t *testing.T) {
+	weapon1 := CreateWithMagazine(2, 2, "test weapon1", 1.0, 1, 3)
+	target := testTarget{}
+
+	if !weapon1.Fire(1, &target) {
+		t.Fatalf("loaded weapon did not hit")
+	}
+	if weapon1.Fire(1, &target) {
+		t.Errorf("weapon with an empty magazine hit")
+	}
 	if target.DamageTaken != 2 {
-		t.Errorf("mech not destroyed at range 2 by range 2, damage 2 weapon")
+		t.Errorf("target took %d damage instead of 2", target.DamageTaken)
+	}
+
+	for i := 0; i < 3; i++ {
+		weapon1.Tick()
+	}
+	if weapon1.Ammo() != 1 {
+		t.Errorf("weapon has %d ammo after reloading instead of 1", weapon1.Ammo())
+	}
+}
+
+func TestWeaponJamsAtZeroCondition(t *testing.T) {
+	weapon1 := Create(2, 2, "test weapon1", 1.0)
+	weapon1.conditionDegradation = 50
+	target := testTarget{}
+
+	if !weapon1.Fire(1, &target) || !weapon1.Fire(1, &target) {
+		t.Fatalf("weapon in good condition missed")
+	}
+	if !weapon1.Jammed() {
+		t.Fatalf("weapon at condition %d is not jammed", weapon1.Condition())
+	}
+	if weapon1.Fire(1, &target) {
+		t.Errorf("jammed weapon hit")
+	}
+	if target.DamageTaken != 4 {
+		t.Errorf("target took %d damage instead of 4", target.DamageTaken)
+	}
+
+	weapon1.Repair(150)
+	if weapon1.Condition() != 100 {
+		t.Errorf("repaired condition is %d instead of 100", weapon1.Condition())
+	}
+	if !weapon1.Fire(1, &target) {
+		t.Errorf("repaired weapon missed")
+	}
+}
+
+func TestWornWeaponAccuracy(t *testing.T) {
+	weapon1 := Create(2, 2, "test weapon1", 0.5)
+	weapon1.condition = 25
+	if weapon1.Accuracy() != 0.4 {
+		t.Errorf("worn weapon accuracy is %v instead of 0.4", weapon1.Accuracy())
+	}
+}
+
+func TestBulletLimitHoldsFire(t *testing.T) {
+	defer func(limit int) { MaxConcurrentBullets = limit }(MaxConcurrentBullets)
+	MaxConcurrentBullets = 2
+	level := tl.NewBaseLevel(tl.Cell{})
+	w := Create(10, 1, "test rifle", 1.0)
+	w.SetLevel(level)
+	target := &testTarget{}
+
+	for i := 0; i < 2; i++ {
+		if !w.Fire(5, target) {
+			t.Fatalf("shot %d below the bullet limit missed", i+1)
+		}
+	}
+	if len(level.Entities) != 2 {
+		t.Fatalf("%d bullets in flight instead of 2", len(level.Entities))
 	}
+
+	if w.Fire(5, target) {
+		t.Error("shot at the bullet limit hit")
+	}
+	if len(level.Entities) != 2 || target.DamageTaken != 2 {
+		t.Errorf("shot at the bullet limit created %d bullets and dealt %d damage",
+			len(level.Entities)-2, target.DamageTaken-2)
+	}
+}
+
+func TestProjectileStyle(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	w := Create(10, 1, "test blaster", 1.0).WithProjectileStyle(tl.ColorBlue, '!')
+	w.SetLevel(level)
+	w.Fire(5, &testTarget{})
+
+	if len(level.Entities) != 1 {
+		t.Fatalf("%d bullets fired instead of 1", len(level.Entities))
+	}
+	bullet, ok := level.Entities[0].(*projectile.Bullet)
+	if !ok {
+		t.Fatalf("fired a %T instead of a bullet", level.Entities[0])
+	}
+	if color, symbol := bullet.Style(); color != tl.ColorBlue || symbol != '!' {
+		t.Errorf("bullet is drawn as %q in %v, want '!' in blue", symbol, color)
+	}
+}
+
+func TestSwordFiresNoProjectile(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	sword := CreateSword()
+	sword.SetLevel(level)
+	sword.Fire(1, &testTarget{})
+	if len(level.Entities) != 0 {
+		t.Errorf("sword fired %d projectiles", len(level.Entities))
+	}
+}
+
+func TestFireAtTickRespectsFireRate(t *testing.T) {
+	weapon1 := Create(2, 1, "test weapon1", 1.0)
+	weapon1.fireRateTicks = 3
+	target := testTarget{}
+
+	fired := make([]int, 0)
+	for tick := 10; tick < 20; tick++ {
+		if weapon1.FireAtTick(1, &target, tick) {
+			fired = append(fired, tick)
+		}
+	}
+	expected := []int{10, 13, 16, 19}
+	if len(fired) != len(expected) {
+		t.Fatalf("fired on ticks %v instead of %v", fired, expected)
+	}
+	for i, tick := range expected {
+		if fired[i] != tick {
+			t.Errorf("fired on ticks %v instead of %v", fired, expected)
+			break
+		}
+	}
+}
+
+func TestDefaultFireRates(t *testing.T) {
+	got := map[string]int{
+		"Rifle":   CreateRifle().FireRateTicks(),
+		"Shotgun": CreateShotgun().FireRateTicks(),
+		"Sword":   CreateSword().FireRateTicks(),
+		"Fist":    CreateFist().FireRateTicks(),
+	}
+	for name, want := range map[string]int{"Rifle": 3, "Shotgun": 8, "Sword": 2, "Fist": 1} {
+		if got[name] != want {
+			t.Errorf("%s fires every %d ticks instead of %d", name, got[name], want)
+		}
+	}
+}
+
+func TestDamageFallsOffWithRange(t *testing.T) {
+	// Damage at 0%, 50% and 100% of each gun's range, stretched to 10 so
+	// half of it is a whole cell, and beside and at the sword's reach
+	tests := []struct {
+		name   string
+		weapon Weapon
+		ranges [3]int
+		want   [3]int
+	}{
+		{"default", Create(10, 10, "test weapon1", 1.0), [3]int{0, 5, 10}, [3]int{10, 10, 4}},
+		{"rifle", withRange(withDamage(CreateRifle(), 10), 10), [3]int{0, 5, 10}, [3]int{10, 10, 5}},
+		{"shotgun", withRange(withDamage(CreateShotgun(), 10), 10), [3]int{0, 5, 10}, [3]int{10, 9, 2}},
+		{"sword", CreateSword(), [3]int{0, 1, 1}, [3]int{2, 2, 2}},
+	}
+	for _, test := range tests {
+		for i, rangeToTarget := range test.ranges {
+			if got := test.weapon.DamageAt(rangeToTarget); got != test.want[i] {
+				t.Errorf("%s deals %d damage at range %d, want %d", test.name, got, rangeToTarget, test.want[i])
+			}
+		}
+	}
+}
+
+// withDamage returns weapon dealing damage per hit
+func withDamage(weapon Weapon, damage int) Weapon {
+	weapon.SetDamage(damage)
+	return weapon
+}
+
+// withRange returns weapon reaching maxRange cells
+func withRange(weapon Weapon, maxRange int) Weapon {
+	weapon.maxRange = maxRange
+	return weapon
 }
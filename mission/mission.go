@@ -0,0 +1,185 @@
+// Package mission defines the objectives the player can take on and
+// tracks the ones in progress
+package mission
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Reward is what the player earns for completing a mission
+type Reward struct {
+	Money int
+	XP    int
+}
+
+// Progress is what the player has just done, checked against every active
+// mission
+type Progress struct {
+	// Destroyed is the name of an enemy that was just destroyed
+	Destroyed string
+	// X, Y is the player's position
+	X, Y int
+	// Building is the type of building the player is standing against
+	Building string
+	// Carrying are the kinds of item the player is carrying
+	Carrying []string
+}
+
+// carries returns true if the player is carrying an item of kind
+func (p Progress) carries(kind string) bool {
+	for _, item := range p.Carrying {
+		if item == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Mission is an objective the player can accept
+type Mission interface {
+	Description() string
+	Reward() Reward
+	// Advance records progress, returning true once the mission is complete
+	Advance(progress Progress) bool
+}
+
+// EliminateTarget is complete when the named enemy is destroyed
+type EliminateTarget struct {
+	EnemyName string
+	Pay       Reward
+}
+
+// Description describes the mission
+func (m *EliminateTarget) Description() string {
+	return fmt.Sprintf("Destroy %s", m.EnemyName)
+}
+
+// Reward returns the mission's reward
+func (m *EliminateTarget) Reward() Reward {
+	return m.Pay
+}
+
+// Advance completes the mission when the target is destroyed
+func (m *EliminateTarget) Advance(progress Progress) bool {
+	return progress.Destroyed == m.EnemyName
+}
+
+// DeliverItem is complete when the player reaches a building of
+// BuildingType carrying ItemKind
+type DeliverItem struct {
+	ItemKind     string
+	BuildingType string
+	Pay          Reward
+}
+
+// Description describes the mission
+func (m *DeliverItem) Description() string {
+	return fmt.Sprintf("Deliver %s to the %s", m.ItemKind, m.BuildingType)
+}
+
+// Reward returns the mission's reward
+func (m *DeliverItem) Reward() Reward {
+	return m.Pay
+}
+
+// Advance completes the mission when the player reaches the building
+// carrying the item
+func (m *DeliverItem) Advance(progress Progress) bool {
+	return progress.Building == m.BuildingType && progress.carries(m.ItemKind)
+}
+
+// PatrolRoute is complete once the player has visited every waypoint in
+// order
+type PatrolRoute struct {
+	Waypoints [][2]int
+	Pay       Reward
+	next      int
+}
+
+// Description describes the mission
+func (m *PatrolRoute) Description() string {
+	return fmt.Sprintf("Patrol %d waypoints", len(m.Waypoints))
+}
+
+// Reward returns the mission's reward
+func (m *PatrolRoute) Reward() Reward {
+	return m.Pay
+}
+
+// NextWaypoint returns the waypoint to visit next and false once the
+// route is finished
+func (m *PatrolRoute) NextWaypoint() ([2]int, bool) {
+	if m.next >= len(m.Waypoints) {
+		return [2]int{}, false
+	}
+	return m.Waypoints[m.next], true
+}
+
+// Advance moves on to the next waypoint when the player reaches the
+// current one
+func (m *PatrolRoute) Advance(progress Progress) bool {
+	if waypoint, ok := m.NextWaypoint(); ok && waypoint == [2]int{progress.X, progress.Y} {
+		m.next++
+	}
+	return m.next >= len(m.Waypoints)
+}
+
+// Manager holds the missions the player has accepted
+type Manager struct {
+	mu        sync.Mutex
+	active    []Mission
+	completed map[Mission]bool
+}
+
+// NewManager creates a manager with no missions
+func NewManager() *Manager {
+	return &Manager{completed: make(map[Mission]bool)}
+}
+
+// Accept starts tracking m
+func (mgr *Manager) Accept(m Mission) {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	mgr.active = append(mgr.active, m)
+}
+
+// Active returns the accepted missions still in progress
+func (mgr *Manager) Active() []Mission {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	return append([]Mission(nil), mgr.active...)
+}
+
+// Completed returns true once m has been completed
+func (mgr *Manager) Completed(m Mission) bool {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	return mgr.completed[m]
+}
+
+// CompletedCount returns how many missions have been completed
+func (mgr *Manager) CompletedCount() int {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	return len(mgr.completed)
+}
+
+// Advance records progress on every active mission and returns those it
+// completed
+func (mgr *Manager) Advance(progress Progress) []Mission {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	var done []Mission
+	active := mgr.active[:0]
+	for _, m := range mgr.active {
+		if m.Advance(progress) {
+			mgr.completed[m] = true
+			done = append(done, m)
+			continue
+		}
+		active = append(active, m)
+	}
+	mgr.active = active
+	return done
+}
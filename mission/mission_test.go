@@ -0,0 +1,35 @@
+package mission
+
+import "testing"
+
+func TestPatrolRouteVisitsWaypointsInOrder(t *testing.T) {
+	route := &PatrolRoute{Waypoints: [][2]int{{1, 1}, {5, 1}}}
+	manager := NewManager()
+	manager.Accept(route)
+
+	if done := manager.Advance(Progress{X: 5, Y: 1}); len(done) != 0 {
+		t.Fatal("route completed by visiting the last waypoint first")
+	}
+	manager.Advance(Progress{X: 1, Y: 1})
+	done := manager.Advance(Progress{X: 5, Y: 1})
+	if len(done) != 1 || !manager.Completed(route) || len(manager.Active()) != 0 {
+		t.Error("route not completed after visiting every waypoint in order")
+	}
+}
+
+func TestDeliverItemNeedsTheItem(t *testing.T) {
+	delivery := &DeliverItem{ItemKind: "medical supplies", BuildingType: "Hospital"}
+	manager := NewManager()
+	manager.Accept(delivery)
+
+	if done := manager.Advance(Progress{Building: "Hospital"}); len(done) != 0 {
+		t.Fatal("delivery completed without carrying the item")
+	}
+	if done := manager.Advance(Progress{Building: "School", Carrying: []string{"medical supplies"}}); len(done) != 0 {
+		t.Fatal("delivery completed at the wrong building")
+	}
+	done := manager.Advance(Progress{Building: "Hospital", Carrying: []string{"medical supplies"}})
+	if len(done) != 1 || !manager.Completed(delivery) {
+		t.Error("delivery not completed at the hospital carrying the item")
+	}
+}
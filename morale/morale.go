@@ -0,0 +1,70 @@
+// Package morale provides the rules for how civilians react to the chaos
+// of battle around them
+package morale
+
+const (
+	// Start is the morale of a civilian before any fighting
+	Start = 70
+	// Max is the highest morale a civilian can reach
+	Max = 100
+
+	// ExplosionRadius is how close in cells an explosion must be to be felt
+	ExplosionRadius = 10
+	// ExplosionPenalty is the morale lost to a nearby explosion
+	ExplosionPenalty = 5
+	// DestroyedPenalty is the morale lost when a mech is destroyed
+	DestroyedPenalty = 10
+	// ShelterGain is the morale regained each tick inside a building
+	ShelterGain = 2
+
+	// FleeThreshold is the morale below which civilians flee
+	FleeThreshold = 30
+	// PanicThreshold is the morale below which civilians freeze in panic
+	PanicThreshold = 10
+	// RallyThreshold is the morale above which civilians encourage others
+	RallyThreshold = 80
+)
+
+// AfterExplosion returns morale after an explosion distance cells away
+func AfterExplosion(morale int, distance float64) int {
+	if distance > ExplosionRadius {
+		return morale
+	}
+	return clamp(morale - ExplosionPenalty)
+}
+
+// AfterDestruction returns morale after witnessing a destruction
+func AfterDestruction(morale int) int {
+	return clamp(morale - DestroyedPenalty)
+}
+
+// AfterShelter returns morale after a tick spent safe inside a building
+func AfterShelter(morale int) int {
+	return clamp(morale + ShelterGain)
+}
+
+// Fleeing returns true if morale is low enough to run from the fighting
+func Fleeing(morale int) bool {
+	return morale < FleeThreshold
+}
+
+// Panicked returns true if morale is low enough to freeze in place
+func Panicked(morale int) bool {
+	return morale < PanicThreshold
+}
+
+// Rallying returns true if morale is high enough to encourage others
+func Rallying(morale int) bool {
+	return morale > RallyThreshold
+}
+
+// clamp keeps morale between 0 and Max
+func clamp(morale int) int {
+	if morale < 0 {
+		return 0
+	}
+	if morale > Max {
+		return Max
+	}
+	return morale
+}
@@ -0,0 +1,40 @@
+package morale
+
+import "testing"
+
+func TestMoraleDropsOnNearbyExplosion(t *testing.T) {
+	if got := AfterExplosion(Start, 4); got != Start-ExplosionPenalty {
+		t.Errorf("morale after an explosion 4 cells away is %d instead of %d", got, Start-ExplosionPenalty)
+	}
+	if got := AfterExplosion(Start, ExplosionRadius); got != Start-ExplosionPenalty {
+		t.Errorf("morale after an explosion at the edge of the radius is %d instead of %d",
+			got, Start-ExplosionPenalty)
+	}
+	if got := AfterExplosion(Start, ExplosionRadius+1); got != Start {
+		t.Errorf("morale after a distant explosion is %d instead of %d", got, Start)
+	}
+	if got := AfterExplosion(2, 0); got != 0 {
+		t.Errorf("morale fell to %d instead of stopping at 0", got)
+	}
+}
+
+func TestMoraleThresholds(t *testing.T) {
+	morale := Start
+	for i := 0; i < 5; i++ {
+		morale = AfterDestruction(morale)
+	}
+	if morale != 20 || !Fleeing(morale) || Panicked(morale) {
+		t.Errorf("morale %d should be fleeing but not panicked", morale)
+	}
+	morale = AfterDestruction(morale)
+	morale = AfterDestruction(morale)
+	if !Panicked(morale) {
+		t.Errorf("morale %d should be panicked", morale)
+	}
+	for i := 0; i < 50; i++ {
+		morale = AfterShelter(morale)
+	}
+	if morale != Max || !Rallying(morale) {
+		t.Errorf("morale %d should have recovered to %d", morale, Max)
+	}
+}
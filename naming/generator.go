@@ -0,0 +1,62 @@
+// Package naming gives every enemy mech a unique name
+package naming
+
+import (
+	"strings"
+)
+
+// romanNumerals are the values and numerals used to build roman numerals
+var romanNumerals = []struct {
+	value   int
+	numeral string
+}{
+	{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"},
+	{100, "C"}, {90, "XC"}, {50, "L"}, {40, "XL"},
+	{10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+}
+
+// Roman returns n written as a roman numeral
+func Roman(n int) string {
+	var b strings.Builder
+	for _, r := range romanNumerals {
+		for n >= r.value {
+			b.WriteString(r.numeral)
+			n -= r.value
+		}
+	}
+	return b.String()
+}
+
+// Generator names mechs after their symbol letter followed by a roman
+// numeral, never handing out the same name twice
+type Generator struct {
+	used   map[string]bool
+	counts map[rune]int
+}
+
+// NewNamingGenerator creates a generator with no names used
+func NewNamingGenerator() *Generator {
+	return &Generator{
+		used:   make(map[string]bool),
+		counts: make(map[rune]int),
+	}
+}
+
+// NextName returns the next unused name for a mech shown as symbol,
+// e.g. "A I" then "A II"
+func (g *Generator) NextName(symbol rune) string {
+	for {
+		g.counts[symbol]++
+		name := string(symbol) + " " + Roman(g.counts[symbol])
+		if !g.used[name] {
+			g.used[name] = true
+			return name
+		}
+	}
+}
+
+// Reset clears the used names for a fresh game
+func (g *Generator) Reset() {
+	g.used = make(map[string]bool)
+	g.counts = make(map[rune]int)
+}
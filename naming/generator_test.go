@@ -0,0 +1,39 @@
+package naming
+
+import "testing"
+
+func TestNextNameUnique(t *testing.T) {
+	generator := NewNamingGenerator()
+	symbols := []rune{'A', 'B', 'C'}
+
+	seen := make(map[string]bool)
+	for i := 0; i < 30; i++ {
+		name := generator.NextName(symbols[i%len(symbols)])
+		if seen[name] {
+			t.Errorf("name %q was generated twice", name)
+		}
+		seen[name] = true
+	}
+
+	if !seen["A I"] || !seen["A II"] || !seen["B I"] || !seen["A X"] {
+		t.Errorf("generated names do not follow the letter and roman numeral pattern: %v", seen)
+	}
+}
+
+func TestResetReusesNames(t *testing.T) {
+	generator := NewNamingGenerator()
+	generator.NextName('A')
+	generator.Reset()
+	if name := generator.NextName('A'); name != "A I" {
+		t.Errorf("first name after reset is %q instead of \"A I\"", name)
+	}
+}
+
+func TestRoman(t *testing.T) {
+	expected := map[int]string{1: "I", 4: "IV", 9: "IX", 14: "XIV", 40: "XL", 1994: "MCMXCIV"}
+	for n, numeral := range expected {
+		if Roman(n) != numeral {
+			t.Errorf("Roman(%d) is %q instead of %q", n, Roman(n), numeral)
+		}
+	}
+}
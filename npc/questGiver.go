@@ -0,0 +1,180 @@
+// Package npc contains the non player characters the player can talk to
+package npc
+
+import (
+	"strconv"
+
+	"github.com/Ariemeth/frame_assault/mission"
+	"github.com/Ariemeth/frame_assault/util"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// questGiverName is shown as the speaker of quest offers
+	questGiverName = "Quest giver"
+	// interactRange is how close the player must be to talk, in cells
+	interactRange = 2
+
+	questAvailableGlyph = '?'
+	questPulseGlyph     = '!'
+)
+
+// Civilian is the city dweller a quest giver is played by
+type Civilian interface {
+	tl.DynamicPhysical
+	Draw(screen *tl.Screen)
+	Tick(event tl.Event)
+}
+
+// Player is who quests are offered to and rewarded
+type Player interface {
+	Position() (int, int)
+	AddBountyPoints(points int)
+	AddXP(xp int)
+}
+
+// Dialogue shows a quest offer the player can accept
+type Dialogue interface {
+	Open(speaker, text string, onAccept func())
+	IsOpen() bool
+}
+
+// QuestGiver is a civilian with a pool of quests for the player. Quests
+// are offered one at a time, the next only once the current one is done.
+type QuestGiver struct {
+	Civilian
+	quests   []mission.Mission
+	missions *mission.Manager
+	player   Player
+	dialogue Dialogue
+	notifier util.Notifier
+	// accepted is the index of the quest in progress, -1 if none
+	accepted int
+	// pulseTicks is how many frames each glyph of the pulse is shown
+	pulseTicks int
+	ticks      int
+}
+
+// NewQuestGiver creates a quest giver played by civilian offering quests
+// in order, pulsing once a second at fps frames per second
+func NewQuestGiver(civilian Civilian, quests []mission.Mission, missions *mission.Manager, fps int) *QuestGiver {
+	return &QuestGiver{
+		Civilian:   civilian,
+		quests:     quests,
+		missions:   missions,
+		accepted:   -1,
+		pulseTicks: fps,
+	}
+}
+
+// AttachPlayer sets the player quests are offered to
+func (q *QuestGiver) AttachPlayer(player Player) {
+	q.player = player
+}
+
+// AttachDialogue sets the dialogue box quests are offered through
+func (q *QuestGiver) AttachDialogue(dialogue Dialogue) {
+	q.dialogue = dialogue
+}
+
+// AttachNotifier sets where quest rewards are announced
+func (q *QuestGiver) AttachNotifier(notifier util.Notifier) {
+	q.notifier = notifier
+}
+
+// Quests returns the quests not yet completed
+func (q *QuestGiver) Quests() []mission.Mission {
+	return q.quests
+}
+
+// Available returns the quest on offer and false if a quest is in
+// progress or none are left
+func (q *QuestGiver) Available() (mission.Mission, bool) {
+	if q.accepted >= 0 || len(q.quests) == 0 {
+		return nil, false
+	}
+	return q.quests[0], true
+}
+
+// Offer opens the dialogue offering the available quest, if any
+func (q *QuestGiver) Offer() {
+	quest, ok := q.Available()
+	if !ok || q.dialogue == nil || q.dialogue.IsOpen() {
+		return
+	}
+	reward := quest.Reward()
+	text := quest.Description() + " for $" + strconv.Itoa(reward.Money) + " and " + strconv.Itoa(reward.XP) + " XP"
+	q.dialogue.Open(questGiverName, text, func() { q.Accept(0) })
+}
+
+// Accept starts the quest at questIndex, adding it to the mission manager
+func (q *QuestGiver) Accept(questIndex int) {
+	if questIndex < 0 || questIndex >= len(q.quests) || q.accepted >= 0 {
+		return
+	}
+	q.accepted = questIndex
+	q.missions.Accept(q.quests[questIndex])
+}
+
+// CompleteQuest pays the player for the quest at questIndex and removes it
+// from the pool, making the next quest available
+func (q *QuestGiver) CompleteQuest(questIndex int) {
+	if questIndex < 0 || questIndex >= len(q.quests) {
+		return
+	}
+	quest := q.quests[questIndex]
+	reward := quest.Reward()
+	if q.player != nil {
+		q.player.AddBountyPoints(reward.Money)
+		q.player.AddXP(reward.XP)
+	}
+	if q.notifier != nil {
+		q.notifier.AddMessage("Quest complete: " + quest.Description())
+	}
+	q.quests = append(q.quests[:questIndex], q.quests[questIndex+1:]...)
+	if q.accepted == questIndex {
+		q.accepted = -1
+	}
+}
+
+// inRange returns true if the player is close enough to talk
+func (q *QuestGiver) inRange() bool {
+	if q.player == nil {
+		return false
+	}
+	x, y := q.Position()
+	px, py := q.player.Position()
+	return util.Distance(x, y, px, py, util.Euclidean) <= interactRange
+}
+
+// Draw renders the quest giver as a question mark pulsing to an
+// exclamation mark while a quest is on offer
+func (q *QuestGiver) Draw(screen *tl.Screen) {
+	if _, ok := q.Available(); !ok {
+		q.Civilian.Draw(screen)
+		return
+	}
+	glyph := questAvailableGlyph
+	if q.pulseTicks > 0 && (q.ticks/q.pulseTicks)%2 == 1 {
+		glyph = questPulseGlyph
+	}
+	x, y := q.Position()
+	screen.RenderCell(x, y, &tl.Cell{Fg: tl.ColorYellow, Ch: glyph})
+}
+
+// Tick offers a quest when the nearby player presses Ctrl+E and pays out
+// the quest in progress once it is complete
+func (q *QuestGiver) Tick(event tl.Event) {
+	q.Civilian.Tick(event)
+	switch event.Type {
+	case tl.EventNone:
+		q.ticks++
+		if q.accepted >= 0 && q.missions.Completed(q.quests[q.accepted]) {
+			q.CompleteQuest(q.accepted)
+		}
+	case tl.EventKey:
+		if event.Key == tl.KeyCtrlE && q.inRange() {
+			q.Offer()
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package npc
+
+import (
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/mission"
+	tl "github.com/Ariemeth/termloop"
+)
+
+type testCivilian struct {
+	*tl.Entity
+}
+
+func (c *testCivilian) Collide(collision tl.Physical) {}
+
+type testPlayer struct {
+	x, y   int
+	points int
+	xp     int
+}
+
+func (p *testPlayer) Position() (int, int)       { return p.x, p.y }
+func (p *testPlayer) AddBountyPoints(points int) { p.points += points }
+func (p *testPlayer) AddXP(xp int)               { p.xp += xp }
+
+type testDialogue struct {
+	text     string
+	onAccept func()
+}
+
+func (d *testDialogue) Open(speaker, text string, onAccept func()) {
+	d.text, d.onAccept = text, onAccept
+}
+
+func (d *testDialogue) IsOpen() bool {
+	return d.onAccept != nil
+}
+
+func newTestQuestGiver(quests []mission.Mission, missions *mission.Manager) (*QuestGiver, *testPlayer, *testDialogue) {
+	giver := NewQuestGiver(&testCivilian{tl.NewEntity(5, 5, 1, 1)}, quests, missions, 10)
+	player := &testPlayer{x: 6, y: 5}
+	dialogue := &testDialogue{}
+	giver.AttachPlayer(player)
+	giver.AttachDialogue(dialogue)
+	return giver, player, dialogue
+}
+
+func TestAcceptingQuestAddsItToManager(t *testing.T) {
+	missions := mission.NewManager()
+	quest := &mission.EliminateTarget{EnemyName: "A I", Pay: mission.Reward{Money: 100, XP: 50}}
+	giver, _, dialogue := newTestQuestGiver([]mission.Mission{quest}, missions)
+
+	giver.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyCtrlE})
+	if dialogue.onAccept == nil {
+		t.Fatal("quest was not offered to the nearby player")
+	}
+	dialogue.onAccept()
+
+	active := missions.Active()
+	if len(active) != 1 || active[0] != quest {
+		t.Fatalf("manager has %v active instead of the accepted quest", active)
+	}
+	if _, ok := giver.Available(); ok {
+		t.Error("another quest is available while one is in progress")
+	}
+}
+
+func TestCompletedQuestPaysAndOffersNext(t *testing.T) {
+	missions := mission.NewManager()
+	first := &mission.EliminateTarget{EnemyName: "A I", Pay: mission.Reward{Money: 100, XP: 50}}
+	second := &mission.DeliverItem{ItemKind: "medicine", BuildingType: "Hospital"}
+	giver, player, _ := newTestQuestGiver([]mission.Mission{first, second}, missions)
+
+	giver.Accept(0)
+	missions.Advance(mission.Progress{Destroyed: "A I"})
+	giver.Tick(tl.Event{Type: tl.EventNone})
+
+	if player.points != 100 || player.xp != 50 {
+		t.Errorf("player paid %d money and %d XP, want 100 and 50", player.points, player.xp)
+	}
+	if next, ok := giver.Available(); !ok || next != second {
+		t.Errorf("next quest available is %v, want the delivery", next)
+	}
+}
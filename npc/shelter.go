@@ -0,0 +1,135 @@
+package npc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/Ariemeth/frame_assault/building"
+)
+
+const (
+	// shelterCacheTicks is how long a shelter decision is kept before the
+	// advisor is asked again
+	shelterCacheTicks = 60
+	// shelterSearchRadius is how far in cells buildings are offered to the
+	// advisor
+	shelterSearchRadius = 30
+	// fallbackShelter is where civilians go when the advice names nothing
+	fallbackShelter = "Home"
+)
+
+// Advisor gives freeform advice on the situation described by a prompt
+type Advisor interface {
+	GetStrategicAdvice(ctx context.Context, prompt string) (string, error)
+}
+
+// ShelterSearch asks an advisor which nearby building a frightened
+// civilian should run to and remembers the answer for a while
+type ShelterSearch struct {
+	mu        sync.Mutex
+	advisor   Advisor
+	buildings *building.Manager
+	target    *building.Building
+	ticksLeft int
+}
+
+// NewShelterSearch creates a shelter search choosing among buildings on
+// the advice of advisor
+func NewShelterSearch(advisor Advisor, buildings *building.Manager) *ShelterSearch {
+	return &ShelterSearch{advisor: advisor, buildings: buildings}
+}
+
+// Target returns the building the civilian is heading for, nil if none
+func (s *ShelterSearch) Target() *building.Building {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.target
+}
+
+// Tick ages the cached decision by one frame
+func (s *ShelterSearch) Tick() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ticksLeft > 0 {
+		s.ticksLeft--
+	}
+}
+
+// Search returns the shelter for a civilian at x,y facing threat from 0 to
+// 100. A decision made in the last shelterCacheTicks is reused, otherwise
+// the advisor is asked. Advice naming no known building type sends the
+// civilian to the nearest home.
+func (s *ShelterSearch) Search(ctx context.Context, x, y, threat int) *building.Building {
+	s.mu.Lock()
+	if s.ticksLeft > 0 && s.target != nil {
+		target := s.target
+		s.mu.Unlock()
+		return target
+	}
+	s.mu.Unlock()
+
+	name := fallbackShelter
+	advice, err := s.advisor.GetStrategicAdvice(ctx, FormatShelterPrompt(s.nearby(x, y), x, y, threat))
+	if err == nil {
+		if chosen, ok := ParseBuildingType(advice); ok {
+			name = chosen
+		}
+	}
+	target := s.buildings.FindNearest(name, x, y)
+	if target == nil && name != fallbackShelter {
+		target = s.buildings.FindNearest(fallbackShelter, x, y)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.target = target
+	s.ticksLeft = shelterCacheTicks
+	return target
+}
+
+// nearby returns the buildings within shelterSearchRadius of x,y
+func (s *ShelterSearch) nearby(x, y int) []*building.Building {
+	nearby := make([]*building.Building, 0)
+	for _, b := range s.buildings.Buildings() {
+		if buildingDistance(b, x, y) <= shelterSearchRadius {
+			nearby = append(nearby, b)
+		}
+	}
+	return nearby
+}
+
+// FormatShelterPrompt describes the buildings around x,y and the threat
+// level to the advisor
+func FormatShelterPrompt(buildings []*building.Building, x, y, threat int) string {
+	var prompt strings.Builder
+	fmt.Fprintf(&prompt, "Mechs are fighting nearby and the threat level is %d out of 100.", threat)
+	prompt.WriteString(" The buildings you can reach are:")
+	for _, b := range buildings {
+		fmt.Fprintf(&prompt, " a %s %.0f cells away,", b.Name(), buildingDistance(b, x, y))
+	}
+	prompt.WriteString(" Which building offers the best shelter?")
+	return prompt.String()
+}
+
+// ParseBuildingType returns the building type named first in advice
+func ParseBuildingType(advice string) (string, bool) {
+	lower := strings.ToLower(advice)
+	name, first := "", len(lower)
+	for _, bt := range building.Types {
+		if i := strings.Index(lower, strings.ToLower(bt.Name)); i >= 0 && i < first {
+			name, first = bt.Name, i
+		}
+	}
+	return name, name != ""
+}
+
+// buildingDistance returns the distance from x,y to the center of b
+func buildingDistance(b *building.Building, x, y int) float64 {
+	bx, by := b.Position()
+	bw, bh := b.Size()
+	dx, dy := float64(bx+bw/2-x), float64(by+bh/2-y)
+	return math.Sqrt(dx*dx + dy*dy)
+}
@@ -0,0 +1,75 @@
+package npc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/building"
+)
+
+type testAdvisor struct {
+	advice  string
+	prompts []string
+}
+
+func (a *testAdvisor) GetStrategicAdvice(ctx context.Context, prompt string) (string, error) {
+	a.prompts = append(a.prompts, prompt)
+	return a.advice, nil
+}
+
+type noRoads struct{}
+
+func (noRoads) HasRoad(x, y int) bool { return false }
+
+func newTestCity(t *testing.T) (*building.Manager, *building.Building, *building.Building) {
+	t.Helper()
+	hospitalType, _ := building.TypeByName("Hospital")
+	homeType, _ := building.TypeByName("Home")
+	manager := building.NewManager(noRoads{})
+	near := building.NewBuilding(10, 0, 4, 4, hospitalType)
+	far := building.NewBuilding(40, 0, 4, 4, hospitalType)
+	home := building.NewBuilding(0, 10, 4, 4, homeType)
+	manager.Add(far)
+	manager.Add(near)
+	manager.Add(home)
+	return manager, near, home
+}
+
+func TestShelterSearchFollowsAdvice(t *testing.T) {
+	manager, hospital, _ := newTestCity(t)
+	advisor := &testAdvisor{advice: "go to the Hospital"}
+	search := NewShelterSearch(advisor, manager)
+
+	if target := search.Search(context.Background(), 0, 0, 80); target != hospital {
+		t.Fatalf("civilian was sent to %v instead of the nearest hospital", target)
+	}
+	if search.Target() != hospital {
+		t.Errorf("civilian's target was not set to the nearest hospital")
+	}
+	if !strings.Contains(advisor.prompts[0], "Hospital") || !strings.Contains(advisor.prompts[0], "80") {
+		t.Errorf("prompt %q is missing the buildings or threat level", advisor.prompts[0])
+	}
+
+	// The decision is cached rather than asked again
+	search.Search(context.Background(), 0, 0, 80)
+	if len(advisor.prompts) != 1 {
+		t.Errorf("advisor asked %d times within the cache period", len(advisor.prompts))
+	}
+	for i := 0; i < shelterCacheTicks; i++ {
+		search.Tick()
+	}
+	search.Search(context.Background(), 0, 0, 80)
+	if len(advisor.prompts) != 2 {
+		t.Errorf("advisor not asked again after the cache expired")
+	}
+}
+
+func TestShelterSearchFallsBackToHome(t *testing.T) {
+	manager, _, home := newTestCity(t)
+	search := NewShelterSearch(&testAdvisor{advice: "run and hide"}, manager)
+
+	if target := search.Search(context.Background(), 0, 0, 50); target != home {
+		t.Errorf("advice naming no building sent the civilian to %v instead of home", target)
+	}
+}
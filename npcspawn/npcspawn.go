@@ -0,0 +1,36 @@
+// Package npcspawn finds where civilians step out of their homes onto the
+// city's roads
+package npcspawn
+
+import (
+	"fmt"
+
+	"github.com/Ariemeth/frame_assault/building"
+	"github.com/Ariemeth/frame_assault/roadgraph"
+)
+
+// searchDistance is how far from a building's door a road is looked for
+// before settling for the nearest road anywhere
+const searchDistance = 3
+
+// FindAdjacentSpawnCell returns a road cell beside b for a civilian to
+// start on. It looks out from the door below the middle of the building,
+// first south, then west, then east, up to searchDistance cells, and
+// falls back to the road nearest the building's corner if none is found.
+func FindAdjacentSpawnCell(b *building.Building, roadGraph *roadgraph.Graph) (x, y int, err error) {
+	bx, by := b.Position()
+	width, height := b.Size()
+	doorX, doorY := bx+width/2, by+height
+	for d := 0; d <= searchDistance; d++ {
+		for _, cell := range [][2]int{{doorX, doorY + d}, {doorX - d, doorY}, {doorX + d, doorY}} {
+			if roadGraph.HasRoad(cell[0], cell[1]) && !b.Contains(cell[0], cell[1]) {
+				return cell[0], cell[1], nil
+			}
+		}
+	}
+	x, y, err = roadGraph.NearestRoadNode(bx, by)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error finding spawn cell for %s: %v", b.Name(), err)
+	}
+	return x, y, nil
+}
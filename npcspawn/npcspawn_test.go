@@ -0,0 +1,51 @@
+package npcspawn
+
+import (
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/building"
+	"github.com/Ariemeth/frame_assault/roadgraph"
+)
+
+func TestSpawnCellIsOnRoadOutsideBuilding(t *testing.T) {
+	home := building.NewBuilding(10, 10, 8, 6, building.Types[0])
+	// A street two cells below the building and an avenue to its east
+	roads := make([][2]int, 0)
+	for x := 0; x < 30; x++ {
+		roads = append(roads, [2]int{x, 17})
+	}
+	for y := 0; y < 30; y++ {
+		roads = append(roads, [2]int{20, y})
+	}
+	graph := roadgraph.NewGraph(roads)
+
+	x, y, err := FindAdjacentSpawnCell(home, graph)
+	if err != nil {
+		t.Fatalf("no spawn cell found: %v", err)
+	}
+	if !graph.HasRoad(x, y) {
+		t.Errorf("spawn cell %d,%d is not on a road", x, y)
+	}
+	if home.Contains(x, y) {
+		t.Errorf("spawn cell %d,%d is inside the building", x, y)
+	}
+	if x != 14 || y != 17 {
+		t.Errorf("spawn cell is %d,%d, want 14,17 south of the door", x, y)
+	}
+}
+
+func TestSpawnCellFallsBackToNearestRoad(t *testing.T) {
+	home := building.NewBuilding(10, 10, 8, 6, building.Types[0])
+	graph := roadgraph.NewGraph([][2]int{{40, 40}, {2, 9}})
+
+	x, y, err := FindAdjacentSpawnCell(home, graph)
+	if err != nil {
+		t.Fatalf("no spawn cell found: %v", err)
+	}
+	if x != 2 || y != 9 {
+		t.Errorf("spawn cell is %d,%d, want the nearest road at 2,9", x, y)
+	}
+	if _, _, err := FindAdjacentSpawnCell(home, roadgraph.NewGraph(nil)); err == nil {
+		t.Error("found a spawn cell without any roads")
+	}
+}
@@ -0,0 +1,93 @@
+// Package poi marks the places in the city that matter in a fight, so the
+// language model can send civilians toward or away from them
+package poi
+
+import (
+	"sync"
+
+	"github.com/Ariemeth/frame_assault/eventbus"
+)
+
+// HighValue is the strategic value of the buildings the city cannot do
+// without
+const HighValue = 10
+
+// EntityID identifies the entity a point of interest marks
+type EntityID int
+
+// PointOfInterest is a strategically important place in the city
+type PointOfInterest struct {
+	EntityID       EntityID
+	Label          string
+	StrategicValue int
+	Position       [2]int
+}
+
+// Registry holds the city's points of interest. It is safe for use by the
+// AI workers while the game marks destroyed buildings.
+type Registry struct {
+	mu        sync.RWMutex
+	points    []PointOfInterest
+	destroyed map[EntityID]bool
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{destroyed: make(map[EntityID]bool)}
+}
+
+// Register adds a point of interest, replacing any already registered for
+// the same entity
+func (r *Registry) Register(point PointOfInterest) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, registered := range r.points {
+		if registered.EntityID == point.EntityID {
+			r.points[i] = point
+			return
+		}
+	}
+	r.points = append(r.points, point)
+}
+
+// GetAll returns every point of interest that has not been destroyed
+func (r *Registry) GetAll() []PointOfInterest {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	standing := make([]PointOfInterest, 0, len(r.points))
+	for _, point := range r.points {
+		if !r.destroyed[point.EntityID] {
+			standing = append(standing, point)
+		}
+	}
+	return standing
+}
+
+// FindNearest returns the standing point of interest closest to x,y, false
+// if there is none
+func (r *Registry) FindNearest(x, y int) (*PointOfInterest, bool) {
+	var nearest *PointOfInterest
+	bestDistance := 0
+	for _, point := range r.GetAll() {
+		dx, dy := point.Position[0]-x, point.Position[1]-y
+		if distance := dx*dx + dy*dy; nearest == nil || distance < bestDistance {
+			found := point
+			nearest, bestDistance = &found, distance
+		}
+	}
+	return nearest, nearest != nil
+}
+
+// MarkDestroyed leaves the point of interest marking id out from now on
+func (r *Registry) MarkDestroyed(id EntityID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.destroyed[id] = true
+}
+
+// HandleEvent marks the points of interest of destroyed buildings
+func (r *Registry) HandleEvent(event eventbus.Event) {
+	if burned, ok := event.(eventbus.BuildingBurnedEvent); ok {
+		r.MarkDestroyed(EntityID(burned.ID))
+	}
+}
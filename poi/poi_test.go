@@ -0,0 +1,31 @@
+package poi
+
+import (
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/eventbus"
+)
+
+func TestDestroyedPointsAreNotReturned(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register(PointOfInterest{EntityID: 1, Label: "Hospital", StrategicValue: HighValue, Position: [2]int{10, 10}})
+	registry.Register(PointOfInterest{EntityID: 2, Label: "Police", StrategicValue: HighValue, Position: [2]int{50, 50}})
+
+	registry.MarkDestroyed(1)
+	all := registry.GetAll()
+	if len(all) != 1 || all[0].EntityID != 2 {
+		t.Fatalf("GetAll returned %+v, want only the police station", all)
+	}
+	nearest, ok := registry.FindNearest(10, 10)
+	if !ok || nearest.EntityID != 2 {
+		t.Errorf("nearest to the destroyed hospital is %+v, want the police station", nearest)
+	}
+
+	registry.HandleEvent(eventbus.BuildingBurnedEvent{ID: 2})
+	if all := registry.GetAll(); len(all) != 0 {
+		t.Errorf("GetAll returned %+v after every point was destroyed", all)
+	}
+	if _, ok := registry.FindNearest(0, 0); ok {
+		t.Error("found a point of interest when every one is destroyed")
+	}
+}
@@ -0,0 +1,94 @@
+// Package power tracks whether the city has power
+package power
+
+import (
+	"sync"
+
+	"github.com/Ariemeth/frame_assault/eventbus"
+	tl "github.com/Ariemeth/termloop"
+)
+
+// Plant is the building that powers the city
+type Plant interface {
+	Structure() int
+}
+
+// GridSystem manages the city's power state, publishing outages and
+// restorations on the event bus
+type GridSystem struct {
+	mu      sync.Mutex
+	bus     *eventbus.Bus
+	plant   Plant
+	powered bool
+}
+
+// NewGridSystem creates a powered grid publishing on bus
+func NewGridSystem(bus *eventbus.Bus) *GridSystem {
+	return &GridSystem{
+		bus:     bus,
+		powered: true,
+	}
+}
+
+// AttachPlant sets the power plant whose destruction cuts the power
+func (g *GridSystem) AttachPlant(plant Plant) {
+	g.plant = plant
+}
+
+// Powered returns true while the city has power
+func (g *GridSystem) Powered() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.powered
+}
+
+// CutPower turns the city's power off, publishing a PowerOutageEvent if
+// it was on
+func (g *GridSystem) CutPower() {
+	if !g.setPowered(false) {
+		return
+	}
+	g.publish(eventbus.PowerOutageEvent{})
+}
+
+// RestorePower turns the city's power back on, publishing a
+// PowerRestoredEvent if it was off. A destroyed plant cannot restore power.
+func (g *GridSystem) RestorePower() {
+	if g.plantDestroyed() || !g.setPowered(true) {
+		return
+	}
+	g.publish(eventbus.PowerRestoredEvent{})
+}
+
+// setPowered changes the power state, returning true if it changed
+func (g *GridSystem) setPowered(powered bool) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.powered == powered {
+		return false
+	}
+	g.powered = powered
+	return true
+}
+
+// plantDestroyed returns true if the attached plant has no structure left
+func (g *GridSystem) plantDestroyed() bool {
+	return g.plant != nil && g.plant.Structure() <= 0
+}
+
+// publish sends event on the bus if there is one
+func (g *GridSystem) publish(event eventbus.Event) {
+	if g.bus != nil {
+		g.bus.Publish(event)
+	}
+}
+
+// Draw is a no-op, the grid is shown through the city's lighting.
+func (g *GridSystem) Draw(screen *tl.Screen) {}
+
+// Tick cuts the power once the plant is destroyed
+func (g *GridSystem) Tick(event tl.Event) {
+	if event.Type == tl.EventNone && g.plantDestroyed() {
+		g.CutPower()
+	}
+}
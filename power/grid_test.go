@@ -0,0 +1,63 @@
+package power
+
+import (
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/eventbus"
+	tl "github.com/Ariemeth/termloop"
+)
+
+type testPlant struct {
+	structure int
+}
+
+func (p *testPlant) Structure() int {
+	return p.structure
+}
+
+func TestDestroyedPlantCutsPower(t *testing.T) {
+	bus := eventbus.New()
+	var received []eventbus.Event
+	bus.Subscribe(func(event eventbus.Event) {
+		received = append(received, event)
+	})
+	plant := &testPlant{structure: 100}
+	grid := NewGridSystem(bus)
+	grid.AttachPlant(plant)
+
+	grid.Tick(tl.Event{Type: tl.EventNone})
+	if !grid.Powered() || len(received) != 0 {
+		t.Fatal("grid lost power while the plant was standing")
+	}
+
+	plant.structure = 0
+	grid.Tick(tl.Event{Type: tl.EventNone})
+	grid.Tick(tl.Event{Type: tl.EventNone})
+	if grid.Powered() {
+		t.Error("grid still powered after the plant was destroyed")
+	}
+	if len(received) != 1 || received[0] != (eventbus.PowerOutageEvent{}) {
+		t.Errorf("published %v instead of a single outage", received)
+	}
+
+	grid.RestorePower()
+	if grid.Powered() {
+		t.Error("power restored without a working plant")
+	}
+}
+
+func TestRestorePowerPublishesEvent(t *testing.T) {
+	bus := eventbus.New()
+	var restored bool
+	bus.Subscribe(func(event eventbus.Event) {
+		_, restored = event.(eventbus.PowerRestoredEvent)
+	})
+	grid := NewGridSystem(bus)
+	grid.AttachPlant(&testPlant{structure: 100})
+
+	grid.CutPower()
+	grid.RestorePower()
+	if !grid.Powered() || !restored {
+		t.Error("restoring power did not power the grid and publish PowerRestoredEvent")
+	}
+}
@@ -4,43 +4,109 @@ import (
 	"math"
 	"time"
 
+	"github.com/Ariemeth/frame_assault/damagelog"
+	"github.com/Ariemeth/frame_assault/lifecycle"
+	"github.com/Ariemeth/frame_assault/util"
 	tl "github.com/Ariemeth/termloop"
 )
 
+const (
+	// Trail glyphs
+	trailBrightGlyph     = '•'
+	trailDimGlyph        = '·'
+	trailHorizontalGlyph = '─'
+	trailVerticalGlyph   = '│'
+	trailRisingGlyph     = '/'
+	trailFallingGlyph    = '\\'
+
+	// bulletMoveDelay is how long a bullet takes to move a cell at normal
+	// speed
+	bulletMoveDelay = time.Millisecond * 100
+
+	// ReferenceSpeed is the bullet speed that deals exactly its base damage
+	ReferenceSpeed = 1.0
+
+	// axisThreshold is how far off an axis a direction can be and still be
+	// treated as purely horizontal or vertical
+	axisThreshold = 0.38
+
+	// DefaultColor and DefaultSymbol are how a bullet is drawn unless its
+	// weapon says otherwise
+	DefaultColor  = tl.ColorYellow | tl.AttrBold
+	DefaultSymbol = '*'
+)
+
+// trailPoint is a previous bullet position that fades over time
+type trailPoint struct {
+	x, y  float64
+	alpha float64
+}
+
+// Target is something a bullet can damage when it arrives
+type Target interface {
+	Hit(int, damagelog.EntityID)
+}
+
+// KineticDamage scales baseDamage by the kinetic energy of a projectile
+// travelling at speed relative to ReferenceSpeed
+func KineticDamage(baseDamage int, speed float64) int {
+	return int(float64(baseDamage) * speed / ReferenceSpeed)
+}
+
+// cellRenderer is the part of tl.Screen used to draw a bullet
+type cellRenderer interface {
+	RenderCell(x, y int, c *tl.Cell)
+}
+
 // Bullet represents a projectile fired from a weapon
 type Bullet struct {
 	*tl.Entity
-	x, y             float64 // Current position as float for smooth movement
-	targetX, targetY int     // Target position
-	dx, dy           float64 // Direction vector
-	speed            float64
-	symbol           rune
-	color            tl.Attr
-	level            *tl.BaseLevel
-	lastMove         time.Time
-	moveDelay        time.Duration
-	trail            [][2]float64 // Trail positions
-	trailLength      int
-}
-
-// NewBullet creates a new bullet entity
-func NewBullet(startX, startY, targetX, targetY int, level *tl.BaseLevel) *Bullet {
+	x, y              float64 // Current position as float for smooth movement
+	targetX, targetY  int     // Target position
+	dx, dy            float64 // Direction vector
+	speed             float64
+	symbol            rune
+	color             tl.Attr
+	level             *tl.BaseLevel
+	lastMove          time.Time
+	moveDelay         time.Duration
+	baseDelay         time.Duration // moveDelay at normal speed
+	trail             []trailPoint // Trail positions, oldest first
+	trailLength       int
+	owner             tl.Drawable // Entity added to the level, the bullet or a wrapper
+	baseDamage        int
+	kineticMultiplier float64
+	target            Target   // Hit on arrival when set
+	landed            bool     // Set once the bullet stops being counted
+	counter           *Counter // Counts the bullets flying in the level
+	attacker          damagelog.EntityID
+}
+
+// NewBullet creates a new bullet entity drawn as symbol in color
+func NewBullet(startX, startY, targetX, targetY int, color tl.Attr, symbol rune, level *tl.BaseLevel) *Bullet {
 	bullet := &Bullet{
-		Entity:      tl.NewEntity(startX, startY, 1, 1),
-		x:           float64(startX),
-		y:           float64(startY),
-		targetX:     targetX,
-		targetY:     targetY,
-		speed:       1.0,
-		symbol:      '*',
-		color:       tl.ColorYellow | tl.AttrBold,
-		level:       level,
-		lastMove:    time.Now(),
-		moveDelay:   time.Millisecond * 100,
-		trail:       make([][2]float64, 0),
-		trailLength: 3, // Number of trailing bullets
+		Entity:            tl.NewEntity(startX, startY, 1, 1),
+		x:                 float64(startX),
+		y:                 float64(startY),
+		targetX:           targetX,
+		targetY:           targetY,
+		speed:             1.0,
+		symbol:            symbol,
+		color:             color,
+		level:             level,
+		lastMove:          time.Now(),
+		baseDelay:         bulletMoveDelay,
+		trail:             make([]trailPoint, 0),
+		trailLength:       3, // Number of trailing bullets
+		kineticMultiplier: 1.0,
 	}
 
+	bullet.rescale()
+	bullet.owner = bullet
+	bullet.counter = BulletCounter(level)
+	bullet.counter.Add()
+	lifecycle.NotifyCreate(bullet)
+
 	// Calculate direction vector
 	dx := float64(targetX) - bullet.x
 	dy := float64(targetY) - bullet.y
@@ -53,17 +119,90 @@ func NewBullet(startX, startY, targetX, targetY int, level *tl.BaseLevel) *Bulle
 	return bullet
 }
 
+// NewBulletWithDamage creates a bullet that deals baseDamage scaled by its
+// kinetic energy to its target on arrival
+func NewBulletWithDamage(startX, startY, targetX, targetY, baseDamage int, speed float64, level *tl.BaseLevel) *Bullet {
+	bullet := NewBullet(startX, startY, targetX, targetY, DefaultColor, DefaultSymbol, level)
+	bullet.baseDamage = baseDamage
+	bullet.SetSpeed(speed)
+	return bullet
+}
+
+// SetSpeed sets how many cells the bullet travels per move
+func (b *Bullet) SetSpeed(speed float64) {
+	b.speed = speed
+	b.kineticMultiplier = speed / ReferenceSpeed
+}
+
+// Cull stops counting the bullet without hitting its target, for bullets
+// taken out of the level before they land
+func (b *Bullet) Cull() {
+	b.target = nil
+	if !b.landed {
+		b.landed = true
+		b.counter.Remove()
+	}
+}
+
+// SetTarget sets the target hit when the bullet arrives
+func (b *Bullet) SetTarget(target Target) {
+	b.target = target
+}
+
+// SetAttacker sets who is recorded as firing the bullet when it hits
+func (b *Bullet) SetAttacker(attacker damagelog.EntityID) {
+	b.attacker = attacker
+}
+
+// Style returns the color and symbol the bullet is drawn with
+func (b *Bullet) Style() (tl.Attr, rune) {
+	return b.color, b.symbol
+}
+
+// Damage returns the damage dealt on arrival after kinetic scaling
+func (b *Bullet) Damage() int {
+	return int(float64(b.baseDamage) * b.kineticMultiplier)
+}
+
+// directionGlyph returns the trail glyph matching the bullet's direction
+func (b *Bullet) directionGlyph() rune {
+	switch {
+	case math.Abs(b.dy) <= axisThreshold:
+		return trailHorizontalGlyph
+	case math.Abs(b.dx) <= axisThreshold:
+		return trailVerticalGlyph
+	case (b.dx > 0) == (b.dy > 0):
+		// Screen y grows downward so matching signs slope like a backslash
+		return trailFallingGlyph
+	}
+	return trailRisingGlyph
+}
+
+// trailCell maps a trail point's alpha to a glyph and attribute.
+// Full alpha is bold, half alpha is normal and anything fainter is dim.
+func (b *Bullet) trailCell(alpha float64) *tl.Cell {
+	color := b.color & ^tl.AttrBold
+	switch {
+	case alpha >= 1.0:
+		return &tl.Cell{Fg: color | tl.AttrBold, Ch: trailBrightGlyph}
+	case alpha >= 0.5:
+		return &tl.Cell{Fg: color, Ch: b.directionGlyph()}
+	}
+	return &tl.Cell{Fg: color | util.AttrDim, Ch: trailDimGlyph}
+}
+
 // Draw implements the Draw method of the Drawable interface
 func (b *Bullet) Draw(screen *tl.Screen) {
+	b.render(screen)
+}
+
+// render draws the fading trail and the bullet itself
+func (b *Bullet) render(screen cellRenderer) {
 	// Draw trail
-	for _, pos := range b.trail {
-		screenX := int(math.Round(pos[0]))
-		screenY := int(math.Round(pos[1]))
-		// Make trail bullets slightly dimmer
-		screen.RenderCell(screenX, screenY, &tl.Cell{
-			Fg: b.color & ^tl.AttrBold,
-			Ch: b.symbol,
-		})
+	for _, point := range b.trail {
+		screenX := int(math.Round(point.x))
+		screenY := int(math.Round(point.y))
+		screen.RenderCell(screenX, screenY, b.trailCell(point.alpha))
 	}
 
 	// Draw current bullet position
@@ -75,15 +214,25 @@ func (b *Bullet) Draw(screen *tl.Screen) {
 	})
 }
 
+// rescale sets how long the bullet takes to move a cell at the speed of
+// its level
+func (b *Bullet) rescale() {
+	b.moveDelay = scaledDelay(b.baseDelay, Speed(b.level))
+}
+
 // Tick implements the Tick method of the Drawable interface
 func (b *Bullet) Tick(event tl.Event) {
+	lifecycle.NotifyTick(b)
 	// Only move if enough time has passed
 	if time.Since(b.lastMove) < b.moveDelay {
 		return
 	}
 
-	// Add current position to trail
-	b.trail = append(b.trail, [2]float64{b.x, b.y})
+	// Fade existing trail points and add current position to trail
+	for i := range b.trail {
+		b.trail[i].alpha -= 1.0 / float64(b.trailLength)
+	}
+	b.trail = append(b.trail, trailPoint{x: b.x, y: b.y, alpha: 1.0})
 	if len(b.trail) > b.trailLength {
 		b.trail = b.trail[1:]
 	}
@@ -96,9 +245,22 @@ func (b *Bullet) Tick(event tl.Event) {
 	screenX := int(math.Round(b.x))
 	screenY := int(math.Round(b.y))
 
-	// Check if bullet reached target
-	if math.Abs(float64(b.targetX)-b.x) < 0.5 && math.Abs(float64(b.targetY)-b.y) < 0.5 {
-		b.level.RemoveEntity(b)
+	// Check if bullet reached or, at higher speeds, passed its target
+	toTargetX := float64(b.targetX) - b.x
+	toTargetY := float64(b.targetY) - b.y
+	passed := toTargetX*b.dx+toTargetY*b.dy < 0
+	if passed || math.Abs(toTargetX) < 0.5 && math.Abs(toTargetY) < 0.5 {
+		if b.target != nil && b.baseDamage > 0 {
+			b.target.Hit(b.Damage(), b.attacker)
+			b.target = nil
+		}
+		if !b.landed {
+			b.landed = true
+			b.counter.Remove()
+		}
+		if b.level != nil {
+			lifecycle.RemoveEntity(b.level, b.owner)
+		}
 		return
 	}
 
@@ -0,0 +1,104 @@
+package projectile
+
+import (
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/damagelog"
+	"github.com/Ariemeth/frame_assault/util"
+	tl "github.com/Ariemeth/termloop"
+)
+
+type testScreen struct {
+	cells map[[2]int]tl.Cell
+}
+
+func (s *testScreen) RenderCell(x, y int, c *tl.Cell) {
+	s.cells[[2]int{x, y}] = *c
+}
+
+func TestHorizontalBulletTrail(t *testing.T) {
+	bullet := NewBullet(0, 0, 10, 0, DefaultColor, DefaultSymbol, nil)
+	bullet.moveDelay = 0
+
+	for i := 0; i < 4; i++ {
+		bullet.Tick(tl.Event{})
+	}
+
+	screen := &testScreen{cells: make(map[[2]int]tl.Cell)}
+	bullet.render(screen)
+
+	expected := map[[2]int]rune{
+		{1, 0}: trailDimGlyph,
+		{2, 0}: trailHorizontalGlyph,
+		{3, 0}: trailBrightGlyph,
+		{4, 0}: '*',
+	}
+	if len(screen.cells) != len(expected) {
+		t.Errorf("bullet drew %d cells instead of %d", len(screen.cells), len(expected))
+	}
+	for pos, ch := range expected {
+		cell, ok := screen.cells[pos]
+		if !ok {
+			t.Errorf("nothing drawn at (%d,%d)", pos[0], pos[1])
+			continue
+		}
+		if cell.Ch != ch {
+			t.Errorf("(%d,%d) drew %q instead of %q", pos[0], pos[1], cell.Ch, ch)
+		}
+	}
+
+	if screen.cells[[2]int{3, 0}].Fg&tl.AttrBold == 0 {
+		t.Errorf("newest trail segment is not bold")
+	}
+	if screen.cells[[2]int{1, 0}].Fg&util.AttrDim == 0 {
+		t.Errorf("oldest trail segment is not dim")
+	}
+}
+
+func TestTrailDirectionGlyph(t *testing.T) {
+	tests := []struct {
+		targetX, targetY int
+		glyph            rune
+	}{
+		{10, 0, trailHorizontalGlyph},
+		{-10, 0, trailHorizontalGlyph},
+		{0, 10, trailVerticalGlyph},
+		{10, 10, trailFallingGlyph},
+		{10, -10, trailRisingGlyph},
+	}
+
+	for _, test := range tests {
+		bullet := NewBullet(0, 0, test.targetX, test.targetY, DefaultColor, DefaultSymbol, nil)
+		if glyph := bullet.directionGlyph(); glyph != test.glyph {
+			t.Errorf("bullet towards (%d,%d) uses %q instead of %q",
+				test.targetX, test.targetY, glyph, test.glyph)
+		}
+	}
+}
+
+type testTarget struct {
+	damage int
+}
+
+func (target *testTarget) Hit(damage int, attacker damagelog.EntityID) {
+	target.damage += damage
+}
+
+func TestKineticDamage(t *testing.T) {
+	target := &testTarget{}
+	bullet := NewBulletWithDamage(0, 0, 4, 0, 3, 2*ReferenceSpeed, nil)
+	bullet.SetTarget(target)
+	bullet.moveDelay = 0
+
+	for i := 0; i < 5; i++ {
+		bullet.Tick(tl.Event{})
+	}
+	if target.damage != 6 {
+		t.Errorf("bullet at double speed dealt %d damage instead of 6", target.damage)
+	}
+
+	slow := NewBulletWithDamage(0, 0, 4, 0, 3, ReferenceSpeed/2, nil)
+	if slow.Damage() != 1 {
+		t.Errorf("bullet at half speed deals %d damage instead of 1", slow.Damage())
+	}
+}
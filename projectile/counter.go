@@ -0,0 +1,57 @@
+package projectile
+
+import (
+	"sync"
+	"sync/atomic"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+// Counter counts projectiles alive at the same time. It is safe for
+// concurrent use.
+type Counter struct {
+	alive int64
+}
+
+// counters holds the bullet counter of each level, so a rebuilt level
+// starts counting from zero
+var (
+	countersMu sync.Mutex
+	counters   = make(map[*tl.BaseLevel]*Counter)
+)
+
+// BulletCounter returns the counter of the bullets flying in level,
+// creating it the first time the level is asked for
+func BulletCounter(level *tl.BaseLevel) *Counter {
+	countersMu.Lock()
+	defer countersMu.Unlock()
+	counter, ok := counters[level]
+	if !ok {
+		counter = &Counter{}
+		counters[level] = counter
+	}
+	return counter
+}
+
+// ReleaseBulletCounter forgets the counter of a level that is no longer
+// played
+func ReleaseBulletCounter(level *tl.BaseLevel) {
+	countersMu.Lock()
+	defer countersMu.Unlock()
+	delete(counters, level)
+}
+
+// Add counts a new projectile
+func (c *Counter) Add() {
+	atomic.AddInt64(&c.alive, 1)
+}
+
+// Remove stops counting a projectile that has landed
+func (c *Counter) Remove() {
+	atomic.AddInt64(&c.alive, -1)
+}
+
+// Count returns the number of projectiles alive
+func (c *Counter) Count() int {
+	return int(atomic.LoadInt64(&c.alive))
+}
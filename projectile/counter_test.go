@@ -0,0 +1,44 @@
+package projectile
+
+import (
+	"testing"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+func TestCounterTracksLandedBullets(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	defer ReleaseBulletCounter(level)
+	bullet := NewBullet(0, 0, 2, 0, DefaultColor, DefaultSymbol, level)
+	bullet.moveDelay = 0
+	if count := BulletCounter(level).Count(); count != 1 {
+		t.Fatalf("count is %d after firing, want 1", count)
+	}
+	rebuilt := tl.NewBaseLevel(tl.Cell{})
+	defer ReleaseBulletCounter(rebuilt)
+	if count := BulletCounter(rebuilt).Count(); count != 0 {
+		t.Errorf("a rebuilt level counts %d bullets, want 0", count)
+	}
+
+	for i := 0; i < 5; i++ {
+		bullet.Tick(tl.Event{})
+	}
+	if count := BulletCounter(level).Count(); count != 0 {
+		t.Errorf("count is %d after landing, want 0", count)
+	}
+}
+
+// Run with -race to check the counter under contention
+func BenchmarkBulletCounter(b *testing.B) {
+	var counter Counter
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			counter.Add()
+			counter.Count()
+			counter.Remove()
+		}
+	})
+	if counter.Count() != 0 {
+		b.Fatalf("counter is %d after balanced adds and removes", counter.Count())
+	}
+}
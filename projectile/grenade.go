@@ -0,0 +1,30 @@
+package projectile
+
+import (
+	"time"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	grenadeSymbol    = 'o'
+	grenadeMoveDelay = time.Millisecond * 200
+)
+
+// Grenade is a slow, lobbed projectile fired by grenade launchers
+type Grenade struct {
+	*Bullet
+}
+
+// NewGrenade creates a new grenade entity
+func NewGrenade(startX, startY, targetX, targetY int, level *tl.BaseLevel) *Grenade {
+	bullet := NewBullet(startX, startY, targetX, targetY, tl.ColorGreen|tl.AttrBold, grenadeSymbol, level)
+	bullet.baseDelay = grenadeMoveDelay
+	bullet.rescale()
+	bullet.trailLength = 1
+
+	grenade := &Grenade{Bullet: bullet}
+	// Remove the grenade rather than its inner bullet once it lands
+	bullet.owner = grenade
+	return grenade
+}
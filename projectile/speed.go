@@ -0,0 +1,61 @@
+package projectile
+
+import (
+	"sync"
+	"time"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+// speeds holds how many times faster than normal projectiles fly in each
+// level, so the game speed stays with the level it was set on
+var (
+	speedsMu sync.Mutex
+	speeds   = make(map[*tl.BaseLevel]float64)
+)
+
+// Speed returns how many times faster than normal projectiles fly in
+// level, 1 until SetSpeed is called for it
+func Speed(level *tl.BaseLevel) float64 {
+	speedsMu.Lock()
+	defer speedsMu.Unlock()
+	speed, ok := speeds[level]
+	if !ok {
+		return 1.0
+	}
+	return speed
+}
+
+// SetSpeed sets how many times faster than normal projectiles fly in
+// level, rescaling the ones already in flight
+func SetSpeed(level *tl.BaseLevel, speed float64) {
+	speedsMu.Lock()
+	speeds[level] = speed
+	speedsMu.Unlock()
+	if level == nil {
+		return
+	}
+	for _, entity := range level.Entities {
+		switch p := entity.(type) {
+		case *Bullet:
+			p.rescale()
+		case *Grenade:
+			p.rescale()
+		}
+	}
+}
+
+// ReleaseSpeed forgets the speed of a level that is no longer played
+func ReleaseSpeed(level *tl.BaseLevel) {
+	speedsMu.Lock()
+	defer speedsMu.Unlock()
+	delete(speeds, level)
+}
+
+// scaledDelay returns delay shortened or lengthened by speed
+func scaledDelay(delay time.Duration, speed float64) time.Duration {
+	if speed <= 0 {
+		return delay
+	}
+	return time.Duration(float64(delay) / speed)
+}
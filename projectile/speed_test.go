@@ -0,0 +1,31 @@
+package projectile
+
+import (
+	"testing"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+func TestSetSpeedRescalesBulletsInFlight(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	defer ReleaseBulletCounter(level)
+	defer ReleaseSpeed(level)
+	bullet := NewBullet(0, 0, 10, 0, DefaultColor, DefaultSymbol, level)
+	level.AddEntity(bullet)
+	grenade := NewGrenade(0, 0, 10, 0, level)
+	level.AddEntity(grenade)
+
+	SetSpeed(level, 2)
+	if bullet.moveDelay != bulletMoveDelay/2 {
+		t.Errorf("bullet in flight moves every %v at 2x speed, want %v", bullet.moveDelay, bulletMoveDelay/2)
+	}
+	if grenade.moveDelay != grenadeMoveDelay/2 {
+		t.Errorf("grenade in flight moves every %v at 2x speed, want %v", grenade.moveDelay, grenadeMoveDelay/2)
+	}
+
+	other := tl.NewBaseLevel(tl.Cell{})
+	defer ReleaseBulletCounter(other)
+	if fresh := NewBullet(0, 0, 10, 0, DefaultColor, DefaultSymbol, other); fresh.moveDelay != bulletMoveDelay {
+		t.Errorf("bullet in another level moves every %v, want the normal %v", fresh.moveDelay, bulletMoveDelay)
+	}
+}
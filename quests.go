@@ -0,0 +1,104 @@
+package main
+
+import (
+    "github.com/Ariemeth/frame_assault/building"
+    "github.com/Ariemeth/frame_assault/display"
+    "github.com/Ariemeth/frame_assault/eventbus"
+    "github.com/Ariemeth/frame_assault/mech"
+    "github.com/Ariemeth/frame_assault/mission"
+    "github.com/Ariemeth/frame_assault/npc"
+    "github.com/Ariemeth/frame_assault/spawnzones"
+    tl "github.com/Ariemeth/termloop"
+)
+
+// medicalSupplies are delivered to the hospital, carried as repair kits
+const medicalSupplies = "medical supplies"
+
+// newQuests returns the quest giver's pool, starting with a hit on target
+func newQuests(target string) []mission.Mission {
+    return []mission.Mission{
+        &mission.EliminateTarget{EnemyName: target, Pay: mission.Reward{Money: 200, XP: 100}},
+        &mission.DeliverItem{ItemKind: medicalSupplies, BuildingType: "Hospital",
+            Pay: mission.Reward{Money: 150, XP: 75}},
+        &mission.PatrolRoute{
+            Waypoints: [][2]int{
+                {avenueSpacing, buildingMargin},
+                {2 * avenueSpacing, buildingMargin},
+                {2 * avenueSpacing, buildingMargin + streetSpacing},
+            },
+            Pay: mission.Reward{Money: 100, XP: 50},
+        },
+    }
+}
+
+// placeQuestGiver puts a quest giver beside the player with quests
+// tracked by missions
+func placeQuestGiver(player *mech.PlayerMech, quests []mission.Mission, missions *mission.Manager,
+    notifier *display.Notification, level *tl.BaseLevel) *npc.QuestGiver {
+    x, y := player.Position()
+    x += 2
+    if spawnzones.HasCollision(x, y, level) {
+        x -= 4
+    }
+    civilian := NewComputerUserEntity(GenerateComputerUsers(1)[0], x, y)
+    giver := npc.NewQuestGiver(civilian, quests, missions, gameFPS)
+    giver.AttachPlayer(player)
+    giver.AttachNotifier(notifier)
+
+    dialogue := display.NewDialogueBox(25, 12, level)
+    giver.AttachDialogue(dialogue)
+    player.AttachDialogue(dialogue)
+    level.AddEntity(giver)
+    level.AddEntity(dialogue)
+    return giver
+}
+
+// missionTracker reports the player's position, the building they are
+// standing against, what they are carrying and destroyed enemies to the
+// mission manager
+type missionTracker struct {
+    missions  *mission.Manager
+    player    *mech.PlayerMech
+    buildings *building.Manager
+}
+
+// newMissionTracker creates a tracker advancing missions for player
+func newMissionTracker(missions *mission.Manager, player *mech.PlayerMech, buildings *building.Manager, bus *eventbus.Bus) *missionTracker {
+    tracker := &missionTracker{missions: missions, player: player, buildings: buildings}
+    bus.Subscribe(tracker.HandleEvent)
+    return tracker
+}
+
+// HandleEvent advances missions waiting on a destroyed enemy
+func (t *missionTracker) HandleEvent(event eventbus.Event) {
+    if destroyed, ok := event.(eventbus.MechDestroyedEvent); ok {
+        t.missions.Advance(mission.Progress{Destroyed: destroyed.Name})
+    }
+}
+
+// Draw does nothing, missions are shown through the quest giver
+func (t *missionTracker) Draw(screen *tl.Screen) {}
+
+// Tick advances missions with where the player is standing
+func (t *missionTracker) Tick(event tl.Event) {
+    if event.Type != tl.EventNone {
+        return
+    }
+    x, y := t.player.Position()
+    progress := mission.Progress{X: x, Y: y}
+    for _, b := range t.buildings.Buildings() {
+        if b.Contains(x+1, y) || b.Contains(x-1, y) || b.Contains(x, y+1) || b.Contains(x, y-1) {
+            progress.Building = b.Name()
+            break
+        }
+    }
+    if t.player.RepairKits() > 0 {
+        progress.Carrying = append(progress.Carrying, medicalSupplies)
+    }
+    for _, done := range t.missions.Advance(progress) {
+        // Delivering the medical supplies hands over a repair kit
+        if delivery, ok := done.(*mission.DeliverItem); ok && delivery.ItemKind == medicalSupplies {
+            t.player.UseRepairKit()
+        }
+    }
+}
@@ -0,0 +1,174 @@
+package replay
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	asciiPlayer   = '@'
+	asciiEnemy    = '!'
+	asciiBullet   = '·'
+	asciiBuilding = '█'
+	asciiRoad     = ' '
+	asciiHeader   = "# frame_assault replay %dx%d"
+	asciiFrame    = "---FRAME %d---"
+	asciiDelay    = "# delay "
+	asciiFired    = "# fired "
+	asciiHit      = "# hit"
+)
+
+// ExportDelay is the delay written between frames of an exported replay
+var ExportDelay = 100 * time.Millisecond
+
+// ExportASCII writes every recorded frame of replay to out as a text grid
+// the size of the level, so a replay can be shared and watched without the
+// game. Each frame starts with a ---FRAME N--- marker and ends with a
+// # delay line. The player is drawn as @, enemies as !, bullets as · and
+// buildings as █. Anything outside the level is left out.
+func ExportASCII(replay *ShortReplay, out io.Writer) error {
+	frames := replay.Buffer().Frames()
+	if len(frames) == 0 {
+		return errors.New("nothing recorded to export")
+	}
+	buildings := replay.buildingCells()
+	w := bufio.NewWriter(out)
+	fmt.Fprintf(w, asciiHeader+"\n", replay.width, replay.height)
+	for i, frame := range frames {
+		fmt.Fprintf(w, asciiFrame+"\n", i+1)
+		for _, row := range renderASCII(frame, buildings, replay.width, replay.height) {
+			fmt.Fprintln(w, string(row))
+		}
+		if frame.FiredAt != nil {
+			fmt.Fprintf(w, "%s%d,%d\n", asciiFired, frame.FiredAt[0], frame.FiredAt[1])
+		}
+		if frame.Hit {
+			fmt.Fprintln(w, asciiHit)
+		}
+		fmt.Fprintln(w, asciiDelay+ExportDelay.String())
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("error writing replay: %v", err)
+	}
+	return nil
+}
+
+// renderASCII draws frame as width by height rows of characters
+func renderASCII(frame Frame, buildings map[[2]int]bool, width, height int) [][]rune {
+	rows := make([][]rune, height)
+	for y := range rows {
+		rows[y] = make([]rune, width)
+		for x := range rows[y] {
+			rows[y][x] = asciiRoad
+			if buildings[[2]int{x, y}] {
+				rows[y][x] = asciiBuilding
+			}
+		}
+	}
+	set := func(pos [2]int, ch rune) {
+		if pos[0] >= 0 && pos[0] < width && pos[1] >= 0 && pos[1] < height {
+			rows[pos[1]][pos[0]] = ch
+		}
+	}
+	for _, pos := range frame.Bullets {
+		set(pos, asciiBullet)
+	}
+	for _, pos := range frame.Enemies {
+		set(pos, asciiEnemy)
+	}
+	set(frame.Pos, asciiPlayer)
+	return rows
+}
+
+// LoadASCIIReplay reads a replay written by ExportASCII. The loaded
+// replay's buffer can be played back like a recorded one.
+func LoadASCIIReplay(r io.Reader) (*ShortReplay, error) {
+	replay := NewShortReplay(nil, nil)
+	replay.buildings = make(map[[2]int]bool)
+	scanner := bufio.NewScanner(r)
+	var frame *Frame
+	row := 0
+	finish := func() error {
+		if frame == nil {
+			return nil
+		}
+		if frame.Pos == [2]int{-1, -1} {
+			return errors.New("error loading replay: frame has no player")
+		}
+		replay.buffer.record(*frame)
+		frame = nil
+		return nil
+	}
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "---FRAME"):
+			if err := finish(); err != nil {
+				return nil, err
+			}
+			frame = &Frame{Pos: [2]int{-1, -1}}
+			row = 0
+		case strings.HasPrefix(line, "# frame_assault replay"):
+			if _, err := fmt.Sscanf(line, asciiHeader, &replay.width, &replay.height); err != nil {
+				return nil, fmt.Errorf("error loading replay header: %v", err)
+			}
+		case strings.HasPrefix(line, asciiFired) && frame != nil:
+			target, err := parsePoint(strings.TrimPrefix(line, asciiFired))
+			if err != nil {
+				return nil, fmt.Errorf("error loading replay: %v", err)
+			}
+			frame.FiredAt = &target
+		case line == asciiHit && frame != nil:
+			frame.Hit = true
+		case strings.HasPrefix(line, "#"):
+			// Delays and other comments only matter to viewers
+		case frame != nil:
+			for x, ch := range []rune(line) {
+				pos := [2]int{x, row}
+				switch ch {
+				case asciiPlayer:
+					frame.Pos = pos
+				case asciiEnemy:
+					frame.Enemies = append(frame.Enemies, pos)
+				case asciiBullet:
+					frame.Bullets = append(frame.Bullets, pos)
+				case asciiBuilding:
+					replay.buildings[pos] = true
+				}
+			}
+			row++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading replay: %v", err)
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+	if len(replay.buffer.Frames()) == 0 {
+		return nil, errors.New("error loading replay: no frames")
+	}
+	return replay, nil
+}
+
+// parsePoint parses an x,y pair
+func parsePoint(s string) ([2]int, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return [2]int{}, fmt.Errorf("invalid point %q", s)
+	}
+	x, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return [2]int{}, fmt.Errorf("invalid point %q: %v", s, err)
+	}
+	y, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return [2]int{}, fmt.Errorf("invalid point %q: %v", s, err)
+	}
+	return [2]int{x, y}, nil
+}
@@ -0,0 +1,61 @@
+package replay
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/building"
+	tl "github.com/Ariemeth/termloop"
+)
+
+func TestASCIIExportRoundTrip(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	level.AddEntity(building.NewBuilding(10, 10, 4, 3, building.Types[0]))
+	recorded := NewShortReplay(nil, level)
+	recorded.SetBounds(30, 20)
+	enemy := [2]int{20, 5}
+	recorded.AttachEnemies(func() [][2]int { return [][2]int{enemy} })
+	for i := 0; i < 5; i++ {
+		enemy[0]--
+		var firedAt *[2]int
+		if i == 2 {
+			firedAt = &[2]int{20, 5}
+		}
+		recorded.RecordTick([2]int{2 + i, 3}, firedAt, i == 4)
+	}
+
+	var out bytes.Buffer
+	if err := ExportASCII(recorded, &out); err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if markers := strings.Count(out.String(), "---FRAME"); markers != 5 {
+		t.Errorf("export has %d frame markers, want 5", markers)
+	}
+	if !strings.Contains(out.String(), "# delay 100ms") {
+		t.Error("export has no delay lines")
+	}
+
+	loaded, err := LoadASCIIReplay(&out)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	original, frames := recorded.Buffer().Frames(), loaded.Buffer().Frames()
+	if len(frames) != len(original) {
+		t.Fatalf("loaded %d frames, want %d", len(frames), len(original))
+	}
+	for i := range original {
+		if frames[i].Pos != original[i].Pos {
+			t.Errorf("frame %d player is at %v, want %v", i+1, frames[i].Pos, original[i].Pos)
+		}
+		if len(frames[i].Enemies) != 1 || frames[i].Enemies[0] != original[i].Enemies[0] {
+			t.Errorf("frame %d enemies are %v, want %v", i+1, frames[i].Enemies, original[i].Enemies)
+		}
+		if frames[i].Hit != original[i].Hit || (frames[i].FiredAt == nil) != (original[i].FiredAt == nil) {
+			t.Errorf("frame %d hit and shot do not match the original", i+1)
+		}
+	}
+	if !loaded.buildingCells()[[2]int{11, 11}] {
+		t.Error("loaded replay lost the building")
+	}
+}
@@ -0,0 +1,85 @@
+// Package replay records recent player actions and plays them back
+package replay
+
+import (
+	"errors"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// BufferTicks is the number of ticks kept, 30 seconds at 10 FPS
+	BufferTicks = 300
+	// playbackSpeed is the number of recorded ticks shown per game tick
+	playbackSpeed = 2
+)
+
+// Frame is the player's state during a single recorded tick
+type Frame struct {
+	Pos     [2]int
+	FiredAt *[2]int // target of a shot fired this tick, nil if none
+	Hit     bool    // true if the player took damage this tick
+	// Enemies and Bullets are where the enemies and bullets were this tick
+	Enemies [][2]int
+	Bullets [][2]int
+}
+
+// ShortReplayBuffer keeps the most recent BufferTicks frames in a circular
+// buffer
+type ShortReplayBuffer struct {
+	frames []Frame
+	next   int
+	count  int
+}
+
+// NewShortReplayBuffer creates an empty replay buffer
+func NewShortReplayBuffer() *ShortReplayBuffer {
+	return &ShortReplayBuffer{frames: make([]Frame, BufferTicks)}
+}
+
+// RecordTick stores one tick of player state, overwriting the oldest tick
+// once the buffer is full
+func (b *ShortReplayBuffer) RecordTick(pos [2]int, firedAt *[2]int, hit bool) {
+	b.record(Frame{Pos: pos, FiredAt: firedAt, Hit: hit})
+}
+
+// record stores frame, overwriting the oldest once the buffer is full
+func (b *ShortReplayBuffer) record(frame Frame) {
+	b.frames[b.next] = frame
+	b.next = (b.next + 1) % len(b.frames)
+	if b.count < len(b.frames) {
+		b.count++
+	}
+}
+
+// Frames returns the recorded frames from oldest to newest
+func (b *ShortReplayBuffer) Frames() []Frame {
+	frames := make([]Frame, 0, b.count)
+	start := (b.next - b.count + len(b.frames)) % len(b.frames)
+	for i := 0; i < b.count; i++ {
+		frames = append(frames, b.frames[(start+i)%len(b.frames)])
+	}
+	return frames
+}
+
+// Play pauses level by replacing it on screen with a playback of the
+// recorded frames. The level is restored once playback completes or is
+// cancelled.
+func (b *ShortReplayBuffer) Play(screen *tl.Screen, level *tl.BaseLevel) error {
+	if screen == nil || level == nil {
+		return errors.New("replay needs a screen and level")
+	}
+	frames := b.Frames()
+	if len(frames) == 0 {
+		return errors.New("nothing recorded to replay")
+	}
+	// Resume the level as it was shown, which may layer more over it
+	shown := screen.Level()
+	if shown == nil {
+		shown = level
+	}
+	p := newPlayback(frames, level, func() { screen.SetLevel(shown) })
+	p.shown = shown
+	screen.SetLevel(p)
+	return nil
+}
@@ -0,0 +1,65 @@
+package replay
+
+import (
+	"testing"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+func TestBufferKeepsLastTicks(t *testing.T) {
+	buffer := NewShortReplayBuffer()
+	for i := 0; i < BufferTicks+5; i++ {
+		buffer.RecordTick([2]int{i, 0}, nil, false)
+	}
+
+	frames := buffer.Frames()
+	if len(frames) != BufferTicks {
+		t.Fatalf("buffer holds %d frames instead of %d", len(frames), BufferTicks)
+	}
+	if frames[0].Pos[0] != 5 {
+		t.Errorf("oldest frame is tick %d instead of 5", frames[0].Pos[0])
+	}
+	if frames[len(frames)-1].Pos[0] != BufferTicks+4 {
+		t.Errorf("newest frame is tick %d instead of %d", frames[len(frames)-1].Pos[0], BufferTicks+4)
+	}
+}
+
+func TestPlaybackRunsAtDoubleSpeed(t *testing.T) {
+	buffer := NewShortReplayBuffer()
+	for i := 0; i < 10; i++ {
+		buffer.RecordTick([2]int{i, 0}, nil, false)
+	}
+
+	finished := false
+	p := newPlayback(buffer.Frames(), tl.NewBaseLevel(tl.Cell{}), func() { finished = true })
+	for i := 0; i < 4; i++ {
+		p.Tick(tl.Event{Type: tl.EventNone})
+	}
+	if finished {
+		t.Fatalf("playback of 10 ticks finished after 4 ticks")
+	}
+	p.Tick(tl.Event{Type: tl.EventNone})
+	if !finished {
+		t.Errorf("playback of 10 ticks did not finish after 5 ticks")
+	}
+}
+
+func TestPlaybackSkip(t *testing.T) {
+	buffer := NewShortReplayBuffer()
+	buffer.RecordTick([2]int{0, 0}, nil, false)
+	buffer.RecordTick([2]int{1, 0}, nil, false)
+	buffer.RecordTick([2]int{2, 0}, nil, false)
+
+	finished := false
+	p := newPlayback(buffer.Frames(), tl.NewBaseLevel(tl.Cell{}), func() { finished = true })
+	p.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyF10})
+	if !finished {
+		t.Errorf("F10 did not end playback")
+	}
+}
+
+func TestPlayNeedsFrames(t *testing.T) {
+	if err := NewShortReplayBuffer().Play(tl.NewScreen(), tl.NewBaseLevel(tl.Cell{})); err == nil {
+		t.Errorf("empty buffer played without error")
+	}
+}
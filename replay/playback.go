@@ -0,0 +1,113 @@
+package replay
+
+import (
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	trailGlyph = '·'
+	flashGlyph = '*'
+	// flashTicks is how many recorded ticks a weapon flash stays visible
+	flashTicks = 4
+	banner     = "REPLAY - F10 to skip"
+)
+
+// cellRenderer is the part of tl.Screen used to draw the replay
+type cellRenderer interface {
+	RenderCell(x, y int, c *tl.Cell)
+}
+
+// playback is a level shown in place of the paused game level. The paused
+// level is drawn but never ticked, so nothing in it moves until playback
+// finishes.
+type playback struct {
+	frames []Frame
+	paused *tl.BaseLevel
+	// shown draws the paused level as it was on screen, which may layer
+	// more over it
+	shown   tl.Level
+	current int
+	done    func()
+}
+
+// newPlayback creates a playback of frames over paused, calling done when
+// playback ends
+func newPlayback(frames []Frame, paused *tl.BaseLevel, done func()) *playback {
+	return &playback{frames: frames, paused: paused, shown: paused, done: done}
+}
+
+// Tick advances playback on each frame and ends it once every recorded
+// frame is shown or the player skips it
+func (p *playback) Tick(event tl.Event) {
+	if event.Type == tl.EventKey {
+		if event.Key == tl.KeyF10 || event.Key == tl.KeyEsc {
+			p.finish()
+		}
+		return
+	}
+	p.current += playbackSpeed
+	if p.current >= len(p.frames) {
+		p.finish()
+	}
+}
+
+// finish ends playback, resuming the paused level
+func (p *playback) finish() {
+	if p.done != nil {
+		p.done()
+		p.done = nil
+	}
+}
+
+// DrawBackground draws the paused level's background
+func (p *playback) DrawBackground(screen *tl.Screen) {
+	p.shown.DrawBackground(screen)
+}
+
+// Draw draws the frozen level with the replay on top
+func (p *playback) Draw(screen *tl.Screen) {
+	p.shown.Draw(screen)
+	offsetX, offsetY := p.paused.Offset()
+	p.render(screen, offsetX, offsetY)
+}
+
+// render draws the trail, weapon flashes and banner up to the current frame
+func (p *playback) render(screen cellRenderer, offsetX, offsetY int) {
+	last := p.current
+	if last >= len(p.frames) {
+		last = len(p.frames) - 1
+	}
+	for i := 0; i < last; i++ {
+		pos := p.frames[i].Pos
+		screen.RenderCell(pos[0]+offsetX, pos[1]+offsetY, &tl.Cell{Fg: tl.ColorWhite, Ch: trailGlyph})
+	}
+	for i := last - flashTicks; i <= last; i++ {
+		if i < 0 || p.frames[i].FiredAt == nil {
+			continue
+		}
+		target := *p.frames[i].FiredAt
+		screen.RenderCell(target[0]+offsetX, target[1]+offsetY,
+			&tl.Cell{Fg: tl.ColorYellow | tl.AttrBold, Ch: flashGlyph})
+	}
+
+	frame := p.frames[last]
+	player := &tl.Cell{Fg: tl.ColorWhite | tl.AttrBold, Bg: tl.ColorBlack, Ch: 'M'}
+	if frame.Hit {
+		player.Bg = tl.ColorRed
+	}
+	screen.RenderCell(frame.Pos[0]+offsetX, frame.Pos[1]+offsetY, player)
+
+	for i, ch := range banner {
+		screen.RenderCell(i, 0, &tl.Cell{Fg: tl.ColorBlack, Bg: tl.ColorYellow, Ch: ch})
+	}
+}
+
+// AddEntity adds an entity to the paused level
+func (p *playback) AddEntity(d tl.Drawable) {
+	p.paused.AddEntity(d)
+}
+
+// RemoveEntity removes an entity from the paused level
+func (p *playback) RemoveEntity(d tl.Drawable) {
+	p.paused.RemoveEntity(d)
+}
@@ -0,0 +1,106 @@
+package replay
+
+import (
+	"github.com/Ariemeth/frame_assault/building"
+	"github.com/Ariemeth/frame_assault/projectile"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// defaultWidth and defaultHeight are the size of the exported grid
+	// until SetBounds is called
+	defaultWidth  = 100
+	defaultHeight = 60
+)
+
+// ShortReplay is a level entity that starts a playback of the last 30
+// seconds when F10 is pressed
+type ShortReplay struct {
+	buffer  *ShortReplayBuffer
+	screen  *tl.Screen
+	level   *tl.BaseLevel
+	enemies func() [][2]int
+	width   int
+	height  int
+	// buildings are the building cells of a loaded replay, which has no
+	// level to find them in
+	buildings map[[2]int]bool
+}
+
+// NewShortReplay creates a replay of level shown on screen
+func NewShortReplay(screen *tl.Screen, level *tl.BaseLevel) *ShortReplay {
+	return &ShortReplay{
+		buffer: NewShortReplayBuffer(),
+		screen: screen,
+		level:  level,
+		width:  defaultWidth,
+		height: defaultHeight,
+	}
+}
+
+// Buffer returns the buffer the player's actions are recorded into
+func (r *ShortReplay) Buffer() *ShortReplayBuffer {
+	return r.buffer
+}
+
+// AttachEnemies sets where the positions of the enemies recorded each tick
+// come from
+func (r *ShortReplay) AttachEnemies(enemies func() [][2]int) {
+	r.enemies = enemies
+}
+
+// SetBounds sets the size of the level, which is the size of each
+// exported frame
+func (r *ShortReplay) SetBounds(width, height int) {
+	r.width, r.height = width, height
+}
+
+// RecordTick records one tick of player state along with the enemies and
+// bullets in the level
+func (r *ShortReplay) RecordTick(pos [2]int, firedAt *[2]int, hit bool) {
+	frame := Frame{Pos: pos, FiredAt: firedAt, Hit: hit}
+	if r.enemies != nil {
+		frame.Enemies = r.enemies()
+	}
+	if r.level != nil {
+		for _, entity := range r.level.Entities {
+			if bullet, ok := entity.(*projectile.Bullet); ok {
+				x, y := bullet.Position()
+				frame.Bullets = append(frame.Bullets, [2]int{x, y})
+			}
+		}
+	}
+	r.buffer.record(frame)
+}
+
+// buildingCells returns every cell covered by a standing building
+func (r *ShortReplay) buildingCells() map[[2]int]bool {
+	if r.level == nil {
+		return r.buildings
+	}
+	cells := make(map[[2]int]bool)
+	for _, entity := range r.level.Entities {
+		b, ok := entity.(*building.Building)
+		if !ok || b.Structure() == 0 {
+			continue
+		}
+		x, y := b.Position()
+		width, height := b.Size()
+		for i := 0; i < width; i++ {
+			for j := 0; j < height; j++ {
+				cells[[2]int{x + i, y + j}] = true
+			}
+		}
+	}
+	return cells
+}
+
+// Tick starts playback when F10 is pressed
+func (r *ShortReplay) Tick(event tl.Event) {
+	if event.Type == tl.EventKey && event.Key == tl.KeyF10 {
+		r.buffer.Play(r.screen, r.level)
+	}
+}
+
+// Draw does nothing, playback draws itself in place of the level
+func (r *ShortReplay) Draw(screen *tl.Screen) {}
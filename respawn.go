@@ -0,0 +1,214 @@
+package main
+
+import (
+    "strconv"
+
+    "github.com/Ariemeth/frame_assault/achievements"
+    "github.com/Ariemeth/frame_assault/config"
+    "github.com/Ariemeth/frame_assault/damagelog"
+    "github.com/Ariemeth/frame_assault/display"
+    "github.com/Ariemeth/frame_assault/integrity"
+    "github.com/Ariemeth/frame_assault/mech"
+    "github.com/Ariemeth/frame_assault/projectile"
+    "github.com/Ariemeth/frame_assault/waves"
+    "github.com/Ariemeth/frame_assault/worldstate"
+    tl "github.com/Ariemeth/termloop"
+)
+
+const (
+    defaultLives     = 3
+    defaultWorldFile = "world_state.json"
+    // damageLogFile is where the hits of the session are exported on game over
+    damageLogFile = "damage_log.json"
+    // playerID names the player's mech and its hits in the damage log
+    playerID damagelog.EntityID = "Player"
+)
+
+// worldSettings are the options the world is built with every life
+type worldSettings struct {
+    config         *config.Game
+    waves          []waves.Wave
+    fovAngle       float64
+    debugInspector bool
+    lives          int
+    worldFile      string
+    challenge      bool
+    fogOfWar       bool
+    noBell         bool
+    // onboardingFile marks the AI intro as seen, empty to never show it
+    onboardingFile string
+    ollamaHost     string
+    ollamaModel    string
+    // player is the loadout, symbol and color chosen at the start
+    player mech.PlayerConfig
+}
+
+// livesFromConfig returns the configured number of lives or the default
+func livesFromConfig(cfg *config.Game) int {
+    if cfg == nil || cfg.Lives <= 0 {
+        return defaultLives
+    }
+    return cfg.Lives
+}
+
+// LivesLeft returns how many more times the player can respawn
+func (gs *GameState) LivesLeft() int {
+    return gs.settings.lives - gs.livesUsed
+}
+
+// playerDied saves the damage done to the city, the player's parts and
+// their waypoints and shows the game over screen
+func (gs *GameState) playerDied() {
+    gs.dead = true
+    gs.livesUsed++
+
+    state := worldstate.New()
+    state.CaptureBuildings(gs.buildings)
+    state.CapturePlayer(gs.player)
+    state.CaptureWaypoints(gs.waypoints)
+    gs.undosRemaining = gs.player.UndosRemaining()
+    integrity.Sign(state, integrity.OfflineSecret)
+    if err := worldstate.Save(gs.settings.worldFile, state); err != nil {
+        logger.Warn("failed to save world state", "file", gs.settings.worldFile, "error", err)
+    }
+    logger.Info("player destroyed", "lives_left", gs.LivesLeft())
+    if gs.LivesLeft() <= 0 {
+        gs.checkAchievement(achievements.Event{Type: achievements.GameCompleted})
+    }
+    if gs.damageLog != nil {
+        if err := gs.damageLog.Save(damageLogFile); err != nil {
+            logger.Warn("failed to save damage log", "file", damageLogFile, "error", err)
+        }
+    }
+
+    gs.game.Screen().SetLevel(newGameOverLevel(gs.LivesLeft(), gs.respawn, gs.damageLog,
+        display.NewStatsScreen(gs.buildingStats), display.NewBestiaryScreen(gs.bestiary, bestiaryModels())))
+}
+
+// playerExtracted ends the game with the player flown out of the city
+func (gs *GameState) playerExtracted() {
+    gs.dead = true
+    logger.Info("player extracted", "lives_left", gs.LivesLeft())
+    gs.checkAchievement(achievements.Event{Type: achievements.GameCompleted})
+    if gs.damageLog != nil {
+        if err := gs.damageLog.Save(damageLogFile); err != nil {
+            logger.Warn("failed to save damage log", "file", damageLogFile, "error", err)
+        }
+    }
+
+    gs.game.Screen().SetLevel(newExtractionLevel(gs.damageLog,
+        display.NewStatsScreen(gs.buildingStats), display.NewBestiaryScreen(gs.bestiary, bestiaryModels())))
+}
+
+// respawn rebuilds the city with the damage saved when the player died
+// and starts the next life with the parts they had salvaged
+func (gs *GameState) respawn() {
+    state, err := worldstate.Load(gs.settings.worldFile)
+    if err != nil {
+        logger.Warn("failed to load world state", "file", gs.settings.worldFile, "error", err)
+    } else if !integrity.Check(state, integrity.OfflineSecret) {
+        // An edited save would hand the player whatever parts they liked
+        logger.Warn("world state failed its integrity check", "file", gs.settings.worldFile)
+        state = nil
+    }
+
+    // Bullets still flying in the last life are no longer counted
+    projectile.ReleaseBulletCounter(gs.level)
+    projectile.ReleaseSpeed(gs.level)
+    gs.level = newLevel()
+    gs.dead = false
+    gs.buildWorld()
+    if state != nil {
+        state.ApplyBuildings(gs.buildings)
+        state.ApplyPlayer(gs.player)
+        state.ApplyWaypoints(gs.waypoints)
+    }
+    gs.game.Screen().SetLevel(gs.screenLevel)
+}
+
+// gameOverLevel is shown after the player is destroyed, offering a
+// respawn while lives remain, the lifetime stats and the bestiary
+type gameOverLevel struct {
+    *tl.BaseLevel
+    livesLeft    int
+    respawn      func()
+    screen       *display.GameOverScreen
+    stats        *display.StatsScreen
+    showStats    bool
+    bestiary     *display.BestiaryScreen
+    showBestiary bool
+}
+
+// newGameOverLevel creates the game over screen calling respawn when R is
+// pressed with lives left, showing stats when S is pressed and the bestiary
+// when B is pressed. The player's top attackers are taken from damageLog.
+func newGameOverLevel(livesLeft int, respawn func(), damageLog *damagelog.Log, stats *display.StatsScreen,
+    bestiary *display.BestiaryScreen) *gameOverLevel {
+    title := "YOUR MECH HAS BEEN DESTROYED"
+    prompt := strconv.Itoa(livesLeft) + " lives left - press R to respawn, S for stats, B for bestiary, Esc to quit"
+    if livesLeft <= 0 {
+        title = "GAME OVER"
+        prompt = "No lives left - press S for stats, B for bestiary, Esc to quit"
+    }
+    return &gameOverLevel{
+        BaseLevel: newLevel(),
+        livesLeft: livesLeft,
+        respawn:   respawn,
+        screen:    display.NewGameOverScreen(title, prompt, damageLog, playerID),
+        stats:     stats,
+        bestiary:  bestiary,
+    }
+}
+
+// newExtractionLevel creates the end screen shown when the player is
+// extracted, showing stats when S is pressed and the bestiary when B is
+// pressed
+func newExtractionLevel(damageLog *damagelog.Log, stats *display.StatsScreen,
+    bestiary *display.BestiaryScreen) *gameOverLevel {
+    return &gameOverLevel{
+        BaseLevel: newLevel(),
+        screen:    display.NewGameOverScreen("EXTRACTION SUCCESSFUL",
+            "Press S for stats, B for bestiary, Esc to quit", damageLog, playerID),
+        stats:     stats,
+        bestiary:  bestiary,
+    }
+}
+
+// Tick toggles the stats when S is pressed and the bestiary when B is
+// pressed, and respawns the player when R is pressed and lives remain
+func (l *gameOverLevel) Tick(event tl.Event) {
+    if event.Type != tl.EventKey {
+        return
+    }
+    if (event.Ch == 's' || event.Ch == 'S') && l.stats != nil {
+        l.showStats = !l.showStats
+        l.showBestiary = false
+        return
+    }
+    if (event.Ch == 'b' || event.Ch == 'B') && l.bestiary != nil {
+        l.showBestiary = !l.showBestiary
+        l.showStats = false
+        return
+    }
+    if l.livesLeft <= 0 {
+        return
+    }
+    if event.Ch == 'r' || event.Ch == 'R' {
+        l.respawn()
+    }
+}
+
+// Draw centers the game over message, the stats or the bestiary on the
+// screen
+func (l *gameOverLevel) Draw(screen *tl.Screen) {
+    l.BaseLevel.Draw(screen)
+    if l.showBestiary {
+        l.bestiary.Draw(screen)
+        return
+    }
+    if l.showStats {
+        l.stats.Draw(screen)
+        return
+    }
+    l.screen.Draw(screen)
+}
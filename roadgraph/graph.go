@@ -0,0 +1,72 @@
+// Package roadgraph links the city's road cells into a graph that can be
+// searched for the roads nearest a point
+package roadgraph
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrNoRoads is returned when the graph has no road to find
+var ErrNoRoads = errors.New("no roads in graph")
+
+// neighbors are the orthogonal offsets of a cell
+var neighbors = [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+
+// Graph is the city's road cells, each node joined to the road cells
+// beside it
+type Graph struct {
+	nodes map[[2]int]bool
+	// sorted keeps NearestRoadNode's choice between equally near roads
+	// the same from game to game
+	sorted [][2]int
+}
+
+// NewGraph creates a graph of the road cells
+func NewGraph(cells [][2]int) *Graph {
+	g := &Graph{nodes: make(map[[2]int]bool, len(cells))}
+	for _, cell := range cells {
+		if !g.nodes[cell] {
+			g.nodes[cell] = true
+			g.sorted = append(g.sorted, cell)
+		}
+	}
+	sort.Slice(g.sorted, func(i, j int) bool {
+		if g.sorted[i][0] != g.sorted[j][0] {
+			return g.sorted[i][0] < g.sorted[j][0]
+		}
+		return g.sorted[i][1] < g.sorted[j][1]
+	})
+	return g
+}
+
+// HasRoad returns true if x,y is a road node
+func (g *Graph) HasRoad(x, y int) bool {
+	return g.nodes[[2]int{x, y}]
+}
+
+// Neighbors returns the road nodes joined to x,y
+func (g *Graph) Neighbors(x, y int) [][2]int {
+	joined := make([][2]int, 0, len(neighbors))
+	for _, offset := range neighbors {
+		if cell := [2]int{x + offset[0], y + offset[1]}; g.nodes[cell] {
+			joined = append(joined, cell)
+		}
+	}
+	return joined
+}
+
+// NearestRoadNode returns the road node closest to x,y
+func (g *Graph) NearestRoadNode(x, y int) (roadX, roadY int, err error) {
+	if len(g.sorted) == 0 {
+		return 0, 0, ErrNoRoads
+	}
+	best, bestDistance := g.sorted[0], -1
+	for _, cell := range g.sorted {
+		dx, dy := cell[0]-x, cell[1]-y
+		if distance := dx*dx + dy*dy; bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = cell, distance
+		}
+	}
+	return best[0], best[1], nil
+}
@@ -0,0 +1,110 @@
+// Package spawnzones finds places for enemy mechs to enter the city
+// beside its roads
+package spawnzones
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+// maxAttempts is how many road cells are tried before giving up
+const maxAttempts = 20
+
+// ErrNoSpawnPoint is returned when no free cell beside a road was found
+var ErrNoSpawnPoint = errors.New("no free cell beside a road found")
+
+// RoadSystem reports where the city's roads are
+type RoadSystem interface {
+	RoadCells() [][2]int
+	HasRoad(x, y int) bool
+}
+
+// neighbors are the orthogonal offsets of a cell
+var neighbors = [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+
+// FindRoadAdjacentSpawnPoint returns a free cell next to a random road cell
+func FindRoadAdjacentSpawnPoint(roadSystem RoadSystem, level *tl.BaseLevel, rng *rand.Rand) (x, y int, err error) {
+	return findSpawnPoint(roadSystem, level, sortedCells(roadSystem.RoadCells()), nil, rng)
+}
+
+// FindSpawnPointInZone returns a free cell next to a random road cell,
+// both inside zone given as minX, minY, maxX, maxY
+func FindSpawnPointInZone(roadSystem RoadSystem, level *tl.BaseLevel, zone [4]int, rng *rand.Rand) (x, y int, err error) {
+	cells := make([][2]int, 0)
+	for _, cell := range sortedCells(roadSystem.RoadCells()) {
+		if inZone(cell[0], cell[1], zone) {
+			cells = append(cells, cell)
+		}
+	}
+	return findSpawnPoint(roadSystem, level, cells, &zone, rng)
+}
+
+// findSpawnPoint picks a random road cell from cells and one of its
+// non-road neighbors, within zone if given, that nothing occupies
+func findSpawnPoint(roadSystem RoadSystem, level *tl.BaseLevel, cells [][2]int, zone *[4]int, rng *rand.Rand) (int, int, error) {
+	if len(cells) == 0 {
+		return 0, 0, ErrNoSpawnPoint
+	}
+	for attempts := 0; attempts < maxAttempts; attempts++ {
+		road := cells[rng.Intn(len(cells))]
+		candidates := make([][2]int, 0, len(neighbors))
+		for _, offset := range neighbors {
+			x, y := road[0]+offset[0], road[1]+offset[1]
+			if !roadSystem.HasRoad(x, y) {
+				candidates = append(candidates, [2]int{x, y})
+			}
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+		cell := candidates[rng.Intn(len(candidates))]
+		if HasCollision(cell[0], cell[1], level) || zone != nil && !inZone(cell[0], cell[1], *zone) {
+			continue
+		}
+		return cell[0], cell[1], nil
+	}
+	return 0, 0, ErrNoSpawnPoint
+}
+
+// sortedCells orders cells so the same rng always picks the same cell
+func sortedCells(cells [][2]int) [][2]int {
+	sort.Slice(cells, func(i, j int) bool {
+		if cells[i][0] != cells[j][0] {
+			return cells[i][0] < cells[j][0]
+		}
+		return cells[i][1] < cells[j][1]
+	})
+	return cells
+}
+
+// inZone returns true if x,y lies within zone
+func inZone(x, y int, zone [4]int) bool {
+	return x >= zone[0] && x <= zone[2] && y >= zone[1] && y <= zone[3]
+}
+
+// HasCollision checks if a point collides with any physical entity
+func HasCollision(x, y int, level *tl.BaseLevel) bool {
+	if level == nil {
+		return false
+	}
+	for _, entity := range level.Entities {
+		if entity == nil {
+			continue
+		}
+
+		physical, ok := entity.(tl.Physical)
+		if !ok {
+			continue
+		}
+
+		// Get entity position and size
+		eX, eY := physical.Position()
+		if eX == x && eY == y {
+			return true
+		}
+	}
+	return false
+}
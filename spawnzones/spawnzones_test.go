@@ -0,0 +1,65 @@
+package spawnzones
+
+import (
+	"math/rand"
+	"testing"
+
+	tl "github.com/Ariemeth/termloop"
+)
+
+// testRoads is a horizontal road along y = 5 from x = 0 to 9
+type testRoads struct{}
+
+func (testRoads) RoadCells() [][2]int {
+	cells := make([][2]int, 0)
+	for x := 0; x < 10; x++ {
+		cells = append(cells, [2]int{x, 5})
+	}
+	return cells
+}
+
+func (testRoads) HasRoad(x, y int) bool {
+	return y == 5 && x >= 0 && x < 10
+}
+
+func TestSpawnPointIsBesideRoadAndFree(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	// Block every cell above the road
+	for x := 0; x < 10; x++ {
+		level.AddEntity(tl.NewRectangle(x, 4, 1, 1, tl.ColorWhite))
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		x, y, err := FindRoadAdjacentSpawnPoint(testRoads{}, level, rng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (testRoads{}).HasRoad(x, y) {
+			t.Fatalf("spawn point (%d,%d) is on the road", x, y)
+		}
+		if HasCollision(x, y, level) {
+			t.Fatalf("spawn point (%d,%d) collides with an entity", x, y)
+		}
+	}
+}
+
+func TestSpawnPointInZone(t *testing.T) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	zone := [4]int{2, 5, 4, 6}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		x, y, err := FindSpawnPointInZone(testRoads{}, level, zone, rng)
+		if err != nil {
+			continue
+		}
+		if !inZone(x, y, zone) || y != 6 {
+			t.Fatalf("spawn point (%d,%d) is outside zone %v", x, y, zone)
+		}
+	}
+
+	if _, _, err := FindSpawnPointInZone(testRoads{}, level, [4]int{20, 20, 30, 30}, rng); err != ErrNoSpawnPoint {
+		t.Errorf("zone without roads returned %v, want ErrNoSpawnPoint", err)
+	}
+}
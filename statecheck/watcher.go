@@ -0,0 +1,172 @@
+// Package statecheck compares entity state between ticks to catch
+// teleporting entities and impossible damage
+package statecheck
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+
+	"github.com/Ariemeth/frame_assault/util"
+	"github.com/Ariemeth/frame_assault/util/debug"
+	tl "github.com/Ariemeth/termloop"
+)
+
+const (
+	// MaxMovePerTick is the furthest an entity may move in a single tick
+	MaxMovePerTick = 5.0
+
+	// Fields reported in a StateDelta
+	FieldPosition  = "position"
+	FieldStructure = "structure"
+)
+
+// positioned is implemented by entities with a location
+type positioned interface {
+	Position() (int, int)
+}
+
+// named is implemented by entities with a stable name used as their ID
+type named interface {
+	Name() string
+}
+
+// structured is implemented by entities that can take damage
+type structured interface {
+	StructureLeft() int
+	MaxStructure() int
+}
+
+// EntityState is the tracked state of one entity
+type EntityState struct {
+	X, Y         int
+	Structure    int
+	MaxStructure int
+	HasStructure bool
+}
+
+// StateSnapshot maps entity IDs to their state at one moment
+type StateSnapshot map[string]EntityState
+
+// StateDelta describes a change to one field of an entity between snapshots
+type StateDelta struct {
+	ID      string
+	Field   string
+	Change  float64
+	Anomaly bool
+}
+
+// Watcher snapshots level entities every tick and logs anomalous changes
+// while movement validation debugging is enabled
+type Watcher struct {
+	level    *tl.BaseLevel
+	logger   *slog.Logger
+	previous StateSnapshot
+}
+
+// NewWatcher creates a watcher of level's entities logging to logger
+func NewWatcher(level *tl.BaseLevel, logger *slog.Logger) *Watcher {
+	return &Watcher{level: level, logger: logger}
+}
+
+// entityID returns the name of named entities, otherwise the entity's
+// type and address
+func entityID(entity tl.Drawable) string {
+	if n, ok := entity.(named); ok {
+		return n.Name()
+	}
+	return fmt.Sprintf("%T@%p", entity, entity)
+}
+
+// Snapshot records the state of every positioned entity
+func (w *Watcher) Snapshot(entities []tl.Drawable) StateSnapshot {
+	snapshot := make(StateSnapshot, len(entities))
+	for _, entity := range entities {
+		p, ok := entity.(positioned)
+		if !ok {
+			continue
+		}
+		var state EntityState
+		state.X, state.Y = p.Position()
+		if s, ok := entity.(structured); ok {
+			state.Structure = s.StructureLeft()
+			state.MaxStructure = s.MaxStructure()
+			state.HasStructure = true
+		}
+		snapshot[entityID(entity)] = state
+	}
+	return snapshot
+}
+
+// Diff returns the changes between two snapshots of the same entities,
+// flagging moves over MaxMovePerTick and structure changes larger than the
+// entity's maximum structure as anomalies
+func (w *Watcher) Diff(old, new StateSnapshot) []StateDelta {
+	ids := make([]string, 0, len(new))
+	for id := range new {
+		if _, ok := old[id]; ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	deltas := make([]StateDelta, 0)
+	for _, id := range ids {
+		before, after := old[id], new[id]
+		if moved := util.CalculateDistance(before.X, before.Y, after.X, after.Y); moved > 0 {
+			deltas = append(deltas, StateDelta{
+				ID:      id,
+				Field:   FieldPosition,
+				Change:  moved,
+				Anomaly: moved > MaxMovePerTick,
+			})
+		}
+		if before.HasStructure && after.HasStructure && before.Structure != after.Structure {
+			change := float64(after.Structure - before.Structure)
+			deltas = append(deltas, StateDelta{
+				ID:      id,
+				Field:   FieldStructure,
+				Change:  change,
+				Anomaly: math.Abs(change) > float64(after.MaxStructure),
+			})
+		}
+	}
+	return deltas
+}
+
+// Check snapshots entities, logs any anomalies since the previous check
+// and returns every change found
+func (w *Watcher) Check(entities []tl.Drawable) []StateDelta {
+	snapshot := w.Snapshot(entities)
+	var deltas []StateDelta
+	if w.previous != nil {
+		deltas = w.Diff(w.previous, snapshot)
+		for _, delta := range deltas {
+			if delta.Anomaly && w.logger != nil {
+				w.logger.Warn("state anomaly detected",
+					"event_type", "anomaly",
+					"entity", delta.ID,
+					"field", delta.Field,
+					"change", delta.Change)
+			}
+		}
+	}
+	w.previous = snapshot
+	return deltas
+}
+
+// Tick checks the level once per frame while movement validation is on
+func (w *Watcher) Tick(event tl.Event) {
+	if !debug.MovementValidation || w.level == nil {
+		w.previous = nil
+		return
+	}
+	if event.Type != tl.EventNone {
+		return
+	}
+	w.Check(w.level.Entities)
+}
+
+// Draw does nothing, the watcher only logs
+func (w *Watcher) Draw(screen *tl.Screen) {}
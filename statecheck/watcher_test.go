@@ -0,0 +1,77 @@
+package statecheck
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/util/debug"
+	tl "github.com/Ariemeth/termloop"
+)
+
+type testEntity struct {
+	*tl.Entity
+	name      string
+	structure int
+}
+
+func (e *testEntity) Name() string       { return e.name }
+func (e *testEntity) StructureLeft() int { return e.structure }
+func (e *testEntity) MaxStructure() int  { return 10 }
+
+func TestTeleportIsLoggedAsAnomaly(t *testing.T) {
+	var out bytes.Buffer
+	level := tl.NewBaseLevel(tl.Cell{})
+	watcher := NewWatcher(level, slog.New(slog.NewTextHandler(&out, nil)))
+
+	entity := &testEntity{Entity: tl.NewEntity(0, 0, 1, 1), name: "Mech A", structure: 10}
+	walker := &testEntity{Entity: tl.NewEntity(20, 20, 1, 1), name: "Mech B", structure: 10}
+	level.AddEntity(entity)
+	level.AddEntity(walker)
+
+	debug.MovementValidation = true
+	defer func() { debug.MovementValidation = false }()
+
+	watcher.Tick(tl.Event{Type: tl.EventNone})
+	entity.SetPosition(10, 0)
+	walker.SetPosition(21, 20)
+	watcher.Tick(tl.Event{Type: tl.EventNone})
+
+	logged := out.String()
+	if !strings.Contains(logged, "state anomaly detected") || !strings.Contains(logged, "Mech A") {
+		t.Errorf("teleport was not logged as an anomaly: %q", logged)
+	}
+	if strings.Contains(logged, "Mech B") {
+		t.Errorf("a one cell move was logged as an anomaly: %q", logged)
+	}
+}
+
+func TestDiffFlagsImpossibleDamage(t *testing.T) {
+	watcher := NewWatcher(nil, nil)
+	old := StateSnapshot{"Mech A": {Structure: 10, MaxStructure: 10, HasStructure: true}}
+	new := StateSnapshot{"Mech A": {Structure: -5, MaxStructure: 10, HasStructure: true}}
+
+	deltas := watcher.Diff(old, new)
+	if len(deltas) != 1 {
+		t.Fatalf("found %d deltas instead of 1", len(deltas))
+	}
+	if deltas[0].Field != FieldStructure || deltas[0].Change != -15 || !deltas[0].Anomaly {
+		t.Errorf("structure delta is %+v", deltas[0])
+	}
+}
+
+func TestWatcherIdleWithoutMovementValidation(t *testing.T) {
+	var out bytes.Buffer
+	level := tl.NewBaseLevel(tl.Cell{})
+	watcher := NewWatcher(level, slog.New(slog.NewTextHandler(&out, nil)))
+	entity := &testEntity{Entity: tl.NewEntity(0, 0, 1, 1), name: "Mech A"}
+	level.AddEntity(entity)
+
+	watcher.Tick(tl.Event{Type: tl.EventNone})
+	entity.SetPosition(10, 0)
+	watcher.Tick(tl.Event{Type: tl.EventNone})
+	if out.Len() != 0 {
+		t.Errorf("watcher logged with movement validation off: %q", out.String())
+	}
+}
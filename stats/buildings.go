@@ -0,0 +1,103 @@
+// Package stats keeps lifetime statistics across game sessions
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// BuildingStats counts how many times each building type has been
+// destroyed across every game
+type BuildingStats struct {
+	Destroyed map[string]int
+	path      string
+}
+
+// Count is the number of times a building type has been destroyed
+type Count struct {
+	Name      string
+	Destroyed int
+}
+
+// DefaultPath returns the building stats file in the player's home directory
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error finding home directory: %v", err)
+	}
+	return filepath.Join(home, ".frame_assault", "building_stats.json"), nil
+}
+
+// Load creates building stats saving to path, loading any counts already
+// saved there
+func Load(path string) (*BuildingStats, error) {
+	s := &BuildingStats{
+		Destroyed: make(map[string]int),
+		path:      path,
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("error reading building stats: %v", err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return s, fmt.Errorf("error parsing building stats: %v", err)
+	}
+	if s.Destroyed == nil {
+		s.Destroyed = make(map[string]int)
+	}
+	return s, nil
+}
+
+// Save writes the counts to the stats file, creating its directory if needed
+func (s *BuildingStats) Save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding building stats: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("error creating building stats directory: %v", err)
+	}
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("error writing building stats: %v", err)
+	}
+	return nil
+}
+
+// IncrementDestroyed records a building of type name being destroyed
+func (s *BuildingStats) IncrementDestroyed(name string) {
+	s.Destroyed[name]++
+}
+
+// Total returns how many buildings of any type have been destroyed
+func (s *BuildingStats) Total() int {
+	total := 0
+	for _, count := range s.Destroyed {
+		total += count
+	}
+	return total
+}
+
+// Top returns up to n building types, most destroyed first
+func (s *BuildingStats) Top(n int) []Count {
+	counts := make([]Count, 0, len(s.Destroyed))
+	for name, destroyed := range s.Destroyed {
+		counts = append(counts, Count{Name: name, Destroyed: destroyed})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Destroyed != counts[j].Destroyed {
+			return counts[i].Destroyed > counts[j].Destroyed
+		}
+		return counts[i].Name < counts[j].Name
+	})
+	if len(counts) > n {
+		counts = counts[:n]
+	}
+	return counts
+}
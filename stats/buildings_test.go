@@ -0,0 +1,32 @@
+package stats
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDestroyedCountsPersist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "building_stats.json")
+	s, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		s.IncrementDestroyed("Hospital")
+	}
+	s.IncrementDestroyed("School")
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded.Destroyed["Hospital"]; got != 5 {
+		t.Errorf("reloaded %d hospital destructions, want 5", got)
+	}
+	if top := loaded.Top(3); len(top) != 2 || top[0].Name != "Hospital" {
+		t.Errorf("top destroyed buildings are %v, want Hospital first of 2", top)
+	}
+}
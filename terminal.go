@@ -0,0 +1,45 @@
+package main
+
+import (
+    "fmt"
+    "os"
+
+    "github.com/Ariemeth/frame_assault/display"
+    "github.com/Ariemeth/frame_assault/layout"
+)
+
+// fitLevelToTerminal shrinks the city to fit the terminal reported by
+// detector, scaling its roads and buildings to keep the same density. The
+// city is left at full size if the terminal size cannot be read.
+func fitLevelToTerminal(detector *display.TerminalSizeDetector) {
+    width, height, err := detector.Size()
+    if err != nil {
+        logger.Warn("failed to detect terminal size", "error", err)
+        return
+    }
+    scaler := layout.NewScaler(width, height)
+    if scaler.TooSmall() {
+        logger.Warn("terminal below minimum size", "width", width, "height", height,
+            "min_width", layout.MinWidth, "min_height", layout.MinHeight)
+        fmt.Fprintf(os.Stderr, "Your terminal is %dx%d, smaller than the %dx%d the game needs; "+
+            "enlarge it for a playable city.\n", width, height, layout.MinWidth, layout.MinHeight)
+    }
+
+    scale := func(length int) int {
+        return scaler.ScaleLength(length, baseLevelWidth, baseLevelHeight)
+    }
+    levelWidth, levelHeight = scaler.ScaleLevel(baseLevelWidth, baseLevelHeight)
+    maxLevelWidth, maxLevelHeight = levelWidth-1, levelHeight-1
+    avenueSpacing = scale(avenueSpacing)
+    streetSpacing = scale(streetSpacing)
+    buildingWidth = scale(buildingWidth)
+    buildingHeight = scale(buildingHeight)
+    residentialStartX = scale(residentialStartX)
+    residentialStartY = scale(residentialStartY)
+    residentialWidth = scale(residentialWidth)
+    residentialHeight = scale(residentialHeight)
+    if levelWidth != baseLevelWidth || levelHeight != baseLevelHeight {
+        logger.Info("level scaled to terminal", "width", levelWidth, "height", levelHeight,
+            "terminal_width", width, "terminal_height", height)
+    }
+}
@@ -0,0 +1,101 @@
+// Package timing keeps game loops to a steady frame rate
+package timing
+
+import (
+	"log/slog"
+	"time"
+)
+
+// frameSamples is how many of the latest frame times the actual frame
+// rate is averaged over
+const frameSamples = 10
+
+// TickGovernor holds a loop to its target frame rate by sleeping off the
+// rest of every frame that finishes early. Frames that run long are
+// logged at debug level with how far the frame rate fell short.
+type TickGovernor struct {
+	targetInterval time.Duration
+	// frames is a ring buffer of the latest frame times
+	frames    [frameSamples]time.Duration
+	next      int
+	samples   int
+	lastFrame time.Time
+}
+
+// NewTickGovernor creates a governor holding a loop to fps frames a second
+func NewTickGovernor(fps float64) *TickGovernor {
+	return &TickGovernor{targetInterval: time.Duration(float64(time.Second) / fps)}
+}
+
+// MaySleep ends a frame, sleeping until the target interval has passed
+// since the last frame ended. The first call only starts the clock.
+func (g *TickGovernor) MaySleep() {
+	now := time.Now()
+	if g.lastFrame.IsZero() {
+		g.lastFrame = now
+		return
+	}
+	elapsed := now.Sub(g.lastFrame)
+	if elapsed < g.targetInterval {
+		time.Sleep(g.targetInterval - elapsed)
+		now = time.Now()
+	} else {
+		g.reportSlow(elapsed)
+	}
+	g.record(now.Sub(g.lastFrame))
+	g.lastFrame = now
+}
+
+// Mark ends a frame without sleeping, measuring a loop that something
+// else holds to its frame rate. The first call only starts the clock.
+func (g *TickGovernor) Mark() {
+	now := time.Now()
+	if g.lastFrame.IsZero() {
+		g.lastFrame = now
+		return
+	}
+	elapsed := now.Sub(g.lastFrame)
+	g.reportSlow(elapsed)
+	g.record(elapsed)
+	g.lastFrame = now
+}
+
+// reportSlow logs how far the frame rate fell short if a frame took
+// longer than the target interval
+func (g *TickGovernor) reportSlow(elapsed time.Duration) {
+	if elapsed <= g.targetInterval {
+		return
+	}
+	target := float64(time.Second) / float64(g.targetInterval)
+	actual := float64(time.Second) / float64(elapsed)
+	slog.Debug("running below target frame rate",
+		"event_type", "frame_rate",
+		"target_fps", target,
+		"actual_fps", actual,
+		"deficit", target-actual)
+}
+
+// record adds a frame time to the ring buffer, replacing the oldest
+func (g *TickGovernor) record(frame time.Duration) {
+	g.frames[g.next] = frame
+	g.next = (g.next + 1) % frameSamples
+	if g.samples < frameSamples {
+		g.samples++
+	}
+}
+
+// ActualFPS returns the frame rate averaged over the latest frames, 0
+// before any frame has ended
+func (g *TickGovernor) ActualFPS() float64 {
+	if g.samples == 0 {
+		return 0
+	}
+	var total time.Duration
+	for i := 0; i < g.samples; i++ {
+		total += g.frames[i]
+	}
+	if total <= 0 {
+		return 0
+	}
+	return float64(g.samples) * float64(time.Second) / float64(total)
+}
@@ -0,0 +1,35 @@
+package timing
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestActualFPSNearTargetWithSlowFrames(t *testing.T) {
+	const targetFPS = 30.0
+	governor := NewTickGovernor(targetFPS)
+	if governor.ActualFPS() != 0 {
+		t.Errorf("actual FPS is %v before any frame, want 0", governor.ActualFPS())
+	}
+
+	for i := 0; i < 20; i++ {
+		// Each frame spends a millisecond processing
+		time.Sleep(time.Millisecond)
+		governor.MaySleep()
+	}
+	if fps := governor.ActualFPS(); math.Abs(fps-targetFPS) > targetFPS*0.1 {
+		t.Errorf("actual FPS is %.1f after 20 ticks, want within 10%% of %.0f", fps, targetFPS)
+	}
+}
+
+func TestMarkMeasuresWithoutHoldingTheLoop(t *testing.T) {
+	governor := NewTickGovernor(30)
+	for i := 0; i < 20; i++ {
+		time.Sleep(2 * time.Millisecond)
+		governor.Mark()
+	}
+	if fps := governor.ActualFPS(); fps < 100 {
+		t.Errorf("actual FPS is %.1f with 2ms frames, want the loop left running free", fps)
+	}
+}
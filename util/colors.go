@@ -0,0 +1,12 @@
+package util
+
+import tl "github.com/Ariemeth/termloop"
+
+// AttrDim matches termbox's dim attribute which termloop does not export
+const AttrDim tl.Attr = 1 << 12
+
+// Grays from termbox's 16 color palette which termloop does not export
+const (
+	ColorDarkGray  tl.Attr = 9
+	ColorLightGray tl.Attr = 16
+)
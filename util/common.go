@@ -1,9 +1,42 @@
 package util
 
 import (
+	"fmt"
 	"math"
 )
 
+// DistanceMode selects how the distance between two points is measured
+type DistanceMode int
+
+const (
+	// Manhattan sums the horizontal and vertical distance, matching grid movement
+	Manhattan DistanceMode = iota
+	// Euclidean is the straight line distance, matching projectile flight
+	Euclidean
+)
+
+// String returns the name of the distance mode
+func (mode DistanceMode) String() string {
+	switch mode {
+	case Manhattan:
+		return "manhattan"
+	case Euclidean:
+		return "euclidean"
+	}
+	return "unknown"
+}
+
+// ParseDistanceMode converts a name such as "euclidean" to a DistanceMode
+func ParseDistanceMode(name string) (DistanceMode, error) {
+	switch name {
+	case "manhattan":
+		return Manhattan, nil
+	case "euclidean":
+		return Euclidean, nil
+	}
+	return Manhattan, fmt.Errorf("unknown distance mode %q", name)
+}
+
 // CalculateDistance returns the distance between points x1,y1 and x2,y2
 func CalculateDistance(x1, y1, x2, y2 int) float64 {
 	// Use Manhattan distance for grid-based movement
@@ -12,6 +45,84 @@ func CalculateDistance(x1, y1, x2, y2 int) float64 {
 	return dx + dy
 }
 
+// EuclideanDistance returns the straight line distance between points x1,y1 and x2,y2
+func EuclideanDistance(x1, y1, x2, y2 int) float64 {
+	dx := float64(x2 - x1)
+	dy := float64(y2 - y1)
+	return math.Sqrt(dx*dx + dy*dy)
+}
+
+// Distance returns the distance between points x1,y1 and x2,y2 measured using mode
+func Distance(x1, y1, x2, y2 int, mode DistanceMode) float64 {
+	if mode == Euclidean {
+		return EuclideanDistance(x1, y1, x2, y2)
+	}
+	return CalculateDistance(x1, y1, x2, y2)
+}
+
+// Sign returns -1, 0 or 1 matching the sign of n
+func Sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// Line returns the cells on a straight line from x1,y1 to x2,y2 inclusive
+// using Bresenham's algorithm
+func Line(x1, y1, x2, y2 int) [][2]int {
+	dx := int(math.Abs(float64(x2 - x1)))
+	dy := -int(math.Abs(float64(y2 - y1)))
+	sx, sy := 1, 1
+	if x1 > x2 {
+		sx = -1
+	}
+	if y1 > y2 {
+		sy = -1
+	}
+
+	cells := make([][2]int, 0, dx-dy+1)
+	err := dx + dy
+	for {
+		cells = append(cells, [2]int{x1, y1})
+		if x1 == x2 && y1 == y2 {
+			return cells
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x1 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y1 += sy
+		}
+	}
+}
+
+// IsInCone returns true if targetX,targetY lies within halfAngle radians either
+// side of direction, measured in radians from centerX,centerY. The center
+// itself is always in the cone.
+func IsInCone(centerX, centerY int, direction, halfAngle float64, targetX, targetY int) bool {
+	if centerX == targetX && centerY == targetY {
+		return true
+	}
+	if halfAngle >= math.Pi {
+		return true
+	}
+	angle := math.Atan2(float64(targetY-centerY), float64(targetX-centerX))
+	diff := math.Mod(angle-direction, 2*math.Pi)
+	if diff > math.Pi {
+		diff -= 2 * math.Pi
+	} else if diff < -math.Pi {
+		diff += 2 * math.Pi
+	}
+	return math.Abs(diff) <= halfAngle
+}
+
 // Notifier is an interface that can be implemented to recieve messages
 type Notifier interface {
 	AddMessage(string)
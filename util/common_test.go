@@ -0,0 +1,95 @@
+package util
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name           string
+		x1, y1, x2, y2 int
+		mode           DistanceMode
+		expected       float64
+	}{
+		{"manhattan 3,4", 0, 0, 3, 4, Manhattan, 7},
+		{"euclidean 3,4", 0, 0, 3, 4, Euclidean, 5},
+		{"manhattan negative", 3, 4, 0, 0, Manhattan, 7},
+		{"euclidean negative", 3, 4, 0, 0, Euclidean, 5},
+		{"same point", 2, 2, 2, 2, Euclidean, 0},
+	}
+
+	for _, test := range tests {
+		if d := Distance(test.x1, test.y1, test.x2, test.y2, test.mode); d != test.expected {
+			t.Errorf("%s: distance is %v instead of %v", test.name, d, test.expected)
+		}
+	}
+
+	if d := CalculateDistance(0, 0, 3, 4); d != 7 {
+		t.Errorf("CalculateDistance is %v instead of 7", d)
+	}
+	if d := EuclideanDistance(0, 0, 3, 4); d != 5.0 {
+		t.Errorf("EuclideanDistance is %v instead of 5.0", d)
+	}
+}
+
+func TestParseDistanceMode(t *testing.T) {
+	for _, mode := range []DistanceMode{Manhattan, Euclidean} {
+		parsed, err := ParseDistanceMode(mode.String())
+		if err != nil || parsed != mode {
+			t.Errorf("%s parsed as %v, %v", mode, parsed, err)
+		}
+	}
+	if _, err := ParseDistanceMode("chebyshev"); err == nil {
+		t.Errorf("unknown distance mode was accepted")
+	}
+}
+
+func TestSign(t *testing.T) {
+	for n, want := range map[int]int{-7: -1, -1: -1, 0: 0, 1: 1, 12: 1} {
+		if got := Sign(n); got != want {
+			t.Errorf("Sign(%d) is %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestLine(t *testing.T) {
+	cells := Line(0, 0, 3, 0)
+	if len(cells) != 4 || cells[0] != [2]int{0, 0} || cells[3] != [2]int{3, 0} {
+		t.Errorf("horizontal line is %v", cells)
+	}
+
+	cells = Line(0, 0, 2, 2)
+	if len(cells) != 3 || cells[1] != [2]int{1, 1} {
+		t.Errorf("diagonal line is %v", cells)
+	}
+}
+
+func TestIsInCone(t *testing.T) {
+	const halfAngle = math.Pi / 2 // 180 degree field of view facing right
+
+	tests := []struct {
+		x, y    int
+		visible bool
+	}{
+		{5, 0, true},
+		{5, 4, true},
+		{0, 5, true},
+		{-5, 0, false},
+		{-5, 1, false},
+		{-5, -1, false},
+		{0, 0, true},
+	}
+	for _, test := range tests {
+		if visible := IsInCone(0, 0, 0, halfAngle, test.x, test.y); visible != test.visible {
+			t.Errorf("(%d,%d) in cone is %v instead of %v", test.x, test.y, visible, test.visible)
+		}
+	}
+
+	if !IsInCone(0, 0, 0, math.Pi, -5, 0) {
+		t.Errorf("cell behind the center is not in a 360 degree cone")
+	}
+	if !IsInCone(0, 0, math.Pi, halfAngle/2, -5, 1) {
+		t.Errorf("cone facing left does not wrap around the -pi/pi boundary")
+	}
+}
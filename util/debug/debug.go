@@ -1,11 +1,12 @@
 // Package debug provides debug configuration and utilities
 package debug
 
-// Debug flags for different subsystems
-const (
+// Debug flags for different subsystems. These are variables rather than
+// constants so tests and command line flags can toggle them.
+var (
 	// Set to true to enable debug logging for enemy mech ticks
 	EnemyTicks = false
-	// Set to true to enable debug logging for movement validation
+	// Set to true to enable debug logging and path overlays for movement validation
 	MovementValidation = false
 	// Set to true to enable debug logging for weapon systems
 	WeaponSystems = false
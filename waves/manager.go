@@ -0,0 +1,180 @@
+package waves
+
+import (
+	"github.com/Ariemeth/frame_assault/mech"
+	tl "github.com/Ariemeth/termloop"
+)
+
+// EnemyFactory creates a configured enemy mech for a wave.
+// index is the position of the mech within its wave.
+type EnemyFactory func(config MechConfig, index int) *mech.EnemyMech
+
+// Player is the mech the waves are sent against
+type Player interface {
+	AddEnemy(enemy *mech.Mech)
+	Kills() int
+}
+
+// Manager spawns waves of enemies as their trigger conditions are met
+type Manager struct {
+	waves        []Wave
+	spawned      int
+	elapsed      float64
+	timerRunning bool
+	fps          float64
+	factory      EnemyFactory
+	player       Player
+	level        *tl.BaseLevel
+	game         *tl.Game
+	active       []*mech.EnemyMech
+}
+
+// NewManager creates a wave manager ticking at fps frames per second
+func NewManager(waves []Wave, fps float64, factory EnemyFactory, player Player) *Manager {
+	return &Manager{
+		waves:        waves,
+		timerRunning: true,
+		fps:          fps,
+		factory:      factory,
+		player:       player,
+	}
+}
+
+// Attach sets the level and game new waves are spawned into
+func (m *Manager) Attach(level *tl.BaseLevel, game *tl.Game) {
+	m.level = level
+	m.game = game
+}
+
+// CurrentWave returns the number of waves spawned so far
+func (m *Manager) CurrentWave() int {
+	return m.spawned
+}
+
+// TotalWaves returns the number of configured waves
+func (m *Manager) TotalWaves() int {
+	return len(m.waves)
+}
+
+// SetNextWaveMechCount sets how many mechs the next wave to spawn
+// brings, returning false once every wave has spawned
+func (m *Manager) SetNextWaveMechCount(count int) bool {
+	if m.spawned >= len(m.waves) {
+		return false
+	}
+	m.waves[m.spawned].mechCount = count
+	return true
+}
+
+// NextWaveMechCount returns how many mechs the next wave to spawn brings,
+// returning false once every wave has spawned
+func (m *Manager) NextWaveMechCount() (int, bool) {
+	if m.spawned >= len(m.waves) {
+		return 0, false
+	}
+	return m.waves[m.spawned].mechCount, true
+}
+
+// Countdown returns the seconds until the next time triggered wave.
+// Returns false if no countdown is running.
+func (m *Manager) Countdown() (float64, bool) {
+	if !m.timerRunning || m.spawned >= len(m.waves) {
+		return 0, false
+	}
+	trigger := m.waves[m.spawned].triggerCondition
+	if trigger.Type != TimeElapsed {
+		return 0, false
+	}
+	remaining := trigger.Seconds - m.elapsed
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining, true
+}
+
+// Draw is a no-op, the WaveIndicator display shows wave progress.
+func (m *Manager) Draw(screen *tl.Screen) {}
+
+// Tick advances the wave timers by one frame
+func (m *Manager) Tick(event tl.Event) {
+	if m.fps <= 0 {
+		return
+	}
+	m.Advance(1.0 / m.fps)
+}
+
+// Advance moves the wave timers forward and spawns the next wave if due
+func (m *Manager) Advance(seconds float64) {
+	if m.spawned >= len(m.waves) {
+		return
+	}
+
+	// The cooldown only starts once the previous wave is destroyed
+	if !m.timerRunning {
+		if !m.waveDestroyed() {
+			return
+		}
+		m.timerRunning = true
+		m.elapsed = 0
+	}
+	m.elapsed += seconds
+
+	if m.triggered(m.waves[m.spawned].triggerCondition) {
+		m.SpawnWave(m.level, m.game)
+	}
+}
+
+// SpawnWave creates the next wave's enemies, adds them to the level
+// and the player's enemy list. Returns the spawned enemies.
+func (m *Manager) SpawnWave(level *tl.BaseLevel, game *tl.Game) []*mech.EnemyMech {
+	if m.spawned >= len(m.waves) || m.factory == nil {
+		return nil
+	}
+	wave := m.waves[m.spawned]
+	m.spawned++
+	m.timerRunning = false
+	m.active = make([]*mech.EnemyMech, 0, wave.mechCount)
+
+	for i := 0; i < wave.mechCount && len(wave.mechConfigs) > 0; i++ {
+		enemy := m.factory(wave.mechConfigs[i%len(wave.mechConfigs)], i)
+		if enemy == nil {
+			continue
+		}
+		if game != nil {
+			enemy.AttachGame(game)
+		}
+		if level != nil {
+			enemy.SetLevel(level)
+			level.AddEntity(enemy)
+		}
+		if m.player != nil {
+			m.player.AddEnemy(enemy.Mech)
+		}
+		m.active = append(m.active, enemy)
+	}
+	return m.active
+}
+
+// Cleared returns true once every wave has spawned and been destroyed
+func (m *Manager) Cleared() bool {
+	return m.spawned >= len(m.waves) && m.waveDestroyed()
+}
+
+// triggered returns true if the trigger condition has been met
+func (m *Manager) triggered(trigger WaveCondition) bool {
+	switch trigger.Type {
+	case KillCount:
+		return m.player != nil && m.player.Kills() >= trigger.Kills
+	}
+	return m.elapsed >= trigger.Seconds
+}
+
+// waveDestroyed returns true if every enemy from the last wave is destroyed
+func (m *Manager) waveDestroyed() bool {
+	for _, enemy := range m.active {
+		if !enemy.IsDestroyed() {
+			return false
+		}
+	}
+	return true
+}
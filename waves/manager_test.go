@@ -0,0 +1,127 @@
+package waves
+
+import (
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/config"
+	"github.com/Ariemeth/frame_assault/mech"
+	"github.com/Ariemeth/frame_assault/mech/movement"
+	"github.com/Ariemeth/frame_assault/mech/weapon"
+	"github.com/Ariemeth/frame_assault/util"
+	tl "github.com/Ariemeth/termloop"
+)
+
+type testPlayer struct {
+	enemies []*mech.Mech
+	kills   int
+}
+
+func (p *testPlayer) AddEnemy(enemy *mech.Mech) {
+	p.enemies = append(p.enemies, enemy)
+}
+
+func (p *testPlayer) Kills() int {
+	return p.kills
+}
+
+func testFactory(config MechConfig, index int) *mech.EnemyMech {
+	m := mech.NewEnemyMech(config.Name, config.Structure, index, 0, tl.ColorRed, config.Symbol,
+		movement.NewRandomWalkStrategy())
+	m.AddWeapon(config.Weapon())
+	return m
+}
+
+func TestSecondWaveAfterCooldown(t *testing.T) {
+	player := &testPlayer{}
+	manager := NewManager(DefaultWaves(), 10, testFactory, player)
+	manager.Attach(tl.NewBaseLevel(tl.Cell{}), nil)
+
+	manager.Advance(firstWaveDelay - 1)
+	if manager.CurrentWave() != 0 {
+		t.Fatalf("wave %d spawned before the first wave delay", manager.CurrentWave())
+	}
+
+	manager.Advance(1)
+	if manager.CurrentWave() != 1 {
+		t.Fatalf("first wave did not spawn after %d seconds", firstWaveDelay)
+	}
+	if len(player.enemies) != DefaultWaves()[0].MechCount() {
+		t.Fatalf("player has %d enemies instead of %d", len(player.enemies), DefaultWaves()[0].MechCount())
+	}
+
+	// The cooldown must not start while the wave is still alive
+	manager.Advance(waveCooldown * 2)
+	if manager.CurrentWave() != 1 {
+		t.Fatalf("second wave spawned while the first wave was alive")
+	}
+
+	for _, enemy := range player.enemies {
+		enemy.Hit(enemy.StructureLeft(), "Player")
+	}
+
+	manager.Advance(waveCooldown - 1)
+	if manager.CurrentWave() != 1 {
+		t.Fatalf("second wave spawned before the cooldown finished")
+	}
+	if remaining, ok := manager.Countdown(); !ok || remaining != 1 {
+		t.Errorf("countdown is %v,%v instead of 1,true", remaining, ok)
+	}
+
+	manager.Advance(1)
+	if manager.CurrentWave() != 2 {
+		t.Errorf("second wave did not spawn after the cooldown")
+	}
+}
+
+func TestKillTriggeredWave(t *testing.T) {
+	player := &testPlayer{}
+	configs := []MechConfig{{"Mech Z", 'Z', 2, weapon.CreateFist}}
+	manager := NewManager([]Wave{NewWave(WaveCondition{Type: KillCount, Kills: 3}, 2, configs)}, 10, testFactory, player)
+
+	manager.Advance(1000)
+	if manager.CurrentWave() != 0 {
+		t.Fatalf("kill triggered wave spawned without kills")
+	}
+
+	player.kills = 3
+	manager.Advance(0.1)
+	if manager.CurrentWave() != 1 {
+		t.Errorf("kill triggered wave did not spawn after %d kills", player.kills)
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	cfg, err := config.Parse([]byte(`
+waves:
+  - trigger: kills
+    kills: 5
+    mech_count: 3
+    mechs:
+      - name: Mech X
+        symbol: X
+        weapon: rifle
+        distance_mode: manhattan
+`))
+	if err != nil {
+		t.Fatalf("unable to parse config: %v", err)
+	}
+
+	waves, err := FromConfig(cfg.Waves)
+	if err != nil {
+		t.Fatalf("unable to convert wave config: %v", err)
+	}
+	if len(waves) != 1 || waves[0].MechCount() != 3 {
+		t.Fatalf("wave config converted to %+v", waves)
+	}
+	if waves[0].triggerCondition.Type != KillCount || waves[0].triggerCondition.Kills != 5 {
+		t.Errorf("wave trigger is %+v instead of 5 kills", waves[0].triggerCondition)
+	}
+
+	if w := waves[0].mechConfigs[0].Weapon(); w.DistanceMode() != util.Manhattan {
+		t.Errorf("configured weapon uses %s distance instead of manhattan", w.DistanceMode())
+	}
+
+	if _, err := FromConfig([]config.Wave{{Trigger: "time", Mechs: []config.Mech{{Name: "Mech X", Symbol: "X", Weapon: "laser"}}}}); err == nil {
+		t.Errorf("wave config with an unknown weapon was accepted")
+	}
+}
@@ -0,0 +1,146 @@
+// Package waves spawns waves of enemy reinforcements
+package waves
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/Ariemeth/frame_assault/config"
+	"github.com/Ariemeth/frame_assault/mech/weapon"
+	"github.com/Ariemeth/frame_assault/util"
+)
+
+const (
+	// defaultMechStructure is used when a wave mech has no structure configured
+	defaultMechStructure = 4
+	// firstWaveDelay is the number of seconds before the first wave
+	firstWaveDelay = 120
+	// waveCooldown is the number of seconds after a wave is destroyed
+	// before the next one arrives
+	waveCooldown = 60
+)
+
+// ConditionType identifies what triggers a wave
+type ConditionType int
+
+const (
+	// TimeElapsed triggers a wave after a number of seconds
+	TimeElapsed ConditionType = iota
+	// KillCount triggers a wave once the player reaches a number of kills
+	KillCount
+)
+
+// WaveCondition describes when a wave should spawn
+type WaveCondition struct {
+	Type    ConditionType
+	Seconds float64
+	Kills   int
+}
+
+// MechConfig defines the configuration for creating an enemy mech
+type MechConfig struct {
+	Name      string
+	Symbol    rune
+	Structure int
+	Weapon    func() weapon.Weapon
+}
+
+// Wave is a group of enemy mechs spawned together
+type Wave struct {
+	triggerCondition WaveCondition
+	mechCount        int
+	mechConfigs      []MechConfig
+}
+
+// NewWave creates a wave of mechCount mechs cycling through mechConfigs
+func NewWave(trigger WaveCondition, mechCount int, mechConfigs []MechConfig) Wave {
+	return Wave{
+		triggerCondition: trigger,
+		mechCount:        mechCount,
+		mechConfigs:      mechConfigs,
+	}
+}
+
+// MechCount returns the number of mechs in the wave
+func (w Wave) MechCount() int {
+	return w.mechCount
+}
+
+// MechConfigs returns the configurations the wave's mechs cycle through
+func (w Wave) MechConfigs() []MechConfig {
+	return w.mechConfigs
+}
+
+// DefaultWaves returns the waves used when no configuration is provided
+func DefaultWaves() []Wave {
+	reinforcements := []MechConfig{
+		{"Mech A", 'A', defaultMechStructure, weapon.CreateRifle},
+		{"Mech C", 'C', defaultMechStructure, weapon.CreateShotgun},
+		{"Mech E", 'E', defaultMechStructure, weapon.CreateSword},
+		{"Mech G", 'G', defaultMechStructure, weapon.CreateFist},
+	}
+	return []Wave{
+		NewWave(WaveCondition{Type: TimeElapsed, Seconds: firstWaveDelay}, 4, reinforcements),
+		NewWave(WaveCondition{Type: TimeElapsed, Seconds: waveCooldown}, 6, reinforcements),
+		NewWave(WaveCondition{Type: TimeElapsed, Seconds: waveCooldown}, 8, reinforcements),
+	}
+}
+
+// FromConfig converts the waves section of the game configuration
+func FromConfig(waveConfigs []config.Wave) ([]Wave, error) {
+	waves := make([]Wave, 0, len(waveConfigs))
+	for i, wc := range waveConfigs {
+		var trigger WaveCondition
+		switch wc.Trigger {
+		case "time", "":
+			trigger = WaveCondition{Type: TimeElapsed, Seconds: wc.Seconds}
+		case "kills":
+			trigger = WaveCondition{Type: KillCount, Kills: wc.Kills}
+		default:
+			return nil, fmt.Errorf("wave %d has unknown trigger %q", i, wc.Trigger)
+		}
+
+		if len(wc.Mechs) == 0 {
+			return nil, fmt.Errorf("wave %d has no mechs configured", i)
+		}
+		mechConfigs := make([]MechConfig, 0, len(wc.Mechs))
+		for j, mc := range wc.Mechs {
+			create, ok := weapon.CreatorByName(mc.Weapon)
+			if !ok {
+				return nil, fmt.Errorf("wave %d mech %d has unknown weapon %q", i, j, mc.Weapon)
+			}
+			if mc.DistanceMode != "" {
+				mode, err := util.ParseDistanceMode(mc.DistanceMode)
+				if err != nil {
+					return nil, fmt.Errorf("wave %d mech %d: %v", i, j, err)
+				}
+				create = withDistanceMode(create, mode)
+			}
+			symbol, _ := utf8.DecodeRuneInString(mc.Symbol)
+			if symbol == utf8.RuneError {
+				return nil, fmt.Errorf("wave %d mech %d has invalid symbol %q", i, j, mc.Symbol)
+			}
+			structure := mc.Structure
+			if structure <= 0 {
+				structure = defaultMechStructure
+			}
+			mechConfigs = append(mechConfigs, MechConfig{mc.Name, symbol, structure, create})
+		}
+
+		mechCount := wc.MechCount
+		if mechCount <= 0 {
+			mechCount = len(mechConfigs)
+		}
+		waves = append(waves, NewWave(trigger, mechCount, mechConfigs))
+	}
+	return waves, nil
+}
+
+// withDistanceMode wraps a weapon creator to override its distance mode
+func withDistanceMode(create func() weapon.Weapon, mode util.DistanceMode) func() weapon.Weapon {
+	return func() weapon.Weapon {
+		w := create()
+		w.SetDistanceMode(mode)
+		return w
+	}
+}
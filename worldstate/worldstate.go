@@ -0,0 +1,175 @@
+// Package worldstate saves the damage done to the city, the player's
+// salvaged parts and their waypoints so they survive the player's death
+package worldstate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Ariemeth/frame_assault/building"
+	"github.com/Ariemeth/frame_assault/display"
+	"github.com/Ariemeth/frame_assault/mech"
+)
+
+// EntityID identifies a persistent entity, matching its building ID
+type EntityID int
+
+// WorldState is the damage done to the city's buildings, the parts in the
+// player's inventory and the waypoints they dropped
+type WorldState struct {
+	Structures map[EntityID]int  `json:"structures"`
+	Destroyed  map[EntityID]bool `json:"destroyed"`
+	Inventory  []SavedItem       `json:"inventory"`
+	// InstalledParts lists the parts of Inventory that were installed
+	InstalledParts []SavedPart     `json:"installed_parts"`
+	Waypoints      []SavedWaypoint `json:"waypoints"`
+	// Integrity is the signature of the rest of the state, empty until it
+	// is signed
+	Integrity string `json:"integrity,omitempty"`
+}
+
+// SavedItem is a part in the player's inventory, saved by its type and name
+type SavedItem struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// SavedPart is an installed part, saved by its type and the effect it had
+type SavedPart struct {
+	Type    string `json:"type"`
+	Effect  string `json:"effect"`
+	Applied bool   `json:"applied"`
+}
+
+// SavedWaypoint is a waypoint the player dropped, saved by its name and
+// position
+type SavedWaypoint struct {
+	Name string `json:"name"`
+	X    int    `json:"x"`
+	Y    int    `json:"y"`
+}
+
+// New creates an empty world state
+func New() *WorldState {
+	return &WorldState{
+		Structures: make(map[EntityID]int),
+		Destroyed:  make(map[EntityID]bool),
+	}
+}
+
+// Record saves the structure of the entity id
+func (ws *WorldState) Record(id EntityID, structure int) {
+	ws.Structures[id] = structure
+	ws.Destroyed[id] = structure <= 0
+}
+
+// Structure returns the saved structure of id and whether one was saved
+func (ws *WorldState) Structure(id EntityID) (int, bool) {
+	structure, ok := ws.Structures[id]
+	return structure, ok
+}
+
+// IsDestroyed returns true if id was destroyed
+func (ws *WorldState) IsDestroyed(id EntityID) bool {
+	return ws.Destroyed[id]
+}
+
+// CaptureBuildings records the structure of every managed building
+func (ws *WorldState) CaptureBuildings(buildings *building.Manager) {
+	for _, b := range buildings.Buildings() {
+		ws.Record(EntityID(b.ID()), b.Structure())
+	}
+}
+
+// ApplyBuildings restores the saved structure of every managed building.
+// Destroyed buildings come back with no structure.
+func (ws *WorldState) ApplyBuildings(buildings *building.Manager) {
+	for _, b := range buildings.Buildings() {
+		id := EntityID(b.ID())
+		if ws.IsDestroyed(id) {
+			b.SetStructure(0)
+		} else if structure, ok := ws.Structure(id); ok {
+			b.SetStructure(structure)
+		}
+	}
+}
+
+// CapturePlayer records every part in the player's inventory and which of
+// them are installed
+func (ws *WorldState) CapturePlayer(player *mech.PlayerMech) {
+	ws.Inventory = make([]SavedItem, 0, len(player.Parts()))
+	ws.InstalledParts = make([]SavedPart, 0)
+	for _, part := range player.Parts() {
+		ws.Inventory = append(ws.Inventory, SavedItem{Kind: part.Type.String(), Value: part.Name})
+		if part.Equipped {
+			ws.InstalledParts = append(ws.InstalledParts, SavedPart{
+				Type:    part.Type.String(),
+				Effect:  part.Effect(),
+				Applied: true,
+			})
+		}
+	}
+}
+
+// ApplyPlayer gives the player the saved parts, installing those that were
+// installed. Parts that no longer exist are skipped.
+func (ws *WorldState) ApplyPlayer(player *mech.PlayerMech) {
+	for _, item := range ws.Inventory {
+		if part, ok := mech.PartByName(item.Value, item.Kind); ok {
+			player.AddInventoryItem(part)
+		}
+	}
+	for _, saved := range ws.InstalledParts {
+		if !saved.Applied {
+			continue
+		}
+		for _, part := range player.Parts() {
+			if !part.Equipped && part.Type.String() == saved.Type && part.Effect() == saved.Effect {
+				player.InstallPart(part)
+				break
+			}
+		}
+	}
+}
+
+// CaptureWaypoints records every waypoint the player has dropped
+func (ws *WorldState) CaptureWaypoints(waypoints *display.Waypoints) {
+	ws.Waypoints = make([]SavedWaypoint, 0, len(waypoints.All()))
+	for _, waypoint := range waypoints.All() {
+		x, y := waypoint.Position()
+		ws.Waypoints = append(ws.Waypoints, SavedWaypoint{Name: waypoint.Name(), X: x, Y: y})
+	}
+}
+
+// ApplyWaypoints drops the saved waypoints back on the map
+func (ws *WorldState) ApplyWaypoints(waypoints *display.Waypoints) {
+	for _, saved := range ws.Waypoints {
+		waypoints.Add(saved.Name, saved.X, saved.Y)
+	}
+}
+
+// Save writes state to path as JSON
+func Save(path string, state *WorldState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding world state: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("error writing world state: %v", err)
+	}
+	return nil
+}
+
+// Load reads a JSON world state from path
+func Load(path string) (*WorldState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading world state: %v", err)
+	}
+	state := New()
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("error parsing world state: %v", err)
+	}
+	return state, nil
+}
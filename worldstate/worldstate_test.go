@@ -0,0 +1,159 @@
+package worldstate
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/Ariemeth/frame_assault/building"
+	"github.com/Ariemeth/frame_assault/display"
+	"github.com/Ariemeth/frame_assault/mech"
+	tl "github.com/Ariemeth/termloop"
+)
+
+// newCity creates a manager with two homes as a fresh level would
+func newCity() *building.Manager {
+	home, _ := building.TypeByName("Home")
+	buildings := building.NewManager(nil)
+	buildings.Add(building.NewBuilding(0, 0, 4, 4, home))
+	buildings.Add(building.NewBuilding(10, 0, 4, 4, home))
+	return buildings
+}
+
+func TestDestroyedBuildingSurvivesReload(t *testing.T) {
+	city := newCity()
+	destroyed := city.Buildings()[0]
+	destroyed.TakeHit(destroyed.Structure())
+
+	state := New()
+	state.CaptureBuildings(city)
+	path := filepath.Join(t.TempDir(), "world.json")
+	if err := Save(path, state); err != nil {
+		t.Fatalf("failed to save world state: %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("failed to load world state: %v", err)
+	}
+	if !loaded.IsDestroyed(EntityID(destroyed.ID())) {
+		t.Errorf("building %d is not destroyed in the loaded world state", destroyed.ID())
+	}
+
+	rebuilt := newCity()
+	loaded.ApplyBuildings(rebuilt)
+	if structure := rebuilt.Get(destroyed.ID()).Structure(); structure != 0 {
+		t.Errorf("destroyed building reinitialized with %d structure instead of 0", structure)
+	}
+	intact := rebuilt.Buildings()[1]
+	if intact.Structure() != city.Buildings()[1].Structure() {
+		t.Errorf("intact building reinitialized with %d structure instead of %d",
+			intact.Structure(), city.Buildings()[1].Structure())
+	}
+}
+
+// newPlayer creates a player mech with the parts called names
+func newPlayer(t *testing.T, names ...string) *mech.PlayerMech {
+	t.Helper()
+	player := mech.NewPlayerMech("Player", 10, 0, 0, nil, mech.DefaultPlayerConfig())
+	for _, name := range names {
+		found := false
+		for _, partType := range []mech.PartType{mech.PartArmor, mech.PartActuator, mech.PartWeaponMod} {
+			if part, ok := mech.PartByName(name, partType.String()); ok {
+				player.AddInventoryItem(part)
+				found = true
+			}
+		}
+		if !found {
+			t.Fatalf("no part called %s", name)
+		}
+	}
+	return player
+}
+
+func TestInventorySurvivesReload(t *testing.T) {
+	player := newPlayer(t, "Plating", "Servo", "Barrel Mod")
+	player.InstallPart(player.Parts()[1])
+
+	state := New()
+	state.CapturePlayer(player)
+	path := filepath.Join(t.TempDir(), "world.json")
+	if err := Save(path, state); err != nil {
+		t.Fatalf("failed to save world state: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("failed to load world state: %v", err)
+	}
+
+	restored := newPlayer(t)
+	loaded.ApplyPlayer(restored)
+	parts := restored.Parts()
+	if len(parts) != 3 {
+		t.Fatalf("restored %d parts, want 3", len(parts))
+	}
+	for i, want := range []string{"Plating", "Servo", "Barrel Mod"} {
+		if parts[i].Name != want {
+			t.Errorf("part %d is %s, want %s", i, parts[i].Name, want)
+		}
+		if installed := parts[i].Equipped; installed != (want == "Servo") {
+			t.Errorf("%s installed is %v, want %v", want, installed, want == "Servo")
+		}
+	}
+	if restored.Speed() != player.Speed() {
+		t.Errorf("restored speed is %d, want %d", restored.Speed(), player.Speed())
+	}
+}
+
+func TestUnknownPartsAreSkipped(t *testing.T) {
+	state := New()
+	state.Inventory = []SavedItem{{Kind: "Armor", Value: "Plating"}, {Kind: "Shield", Value: "Force Field"}}
+	state.InstalledParts = []SavedPart{{Type: "Shield", Effect: "+5 structure", Applied: true}}
+
+	player := newPlayer(t)
+	state.ApplyPlayer(player)
+	if parts := player.Parts(); len(parts) != 1 || parts[0].Name != "Plating" || parts[0].Equipped {
+		t.Errorf("restored %+v, want only an uninstalled Plating", parts)
+	}
+}
+
+// newWaypoints creates the waypoints of a player at 4,7 on an empty level
+func newWaypoints() (*display.Waypoints, *tl.BaseLevel) {
+	level := tl.NewBaseLevel(tl.Cell{})
+	player := mech.NewPlayerMech("Player", 10, 4, 7, level, mech.DefaultPlayerConfig())
+	return display.NewWaypoints(player, display.NewTextInput(0, 0, display.WaypointNameLength, level), level), level
+}
+
+func TestWaypointSurvivesReload(t *testing.T) {
+	waypoints, _ := newWaypoints()
+	waypoints.Tick(tl.Event{Type: tl.EventKey, Ch: 'w'})
+	for _, ch := range "Depot" {
+		waypoints.Tick(tl.Event{Type: tl.EventKey, Ch: ch})
+	}
+	waypoints.Tick(tl.Event{Type: tl.EventKey, Key: tl.KeyEnter})
+
+	state := New()
+	state.CaptureWaypoints(waypoints)
+	path := filepath.Join(t.TempDir(), "world.json")
+	if err := Save(path, state); err != nil {
+		t.Fatalf("failed to save world state: %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("failed to load world state: %v", err)
+	}
+
+	restored, level := newWaypoints()
+	loaded.ApplyWaypoints(restored)
+	found := false
+	for _, entity := range level.Entities {
+		if waypoint, ok := entity.(*display.Waypoint); ok {
+			found = true
+			if x, y := waypoint.Position(); x != 4 || y != 7 || waypoint.Name() != "Depot" {
+				t.Errorf("restored waypoint %s at %d,%d, want Depot at 4,7", waypoint.Name(), x, y)
+			}
+		}
+	}
+	if !found {
+		t.Error("the waypoint was not added back to the level")
+	}
+}